@@ -0,0 +1,124 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditEvent is a record of a call to a service that changed, or attempted to
+// change, a resource.
+type AuditEvent struct {
+	Time time.Time `json:"time"`
+
+	// UserID and AuthorizationID identify who made the call. AuthorizationID
+	// is only set if the call was authorized by a token.
+	UserID          ID `json:"userID,omitempty"`
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+	// Source is the remote address of the request that made the call.
+	Source string `json:"source,omitempty"`
+
+	// Operation is the service method that was called, such as CreateBucket.
+	Operation  string   `json:"operation"`
+	Resource   Resource `json:"resource"`
+	ResourceID ID       `json:"resourceID,omitempty"`
+	OrgID      ID       `json:"orgID,omitempty"`
+
+	// Before and After are the state of the resource before and after the
+	// call, without any of its secrets.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// Error is the error the call returned, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ops for audit log errors.
+const (
+	OpLogAuditEvent     = "LogAuditEvent"
+	OpFindAuditEvents   = "FindAuditEvents"
+	OpDeleteAuditEvents = "DeleteAuditEvents"
+)
+
+// AuditLogService records and retrieves audit events.
+type AuditLogService interface {
+	// LogAuditEvent adds the event to the audit log.
+	LogAuditEvent(ctx context.Context, e *AuditEvent) error
+
+	// FindAuditEvents returns the events that match the filter, and the total
+	// count of matching events. Events are returned in ascending time order
+	// unless the options are descending.
+	FindAuditEvents(ctx context.Context, filter AuditFilter, opt ...FindOptions) ([]*AuditEvent, int, error)
+}
+
+// AuditFilter restricts the audit events returned.
+type AuditFilter struct {
+	UserID          *ID
+	AuthorizationID *ID
+	Operation       *string
+	Resource        *Resource
+	ResourceID      *ID
+	OrgID           *ID
+	// Since and Until restrict events to the time range [Since, Until).
+	Since *time.Time
+	Until *time.Time
+}
+
+// Match returns true if the event passes the filter.
+func (f AuditFilter) Match(e *AuditEvent) bool {
+	if f.UserID != nil && e.UserID != *f.UserID {
+		return false
+	}
+	if f.AuthorizationID != nil && e.AuthorizationID != *f.AuthorizationID {
+		return false
+	}
+	if f.Operation != nil && e.Operation != *f.Operation {
+		return false
+	}
+	if f.Resource != nil && e.Resource != *f.Resource {
+		return false
+	}
+	if f.ResourceID != nil && e.ResourceID != *f.ResourceID {
+		return false
+	}
+	if f.OrgID != nil && e.OrgID != *f.OrgID {
+		return false
+	}
+	if f.Since != nil && e.Time.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !e.Time.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+// QueryParams returns the filter as url query params.
+func (f AuditFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if f.UserID != nil {
+		qp["userID"] = []string{f.UserID.String()}
+	}
+	if f.AuthorizationID != nil {
+		qp["authorizationID"] = []string{f.AuthorizationID.String()}
+	}
+	if f.Operation != nil {
+		qp["operation"] = []string{*f.Operation}
+	}
+	if f.Resource != nil {
+		qp["resource"] = []string{string(*f.Resource)}
+	}
+	if f.ResourceID != nil {
+		qp["resourceID"] = []string{f.ResourceID.String()}
+	}
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+	if f.Since != nil {
+		qp["since"] = []string{f.Since.Format(time.RFC3339Nano)}
+	}
+	if f.Until != nil {
+		qp["until"] = []string{f.Until.Format(time.RFC3339Nano)}
+	}
+	return qp
+}
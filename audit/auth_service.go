@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.AuthorizationService = (*AuthorizationService)(nil)
+
+// AuthorizationService records the changes to authorizations in the audit log.
+type AuthorizationService struct {
+	platform.AuthorizationService
+	Log *Log
+}
+
+// authorizationState is the state of an authorization without its token.
+func authorizationState(a *platform.Authorization) *platform.Authorization {
+	if a == nil {
+		return nil
+	}
+	state := *a
+	state.Token = ""
+	return &state
+}
+
+func (s *AuthorizationService) findState(ctx context.Context, id platform.ID) *platform.Authorization {
+	a, err := s.AuthorizationService.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return authorizationState(a)
+}
+
+// CreateAuthorization creates an authorization, and records it.
+func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *platform.Authorization) error {
+	err := s.AuthorizationService.CreateAuthorization(ctx, a)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateAuthorization,
+		Resource:   platform.AuthorizationsResource,
+		ResourceID: a.ID,
+		OrgID:      a.OrgID,
+	}, nil, authorizationState(a), err)
+	return err
+}
+
+// SetAuthorizationStatus updates the status of an authorization, and records it.
+func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
+	before := s.findState(ctx, id)
+	err := s.AuthorizationService.SetAuthorizationStatus(ctx, id, status)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpSetAuthorizationStatus,
+		Resource:   platform.AuthorizationsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrgID
+	}
+	var after *platform.Authorization
+	if err == nil {
+		after = s.findState(ctx, id)
+	}
+	s.Log.record(ctx, e, before, after, err)
+	return err
+}
+
+// DeleteAuthorization deletes an authorization, and records it.
+func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.AuthorizationService.DeleteAuthorization(ctx, id)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpDeleteAuthorization,
+		Resource:   platform.AuthorizationsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrgID
+	}
+	s.Log.record(ctx, e, before, nil, err)
+	return err
+}
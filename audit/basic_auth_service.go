@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BasicAuthService = (*BasicAuthService)(nil)
+
+// BasicAuthService records the changes to the passwords of users in the audit
+// log. Only that a password changed is recorded, never the password.
+type BasicAuthService struct {
+	platform.BasicAuthService
+	Log *Log
+
+	// UserService, if set, finds the users whose passwords change, so that
+	// the changes are recorded by the ID of the user.
+	UserService platform.UserService
+}
+
+func (s *BasicAuthService) findState(ctx context.Context, name string) *platform.User {
+	if s.UserService == nil {
+		return &platform.User{Name: name}
+	}
+	u, err := s.UserService.FindUser(ctx, platform.UserFilter{Name: &name})
+	if err != nil {
+		return &platform.User{Name: name}
+	}
+	return u
+}
+
+// change calls fn to change the password of the user, and records the user.
+func (s *BasicAuthService) change(ctx context.Context, op, name string, fn func() error) error {
+	u := s.findState(ctx, name)
+	err := fn()
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  op,
+		Resource:   platform.UsersResource,
+		ResourceID: u.ID,
+	}, u, u, err)
+	return err
+}
+
+// SetPassword sets the password of a user, and records it.
+func (s *BasicAuthService) SetPassword(ctx context.Context, name string, password string) error {
+	return s.change(ctx, platform.OpSetPassword, name, func() error {
+		return s.BasicAuthService.SetPassword(ctx, name, password)
+	})
+}
+
+// CompareAndSetPassword replaces the password of a user, and records it.
+func (s *BasicAuthService) CompareAndSetPassword(ctx context.Context, name string, old string, new string) error {
+	return s.change(ctx, platform.OpCompareAndSetPassword, name, func() error {
+		return s.BasicAuthService.CompareAndSetPassword(ctx, name, old, new)
+	})
+}
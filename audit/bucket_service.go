@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BucketService = (*BucketService)(nil)
+
+// BucketService records the changes to buckets in the audit log.
+type BucketService struct {
+	platform.BucketService
+	Log *Log
+}
+
+func (s *BucketService) findState(ctx context.Context, id platform.ID) *platform.Bucket {
+	b, err := s.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// CreateBucket creates a bucket, and records it.
+func (s *BucketService) CreateBucket(ctx context.Context, b *platform.Bucket) error {
+	err := s.BucketService.CreateBucket(ctx, b)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateBucket,
+		Resource:   platform.BucketsResource,
+		ResourceID: b.ID,
+		OrgID:      b.OrganizationID,
+	}, nil, b, err)
+	return err
+}
+
+// UpdateBucket updates a bucket, and records it.
+func (s *BucketService) UpdateBucket(ctx context.Context, id platform.ID, upd platform.BucketUpdate) (*platform.Bucket, error) {
+	before := s.findState(ctx, id)
+	b, err := s.BucketService.UpdateBucket(ctx, id, upd)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpUpdateBucket,
+		Resource:   platform.BucketsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, b, err)
+	return b, err
+}
+
+// DeleteBucket deletes a bucket, and records it.
+func (s *BucketService) DeleteBucket(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.BucketService.DeleteBucket(ctx, id)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpDeleteBucket,
+		Resource:   platform.BucketsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, nil, err)
+	return err
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DashboardService = (*DashboardService)(nil)
+
+// DashboardService records the changes to dashboards and their cells in the
+// audit log. Changes to cells are recorded as changes to their dashboard.
+type DashboardService struct {
+	platform.DashboardService
+	Log *Log
+}
+
+func (s *DashboardService) findState(ctx context.Context, id platform.ID) *platform.Dashboard {
+	d, err := s.DashboardService.FindDashboardByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// change calls fn to change the dashboard of id, and records the dashboard
+// before and after the change.
+func (s *DashboardService) change(ctx context.Context, op string, id platform.ID, fn func() error) error {
+	before := s.findState(ctx, id)
+	err := fn()
+
+	var after *platform.Dashboard
+	if err == nil && op != platform.OpDeleteDashboard {
+		after = s.findState(ctx, id)
+	}
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  op,
+		Resource:   platform.DashboardsResource,
+		ResourceID: id,
+	}, before, after, err)
+	return err
+}
+
+// CreateDashboard creates a dashboard, and records it.
+func (s *DashboardService) CreateDashboard(ctx context.Context, d *platform.Dashboard) error {
+	err := s.DashboardService.CreateDashboard(ctx, d)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateDashboard,
+		Resource:   platform.DashboardsResource,
+		ResourceID: d.ID,
+	}, nil, d, err)
+	return err
+}
+
+// UpdateDashboard updates a dashboard, and records it.
+func (s *DashboardService) UpdateDashboard(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (d *platform.Dashboard, err error) {
+	err = s.change(ctx, platform.OpUpdateDashboard, id, func() error {
+		d, err = s.DashboardService.UpdateDashboard(ctx, id, upd)
+		return err
+	})
+	return d, err
+}
+
+// AddDashboardCell adds a cell to a dashboard, and records it.
+func (s *DashboardService) AddDashboardCell(ctx context.Context, id platform.ID, c *platform.Cell, opts platform.AddDashboardCellOptions) error {
+	return s.change(ctx, platform.OpAddDashboardCell, id, func() error {
+		return s.DashboardService.AddDashboardCell(ctx, id, c, opts)
+	})
+}
+
+// RemoveDashboardCell removes a cell from a dashboard, and records it.
+func (s *DashboardService) RemoveDashboardCell(ctx context.Context, dashboardID, cellID platform.ID) error {
+	return s.change(ctx, platform.OpRemoveDashboardCell, dashboardID, func() error {
+		return s.DashboardService.RemoveDashboardCell(ctx, dashboardID, cellID)
+	})
+}
+
+// UpdateDashboardCell updates a cell of a dashboard, and records it.
+func (s *DashboardService) UpdateDashboardCell(ctx context.Context, dashboardID, cellID platform.ID, upd platform.CellUpdate) (c *platform.Cell, err error) {
+	err = s.change(ctx, platform.OpUpdateDashboardCell, dashboardID, func() error {
+		c, err = s.DashboardService.UpdateDashboardCell(ctx, dashboardID, cellID, upd)
+		return err
+	})
+	return c, err
+}
+
+// UpdateDashboardCellView updates the view of a cell of a dashboard, and records
+// the view before and after the update.
+func (s *DashboardService) UpdateDashboardCellView(ctx context.Context, dashboardID, cellID platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
+	before, _ := s.DashboardService.GetDashboardCellView(ctx, dashboardID, cellID)
+	v, err := s.DashboardService.UpdateDashboardCellView(ctx, dashboardID, cellID, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateDashboardCellView,
+		Resource:   platform.DashboardsResource,
+		ResourceID: dashboardID,
+	}, before, v, err)
+	return v, err
+}
+
+// ReplaceDashboardCells replaces the cells of a dashboard, and records it.
+func (s *DashboardService) ReplaceDashboardCells(ctx context.Context, id platform.ID, cs []*platform.Cell) error {
+	return s.change(ctx, platform.OpReplaceDashboardCells, id, func() error {
+		return s.DashboardService.ReplaceDashboardCells(ctx, id, cs)
+	})
+}
+
+// DeleteDashboard deletes a dashboard, and records it.
+func (s *DashboardService) DeleteDashboard(ctx context.Context, id platform.ID) error {
+	return s.change(ctx, platform.OpDeleteDashboard, id, func() error {
+		return s.DashboardService.DeleteDashboard(ctx, id)
+	})
+}
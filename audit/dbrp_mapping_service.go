@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// DBRPMappingService records the changes to dbrp mappings in the audit log.
+// Mappings are recorded by the ID of the bucket they map to.
+type DBRPMappingService struct {
+	platform.DBRPMappingService
+	Log *Log
+}
+
+func (s *DBRPMappingService) findState(ctx context.Context, orgID platform.ID, cluster, db, rp string) *platform.DBRPMapping {
+	m, err := s.DBRPMappingService.FindBy(ctx, orgID, cluster, db, rp)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// Create creates a dbrp mapping, and records it.
+func (s *DBRPMappingService) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	before := s.findState(ctx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
+	err := s.DBRPMappingService.Create(ctx, m)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateDBRPMapping,
+		Resource:   platform.DBRPsResource,
+		ResourceID: m.BucketID,
+		OrgID:      m.OrganizationID,
+	}, before, m, err)
+	return err
+}
+
+// Delete deletes a dbrp mapping, and records it.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID platform.ID, cluster, db, rp string) error {
+	before := s.findState(ctx, orgID, cluster, db, rp)
+	err := s.DBRPMappingService.Delete(ctx, orgID, cluster, db, rp)
+
+	e := &platform.AuditEvent{
+		Operation: platform.OpDeleteDBRPMapping,
+		Resource:  platform.DBRPsResource,
+		OrgID:     orgID,
+	}
+	if before != nil {
+		e.ResourceID = before.BucketID
+	}
+	s.Log.record(ctx, e, before, nil, err)
+	return err
+}
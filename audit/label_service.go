@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.LabelService = (*LabelService)(nil)
+
+// LabelService records the changes to labels in the audit log. Labels are
+// recorded by the ID of the resource they label.
+type LabelService struct {
+	platform.LabelService
+	Log *Log
+}
+
+func (s *LabelService) findState(ctx context.Context, l platform.Label) *platform.Label {
+	ls, err := s.LabelService.FindLabels(ctx, platform.LabelFilter{
+		ResourceID: l.ResourceID,
+		Name:       l.Name,
+	})
+	if err != nil || len(ls) == 0 {
+		return nil
+	}
+	return ls[0]
+}
+
+// CreateLabel creates a label, and records it.
+func (s *LabelService) CreateLabel(ctx context.Context, l *platform.Label) error {
+	err := s.LabelService.CreateLabel(ctx, l)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateLabel,
+		Resource:   platform.LabelsResource,
+		ResourceID: l.ResourceID,
+	}, nil, l, err)
+	return err
+}
+
+// UpdateLabel updates a label, and records it.
+func (s *LabelService) UpdateLabel(ctx context.Context, l *platform.Label, upd platform.LabelUpdate) (*platform.Label, error) {
+	before := s.findState(ctx, *l)
+	label, err := s.LabelService.UpdateLabel(ctx, l, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateLabel,
+		Resource:   platform.LabelsResource,
+		ResourceID: l.ResourceID,
+	}, before, label, err)
+	return label, err
+}
+
+// DeleteLabel deletes a label, and records it.
+func (s *LabelService) DeleteLabel(ctx context.Context, l platform.Label) error {
+	before := s.findState(ctx, l)
+	err := s.LabelService.DeleteLabel(ctx, l)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpDeleteLabel,
+		Resource:   platform.LabelsResource,
+		ResourceID: l.ResourceID,
+	}, before, nil, err)
+	return err
+}
@@ -0,0 +1,281 @@
+// Package audit records who called the services to change which resources.
+// The services are decorated to record an event in a Log for every call that
+// changes a resource.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// SystemBucketName is the name of the bucket of each organization that
+	// its events are mirrored to.
+	SystemBucketName = "_audit"
+
+	// DefaultRetention is how long a Log keeps events by default.
+	DefaultRetention = 365 * 24 * time.Hour
+
+	// DefaultExpireInterval is how often a Log deletes expired events by
+	// default.
+	DefaultExpireInterval = time.Hour
+
+	measurement = "audit"
+)
+
+// Store persists the events of a Log.
+type Store interface {
+	// AddAuditEvent stores the event. It may move the time of the event so
+	// that it does not overwrite an event at the same time.
+	AddAuditEvent(ctx context.Context, e *platform.AuditEvent) error
+
+	// FindAuditEvents returns the events that match the filter, and the
+	// total count of matching events.
+	FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error)
+
+	// DeleteAuditEventsBefore removes the events before t.
+	DeleteAuditEventsBefore(ctx context.Context, t time.Time) error
+}
+
+// PointsWriter is a copy of the storage.PointsWriter interface.
+// Duplicating it here to avoid having audit depend directly on storage.
+type PointsWriter interface {
+	WritePoints(points []models.Point) error
+}
+
+var _ platform.AuditLogService = (*Log)(nil)
+
+// Log is an audit log of events kept in a Store.
+type Log struct {
+	Store Store
+
+	// PointsWriter, if set, mirrors every event of an organization as a
+	// point in the system bucket of the organization. The system bucket is
+	// found, or created, with BucketService, which must not record its
+	// changes in the Log.
+	PointsWriter  PointsWriter
+	BucketService platform.BucketService
+
+	// Retention is how long events are kept in the Store, and in the system
+	// buckets it creates. Zero keeps events forever.
+	Retention      time.Duration
+	ExpireInterval time.Duration
+
+	Logger *zap.Logger
+	Now    func() time.Time
+
+	mu sync.Mutex
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewLog returns a new Log that stores events in s.
+func NewLog(s Store) *Log {
+	return &Log{
+		Store:          s,
+		Retention:      DefaultRetention,
+		ExpireInterval: DefaultExpireInterval,
+		Logger:         zap.NewNop(),
+		Now:            time.Now,
+	}
+}
+
+// Open starts deleting the events older than the retention every
+// ExpireInterval.
+func (l *Log) Open() error {
+	if l.closing != nil {
+		return nil // Already open
+	}
+	l.closing = make(chan struct{})
+
+	ticker := time.NewTicker(l.ExpireInterval)
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.closing:
+				return
+			case <-ticker.C:
+				if err := l.DeleteExpired(context.Background()); err != nil {
+					l.Logger.Error("Failed to delete expired audit events", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops deleting expired events.
+func (l *Log) Close() error {
+	if l.closing == nil {
+		return nil // Already closed
+	}
+	close(l.closing)
+	l.wg.Wait()
+	l.closing = nil
+	return nil
+}
+
+// DeleteExpired deletes the events logged longer ago than the retention.
+func (l *Log) DeleteExpired(ctx context.Context) error {
+	if l.Retention <= 0 {
+		return nil
+	}
+	return l.Store.DeleteAuditEventsBefore(ctx, l.Now().Add(-l.Retention))
+}
+
+// LogAuditEvent adds the event to the audit log. The time of the event is set
+// if it is zero.
+func (l *Log) LogAuditEvent(ctx context.Context, e *platform.AuditEvent) error {
+	if e.Time.IsZero() {
+		e.Time = l.Now()
+	}
+
+	if err := l.Store.AddAuditEvent(ctx, e); err != nil {
+		return &platform.Error{
+			Op:  platform.OpLogAuditEvent,
+			Err: err,
+		}
+	}
+
+	if l.PointsWriter != nil && e.OrgID.Valid() {
+		if err := l.writePoint(ctx, e); err != nil {
+			return &platform.Error{
+				Op:  platform.OpLogAuditEvent,
+				Err: err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// systemBucket returns the ID of the system bucket of the organization, and
+// creates the bucket if it does not exist.
+func (l *Log) systemBucket(ctx context.Context, orgID platform.ID) (platform.ID, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	name := SystemBucketName
+	b, err := l.BucketService.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err == nil {
+		return b.ID, nil
+	}
+	if platform.ErrorCode(err) != platform.ENotFound {
+		return 0, err
+	}
+
+	b = &platform.Bucket{
+		OrganizationID:  orgID,
+		Name:            name,
+		RetentionPeriod: l.Retention,
+	}
+	if err := l.BucketService.CreateBucket(ctx, b); err != nil {
+		return 0, err
+	}
+	return b.ID, nil
+}
+
+func (l *Log) writePoint(ctx context.Context, e *platform.AuditEvent) error {
+	bucketID, err := l.systemBucket(ctx, e.OrgID)
+	if err != nil {
+		return err
+	}
+
+	tags := models.Tags{
+		models.NewTag([]byte("operation"), []byte(e.Operation)),
+		models.NewTag([]byte("resource"), []byte(e.Resource)),
+	}
+	fields := map[string]interface{}{
+		"success": e.Error == "",
+	}
+	if e.ResourceID.Valid() {
+		fields["resourceID"] = e.ResourceID.String()
+	}
+	if e.UserID.Valid() {
+		fields["userID"] = e.UserID.String()
+	}
+	if e.AuthorizationID.Valid() {
+		fields["authorizationID"] = e.AuthorizationID.String()
+	}
+	if e.Source != "" {
+		fields["source"] = e.Source
+	}
+
+	pt, err := models.NewPoint(measurement, tags, fields, e.Time)
+	if err != nil {
+		return err
+	}
+
+	exploded, err := tsdb.ExplodePoints(e.OrgID, bucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+
+	return l.PointsWriter.WritePoints(exploded)
+}
+
+// FindAuditEvents returns the events that match the filter, and the total count
+// of matching events. Offset and limit apply to the matching events.
+func (l *Log) FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	es, n, err := l.Store.FindAuditEvents(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, &platform.Error{
+			Op:  platform.OpFindAuditEvents,
+			Err: err,
+		}
+	}
+	return es, n, nil
+}
+
+// record logs an event of the call to a service by the authorizer of ctx.
+// The state of the resource after the call is only recorded if the call
+// succeeded. Failing to log the event does not fail the call, it is logged.
+func (l *Log) record(ctx context.Context, e *platform.AuditEvent, before, after interface{}, err error) {
+	e.Source = pcontext.GetRequestSource(ctx)
+	if a, aerr := pcontext.GetAuthorizer(ctx); aerr == nil {
+		e.UserID = a.GetUserID()
+		if auth, ok := a.(*platform.Authorization); ok {
+			e.AuthorizationID = auth.ID
+		}
+	}
+
+	e.Before = marshalState(before)
+	if err != nil {
+		e.Error = err.Error()
+	} else {
+		e.After = marshalState(after)
+	}
+
+	if err := l.LogAuditEvent(ctx, e); err != nil {
+		l.Logger.Error("failed to log audit event",
+			zap.String("operation", e.Operation),
+			zap.String("resource", string(e.Resource)),
+			zap.Error(err))
+	}
+}
+
+func marshalState(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) == "null" {
+		return nil
+	}
+	return b
+}
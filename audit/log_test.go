@@ -0,0 +1,420 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	"github.com/influxdata/platform/bolt"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func newTestLog(t *testing.T) (*audit.Log, func()) {
+	t.Helper()
+
+	f, err := ioutil.TempFile("", "influxdata-platform-audit-")
+	if err != nil {
+		t.Fatalf("unable to open temporary boltdb file: %v", err)
+	}
+	f.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("failed to open bolt client: %v", err)
+	}
+
+	l := audit.NewLog(c)
+	now := time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC)
+	l.Now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return l, func() {
+		c.Close()
+		os.Remove(c.Path)
+	}
+}
+
+func operations(es []*platform.AuditEvent) []string {
+	ops := []string{}
+	for _, e := range es {
+		ops = append(ops, e.Operation)
+	}
+	return ops
+}
+
+func TestLog_FindAuditEvents(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	// Finding events before any are logged finds none.
+	es, n, err := l.FindAuditEvents(ctx, platform.AuditFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error finding events of an empty log: %v", err)
+	}
+	if len(es) != 0 || n != 0 {
+		t.Fatalf("expected no events, got %d of %d", len(es), n)
+	}
+
+	events := []*platform.AuditEvent{
+		{Operation: platform.OpCreateBucket, Resource: platform.BucketsResource, ResourceID: 1, OrgID: 10},
+		{Operation: platform.OpUpdateBucket, Resource: platform.BucketsResource, ResourceID: 1, OrgID: 10},
+		{Operation: platform.OpCreateDashboard, Resource: platform.DashboardsResource, ResourceID: 2, OrgID: 20},
+		{Operation: platform.OpDeleteBucket, Resource: platform.BucketsResource, ResourceID: 1, OrgID: 10},
+	}
+	for _, e := range events {
+		if err := l.LogAuditEvent(ctx, e); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+
+	orgID := platform.ID(10)
+	dashboards := platform.DashboardsResource
+	since := events[1].Time
+	until := events[3].Time
+
+	tests := []struct {
+		name   string
+		filter platform.AuditFilter
+		opts   platform.FindOptions
+		ops    []string
+		n      int
+	}{
+		{
+			name: "all events",
+			ops:  []string{platform.OpCreateBucket, platform.OpUpdateBucket, platform.OpCreateDashboard, platform.OpDeleteBucket},
+			n:    4,
+		},
+		{
+			name: "descending",
+			opts: platform.FindOptions{Descending: true},
+			ops:  []string{platform.OpDeleteBucket, platform.OpCreateDashboard, platform.OpUpdateBucket, platform.OpCreateBucket},
+			n:    4,
+		},
+		{
+			name:   "by organization",
+			filter: platform.AuditFilter{OrgID: &orgID},
+			ops:    []string{platform.OpCreateBucket, platform.OpUpdateBucket, platform.OpDeleteBucket},
+			n:      3,
+		},
+		{
+			name:   "by resource",
+			filter: platform.AuditFilter{Resource: &dashboards},
+			ops:    []string{platform.OpCreateDashboard},
+			n:      1,
+		},
+		{
+			name:   "by time",
+			filter: platform.AuditFilter{Since: &since, Until: &until},
+			ops:    []string{platform.OpUpdateBucket, platform.OpCreateDashboard},
+			n:      2,
+		},
+		{
+			name:   "paged",
+			filter: platform.AuditFilter{OrgID: &orgID},
+			opts:   platform.FindOptions{Offset: 1, Limit: 1},
+			ops:    []string{platform.OpUpdateBucket},
+			n:      3,
+		},
+		{
+			name:   "descending by time",
+			filter: platform.AuditFilter{Since: &since, Until: &until},
+			opts:   platform.FindOptions{Descending: true},
+			ops:    []string{platform.OpCreateDashboard, platform.OpUpdateBucket},
+			n:      2,
+		},
+		{
+			name:   "paged descending",
+			filter: platform.AuditFilter{OrgID: &orgID},
+			opts:   platform.FindOptions{Offset: 2, Limit: 2, Descending: true},
+			ops:    []string{platform.OpCreateBucket},
+			n:      3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es, n, err := l.FindAuditEvents(ctx, tt.filter, tt.opts)
+			if err != nil {
+				t.Fatalf("failed to find events: %v", err)
+			}
+			if diff := cmp.Diff(operations(es), tt.ops); diff != "" {
+				t.Errorf("operations are different -got/+want\ndiff %s", diff)
+			}
+			if n != tt.n {
+				t.Errorf("expected total of %d events, got %d", tt.n, n)
+			}
+		})
+	}
+}
+
+func TestLog_LogAuditEvent_SameTime(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	now := time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC)
+	for _, op := range []string{platform.OpCreateBucket, platform.OpDeleteBucket} {
+		if err := l.LogAuditEvent(ctx, &platform.AuditEvent{Time: now, Operation: op}); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+
+	es, _, err := l.FindAuditEvents(ctx, platform.AuditFilter{})
+	if err != nil {
+		t.Fatalf("failed to find events: %v", err)
+	}
+	if diff := cmp.Diff(operations(es), []string{platform.OpCreateBucket, platform.OpDeleteBucket}); diff != "" {
+		t.Errorf("events logged at the same time overwrote each other -got/+want\ndiff %s", diff)
+	}
+}
+
+func TestLog_PointsWriter(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	c := l.Store.(*bolt.Client)
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	pw := &mock.PointsWriter{}
+	l.PointsWriter = pw
+	l.BucketService = c
+
+	if err := l.LogAuditEvent(ctx, &platform.AuditEvent{Operation: platform.OpCreateBucket, Resource: platform.BucketsResource}); err != nil {
+		t.Fatalf("failed to log event: %v", err)
+	}
+	if len(pw.Points) != 0 {
+		t.Fatalf("expected events without an organization not to be written, got %d points", len(pw.Points))
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := l.LogAuditEvent(ctx, &platform.AuditEvent{Operation: platform.OpCreateBucket, Resource: platform.BucketsResource, OrgID: org.ID}); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+	if len(pw.Points) == 0 {
+		t.Fatal("expected the event of an organization to be written as points")
+	}
+
+	// The system bucket is created by the first event, and found by the next.
+	name := audit.SystemBucketName
+	bs, _, err := c.FindBuckets(ctx, platform.BucketFilter{OrganizationID: &org.ID, Name: &name})
+	if err != nil {
+		t.Fatalf("failed to find the system bucket: %v", err)
+	}
+	if len(bs) != 1 {
+		t.Fatalf("expected a single system bucket, got %d", len(bs))
+	}
+	if bs[0].RetentionPeriod != l.Retention {
+		t.Errorf("expected the system bucket to keep points for %v, got %v", l.Retention, bs[0].RetentionPeriod)
+	}
+
+	var name16 [16]byte
+	for _, p := range pw.Points {
+		copy(name16[:], p.Name())
+		if orgID, bucketID := tsdb.DecodeName(name16); orgID != org.ID || bucketID != bs[0].ID {
+			t.Fatalf("expected points to be written to bucket %s of org %s, got bucket %s of org %s", bs[0].ID, org.ID, bucketID, orgID)
+		}
+	}
+}
+
+func TestLog_DeleteExpired(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	l.Retention = 2 * time.Second
+	for _, e := range []*platform.AuditEvent{
+		{Operation: platform.OpCreateBucket, OrgID: 10},
+		{Operation: platform.OpUpdateBucket, OrgID: 10},
+		{Operation: platform.OpDeleteBucket, OrgID: 10},
+	} {
+		if err := l.LogAuditEvent(ctx, e); err != nil {
+			t.Fatalf("failed to log event: %v", err)
+		}
+	}
+
+	// The next time is a second after the last event, so the first event is
+	// older than the retention.
+	if err := l.DeleteExpired(ctx); err != nil {
+		t.Fatalf("failed to delete expired events: %v", err)
+	}
+
+	orgID := platform.ID(10)
+	for _, filter := range []platform.AuditFilter{{}, {OrgID: &orgID}} {
+		es, n, err := l.FindAuditEvents(ctx, filter)
+		if err != nil {
+			t.Fatalf("failed to find events: %v", err)
+		}
+		if diff := cmp.Diff(operations(es), []string{platform.OpUpdateBucket, platform.OpDeleteBucket}); diff != "" {
+			t.Errorf("operations are different -got/+want\ndiff %s", diff)
+		}
+		if n != 2 {
+			t.Errorf("expected total of 2 events, got %d", n)
+		}
+	}
+}
+
+func TestBucketService(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+
+	b := &platform.Bucket{ID: 1, OrganizationID: 10, Name: "before"}
+	svc := mock.NewBucketService()
+	svc.FindBucketByIDFn = func(context.Context, platform.ID) (*platform.Bucket, error) {
+		return b, nil
+	}
+	svc.UpdateBucketFn = func(_ context.Context, id platform.ID, upd platform.BucketUpdate) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: id, OrganizationID: 10, Name: *upd.Name}, nil
+	}
+	svc.DeleteBucketFn = func(context.Context, platform.ID) error {
+		return errors.New("bucket is busy")
+	}
+	s := &audit.BucketService{BucketService: svc, Log: l}
+
+	ctx := pcontext.SetAuthorizer(context.Background(), &platform.Authorization{ID: 100, UserID: 200})
+	ctx = pcontext.SetRequestSource(ctx, "10.0.0.1:1234")
+
+	name := "after"
+	if _, err := s.UpdateBucket(ctx, 1, platform.BucketUpdate{Name: &name}); err != nil {
+		t.Fatalf("unexpected error updating bucket: %v", err)
+	}
+	if err := s.DeleteBucket(ctx, 1); err == nil {
+		t.Fatal("expected the error of deleting the bucket to be returned")
+	}
+
+	es, _, err := l.FindAuditEvents(ctx, platform.AuditFilter{})
+	if err != nil {
+		t.Fatalf("failed to find events: %v", err)
+	}
+	if len(es) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(es))
+	}
+
+	update, del := es[0], es[1]
+	want := platform.AuditEvent{
+		Time:            update.Time,
+		UserID:          200,
+		AuthorizationID: 100,
+		Source:          "10.0.0.1:1234",
+		Operation:       platform.OpUpdateBucket,
+		Resource:        platform.BucketsResource,
+		ResourceID:      1,
+		OrgID:           10,
+	}
+	got := *update
+	got.Before, got.After = nil, nil
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("update events are different -got/+want\ndiff %s", diff)
+	}
+	if bucketName(t, update.Before) != "before" || bucketName(t, update.After) != "after" {
+		t.Errorf("expected the bucket to be renamed from before to after, got %s and %s", update.Before, update.After)
+	}
+
+	if del.Error != "bucket is busy" {
+		t.Errorf("expected the error of the delete to be recorded, got %q", del.Error)
+	}
+	if del.After != nil {
+		t.Errorf("expected no state after a failed delete, got %s", del.After)
+	}
+}
+
+func bucketName(t *testing.T, state json.RawMessage) string {
+	t.Helper()
+	var b platform.Bucket
+	if err := json.Unmarshal(state, &b); err != nil {
+		t.Fatalf("failed to unmarshal bucket state %s: %v", state, err)
+	}
+	return b.Name
+}
+
+func TestBasicAuthService(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	users := mock.NewUserService()
+	users.FindUserFn = func(_ context.Context, filter platform.UserFilter) (*platform.User, error) {
+		return &platform.User{ID: 1, Name: *filter.Name}, nil
+	}
+	passwords := mock.NewBasicAuthService("", "")
+	passwords.SetPasswordFn = func(context.Context, string, string) error { return nil }
+	s := &audit.BasicAuthService{BasicAuthService: passwords, Log: l, UserService: users}
+
+	if err := s.SetPassword(ctx, "user", "secret-password"); err != nil {
+		t.Fatalf("unexpected error setting password: %v", err)
+	}
+
+	es, _, err := l.FindAuditEvents(ctx, platform.AuditFilter{})
+	if err != nil {
+		t.Fatalf("failed to find events: %v", err)
+	}
+	if len(es) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(es))
+	}
+	if e := es[0]; e.Operation != platform.OpSetPassword || e.Resource != platform.UsersResource || e.ResourceID != 1 {
+		t.Errorf("expected the password of user 1 to be set, got %s of %s %s", e.Operation, e.Resource, e.ResourceID)
+	}
+	if b, _ := json.Marshal(es[0]); strings.Contains(string(b), "secret-password") {
+		t.Errorf("expected the password not to be recorded, got %s", b)
+	}
+}
+
+func TestOnboardingService(t *testing.T) {
+	l, done := newTestLog(t)
+	defer done()
+	ctx := context.Background()
+
+	svc := mock.NewOnboardingService()
+	svc.GenerateFn = func(context.Context, *platform.OnboardingRequest) (*platform.OnboardingResults, error) {
+		return &platform.OnboardingResults{
+			User:   &platform.User{ID: 1, Name: "user"},
+			Org:    &platform.Organization{ID: 10, Name: "org"},
+			Bucket: &platform.Bucket{ID: 2, OrganizationID: 10, Name: "bucket"},
+			Auth:   &platform.Authorization{ID: 3, OrgID: 10, UserID: 1, Token: "secret-token"},
+		}, nil
+	}
+	s := &audit.OnboardingService{OnboardingService: svc, Log: l}
+
+	res, err := s.Generate(ctx, &platform.OnboardingRequest{User: "user", Password: "secret-password", Org: "org", Bucket: "bucket"})
+	if err != nil {
+		t.Fatalf("unexpected error onboarding: %v", err)
+	}
+	if res.Auth.Token != "secret-token" {
+		t.Errorf("expected the token to be returned, got %q", res.Auth.Token)
+	}
+
+	orgID := platform.ID(10)
+	es, _, err := l.FindAuditEvents(ctx, platform.AuditFilter{OrgID: &orgID})
+	if err != nil {
+		t.Fatalf("failed to find events: %v", err)
+	}
+	if len(es) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(es))
+	}
+	if e := es[0]; e.Operation != platform.OpGenerateOnboarding || e.ResourceID != 10 {
+		t.Errorf("expected the onboarding of org 10, got %s of %s", e.Operation, e.ResourceID)
+	}
+	b, _ := json.Marshal(es[0])
+	for _, secret := range []string{"secret-token", "secret-password"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("expected %s not to be recorded, got %s", secret, b)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.MacroService = (*MacroService)(nil)
+
+// MacroService records the changes to macros in the audit log.
+type MacroService struct {
+	platform.MacroService
+	Log *Log
+}
+
+func (s *MacroService) findState(ctx context.Context, id platform.ID) *platform.Macro {
+	m, err := s.MacroService.FindMacroByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// CreateMacro creates a macro, and records it.
+func (s *MacroService) CreateMacro(ctx context.Context, m *platform.Macro) error {
+	err := s.MacroService.CreateMacro(ctx, m)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateMacro,
+		Resource:   platform.MacrosResource,
+		ResourceID: m.ID,
+	}, nil, m, err)
+	return err
+}
+
+// UpdateMacro updates a macro, and records it.
+func (s *MacroService) UpdateMacro(ctx context.Context, id platform.ID, upd *platform.MacroUpdate) (*platform.Macro, error) {
+	before := s.findState(ctx, id)
+	m, err := s.MacroService.UpdateMacro(ctx, id, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateMacro,
+		Resource:   platform.MacrosResource,
+		ResourceID: id,
+	}, before, m, err)
+	return m, err
+}
+
+// ReplaceMacro replaces a macro, and records it.
+func (s *MacroService) ReplaceMacro(ctx context.Context, m *platform.Macro) error {
+	before := s.findState(ctx, m.ID)
+	err := s.MacroService.ReplaceMacro(ctx, m)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpReplaceMacro,
+		Resource:   platform.MacrosResource,
+		ResourceID: m.ID,
+	}, before, m, err)
+	return err
+}
+
+// DeleteMacro deletes a macro, and records it.
+func (s *MacroService) DeleteMacro(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.MacroService.DeleteMacro(ctx, id)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpDeleteMacro,
+		Resource:   platform.MacrosResource,
+		ResourceID: id,
+	}, before, nil, err)
+	return err
+}
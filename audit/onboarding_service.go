@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.OnboardingService = (*OnboardingService)(nil)
+
+// OnboardingService records onboarding in the audit log, as the creation of
+// its organization. The results are recorded without the token of their
+// authorization, and the request is not recorded as it holds a password.
+type OnboardingService struct {
+	platform.OnboardingService
+	Log *Log
+}
+
+// Generate onboards the first user, and records the user, organization,
+// bucket and authorization it created.
+func (s *OnboardingService) Generate(ctx context.Context, req *platform.OnboardingRequest) (*platform.OnboardingResults, error) {
+	res, err := s.OnboardingService.Generate(ctx, req)
+
+	e := &platform.AuditEvent{
+		Operation: platform.OpGenerateOnboarding,
+		Resource:  platform.OrgsResource,
+	}
+	var after *platform.OnboardingResults
+	if res != nil {
+		after = &platform.OnboardingResults{
+			User:   res.User,
+			Org:    res.Org,
+			Bucket: res.Bucket,
+			Auth:   authorizationState(res.Auth),
+		}
+		if res.Org != nil {
+			e.ResourceID = res.Org.ID
+			e.OrgID = res.Org.ID
+		}
+	}
+	s.Log.record(ctx, e, nil, after, err)
+	return res, err
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.OrganizationService = (*OrganizationService)(nil)
+
+// OrganizationService records the changes to organizations in the audit log.
+type OrganizationService struct {
+	platform.OrganizationService
+	Log *Log
+}
+
+func (s *OrganizationService) findState(ctx context.Context, id platform.ID) *platform.Organization {
+	o, err := s.OrganizationService.FindOrganizationByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return o
+}
+
+// CreateOrganization creates an organization, and records it.
+func (s *OrganizationService) CreateOrganization(ctx context.Context, o *platform.Organization) error {
+	err := s.OrganizationService.CreateOrganization(ctx, o)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateOrganization,
+		Resource:   platform.OrgsResource,
+		ResourceID: o.ID,
+		OrgID:      o.ID,
+	}, nil, o, err)
+	return err
+}
+
+// UpdateOrganization updates an organization, and records it.
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, id platform.ID, upd platform.OrganizationUpdate) (*platform.Organization, error) {
+	before := s.findState(ctx, id)
+	o, err := s.OrganizationService.UpdateOrganization(ctx, id, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateOrganization,
+		Resource:   platform.OrgsResource,
+		ResourceID: id,
+		OrgID:      id,
+	}, before, o, err)
+	return o, err
+}
+
+// DeleteOrganization deletes an organization, and records it.
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.OrganizationService.DeleteOrganization(ctx, id)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpDeleteOrganization,
+		Resource:   platform.OrgsResource,
+		ResourceID: id,
+		OrgID:      id,
+	}, before, nil, err)
+	return err
+}
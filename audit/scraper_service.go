@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ScraperTargetStoreService = (*ScraperTargetStoreService)(nil)
+
+// ScraperTargetStoreService records the changes to scraper targets in the audit log.
+type ScraperTargetStoreService struct {
+	platform.ScraperTargetStoreService
+	Log *Log
+}
+
+func (s *ScraperTargetStoreService) findState(ctx context.Context, id platform.ID) *platform.ScraperTarget {
+	t, err := s.ScraperTargetStoreService.GetTargetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// AddTarget adds a scraper target, and records it.
+func (s *ScraperTargetStoreService) AddTarget(ctx context.Context, t *platform.ScraperTarget) error {
+	err := s.ScraperTargetStoreService.AddTarget(ctx, t)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpAddTarget,
+		Resource:   platform.ScrapersResource,
+		ResourceID: t.ID,
+	}, nil, t, err)
+	return err
+}
+
+// UpdateTarget updates a scraper target, and records it.
+func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, t *platform.ScraperTarget) (*platform.ScraperTarget, error) {
+	before := s.findState(ctx, t.ID)
+	after, err := s.ScraperTargetStoreService.UpdateTarget(ctx, t)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateTarget,
+		Resource:   platform.ScrapersResource,
+		ResourceID: t.ID,
+	}, before, after, err)
+	return after, err
+}
+
+// RemoveTarget removes a scraper target, and records it.
+func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.ScraperTargetStoreService.RemoveTarget(ctx, id)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpRemoveTarget,
+		Resource:   platform.ScrapersResource,
+		ResourceID: id,
+	}, before, nil, err)
+	return err
+}
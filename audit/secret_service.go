@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.SecretService = (*SecretService)(nil)
+
+// SecretService records the changes to the secrets of organizations in the
+// audit log. Only the keys of the secrets are recorded, never their values.
+type SecretService struct {
+	platform.SecretService
+	Log *Log
+}
+
+func (s *SecretService) findState(ctx context.Context, orgID platform.ID) []string {
+	ks, err := s.SecretService.GetSecretKeys(ctx, orgID)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// change calls fn to change the secrets of the organization, and records the
+// keys of its secrets before and after the change.
+func (s *SecretService) change(ctx context.Context, op string, orgID platform.ID, fn func() error) error {
+	before := s.findState(ctx, orgID)
+	err := fn()
+
+	var after []string
+	if err == nil {
+		after = s.findState(ctx, orgID)
+	}
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  op,
+		Resource:   platform.SecretsResource,
+		ResourceID: orgID,
+		OrgID:      orgID,
+	}, before, after, err)
+	return err
+}
+
+// PutSecret stores a secret, and records its key.
+func (s *SecretService) PutSecret(ctx context.Context, orgID platform.ID, k string, v string) error {
+	return s.change(ctx, platform.OpPutSecret, orgID, func() error {
+		return s.SecretService.PutSecret(ctx, orgID, k, v)
+	})
+}
+
+// PutSecrets replaces the secrets of an organization, and records their keys.
+func (s *SecretService) PutSecrets(ctx context.Context, orgID platform.ID, m map[string]string) error {
+	return s.change(ctx, platform.OpPutSecrets, orgID, func() error {
+		return s.SecretService.PutSecrets(ctx, orgID, m)
+	})
+}
+
+// PatchSecrets updates secrets of an organization, and records their keys.
+func (s *SecretService) PatchSecrets(ctx context.Context, orgID platform.ID, m map[string]string) error {
+	return s.change(ctx, platform.OpPatchSecrets, orgID, func() error {
+		return s.SecretService.PatchSecrets(ctx, orgID, m)
+	})
+}
+
+// DeleteSecret deletes secrets of an organization, and records their keys.
+func (s *SecretService) DeleteSecret(ctx context.Context, orgID platform.ID, ks ...string) error {
+	return s.change(ctx, platform.OpDeleteSecret, orgID, func() error {
+		return s.SecretService.DeleteSecret(ctx, orgID, ks...)
+	})
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.SourceService = (*SourceService)(nil)
+
+// SourceService records the changes to sources in the audit log.
+type SourceService struct {
+	platform.SourceService
+	Log *Log
+}
+
+// sourceState is the state of a source without its credentials.
+func sourceState(src *platform.Source) *platform.Source {
+	if src == nil {
+		return nil
+	}
+	state := *src
+	state.Token = ""
+	state.Password = ""
+	state.SharedSecret = ""
+	return &state
+}
+
+func (s *SourceService) findState(ctx context.Context, id platform.ID) *platform.Source {
+	src, err := s.SourceService.FindSourceByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return sourceState(src)
+}
+
+// CreateSource creates a source, and records it.
+func (s *SourceService) CreateSource(ctx context.Context, src *platform.Source) error {
+	err := s.SourceService.CreateSource(ctx, src)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateSource,
+		Resource:   platform.SourcesResource,
+		ResourceID: src.ID,
+		OrgID:      src.OrganizationID,
+	}, nil, sourceState(src), err)
+	return err
+}
+
+// UpdateSource updates a source, and records it.
+func (s *SourceService) UpdateSource(ctx context.Context, id platform.ID, upd platform.SourceUpdate) (*platform.Source, error) {
+	before := s.findState(ctx, id)
+	src, err := s.SourceService.UpdateSource(ctx, id, upd)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpUpdateSource,
+		Resource:   platform.SourcesResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, sourceState(src), err)
+	return src, err
+}
+
+// DeleteSource deletes a source, and records it.
+func (s *SourceService) DeleteSource(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.SourceService.DeleteSource(ctx, id)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpDeleteSource,
+		Resource:   platform.SourcesResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, nil, err)
+	return err
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.TaskService = (*TaskService)(nil)
+
+// TaskService records the changes to tasks and to their runs in the audit log.
+// Changes to runs are recorded as changes to their task.
+type TaskService struct {
+	platform.TaskService
+	Log *Log
+}
+
+func (s *TaskService) findState(ctx context.Context, id platform.ID) *platform.Task {
+	t, err := s.TaskService.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+func newTaskEvent(op string, t *platform.Task, id platform.ID) *platform.AuditEvent {
+	e := &platform.AuditEvent{
+		Operation:  op,
+		Resource:   platform.TasksResource,
+		ResourceID: id,
+	}
+	if t != nil {
+		e.OrgID = t.Organization
+	}
+	return e
+}
+
+// CreateTask creates a task, and records it.
+func (s *TaskService) CreateTask(ctx context.Context, t *platform.Task) error {
+	err := s.TaskService.CreateTask(ctx, t)
+	s.Log.record(ctx, newTaskEvent(platform.OpCreateTask, t, t.ID), nil, t, err)
+	return err
+}
+
+// UpdateTask updates a task, and records it.
+func (s *TaskService) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	before := s.findState(ctx, id)
+	t, err := s.TaskService.UpdateTask(ctx, id, upd)
+	s.Log.record(ctx, newTaskEvent(platform.OpUpdateTask, before, id), before, t, err)
+	return t, err
+}
+
+// DeleteTask deletes a task, and records it.
+func (s *TaskService) DeleteTask(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.TaskService.DeleteTask(ctx, id)
+	s.Log.record(ctx, newTaskEvent(platform.OpDeleteTask, before, id), before, nil, err)
+	return err
+}
+
+// CancelRun cancels a run of a task, and records it.
+func (s *TaskService) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
+	err := s.TaskService.CancelRun(ctx, taskID, runID)
+	s.Log.record(ctx, newTaskEvent(platform.OpCancelRun, s.findState(ctx, taskID), taskID), nil, nil, err)
+	return err
+}
+
+// RetryRun retries a run of a task, and records the new run.
+func (s *TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	r, err := s.TaskService.RetryRun(ctx, taskID, runID)
+	s.Log.record(ctx, newTaskEvent(platform.OpRetryRun, s.findState(ctx, taskID), taskID), nil, r, err)
+	return r, err
+}
+
+// ForceRun forces a run of a task, and records the new run.
+func (s *TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	r, err := s.TaskService.ForceRun(ctx, taskID, scheduledFor)
+	s.Log.record(ctx, newTaskEvent(platform.OpForceRun, s.findState(ctx, taskID), taskID), nil, r, err)
+	return r, err
+}
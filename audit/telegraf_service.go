@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/telegraf/plugins"
+)
+
+var _ platform.TelegrafConfigStore = (*TelegrafConfigStore)(nil)
+
+// TelegrafConfigStore records the changes to telegraf configs in the audit log.
+type TelegrafConfigStore struct {
+	platform.TelegrafConfigStore
+	Log *Log
+}
+
+// telegrafConfigState is the state of a telegraf config. The configs of its
+// plugins are left out, as they may hold credentials such as tokens.
+type telegrafConfigState struct {
+	ID             platform.ID                  `json:"id"`
+	OrganizationID platform.ID                  `json:"organizationID,omitempty"`
+	Name           string                       `json:"name"`
+	Agent          platform.TelegrafAgentConfig `json:"agent"`
+	Plugins        []telegrafPluginState        `json:"plugins"`
+}
+
+type telegrafPluginState struct {
+	Name    string       `json:"name"`
+	Type    plugins.Type `json:"type"`
+	Comment string       `json:"comment"`
+}
+
+func newTelegrafConfigState(tc *platform.TelegrafConfig) *telegrafConfigState {
+	if tc == nil {
+		return nil
+	}
+	state := &telegrafConfigState{
+		ID:             tc.ID,
+		OrganizationID: tc.OrganizationID,
+		Name:           tc.Name,
+		Agent:          tc.Agent,
+		Plugins:        make([]telegrafPluginState, 0, len(tc.Plugins)),
+	}
+	for _, p := range tc.Plugins {
+		ps := telegrafPluginState{Comment: p.Comment}
+		if p.Config != nil {
+			ps.Name = p.Config.PluginName()
+			ps.Type = p.Config.Type()
+		}
+		state.Plugins = append(state.Plugins, ps)
+	}
+	return state
+}
+
+func (s *TelegrafConfigStore) findState(ctx context.Context, id platform.ID) *telegrafConfigState {
+	tc, err := s.TelegrafConfigStore.FindTelegrafConfigByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return newTelegrafConfigState(tc)
+}
+
+// CreateTelegrafConfig creates a telegraf config, and records it.
+func (s *TelegrafConfigStore) CreateTelegrafConfig(ctx context.Context, tc *platform.TelegrafConfig, userID platform.ID) error {
+	err := s.TelegrafConfigStore.CreateTelegrafConfig(ctx, tc, userID)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateTelegrafConfig,
+		Resource:   platform.TelegrafsResource,
+		ResourceID: tc.ID,
+		OrgID:      tc.OrganizationID,
+	}, nil, newTelegrafConfigState(tc), err)
+	return err
+}
+
+// UpdateTelegrafConfig updates a telegraf config, and records it.
+func (s *TelegrafConfigStore) UpdateTelegrafConfig(ctx context.Context, id platform.ID, tc *platform.TelegrafConfig, userID platform.ID) (*platform.TelegrafConfig, error) {
+	before := s.findState(ctx, id)
+	after, err := s.TelegrafConfigStore.UpdateTelegrafConfig(ctx, id, tc, userID)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpUpdateTelegrafConfig,
+		Resource:   platform.TelegrafsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, newTelegrafConfigState(after), err)
+	return after, err
+}
+
+// DeleteTelegrafConfig deletes a telegraf config, and records it.
+func (s *TelegrafConfigStore) DeleteTelegrafConfig(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.TelegrafConfigStore.DeleteTelegrafConfig(ctx, id)
+
+	e := &platform.AuditEvent{
+		Operation:  platform.OpDeleteTelegrafConfig,
+		Resource:   platform.TelegrafsResource,
+		ResourceID: id,
+	}
+	if before != nil {
+		e.OrgID = before.OrganizationID
+	}
+	s.Log.record(ctx, e, before, nil, err)
+	return err
+}
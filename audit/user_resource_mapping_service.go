@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UserResourceMappingService = (*UserResourceMappingService)(nil)
+
+// UserResourceMappingService records the changes to the owners and members of
+// resources in the audit log. A mapping is recorded as a change to the
+// resource it maps the user to.
+type UserResourceMappingService struct {
+	platform.UserResourceMappingService
+	Log *Log
+}
+
+func (s *UserResourceMappingService) findState(ctx context.Context, resourceID, userID platform.ID) *platform.UserResourceMapping {
+	ms, _, err := s.UserResourceMappingService.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		ResourceID: resourceID,
+		UserID:     userID,
+	})
+	if err != nil || len(ms) == 0 {
+		return nil
+	}
+	return ms[0]
+}
+
+func newUserResourceMappingEvent(op string, m *platform.UserResourceMapping, resourceID platform.ID) *platform.AuditEvent {
+	e := &platform.AuditEvent{
+		Operation:  op,
+		ResourceID: resourceID,
+	}
+	if m != nil {
+		e.Resource = m.Resource
+		if m.Resource == platform.OrgsResource {
+			e.OrgID = m.ResourceID
+		}
+	}
+	return e
+}
+
+// CreateUserResourceMapping creates a user resource mapping, and records it.
+func (s *UserResourceMappingService) CreateUserResourceMapping(ctx context.Context, m *platform.UserResourceMapping) error {
+	err := s.UserResourceMappingService.CreateUserResourceMapping(ctx, m)
+	s.Log.record(ctx, newUserResourceMappingEvent(platform.OpCreateUserResourceMapping, m, m.ResourceID), nil, m, err)
+	return err
+}
+
+// DeleteUserResourceMapping deletes a user resource mapping, and records it.
+func (s *UserResourceMappingService) DeleteUserResourceMapping(ctx context.Context, resourceID, userID platform.ID) error {
+	before := s.findState(ctx, resourceID, userID)
+	err := s.UserResourceMappingService.DeleteUserResourceMapping(ctx, resourceID, userID)
+	s.Log.record(ctx, newUserResourceMappingEvent(platform.OpDeleteUserResourceMapping, before, resourceID), before, nil, err)
+	return err
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UserService = (*UserService)(nil)
+
+// UserService records the changes to users in the audit log.
+type UserService struct {
+	platform.UserService
+	Log *Log
+}
+
+func (s *UserService) findState(ctx context.Context, id platform.ID) *platform.User {
+	u, err := s.UserService.FindUserByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// CreateUser creates a user, and records it.
+func (s *UserService) CreateUser(ctx context.Context, u *platform.User) error {
+	err := s.UserService.CreateUser(ctx, u)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateUser,
+		Resource:   platform.UsersResource,
+		ResourceID: u.ID,
+	}, nil, u, err)
+	return err
+}
+
+// UpdateUser updates a user, and records it.
+func (s *UserService) UpdateUser(ctx context.Context, id platform.ID, upd platform.UserUpdate) (*platform.User, error) {
+	before := s.findState(ctx, id)
+	u, err := s.UserService.UpdateUser(ctx, id, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateUser,
+		Resource:   platform.UsersResource,
+		ResourceID: id,
+	}, before, u, err)
+	return u, err
+}
+
+// DeleteUser deletes a user, and records it.
+func (s *UserService) DeleteUser(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.UserService.DeleteUser(ctx, id)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpDeleteUser,
+		Resource:   platform.UsersResource,
+		ResourceID: id,
+	}, before, nil, err)
+	return err
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ViewService = (*ViewService)(nil)
+
+// ViewService records the changes to views in the audit log.
+type ViewService struct {
+	platform.ViewService
+	Log *Log
+}
+
+func (s *ViewService) findState(ctx context.Context, id platform.ID) *platform.View {
+	v, err := s.ViewService.FindViewByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// CreateView creates a view, and records it.
+func (s *ViewService) CreateView(ctx context.Context, v *platform.View) error {
+	err := s.ViewService.CreateView(ctx, v)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpCreateView,
+		Resource:   platform.ViewsResource,
+		ResourceID: v.ID,
+	}, nil, v, err)
+	return err
+}
+
+// UpdateView updates a view, and records it.
+func (s *ViewService) UpdateView(ctx context.Context, id platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
+	before := s.findState(ctx, id)
+	v, err := s.ViewService.UpdateView(ctx, id, upd)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpUpdateView,
+		Resource:   platform.ViewsResource,
+		ResourceID: id,
+	}, before, v, err)
+	return v, err
+}
+
+// DeleteView deletes a view, and records it.
+func (s *ViewService) DeleteView(ctx context.Context, id platform.ID) error {
+	before := s.findState(ctx, id)
+	err := s.ViewService.DeleteView(ctx, id)
+	s.Log.record(ctx, &platform.AuditEvent{
+		Operation:  platform.OpDeleteView,
+		Resource:   platform.ViewsResource,
+		ResourceID: id,
+	}, before, nil, err)
+	return err
+}
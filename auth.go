@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"time"
 )
 
 var (
@@ -10,6 +11,12 @@ var (
 		Msg:  "unable to create token",
 		Code: EInvalid,
 	}
+
+	// ErrAuthorizationExpired is the error for expired authorizations.
+	ErrAuthorizationExpired = &Error{
+		Code: EUnauthorized,
+		Msg:  "authorization has expired",
+	}
 )
 
 // Authorization is an authorization. 🎉
@@ -21,6 +28,14 @@ type Authorization struct {
 	OrgID       ID           `json:"orgID"`
 	UserID      ID           `json:"userID"`
 	Permissions []Permission `json:"permissions"`
+
+	// CreatedBy is the id of the user who created the authorization.
+	CreatedBy ID        `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// ExpiresAt is when the authorization stops being active, it never expires if nil.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// LastUsedAt is when the authorization last authenticated a request, if ever.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 }
 
 // Allowed returns true if the authorization is active and request permission
@@ -38,9 +53,18 @@ func IsActive(a *Authorization) bool {
 	return a.IsActive()
 }
 
-// IsActive returns true if the authorization active.
+// IsActive returns true if the authorization active and unexpired.
 func (a *Authorization) IsActive() bool {
-	return a.Status == Active
+	return a.Status == Active && a.Expired() == nil
+}
+
+// Expired returns an error if the authorization is expired.
+func (a *Authorization) Expired() error {
+	if a.ExpiresAt != nil && !time.Now().Before(*a.ExpiresAt) {
+		return ErrAuthorizationExpired
+	}
+
+	return nil
 }
 
 // GetUserID returns the user id.
@@ -62,6 +86,7 @@ const (
 	OpCreateAuthorization      = "CreateAuthorization"
 	OpSetAuthorizationStatus   = "SetAuthorizationStatus"
 	OpDeleteAuthorization      = "DeleteAuthorization"
+	OpSetAuthorizationLastUsed = "SetAuthorizationLastUsed"
 )
 
 // AuthorizationService represents a service for managing authorization data.
@@ -87,6 +112,12 @@ type AuthorizationService interface {
 	DeleteAuthorization(ctx context.Context, id ID) error
 }
 
+// AuthorizationUsageRecorder records when authorizations are used.
+type AuthorizationUsageRecorder interface {
+	// SetAuthorizationLastUsed sets the time the authorization was last used.
+	SetAuthorizationLastUsed(ctx context.Context, id ID, t time.Time) error
+}
+
 // AuthorizationFilter represents a set of filter that restrict the returned results.
 type AuthorizationFilter struct {
 	Token *string
@@ -94,4 +125,26 @@ type AuthorizationFilter struct {
 
 	UserID *ID
 	User   *string
+
+	// CreatedBy restricts the results to the authorizations created by a user.
+	CreatedBy *ID
+	// UnusedSince restricts the results to the authorizations that have not been used since then.
+	UnusedSince *time.Time
+	// ExpiresBefore restricts the results to the authorizations that expire, or have expired, before then.
+	ExpiresBefore *time.Time
+}
+
+// Match returns true if the authorization matches the created by, unused since
+// and expires before fields of the filter.
+func (f AuthorizationFilter) Match(a *Authorization) bool {
+	if f.CreatedBy != nil && a.CreatedBy != *f.CreatedBy {
+		return false
+	}
+	if f.UnusedSince != nil && a.LastUsedAt != nil && !a.LastUsedAt.Before(*f.UnusedSince) {
+		return false
+	}
+	if f.ExpiresBefore != nil && (a.ExpiresAt == nil || !a.ExpiresAt.Before(*f.ExpiresBefore)) {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,103 @@
+package platform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+func TestAuthorization_IsActive(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		auth platform.Authorization
+		want bool
+	}{
+		{
+			name: "active without expiration",
+			auth: platform.Authorization{Status: platform.Active},
+			want: true,
+		},
+		{
+			name: "active and unexpired",
+			auth: platform.Authorization{Status: platform.Active, ExpiresAt: &future},
+			want: true,
+		},
+		{
+			name: "active and expired",
+			auth: platform.Authorization{Status: platform.Active, ExpiresAt: &past},
+		},
+		{
+			name: "inactive",
+			auth: platform.Authorization{Status: platform.Inactive, ExpiresAt: &future},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.auth.IsActive(); got != tt.want {
+				t.Errorf("Authorization.IsActive() = %v, want %v", got, tt.want)
+			}
+
+			p := platform.Permission{Action: platform.ReadAction, Resource: platform.BucketsResource}
+			tt.auth.Permissions = []platform.Permission{p}
+			if got := tt.auth.Allowed(p); got != tt.want {
+				t.Errorf("Authorization.Allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationFilter_Match(t *testing.T) {
+	user := platform.ID(1)
+	t1 := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		filter platform.AuthorizationFilter
+		auth   platform.Authorization
+		want   bool
+	}{
+		{
+			name: "empty filter",
+			auth: platform.Authorization{LastUsedAt: &t1},
+			want: true,
+		},
+		{
+			name:   "created by another user",
+			filter: platform.AuthorizationFilter{CreatedBy: &user},
+			auth:   platform.Authorization{CreatedBy: 2},
+		},
+		{
+			name:   "never used",
+			filter: platform.AuthorizationFilter{UnusedSince: &t1},
+			auth:   platform.Authorization{},
+			want:   true,
+		},
+		{
+			name:   "used since",
+			filter: platform.AuthorizationFilter{UnusedSince: &t1},
+			auth:   platform.Authorization{LastUsedAt: &t2},
+		},
+		{
+			name:   "expires before",
+			filter: platform.AuthorizationFilter{ExpiresBefore: &t2},
+			auth:   platform.Authorization{ExpiresAt: &t1},
+			want:   true,
+		},
+		{
+			name:   "never expires",
+			filter: platform.AuthorizationFilter{ExpiresBefore: &t2},
+			auth:   platform.Authorization{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(&tt.auth); got != tt.want {
+				t.Errorf("AuthorizationFilter.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package platform
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -29,7 +30,8 @@ type Authorizer interface {
 	Kind() string
 }
 
-// PermissionAllowed
+// PermissionAllowed returns true if ps contains p, or the admin permission of
+// the resource of p.
 func PermissionAllowed(p Permission, ps []Permission) bool {
 	pID := ID(0)
 	if p.ID != nil {
@@ -47,7 +49,7 @@ func PermissionAllowed(p Permission, ps []Permission) bool {
 				return false
 			}
 		}
-		if perm.Action == p.Action && perm.Resource == p.Resource && permID == pID {
+		if (perm.Action == p.Action || perm.Action == AdminAction) && perm.Resource == p.Resource && permID == pID {
 			return true
 		}
 	}
@@ -62,11 +64,18 @@ const (
 	ReadAction Action = "read" // 1
 	// WriteAction is the action for writing.
 	WriteAction Action = "write" // 2
+	// DeleteAction is the action for deleting.
+	DeleteAction Action = "delete" // 3
+	// AdminAction is the action for managing the owners and members of a resource.
+	// It allows every other action.
+	AdminAction Action = "admin" // 4
 )
 
 var actions = []Action{
-	ReadAction,  // 1
-	WriteAction, // 2
+	ReadAction,   // 1
+	WriteAction,  // 2
+	DeleteAction, // 3
+	AdminAction,  // 4
 }
 
 // Valid checks if the action is a member of the Action enum
@@ -74,6 +83,8 @@ func (a Action) Valid() (err error) {
 	switch a {
 	case ReadAction: // 1
 	case WriteAction: // 2
+	case DeleteAction: // 3
+	case AdminAction: // 4
 	default:
 		err = ErrInvalidAction
 	}
@@ -101,6 +112,26 @@ const (
 	TelegrafsResource = Resource("telegrafs") // 6
 	// UsersResource gives permissions to one or more users.
 	UsersResource = Resource("users") // 7
+	// LabelsResource gives permissions to the labels of one or more resources.
+	LabelsResource = Resource("labels") // 8
+	// MacrosResource gives permissions to one or more macros.
+	MacrosResource = Resource("macros") // 9
+	// ScrapersResource gives permissions to one or more scraper targets.
+	ScrapersResource = Resource("scrapers") // 10
+	// SecretsResource gives permissions to the secrets of one or more orgs.
+	SecretsResource = Resource("secrets") // 11
+	// ProtosResource gives permissions to one or more protos.
+	ProtosResource = Resource("protos") // 12
+	// ViewsResource gives permissions to one or more views.
+	ViewsResource = Resource("views") // 13
+	// DBRPsResource gives permissions to one or more database and retention policy mappings.
+	DBRPsResource = Resource("dbrps") // 14
+	// UsageResource gives permissions to the usage of one or more orgs.
+	UsageResource = Resource("usage") // 15
+	// AuditResource gives permissions to the audit log of one or more orgs.
+	AuditResource = Resource("audit") // 16
+	// BackupResource gives permissions to back up all of the metadata and data.
+	BackupResource = Resource("backup") // 17
 )
 
 // AllResources is the list of all known resource types.
@@ -113,16 +144,33 @@ var AllResources = []Resource{
 	TasksResource,          // 5
 	TelegrafsResource,      // 6
 	UsersResource,          // 7
+	LabelsResource,         // 8
+	MacrosResource,         // 9
+	ScrapersResource,       // 10
+	SecretsResource,        // 11
+	ProtosResource,         // 12
+	ViewsResource,          // 13
+	DBRPsResource,          // 14
+	UsageResource,          // 15
+	AuditResource,          // 16
+	BackupResource,         // 17
 }
 
 // OrgResources is the list of all known resource types that belong to an organization.
 var OrgResources = []Resource{
-	BucketsResource,    // 1
-	DashboardsResource, // 2
-	SourcesResource,    // 4
-	TasksResource,      // 5
-	TelegrafsResource,  // 6
-	UsersResource,      // 7
+	AuthorizationsResource, // 0
+	BucketsResource,        // 1
+	DashboardsResource,     // 2
+	SourcesResource,        // 4
+	TasksResource,          // 5
+	TelegrafsResource,      // 6
+	UsersResource,          // 7
+	LabelsResource,         // 8
+	ScrapersResource,       // 10
+	SecretsResource,        // 11
+	DBRPsResource,          // 14
+	UsageResource,          // 15
+	AuditResource,          // 16
 }
 
 // Valid checks if the resource is a member of the Resource enum.
@@ -136,6 +184,16 @@ func (r Resource) Valid() (err error) {
 	case TelegrafsResource: // 5
 	case SourcesResource: // 6
 	case UsersResource: //7
+	case LabelsResource: // 8
+	case MacrosResource: // 9
+	case ScrapersResource: // 10
+	case SecretsResource: // 11
+	case ProtosResource: // 12
+	case ViewsResource: // 13
+	case DBRPsResource: // 14
+	case UsageResource: // 15
+	case AuditResource: // 16
+	case BackupResource: // 17
 	default:
 		err = ErrInvalidResource
 	}
@@ -209,6 +267,33 @@ func NewPermissionAtID(id ID, a Action, r Resource) (*Permission, error) {
 	return p, p.Valid()
 }
 
+// ParsePermission parses a permission in the form returned by String,
+// that is action:resource with an optional :id.
+func ParsePermission(s string) (*Permission, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid permission %q, expected action:resource[:id]", s),
+		}
+	}
+
+	a, r := Action(parts[0]), Resource(parts[1])
+	if len(parts) == 2 {
+		return NewPermission(a, r)
+	}
+
+	id, err := IDFromString(parts[2])
+	if err != nil {
+		return nil, &Error{
+			Code: EInvalid,
+			Err:  err,
+			Msg:  "invalid id for permission",
+		}
+	}
+	return NewPermissionAtID(*id, a, r)
+}
+
 // OperPermissions are the default permissions for those who setup the application.
 func OperPermissions() []Permission {
 	ps := []Permission{}
@@ -222,9 +307,10 @@ func OperPermissions() []Permission {
 }
 
 // OrgAdminPermissions are the default permissions for org admins.
+// They cover the organization itself and every resource that belongs to it.
 func OrgAdminPermissions(orgID ID) []Permission {
 	ps := []Permission{}
-	for _, r := range OrgResources {
+	for _, r := range append([]Resource{OrgsResource}, OrgResources...) {
 		for _, a := range actions {
 			ps = append(ps, Permission{ID: &orgID, Action: a, Resource: r})
 		}
@@ -234,9 +320,10 @@ func OrgAdminPermissions(orgID ID) []Permission {
 }
 
 // OrgMemberPermissions are the default permissions for org members.
+// They cover the organization itself and every resource that belongs to it.
 func OrgMemberPermissions(orgID ID) []Permission {
 	ps := []Permission{}
-	for _, r := range OrgResources {
+	for _, r := range append([]Resource{OrgsResource}, OrgResources...) {
 		ps = append(ps, Permission{ID: &orgID, Action: ReadAction, Resource: r})
 	}
 
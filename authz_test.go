@@ -123,6 +123,68 @@ func TestAuthorizer_PermissionAllowed(t *testing.T) {
 			},
 			allowed: false,
 		},
+		{
+			name: "write does not allow delete",
+			permission: platform.Permission{
+				Action:   platform.DeleteAction,
+				Resource: platform.BucketsResource,
+				ID:       IDPtr(1),
+			},
+			permissions: []platform.Permission{
+				{
+					Action:   platform.WriteAction,
+					Resource: platform.BucketsResource,
+					ID:       IDPtr(1),
+				},
+			},
+			allowed: false,
+		},
+		{
+			name: "admin allows every action on the resource",
+			permission: platform.Permission{
+				Action:   platform.DeleteAction,
+				Resource: platform.BucketsResource,
+				ID:       IDPtr(1),
+			},
+			permissions: []platform.Permission{
+				{
+					Action:   platform.AdminAction,
+					Resource: platform.BucketsResource,
+					ID:       IDPtr(1),
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "admin differing ID",
+			permission: platform.Permission{
+				Action:   platform.ReadAction,
+				Resource: platform.BucketsResource,
+				ID:       IDPtr(1),
+			},
+			permissions: []platform.Permission{
+				{
+					Action:   platform.AdminAction,
+					Resource: platform.BucketsResource,
+					ID:       IDPtr(2),
+				},
+			},
+			allowed: false,
+		},
+		{
+			name: "admin differing resource",
+			permission: platform.Permission{
+				Action:   platform.ReadAction,
+				Resource: platform.MacrosResource,
+			},
+			permissions: []platform.Permission{
+				{
+					Action:   platform.AdminAction,
+					Resource: platform.BucketsResource,
+				},
+			},
+			allowed: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,6 +270,18 @@ func TestPermissionAllResources_Valid(t *testing.T) {
 		platform.BucketsResource,
 		platform.DashboardsResource,
 		platform.SourcesResource,
+		platform.AuthorizationsResource,
+		platform.TelegrafsResource,
+		platform.LabelsResource,
+		platform.MacrosResource,
+		platform.ScrapersResource,
+		platform.SecretsResource,
+		platform.ProtosResource,
+		platform.ViewsResource,
+		platform.DBRPsResource,
+		platform.UsageResource,
+		platform.AuditResource,
+		platform.BackupResource,
 	}
 
 	for _, r := range resources {
@@ -226,6 +300,8 @@ func TestPermissionAllActions(t *testing.T) {
 	var actions = []platform.Action{
 		platform.ReadAction,
 		platform.WriteAction,
+		platform.DeleteAction,
+		platform.AdminAction,
 	}
 
 	for _, a := range actions {
@@ -284,6 +360,60 @@ func TestPermission_String(t *testing.T) {
 	}
 }
 
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    *platform.Permission
+		wantErr bool
+	}{
+		{
+			name: "permission with no id",
+			s:    "delete:buckets",
+			want: &platform.Permission{Action: platform.DeleteAction, Resource: platform.BucketsResource},
+		},
+		{
+			name: "permission with an id",
+			s:    "admin:macros:0000000000000064",
+			want: &platform.Permission{Action: platform.AdminAction, Resource: platform.MacrosResource, ID: validID()},
+		},
+		{
+			name:    "unknown action",
+			s:       "execute:tasks",
+			wantErr: true,
+		},
+		{
+			name:    "unknown resource",
+			s:       "read:things",
+			wantErr: true,
+		},
+		{
+			name:    "invalid id",
+			s:       "read:buckets:nope",
+			wantErr: true,
+		},
+		{
+			name:    "missing resource",
+			s:       "read",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := platform.ParsePermission(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("ParsePermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func validID() *platform.ID {
 	id := platform.ID(100)
 	return &id
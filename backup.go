@@ -0,0 +1,72 @@
+package platform
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ops for backup errors.
+const (
+	OpBackup        = "Backup"
+	OpRestoreBucket = "RestoreBucket"
+)
+
+// BackupManifest describes the contents of a backup archive.
+type BackupManifest struct {
+	// Time is when the backup was taken.
+	Time time.Time `json:"time"`
+
+	// Incremental is true if the archive only holds the TSM files that
+	// changed since an earlier backup. The metadata, series file and index
+	// are always held in full.
+	Incremental bool `json:"incremental"`
+
+	// Generation is the latest generation of the TSM files at the time of the
+	// backup. A later backup of the generations after it is incremental to
+	// this one.
+	Generation int `json:"generation"`
+
+	// Files are the names of every TSM and tombstone file at the time of the
+	// backup, including those left out of an incremental archive.
+	Files []string `json:"files"`
+}
+
+// BackupFilter selects the TSM files of an incremental backup. The backup is
+// full if neither field is set.
+type BackupFilter struct {
+	// Since, if set, only includes the TSM files modified after it.
+	Since *time.Time
+	// Generation, if set, only includes the TSM files of later generations.
+	Generation *int
+}
+
+// Incremental returns true if the filter leaves out any TSM files.
+func (f BackupFilter) Incremental() bool {
+	return f.Since != nil || f.Generation != nil
+}
+
+// QueryParams returns the filter as url query params.
+func (f BackupFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if f.Since != nil {
+		qp["since"] = []string{f.Since.Format(time.RFC3339Nano)}
+	}
+	if f.Generation != nil {
+		qp["generation"] = []string{strconv.Itoa(*f.Generation)}
+	}
+	return qp
+}
+
+// BackupService backs up the metadata and time series data while they are in
+// use, and restores buckets from the backups.
+type BackupService interface {
+	// Backup writes a tar archive of the metadata, series file, index and TSM
+	// files selected by the filter to w.
+	Backup(ctx context.Context, w io.Writer, filter BackupFilter) error
+
+	// RestoreBucket writes the data of the bucket bucketID in the archive read
+	// from r into the existing bucket newBucketID.
+	RestoreBucket(ctx context.Context, r io.Reader, bucketID, newBucketID ID) error
+}
@@ -0,0 +1,247 @@
+// Package backup writes archives of the metadata and time series data of a
+// running influxd, and restores them.
+//
+// An archive is a tar file laid out as follows:
+//
+//	manifest.json   the platform.BackupManifest of the archive
+//	influxd.bolt    the metadata
+//	_series/        the series file
+//	index/          the tsi1 index
+//	data/           the TSM and tombstone files
+//
+// The metadata, series file and index are always held in full. An incremental
+// archive only holds the TSM files that changed since an earlier backup, and
+// lists the rest in its manifest.
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	intar "github.com/influxdata/platform/pkg/tar"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb/tsm1"
+	"go.uber.org/zap"
+)
+
+const (
+	// ManifestFileName is the name of the manifest in an archive.
+	ManifestFileName = "manifest.json"
+
+	// BoltFileName is the name of the metadata in an archive.
+	BoltFileName = "influxd.bolt"
+
+	// DefaultMaxRestoreSize is the default size limit of the data extracted
+	// from an archive to restore a bucket.
+	DefaultMaxRestoreSize = 10 << 30
+)
+
+// Engine is the storage engine whose data is backed up and restored.
+type Engine interface {
+	// CreateSnapshot writes a consistent copy of the engine into a new
+	// temporary directory, and returns its path.
+	CreateSnapshot() (string, error)
+
+	// WritePoints writes exploded points to the engine.
+	WritePoints(points []models.Point) error
+}
+
+// KV is the store of the metadata that is backed up.
+type KV interface {
+	// Backup writes a consistent copy of the store to a new file at path.
+	Backup(ctx context.Context, path string) error
+}
+
+var _ platform.BackupService = (*Service)(nil)
+
+// Service backs up the metadata and data of a running influxd, and restores
+// buckets into it.
+type Service struct {
+	Engine        Engine
+	KV            KV
+	BucketService platform.BucketService
+
+	// MaxRestoreSize is the size limit of the data extracted from an archive
+	// to restore a bucket. Zero is unlimited.
+	MaxRestoreSize int64
+
+	Logger *zap.Logger
+	Now    func() time.Time
+}
+
+// NewService returns a new Service of the engine e and the metadata in kv.
+func NewService(e Engine, kv KV, bs platform.BucketService) *Service {
+	return &Service{
+		Engine:         e,
+		KV:             kv,
+		BucketService:  bs,
+		MaxRestoreSize: DefaultMaxRestoreSize,
+		Logger:         zap.NewNop(),
+		Now:            time.Now,
+	}
+}
+
+// Backup writes an archive of the metadata, series file, index and the TSM
+// files selected by the filter to w.
+func (s *Service) Backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	if err := s.backup(ctx, w, filter); err != nil {
+		return &platform.Error{
+			Op:  platform.OpBackup,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+func (s *Service) backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	dir, err := s.Engine.CreateSnapshot()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	boltPath := filepath.Join(dir, BoltFileName)
+	if err := s.KV.Backup(ctx, boltPath); err != nil {
+		return err
+	}
+
+	dataPath := filepath.Join(dir, storage.DefaultEngineDirectoryName)
+	m, err := newManifest(dataPath, filter)
+	if err != nil {
+		return err
+	}
+	m.Time = s.Now()
+
+	tw := tar.NewWriter(w)
+	if err := writeManifest(tw, m); err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(boltPath)
+	if err != nil {
+		return err
+	}
+	if err := intar.StreamFile(fi, "", boltPath, tw); err != nil {
+		return err
+	}
+
+	for _, name := range []string{storage.DefaultSeriesFileDirectoryName, storage.DefaultIndexDirectoryName} {
+		if err := intar.StreamDir(tw, filepath.Join(dir, name), name, nil); err != nil {
+			return err
+		}
+	}
+
+	include := filterFile(filter)
+	if err := intar.StreamDir(tw, dataPath, storage.DefaultEngineDirectoryName, func(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error {
+		if !include(f) {
+			return nil
+		}
+		return intar.StreamFile(f, relativePath, fullPath, tw)
+	}); err != nil {
+		return err
+	}
+
+	s.Logger.Info("Backup written",
+		zap.Bool("incremental", m.Incremental),
+		zap.Int("generation", m.Generation),
+		zap.Int("files", len(m.Files)))
+	return tw.Close()
+}
+
+// newManifest returns the manifest of a backup of the TSM and tombstone files
+// in dir.
+func newManifest(dir string, filter platform.BackupFilter) (*platform.BackupManifest, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &platform.BackupManifest{
+		Incremental: filter.Incremental(),
+		Files:       make([]string, 0, len(fis)),
+	}
+	for _, fi := range fis {
+		m.Files = append(m.Files, fi.Name())
+		if !isTSMFile(fi.Name()) {
+			continue
+		}
+		if gen, _, err := tsm1.DefaultParseFileName(fi.Name()); err == nil && gen > m.Generation {
+			m.Generation = gen
+		}
+	}
+	return m, nil
+}
+
+// filterFile returns a function that reports if a TSM or tombstone file is
+// included in a backup. Tombstones are always included, as they may change
+// without their TSM file changing.
+func filterFile(filter platform.BackupFilter) func(os.FileInfo) bool {
+	return func(fi os.FileInfo) bool {
+		if !isTSMFile(fi.Name()) {
+			return true
+		}
+		if filter.Since != nil && !fi.ModTime().After(*filter.Since) {
+			return false
+		}
+		if filter.Generation != nil {
+			if gen, _, err := tsm1.DefaultParseFileName(fi.Name()); err == nil && gen <= *filter.Generation {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isTSMFile(name string) bool {
+	return strings.HasSuffix(name, "."+tsm1.TSMFileExtension)
+}
+
+func writeManifest(tw *tar.Writer, m *platform.BackupManifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestFileName,
+		Mode:     0644,
+		Size:     int64(len(b)),
+		ModTime:  m.Time,
+	}); err != nil {
+		return err
+	}
+	_, err = tw.Write(b)
+	return err
+}
+
+// ReadManifest reads the manifest of the archive read from r. The manifest is
+// the first file of an archive, so only the start of r is read.
+func ReadManifest(r io.Reader) (*platform.BackupManifest, error) {
+	tr := tar.NewReader(r)
+	h, err := tr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if h.Name != ManifestFileName {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "archive does not start with a manifest",
+		}
+	}
+
+	m := &platform.BackupManifest{}
+	if err := json.NewDecoder(tr).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,322 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+)
+
+// Engine is a backup.Engine whose snapshots hold the files of a map.
+type Engine struct {
+	Files map[string]string
+}
+
+func (e *Engine) CreateSnapshot() (string, error) {
+	dir, err := ioutil.TempDir("", "backup-test-snapshot-")
+	if err != nil {
+		return "", err
+	}
+	mustWriteFiles(dir, e.Files)
+	return dir, nil
+}
+
+func (e *Engine) WritePoints(points []models.Point) error { return nil }
+
+// KV is a backup.KV whose backups hold Data.
+type KV struct {
+	Data string
+}
+
+func (kv *KV) Backup(ctx context.Context, path string) error {
+	return ioutil.WriteFile(path, []byte(kv.Data), 0600)
+}
+
+func newService(files map[string]string) *backup.Service {
+	s := backup.NewService(&Engine{Files: files}, &KV{Data: "metadata"}, nil)
+	s.Now = func() time.Time { return time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC) }
+	return s
+}
+
+var snapshotFiles = map[string]string{
+	"_series/00/0000":                    "series",
+	"index/0/MANIFEST":                   "manifest",
+	"data/000000001-000000001.tsm":       "gen1",
+	"data/000000001-000000001.tombstone": "tombstone",
+	"data/000000002-000000001.tsm":       "gen2",
+}
+
+func TestService_Backup(t *testing.T) {
+	gen := 1
+	tests := []struct {
+		name     string
+		filter   platform.BackupFilter
+		manifest platform.BackupManifest
+		files    []string
+	}{
+		{
+			name: "full",
+			manifest: platform.BackupManifest{
+				Generation: 2,
+				Files:      []string{"000000001-000000001.tombstone", "000000001-000000001.tsm", "000000002-000000001.tsm"},
+			},
+			files: []string{
+				"_series/00/0000",
+				"data/000000001-000000001.tombstone",
+				"data/000000001-000000001.tsm",
+				"data/000000002-000000001.tsm",
+				"index/0/MANIFEST",
+				"influxd.bolt",
+				"manifest.json",
+			},
+		},
+		{
+			name:   "incremental",
+			filter: platform.BackupFilter{Generation: &gen},
+			manifest: platform.BackupManifest{
+				Incremental: true,
+				Generation:  2,
+				Files:       []string{"000000001-000000001.tombstone", "000000001-000000001.tsm", "000000002-000000001.tsm"},
+			},
+			files: []string{
+				"_series/00/0000",
+				"data/000000001-000000001.tombstone",
+				"data/000000002-000000001.tsm",
+				"index/0/MANIFEST",
+				"influxd.bolt",
+				"manifest.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := newService(snapshotFiles).Backup(context.Background(), &buf, tt.filter); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := backup.ReadManifest(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.manifest.Time = m.Time
+			if !reflect.DeepEqual(*m, tt.manifest) {
+				t.Errorf("unexpected manifest: got %+v, want %+v", *m, tt.manifest)
+			}
+
+			if got := archiveFiles(t, buf.Bytes()); !reflect.DeepEqual(got, tt.files) {
+				t.Errorf("unexpected files: got %v, want %v", got, tt.files)
+			}
+		})
+	}
+}
+
+func TestRestore_Incremental(t *testing.T) {
+	ctx := context.Background()
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+	enginePath := filepath.Join(dir, "engine")
+	boltPath := filepath.Join(dir, "influxd.bolt")
+
+	var full bytes.Buffer
+	if err := newService(snapshotFiles).Backup(ctx, &full, platform.BackupFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backup.Restore(&full, boltPath, enginePath); err != nil {
+		t.Fatal(err)
+	}
+	mustWriteFiles(enginePath, map[string]string{"wal/_00001.wal": "wal"})
+
+	// The first generation was compacted into the third after the full backup.
+	gen := 2
+	var incr bytes.Buffer
+	if err := newService(map[string]string{
+		"_series/00/0000":              "series2",
+		"index/0/MANIFEST":             "manifest2",
+		"data/000000002-000000001.tsm": "gen2",
+		"data/000000003-000000002.tsm": "gen3",
+	}).Backup(ctx, &incr, platform.BackupFilter{Generation: &gen}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := backup.Restore(&incr, boltPath, enginePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Incremental || m.Generation != 3 {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+
+	want := []string{
+		"_series/00/0000",
+		"data/000000002-000000001.tsm",
+		"data/000000003-000000002.tsm",
+		"index/0/MANIFEST",
+	}
+	if got := listFiles(t, enginePath); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected files: got %v, want %v", got, want)
+	}
+
+	for path, data := range map[string]string{
+		filepath.Join(enginePath, "_series/00/0000"):              "series2",
+		filepath.Join(enginePath, "data/000000002-000000001.tsm"): "gen2",
+		boltPath: "metadata",
+	} {
+		if b, err := ioutil.ReadFile(path); err != nil {
+			t.Fatal(err)
+		} else if string(b) != data {
+			t.Errorf("unexpected data of %s: got %q, want %q", path, b, data)
+		}
+	}
+}
+
+func TestService_Backup_Since(t *testing.T) {
+	since := time.Now().Add(time.Hour)
+	var buf bytes.Buffer
+	if err := newService(snapshotFiles).Backup(context.Background(), &buf, platform.BackupFilter{Since: &since}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+	enginePath := filepath.Join(dir, "engine")
+	mustWriteFiles(filepath.Join(enginePath, "data"), map[string]string{
+		"000000001-000000001.tsm": "gen1",
+		"000000002-000000001.tsm": "gen2",
+	})
+	if _, err := backup.Restore(&buf, filepath.Join(dir, "influxd.bolt"), enginePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// No TSM file was modified after since, so they were all kept.
+	want := []string{
+		"_series/00/0000",
+		"data/000000001-000000001.tombstone",
+		"data/000000001-000000001.tsm",
+		"data/000000002-000000001.tsm",
+		"index/0/MANIFEST",
+	}
+	if got := listFiles(t, enginePath); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected files: got %v, want %v", got, want)
+	}
+}
+
+func TestRestore_MissingBackup(t *testing.T) {
+	gen := 1
+	var buf bytes.Buffer
+	if err := newService(snapshotFiles).Backup(context.Background(), &buf, platform.BackupFilter{Generation: &gen}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := mustTempDir(t)
+	defer os.RemoveAll(dir)
+	if _, err := backup.Restore(&buf, filepath.Join(dir, "influxd.bolt"), filepath.Join(dir, "engine")); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
+
+func TestService_RestoreBucket_CorruptFile(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newService(snapshotFiles).Backup(context.Background(), &buf, platform.BackupFilter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newService(nil)
+	s.BucketService = newBucketService()
+	if err := s.RestoreBucket(context.Background(), &buf, 1, 2); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
+
+func TestService_RestoreBucket_TooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newService(snapshotFiles).Backup(context.Background(), &buf, platform.BackupFilter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newService(nil)
+	s.BucketService = newBucketService()
+	s.MaxRestoreSize = 4
+	if err := s.RestoreBucket(context.Background(), &buf, 1, 2); platform.ErrorCode(err) != platform.ETooLarge {
+		t.Fatalf("expected too large error, got %v", err)
+	}
+}
+
+func TestReadManifest_NoManifest(t *testing.T) {
+	if _, err := backup.ReadManifest(bytes.NewReader(make([]byte, 1024))); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func newBucketService() *mock.BucketService {
+	bs := mock.NewBucketService()
+	bs.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: id, OrganizationID: 1}, nil
+	}
+	return bs
+}
+
+func mustTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func mustWriteFiles(dir string, files map[string]string) {
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			panic(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0666); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// archiveFiles returns the sorted names of the files in the archive.
+func archiveFiles(t *testing.T, b []byte) []string {
+	var files []string
+	tr := tar.NewReader(bytes.NewReader(b))
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, h.Name)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// listFiles returns the sorted paths of the files under dir, relative to it.
+func listFiles(t *testing.T, dir string) []string {
+	var files []string
+	if err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -0,0 +1,315 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/file"
+	intar "github.com/influxdata/platform/pkg/tar"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+	"go.uber.org/zap"
+)
+
+// restoreBatchSize is the number of points written to the engine at a time
+// when a bucket is restored.
+const restoreBatchSize = 5000
+
+// Restore restores the archive read from r into the metadata file at boltPath
+// and the engine directory at enginePath, neither of which may be in use.
+//
+// A full archive replaces all of the metadata and data. An incremental archive
+// must be restored on top of the backup that it is incremental to: the TSM
+// files that still existed at the time of the backup are kept, and the rest
+// are removed.
+func Restore(r io.Reader, boltPath, enginePath string) (*platform.BackupManifest, error) {
+	if err := os.MkdirAll(enginePath, 0777); err != nil {
+		return nil, err
+	}
+
+	// The archive is extracted into the engine directory so that its
+	// directories can be moved into place.
+	dir, err := ioutil.TempDir(enginePath, "restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := intar.Restore(r, dir, ""); err != nil {
+		return nil, err
+	}
+
+	m, err := readManifestFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	dataPath := filepath.Join(dir, storage.DefaultEngineDirectoryName)
+	if err := os.MkdirAll(dataPath, 0777); err != nil {
+		return nil, err
+	}
+	if m.Incremental {
+		if err := linkFiles(m, filepath.Join(enginePath, storage.DefaultEngineDirectoryName), dataPath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range []string{
+		storage.DefaultSeriesFileDirectoryName,
+		storage.DefaultIndexDirectoryName,
+		storage.DefaultEngineDirectoryName,
+	} {
+		src, dst := filepath.Join(dir, name), filepath.Join(enginePath, name)
+		if err := os.MkdirAll(src, 0777); err != nil {
+			return nil, err
+		} else if err := os.RemoveAll(dst); err != nil {
+			return nil, err
+		} else if err := os.Rename(src, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// The cache was written to TSM files when the backup was taken, so a write
+	// ahead log can only hold writes to the data that was replaced.
+	if err := os.RemoveAll(filepath.Join(enginePath, storage.DefaultWALDirectoryName)); err != nil {
+		return nil, err
+	}
+
+	// The metadata may be on another device, so it is copied next to its
+	// destination before it replaces it.
+	if err := os.MkdirAll(filepath.Dir(boltPath), 0700); err != nil {
+		return nil, err
+	}
+	tmpPath := boltPath + ".restore"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	} else if err := file.CopyFile(filepath.Join(dir, BoltFileName), tmpPath); err != nil {
+		return nil, err
+	} else if err := os.Rename(tmpPath, boltPath); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func readManifestFile(path string) (*platform.BackupManifest, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "archive has no manifest",
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &platform.BackupManifest{}
+	if err := json.NewDecoder(f).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// linkFiles links the files of the manifest that an incremental archive left
+// out from the existing data directory at oldPath into the data directory of
+// the archive at newPath.
+func linkFiles(m *platform.BackupManifest, oldPath, newPath string) error {
+	for _, name := range m.Files {
+		newFile := filepath.Join(newPath, name)
+		if _, err := os.Stat(newFile); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		if err := os.Link(filepath.Join(oldPath, name), newFile); os.IsNotExist(err) {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("TSM file %s is missing; the backup this archive is incremental to must be restored first", name),
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreBucket writes the data of the bucket bucketID in the archive read
+// from r into the existing bucket newBucketID. The data is written as points,
+// so the engine remains in use. Only the TSM files held by the archive are
+// read, so an incremental archive only restores the data that changed.
+func (s *Service) RestoreBucket(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error {
+	if err := s.restoreBucket(ctx, r, bucketID, newBucketID); err != nil {
+		return &platform.Error{
+			Op:  platform.OpRestoreBucket,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+func (s *Service) restoreBucket(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error {
+	b, err := s.BucketService.FindBucketByID(ctx, newBucketID)
+	if err != nil {
+		return err
+	}
+
+	dir, err := ioutil.TempDir("", "influxd-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	err = intar.RestoreLimit(r, dir, storage.DefaultEngineDirectoryName+"/", s.MaxRestoreSize)
+	if err == intar.ErrArchiveTooLarge {
+		return &platform.Error{
+			Code: platform.ETooLarge,
+			Msg:  fmt.Sprintf("archive holds more than %d bytes of data", s.MaxRestoreSize),
+		}
+	} else if err != nil {
+		return err
+	}
+
+	dataPath := filepath.Join(dir, storage.DefaultEngineDirectoryName)
+	fis, err := ioutil.ReadDir(dataPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, fi := range fis {
+		if isTSMFile(fi.Name()) {
+			paths = append(paths, filepath.Join(dataPath, fi.Name()))
+		}
+	}
+
+	// The files were uploaded, so they are verified before any of their
+	// values are written.
+	for _, path := range paths {
+		if err := verifyTSMFile(path); err != nil {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("TSM file %s is corrupt: %v", filepath.Base(path), err),
+			}
+		}
+	}
+
+	// Files are sorted by generation, so the values of later files overwrite
+	// those of earlier ones.
+	name := tsdb.EncodeName(b.OrganizationID, b.ID)
+	var n int
+	for _, path := range paths {
+		fn, err := s.restoreFile(path, bucketID, name)
+		if err != nil {
+			return err
+		}
+		n += fn
+	}
+
+	s.Logger.Info("Bucket restored",
+		zap.String("bucket_id", bucketID.String()),
+		zap.String("new_bucket_id", newBucketID.String()),
+		zap.Int("values", n))
+	return nil
+}
+
+// verifyTSMFile returns an error if the TSM file at path can not be read, or if
+// its index points outside of its blocks or any of its blocks is corrupt.
+func verifyTSMFile(path string) (err error) {
+	// Corrupt files can make the reader panic.
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	return r.Verify()
+}
+
+// restoreFile writes the values of the bucket bucketID in the TSM file at path
+// as points of the measurement name. It returns the number of values written.
+func (s *Service) restoreFile(path string, bucketID platform.ID, name [16]byte) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	defer r.Close()
+
+	var (
+		n      int
+		points = make([]models.Point, 0, restoreBatchSize)
+	)
+	itr := r.Iterator(nil)
+	for itr.Next() {
+		key := itr.Key()
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+		oldName, tags := models.ParseKeyBytes(seriesKey)
+		if len(oldName) != len(name) {
+			continue
+		}
+		var on [16]byte
+		copy(on[:], oldName)
+		if _, id := tsdb.DecodeName(on); id != bucketID {
+			continue
+		}
+
+		values, err := r.ReadAll(key)
+		if err != nil {
+			return n, err
+		}
+		for _, v := range values {
+			pt, err := models.NewPoint(string(name[:]), tags, models.Fields{string(field): v.Value()}, time.Unix(0, v.UnixNano()))
+			if err != nil {
+				return n, err
+			}
+			points = append(points, pt)
+
+			if len(points) == cap(points) {
+				if err := s.Engine.WritePoints(points); err != nil {
+					return n, err
+				}
+				n += len(points)
+				points = points[:0]
+			}
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return n, err
+	}
+
+	if len(points) > 0 {
+		if err := s.Engine.WritePoints(points); err != nil {
+			return n, err
+		}
+		n += len(points)
+	}
+	return n, nil
+}
@@ -0,0 +1,171 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+const (
+	// auditLogKey is the key of the log of every audit event.
+	auditLogKey = "audit"
+
+	// auditOrgLogKeyPrefix prefixes the keys of the logs of the audit events
+	// of each organization.
+	auditOrgLogKeyPrefix = "auditorg"
+)
+
+func encodeAuditOrgLogKey(id platform.ID) ([]byte, error) {
+	buf, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(auditOrgLogKeyPrefix), buf...), nil
+}
+
+// AddAuditEvent stores the event in the audit log, and in the audit log of
+// its organization. Events are keyed by their time, so an event at the same
+// time as a stored event is moved to the next nanosecond that is free.
+func (c *Client) AddAuditEvent(ctx context.Context, e *platform.AuditEvent) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		for {
+			key, err := encodeLogEntryKey([]byte(auditLogKey), e.Time.UTC().UnixNano())
+			if err != nil {
+				return err
+			}
+			if tx.Bucket(keyValueLogBucket).Get(key) == nil {
+				break
+			}
+			e.Time = e.Time.Add(time.Nanosecond)
+		}
+
+		v, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		if err := c.addLogEntry(ctx, tx, []byte(auditLogKey), v, e.Time); err != nil {
+			return err
+		}
+
+		if !e.OrgID.Valid() {
+			return nil
+		}
+		k, err := encodeAuditOrgLogKey(e.OrgID)
+		if err != nil {
+			return err
+		}
+		return c.addLogEntry(ctx, tx, k, v, e.Time)
+	})
+
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  getOp(platform.OpLogAuditEvent),
+		}
+	}
+	return nil
+}
+
+// FindAuditEvents returns the events that match the filter, and the total
+// count of matching events. Only the log of the filter's organization, and
+// only the entries within the filter's time range, are read.
+func (c *Client) FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	var opts platform.FindOptions
+	if len(opt) > 0 {
+		opts = opt[0]
+	}
+
+	start, stop := int64(0), int64(math.MaxInt64)
+	if filter.Since != nil {
+		start = filter.Since.UnixNano()
+	}
+	if filter.Until != nil {
+		stop = filter.Until.UnixNano()
+	}
+
+	es := []*platform.AuditEvent{}
+	var n int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		k := []byte(auditLogKey)
+		if filter.OrgID != nil {
+			var err error
+			if k, err = encodeAuditOrgLogKey(*filter.OrgID); err != nil {
+				return err
+			}
+		}
+
+		return c.forEachLogEntryInRange(ctx, tx, k, start, stop, opts.Descending, func(v []byte, t time.Time) error {
+			e := &platform.AuditEvent{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if !filter.Match(e) {
+				return nil
+			}
+
+			n++
+			if n > opts.Offset && (opts.Limit == 0 || len(es) < opts.Limit) {
+				es = append(es, e)
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, 0, &platform.Error{
+			Err: err,
+			Op:  getOp(platform.OpFindAuditEvents),
+		}
+	}
+	return es, n, nil
+}
+
+// DeleteAuditEventsBefore removes the events before t from the audit log, and
+// from the audit logs of their organizations.
+func (c *Client) DeleteAuditEventsBefore(ctx context.Context, t time.Time) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		orgIDs := make(map[platform.ID]bool)
+		err := c.forEachLogEntryInRange(ctx, tx, []byte(auditLogKey), 0, t.UnixNano(), false, func(v []byte, _ time.Time) error {
+			var e struct {
+				OrgID platform.ID `json:"orgID,omitempty"`
+			}
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			if e.OrgID.Valid() {
+				orgIDs[e.OrgID] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := c.deleteLogEntriesBefore(ctx, tx, []byte(auditLogKey), t); err != nil {
+			return err
+		}
+		for id := range orgIDs {
+			k, err := encodeAuditOrgLogKey(id)
+			if err != nil {
+				return err
+			}
+			if err := c.deleteLogEntriesBefore(ctx, tx, k, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  getOp(platform.OpDeleteAuditEvents),
+		}
+	}
+	return nil
+}
@@ -1,30 +1,247 @@
 package bolt
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
 )
 
 var (
-	authorizationBucket = []byte("authorizationsv1")
-	authorizationIndex  = []byte("authorizationindexv1")
+	authorizationBucket     = []byte("authorizationsv1")
+	authorizationTokenIndex = []byte("authorizationtokenindexv1")
+
+	// authorizationIndex indexed the authorizations by their plain text token.
+	// It only exists in databases that have not had their tokens hashed yet.
+	authorizationIndex = []byte("authorizationindexv1")
+
+	// authorizationDeleteActionBucket is empty. It exists in the databases whose
+	// authorizations were granted the delete action where they could write.
+	authorizationDeleteActionBucket = []byte("authorizationdeleteactionv1")
+
+	// authorizationNewResourcesBucket is empty. It exists in the databases
+	// whose authorizations were granted the resources that were added after
+	// their permissions were set.
+	authorizationNewResourcesBucket = []byte("authorizationnewresourcesv1")
+)
+
+// The resources, and those of them that belong to an organization, that
+// existed before labels, macros, scrapers, secrets and the others did. Their
+// permissions only had the read and write actions.
+var (
+	legacyResources = []platform.Resource{
+		platform.AuthorizationsResource,
+		platform.BucketsResource,
+		platform.DashboardsResource,
+		platform.OrgsResource,
+		platform.SourcesResource,
+		platform.TasksResource,
+		platform.TelegrafsResource,
+		platform.UsersResource,
+	}
+	legacyOrgResources = []platform.Resource{
+		platform.BucketsResource,
+		platform.DashboardsResource,
+		platform.SourcesResource,
+		platform.TasksResource,
+		platform.TelegrafsResource,
+		platform.UsersResource,
+	}
+)
+
+var (
+	_ platform.AuthorizationService       = (*Client)(nil)
+	_ platform.AuthorizationUsageRecorder = (*Client)(nil)
 )
 
-var _ platform.AuthorizationService = (*Client)(nil)
+// storedAuthorization is an authorization as it is stored, with the hash of
+// its token in place of the token.
+type storedAuthorization struct {
+	platform.Authorization
+	TokenHash *platform.TokenHash `json:"tokenHash,omitempty"`
+}
 
 func (c *Client) initializeAuthorizations(ctx context.Context, tx *bolt.Tx) error {
 	if _, err := tx.CreateBucketIfNotExists([]byte(authorizationBucket)); err != nil {
 		return err
 	}
-	if _, err := tx.CreateBucketIfNotExists([]byte(authorizationIndex)); err != nil {
+	if _, err := tx.CreateBucketIfNotExists([]byte(authorizationTokenIndex)); err != nil {
 		return err
 	}
+	if tx.Bucket(authorizationIndex) != nil {
+		if err := c.migrateAuthorizationTokens(ctx, tx); err != nil {
+			return err
+		}
+	}
+	if tx.Bucket(authorizationDeleteActionBucket) == nil {
+		if err := c.migrateAuthorizationDeleteAction(ctx, tx); err != nil {
+			return err
+		}
+	}
+	if tx.Bucket(authorizationNewResourcesBucket) == nil {
+		return c.migrateAuthorizationNewResources(ctx, tx)
+	}
 	return nil
 }
 
+// migrateAuthorizationTokens hashes the tokens that were stored in plain text
+// and drops the index of plain text tokens. It runs once, on the first open of
+// a database that still has the old index.
+func (c *Client) migrateAuthorizationTokens(ctx context.Context, tx *bolt.Tx) error {
+	var as []*platform.Authorization
+	err := c.forEachAuthorization(ctx, tx, func(a *platform.Authorization) bool {
+		if a.Token != "" {
+			as = append(as, a)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range as {
+		if pe := c.putAuthorization(ctx, tx, a); pe != nil {
+			return pe
+		}
+	}
+
+	return tx.DeleteBucket(authorizationIndex)
+}
+
+// migrateAuthorizationDeleteAction grants the delete action on the resources
+// that the authorizations may write, as writing a resource allowed deleting it
+// before the delete action existed. It runs once, on the first open of a
+// database that does not have the delete action bucket.
+func (c *Client) migrateAuthorizationDeleteAction(ctx context.Context, tx *bolt.Tx) error {
+	var as []*platform.Authorization
+	err := c.forEachAuthorization(ctx, tx, func(a *platform.Authorization) bool {
+		if grantDeleteAction(a) {
+			as = append(as, a)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range as {
+		if pe := c.putAuthorization(ctx, tx, a); pe != nil {
+			return pe
+		}
+	}
+
+	_, err = tx.CreateBucket(authorizationDeleteActionBucket)
+	return err
+}
+
+// grantDeleteAction adds a delete permission for each write permission of the
+// authorization that does not already allow deleting, and reports whether it
+// added any.
+func grantDeleteAction(a *platform.Authorization) bool {
+	var ps []platform.Permission
+	for _, p := range a.Permissions {
+		if p.Action != platform.WriteAction {
+			continue
+		}
+		p.Action = platform.DeleteAction
+		if !platform.PermissionAllowed(p, a.Permissions) && !platform.PermissionAllowed(p, ps) {
+			ps = append(ps, p)
+		}
+	}
+	a.Permissions = append(a.Permissions, ps...)
+	return len(ps) > 0
+}
+
+// migrateAuthorizationNewResources grants the resources that were added since
+// the permissions of the authorizations were set, so that an authorization
+// that could do everything before, such as the one of the operator, still can.
+// It runs once, on the first open of a database that does not have the new
+// resources bucket.
+func (c *Client) migrateAuthorizationNewResources(ctx context.Context, tx *bolt.Tx) error {
+	var as []*platform.Authorization
+	err := c.forEachAuthorization(ctx, tx, func(a *platform.Authorization) bool {
+		if grantNewResources(a) {
+			as = append(as, a)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range as {
+		if pe := c.putAuthorization(ctx, tx, a); pe != nil {
+			return pe
+		}
+	}
+
+	_, err = tx.CreateBucket(authorizationNewResourcesBucket)
+	return err
+}
+
+// grantNewResources adds the permissions that the authorization is missing of
+// the role its legacy permissions were the default of, and reports whether it
+// added any. An authorization that could read and write every legacy resource,
+// such as the one of the operator, is granted OperPermissions. One that could
+// read and write, or only read, every legacy resource of an organization is
+// granted OrgAdminPermissions, or OrgMemberPermissions, of that organization.
+func grantNewResources(a *platform.Authorization) bool {
+	var ps []platform.Permission
+	grant := func(perms []platform.Permission) {
+		for _, p := range perms {
+			if !platform.PermissionAllowed(p, a.Permissions) && !platform.PermissionAllowed(p, ps) {
+				ps = append(ps, p)
+			}
+		}
+	}
+
+	rw := []platform.Action{platform.ReadAction, platform.WriteAction}
+	if allowsAll(a.Permissions, nil, legacyResources, rw) {
+		grant(platform.OperPermissions())
+	}
+
+	for _, id := range permissionIDs(a.Permissions) {
+		id := id
+		switch {
+		case allowsAll(a.Permissions, &id, legacyOrgResources, rw):
+			grant(platform.OrgAdminPermissions(id))
+		case allowsAll(a.Permissions, &id, legacyOrgResources, rw[:1]):
+			grant(platform.OrgMemberPermissions(id))
+		}
+	}
+
+	a.Permissions = append(a.Permissions, ps...)
+	return len(ps) > 0
+}
+
+// allowsAll reports whether ps allows every action on every resource at id.
+func allowsAll(ps []platform.Permission, id *platform.ID, resources []platform.Resource, actions []platform.Action) bool {
+	for _, r := range resources {
+		for _, action := range actions {
+			if !platform.PermissionAllowed(platform.Permission{Action: action, Resource: r, ID: id}, ps) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// permissionIDs returns the distinct IDs of the permissions.
+func permissionIDs(ps []platform.Permission) []platform.ID {
+	var ids []platform.ID
+	seen := make(map[platform.ID]bool)
+	for _, p := range ps {
+		if p.ID != nil && !seen[*p.ID] {
+			seen[*p.ID] = true
+			ids = append(ids, *p.ID)
+		}
+	}
+	return ids
+}
+
 // FindAuthorizationByID retrieves a authorization by id.
 func (c *Client) FindAuthorizationByID(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
 	var a *platform.Authorization
@@ -43,6 +260,14 @@ func (c *Client) FindAuthorizationByID(ctx context.Context, id platform.ID) (*pl
 }
 
 func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Authorization, *platform.Error) {
+	s, pe := c.findStoredAuthorization(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+	return &s.Authorization, nil
+}
+
+func (c *Client) findStoredAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID) (*storedAuthorization, *platform.Error) {
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, &platform.Error{
@@ -51,7 +276,7 @@ func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id plat
 		}
 	}
 
-	var a platform.Authorization
+	var s storedAuthorization
 	v := tx.Bucket(authorizationBucket).Get(encodedID)
 
 	if len(v) == 0 {
@@ -61,14 +286,14 @@ func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id plat
 		}
 	}
 
-	if err := decodeAuthorization(v, &a); err != nil {
+	if err := decodeAuthorization(v, &s); err != nil {
 		return nil, &platform.Error{
 			Code: platform.EInvalid,
 			Err:  err,
 		}
 	}
 
-	return &a, nil
+	return &s, nil
 }
 
 // FindAuthorizationByToken returns a authorization by token for a particular authorization.
@@ -88,22 +313,33 @@ func (c *Client) FindAuthorizationByToken(ctx context.Context, n string) (*platf
 	return a, err
 }
 
+// findAuthorizationByToken looks up the authorizations indexed by the prefix of
+// the token, and returns the one whose token hash the token matches.
 func (c *Client) findAuthorizationByToken(ctx context.Context, tx *bolt.Tx, n string) (*platform.Authorization, *platform.Error) {
-	a := tx.Bucket(authorizationIndex).Get(authorizationIndexKey(n))
-	if a == nil {
-		return nil, &platform.Error{
-			Code: platform.ENotFound,
-			Msg:  "authorization not found",
+	prefix := []byte(platform.TokenPrefix(n))
+	cur := tx.Bucket(authorizationTokenIndex).Cursor()
+	for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		var id platform.ID
+		if err := id.Decode(v); err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}
 		}
-	}
-	var id platform.ID
-	if err := id.Decode(a); err != nil {
-		return nil, &platform.Error{
-			Code: platform.EInvalid,
-			Err:  err,
+
+		s, pe := c.findStoredAuthorization(ctx, tx, id)
+		if pe != nil {
+			return nil, pe
+		}
+		if s.TokenHash != nil && s.TokenHash.Matches(n, c.TokenPepper) {
+			return &s.Authorization, nil
 		}
 	}
-	return c.findAuthorizationByID(ctx, tx, id)
+
+	return nil, &platform.Error{
+		Code: platform.ENotFound,
+		Msg:  "authorization not found",
+	}
 }
 
 func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
@@ -113,19 +349,13 @@ func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platfor
 		}
 	}
 
-	if filter.Token != nil {
-		return func(a *platform.Authorization) bool {
-			return a.Token == *filter.Token
-		}
-	}
-
 	if filter.UserID != nil {
 		return func(a *platform.Authorization) bool {
-			return a.UserID == *filter.UserID
+			return a.UserID == *filter.UserID && filter.Match(a)
 		}
 	}
 
-	return func(a *platform.Authorization) bool { return true }
+	return filter.Match
 }
 
 // FindAuthorizations retrives all authorizations that match an arbitrary authorization filter.
@@ -215,10 +445,6 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 			return platform.ErrUnableToCreateToken
 		}
 
-		if unique := c.uniqueAuthorizationToken(ctx, tx, a); !unique {
-			return platform.ErrUnableToCreateToken
-		}
-
 		token, err := c.TokenGenerator.Token()
 		if err != nil {
 			return &platform.Error{
@@ -228,7 +454,12 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 		}
 		a.Token = token
 
+		if unique := c.uniqueAuthorizationToken(ctx, tx, a); !unique {
+			return platform.ErrUnableToCreateToken
+		}
+
 		a.ID = c.IDGenerator.ID()
+		a.CreatedAt = c.time()
 
 		pe := c.putAuthorization(ctx, tx, a)
 		if pe != nil {
@@ -240,6 +471,8 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 }
 
 // PutAuthorization will put a authorization without setting an ID.
+// The token of the authorization is hashed, or the hash of the token already
+// stored is kept if a.Token is empty.
 func (c *Client) PutAuthorization(ctx context.Context, a *platform.Authorization) (err error) {
 	return c.db.Update(func(tx *bolt.Tx) error {
 		pe := c.putAuthorization(ctx, tx, a)
@@ -250,7 +483,7 @@ func (c *Client) PutAuthorization(ctx context.Context, a *platform.Authorization
 	})
 }
 
-func encodeAuthorization(a *platform.Authorization) ([]byte, error) {
+func encodeAuthorization(a *platform.Authorization, h *platform.TokenHash) ([]byte, error) {
 	switch a.Status {
 	case platform.Active, platform.Inactive:
 	case "":
@@ -262,29 +495,54 @@ func encodeAuthorization(a *platform.Authorization) ([]byte, error) {
 		}
 	}
 
-	return json.Marshal(a)
+	s := &storedAuthorization{
+		Authorization: *a,
+		TokenHash:     h,
+	}
+	s.Token = ""
+	return json.Marshal(s)
 }
 
 func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.Authorization) *platform.Error {
-	v, err := encodeAuthorization(a)
+	encodedID, err := a.ID.Encode()
 	if err != nil {
 		return &platform.Error{
-			Code: platform.EInvalid,
+			Code: platform.ENotFound,
 			Err:  err,
 		}
 	}
 
-	encodedID, err := a.ID.Encode()
-	if err != nil {
-		return &platform.Error{
-			Code: platform.ENotFound,
-			Err:  err,
+	var h *platform.TokenHash
+	if old, pe := c.findStoredAuthorization(ctx, tx, a.ID); pe == nil {
+		h = old.TokenHash
+	}
+
+	if a.Token != "" {
+		if h != nil {
+			if err := tx.Bucket(authorizationTokenIndex).Delete(authorizationTokenIndexKey(h.Prefix, encodedID)); err != nil {
+				return &platform.Error{
+					Err: err,
+				}
+			}
+		}
+
+		if h, err = platform.HashToken(a.Token, c.TokenPepper); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
+		if err := tx.Bucket(authorizationTokenIndex).Put(authorizationTokenIndexKey(h.Prefix, encodedID), encodedID); err != nil {
+			return &platform.Error{
+				Code: platform.EInternal,
+				Err:  err,
+			}
 		}
 	}
 
-	if err := tx.Bucket(authorizationIndex).Put(authorizationIndexKey(a.Token), encodedID); err != nil {
+	v, err := encodeAuthorization(a, h)
+	if err != nil {
 		return &platform.Error{
-			Code: platform.EInternal,
+			Code: platform.EInvalid,
 			Err:  err,
 		}
 	}
@@ -298,16 +556,20 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 	return nil
 }
 
-func authorizationIndexKey(n string) []byte {
-	return []byte(n)
+// authorizationTokenIndexKey is the prefix of the token followed by the id of
+// the authorization, as several tokens can share a prefix.
+func authorizationTokenIndexKey(prefix string, encodedID []byte) []byte {
+	k := make([]byte, 0, len(prefix)+len(encodedID))
+	k = append(k, prefix...)
+	return append(k, encodedID...)
 }
 
-func decodeAuthorization(b []byte, a *platform.Authorization) error {
-	if err := json.Unmarshal(b, a); err != nil {
+func decodeAuthorization(b []byte, s *storedAuthorization) error {
+	if err := json.Unmarshal(b, s); err != nil {
 		return err
 	}
-	if a.Status == "" {
-		a.Status = platform.Active
+	if s.Status == "" {
+		s.Status = platform.Active
 	}
 	return nil
 }
@@ -316,12 +578,12 @@ func decodeAuthorization(b []byte, a *platform.Authorization) error {
 func (c *Client) forEachAuthorization(ctx context.Context, tx *bolt.Tx, fn func(*platform.Authorization) bool) error {
 	cur := tx.Bucket(authorizationBucket).Cursor()
 	for k, v := cur.First(); k != nil; k, v = cur.Next() {
-		a := &platform.Authorization{}
+		s := &storedAuthorization{}
 
-		if err := decodeAuthorization(v, a); err != nil {
+		if err := decodeAuthorization(v, s); err != nil {
 			return err
 		}
-		if !fn(a) {
+		if !fn(&s.Authorization) {
 			break
 		}
 	}
@@ -330,8 +592,8 @@ func (c *Client) forEachAuthorization(ctx context.Context, tx *bolt.Tx, fn func(
 }
 
 func (c *Client) uniqueAuthorizationToken(ctx context.Context, tx *bolt.Tx, a *platform.Authorization) bool {
-	v := tx.Bucket(authorizationIndex).Get(authorizationIndexKey(a.Token))
-	return len(v) == 0
+	_, pe := c.findAuthorizationByToken(ctx, tx, a.Token)
+	return pe != nil && pe.Code == platform.ENotFound
 }
 
 // DeleteAuthorization deletes a authorization and prunes it from the index.
@@ -348,21 +610,23 @@ func (c *Client) DeleteAuthorization(ctx context.Context, id platform.ID) error
 }
 
 func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID) *platform.Error {
-	a, pe := c.findAuthorizationByID(ctx, tx, id)
+	s, pe := c.findStoredAuthorization(ctx, tx, id)
 	if pe != nil {
 		return pe
 	}
-	if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.Token)); err != nil {
-		return &platform.Error{
-			Err: err,
-		}
-	}
 	encodedID, err := id.Encode()
 	if err != nil {
 		return &platform.Error{
 			Err: err,
 		}
 	}
+	if s.TokenHash != nil {
+		if err := tx.Bucket(authorizationTokenIndex).Delete(authorizationTokenIndexKey(s.TokenHash.Prefix, encodedID)); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
+	}
 
 	if err := tx.Bucket(authorizationBucket).Delete(encodedID); err != nil {
 		return &platform.Error{
@@ -376,7 +640,9 @@ func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 // for setting an authorization to inactive or active.
 func (c *Client) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
 	return c.db.Update(func(tx *bolt.Tx) error {
-		if pe := c.updateAuthorization(ctx, tx, id, status); pe != nil {
+		if pe := c.updateAuthorization(ctx, tx, id, func(a *platform.Authorization) {
+			a.Status = status
+		}); pe != nil {
 			return &platform.Error{
 				Err: pe,
 				Op:  platform.OpSetAuthorizationStatus,
@@ -386,14 +652,33 @@ func (c *Client) SetAuthorizationStatus(ctx context.Context, id platform.ID, sta
 	})
 }
 
-func (c *Client) updateAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID, status platform.Status) *platform.Error {
-	a, pe := c.findAuthorizationByID(ctx, tx, id)
+// SetAuthorizationLastUsed sets the time the authorization was last used, unless
+// it was last used later than t. The authorization is read and written in the
+// same transaction, so that the update does not overwrite concurrent changes.
+func (c *Client) SetAuthorizationLastUsed(ctx context.Context, id platform.ID, t time.Time) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pe := c.updateAuthorization(ctx, tx, id, func(a *platform.Authorization) {
+			if a.LastUsedAt == nil || t.After(*a.LastUsedAt) {
+				a.LastUsedAt = &t
+			}
+		}); pe != nil {
+			return &platform.Error{
+				Err: pe,
+				Op:  platform.OpSetAuthorizationLastUsed,
+			}
+		}
+		return nil
+	})
+}
+
+func (c *Client) updateAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID, update func(*platform.Authorization)) *platform.Error {
+	s, pe := c.findStoredAuthorization(ctx, tx, id)
 	if pe != nil {
 		return pe
 	}
 
-	a.Status = status
-	b, err := encodeAuthorization(a)
+	update(&s.Authorization)
+	b, err := encodeAuthorization(&s.Authorization, s.TokenHash)
 	if err != nil {
 		return &platform.Error{
 			Err: err,
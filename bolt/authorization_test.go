@@ -1,9 +1,15 @@
 package bolt_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
 	"testing"
 
+	bbolt "github.com/coreos/bbolt"
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -16,6 +22,7 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 	}
 	c.IDGenerator = f.IDGenerator
 	c.TokenGenerator = f.TokenGenerator
+	c.WithTime(f.NowFn)
 	ctx := context.Background()
 
 	for _, u := range f.Users {
@@ -61,3 +68,275 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 func TestAuthorizationService(t *testing.T) {
 	platformtesting.AuthorizationService(initAuthorizationService, t)
 }
+
+func TestClient_MigrateAuthorizationTokens(t *testing.T) {
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	// Write an authorization the way it was stored before tokens were hashed.
+	auth := &platform.Authorization{
+		ID:     platformtesting.MustIDBase16("020f755c3c082000"),
+		UserID: platformtesting.MustIDBase16("020f755c3c082001"),
+		OrgID:  platformtesting.MustIDBase16("020f755c3c082002"),
+		Token:  "supersecrettoken",
+		Status: platform.Active,
+	}
+	encodedID, err := auth.ID.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := json.Marshal(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := bbolt.Open(f.Name(), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("authorizationsv1"))
+		if err != nil {
+			return err
+		}
+		if err := b.Put(encodedID, v); err != nil {
+			return err
+		}
+		idx, err := tx.CreateBucket([]byte("authorizationindexv1"))
+		if err != nil {
+			return err
+		}
+		return idx.Put([]byte(auth.Token), encodedID)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	c.TokenPepper = []byte("pepper")
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := c.FindAuthorizationByToken(ctx, auth.Token)
+	if err != nil {
+		t.Fatalf("failed to find authorization by its token: %v", err)
+	}
+	if a.ID != auth.ID || a.Token != "" {
+		t.Fatalf("got authorization %s with token %q, want %s without its token", a.ID, a.Token, auth.ID)
+	}
+	c.Close()
+
+	db, err = bbolt.Open(f.Name(), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("authorizationindexv1")) != nil {
+			t.Error("expected the index of plain text tokens to be dropped")
+		}
+		if v := tx.Bucket([]byte("authorizationsv1")).Get(encodedID); bytes.Contains(v, []byte(auth.Token)) {
+			t.Errorf("expected the token not to be stored, got %s", v)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_MigrateAuthorizationDeleteAction(t *testing.T) {
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	bucketID := platformtesting.MustIDBase16("020f755c3c082003")
+	orgID := platformtesting.MustIDBase16("020f755c3c082002")
+
+	// Write an authorization the way it was stored before the delete action existed.
+	auth := &platform.Authorization{
+		ID:     platformtesting.MustIDBase16("020f755c3c082000"),
+		UserID: platformtesting.MustIDBase16("020f755c3c082001"),
+		OrgID:  orgID,
+		Status: platform.Active,
+		Permissions: []platform.Permission{
+			{Action: platform.WriteAction, Resource: platform.BucketsResource, ID: &bucketID},
+			{Action: platform.ReadAction, Resource: platform.OrgsResource, ID: &orgID},
+			{Action: platform.WriteAction, Resource: platform.DashboardsResource},
+			{Action: platform.DeleteAction, Resource: platform.DashboardsResource},
+		},
+	}
+	encodedID, err := auth.ID.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := json.Marshal(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := bbolt.Open(f.Name(), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("authorizationsv1"))
+		if err != nil {
+			return err
+		}
+		return b.Put(encodedID, v)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	a, err := c.FindAuthorizationByID(ctx, auth.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := append(auth.Permissions, platform.Permission{Action: platform.DeleteAction, Resource: platform.BucketsResource, ID: &bucketID})
+	if diff := cmp.Diff(want, a.Permissions); diff != "" {
+		t.Fatalf("unexpected permissions -want/+got\n%s", diff)
+	}
+}
+
+func TestClient_MigrateAuthorizationNewResources(t *testing.T) {
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	orgID := platformtesting.MustIDBase16("020f755c3c082002")
+
+	// Write authorizations with the permissions of the operator, an org admin
+	// and an org member the way they were before the new resources existed.
+	var operPerms, adminPerms, memberPerms []platform.Permission
+	for _, r := range []platform.Resource{
+		platform.AuthorizationsResource,
+		platform.BucketsResource,
+		platform.DashboardsResource,
+		platform.OrgsResource,
+		platform.SourcesResource,
+		platform.TasksResource,
+		platform.TelegrafsResource,
+		platform.UsersResource,
+	} {
+		operPerms = append(operPerms,
+			platform.Permission{Action: platform.ReadAction, Resource: r},
+			platform.Permission{Action: platform.WriteAction, Resource: r},
+		)
+		if r == platform.AuthorizationsResource || r == platform.OrgsResource {
+			continue
+		}
+		adminPerms = append(adminPerms,
+			platform.Permission{Action: platform.ReadAction, Resource: r, ID: &orgID},
+			platform.Permission{Action: platform.WriteAction, Resource: r, ID: &orgID},
+		)
+		memberPerms = append(memberPerms, platform.Permission{Action: platform.ReadAction, Resource: r, ID: &orgID})
+	}
+	auths := []*platform.Authorization{
+		{ID: platformtesting.MustIDBase16("020f755c3c082000"), Permissions: operPerms},
+		{ID: platformtesting.MustIDBase16("020f755c3c082001"), Permissions: adminPerms},
+		{ID: platformtesting.MustIDBase16("020f755c3c082003"), Permissions: memberPerms},
+		{ID: platformtesting.MustIDBase16("020f755c3c082004"), Permissions: operPerms[:1]},
+	}
+
+	db, err := bbolt.Open(f.Name(), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("authorizationsv1"))
+		if err != nil {
+			return err
+		}
+		for _, a := range auths {
+			a.OrgID, a.UserID, a.Status = orgID, platformtesting.MustIDBase16("020f755c3c082005"), platform.Active
+			encodedID, err := a.ID.Encode()
+			if err != nil {
+				return err
+			}
+			v, err := json.Marshal(a)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(encodedID, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	find := func(id platform.ID) *platform.Authorization {
+		a, err := c.FindAuthorizationByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	allowed := func(a *platform.Authorization, p platform.Permission) bool {
+		return platform.PermissionAllowed(p, a.Permissions)
+	}
+
+	// The operator can do everything.
+	oper := find(auths[0].ID)
+	for _, p := range platform.OperPermissions() {
+		if !allowed(oper, p) {
+			t.Errorf("operator is not allowed %s", p)
+		}
+	}
+
+	// The org admin can do everything on the new resources of its org.
+	admin := find(auths[1].ID)
+	for _, r := range []platform.Resource{platform.LabelsResource, platform.ScrapersResource, platform.SecretsResource, platform.DBRPsResource, platform.UsageResource, platform.AuditResource} {
+		if p := (platform.Permission{Action: platform.AdminAction, Resource: r, ID: &orgID}); !allowed(admin, p) {
+			t.Errorf("org admin is not allowed %s", p)
+		}
+	}
+	if p := (platform.Permission{Action: platform.ReadAction, Resource: platform.LabelsResource}); allowed(admin, p) {
+		t.Errorf("org admin is allowed %s", p)
+	}
+
+	// The org member can read the new resources of its org.
+	member := find(auths[2].ID)
+	if p := (platform.Permission{Action: platform.ReadAction, Resource: platform.ScrapersResource, ID: &orgID}); !allowed(member, p) {
+		t.Errorf("org member is not allowed %s", p)
+	}
+	if p := (platform.Permission{Action: platform.WriteAction, Resource: platform.ScrapersResource, ID: &orgID}); allowed(member, p) {
+		t.Errorf("org member is allowed %s", p)
+	}
+
+	// Other authorizations are left as they were.
+	if diff := cmp.Diff(auths[3].Permissions, find(auths[3].ID).Permissions); diff != "" {
+		t.Fatalf("unexpected permissions -want/+got\n%s", diff)
+	}
+}
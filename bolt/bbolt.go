@@ -29,7 +29,10 @@ type Client struct {
 
 	IDGenerator    platform.IDGenerator
 	TokenGenerator platform.TokenGenerator
-	time           func() time.Time
+	// TokenPepper is the secret that API tokens are hashed with. Tokens can not
+	// be found if it changes, it must be set before the client is opened.
+	TokenPepper []byte
+	time        func() time.Time
 }
 
 // NewClient returns an instance of a Client.
@@ -168,6 +171,11 @@ func (c *Client) initialize(ctx context.Context) error {
 			return err
 		}
 
+		// Always create DBRPMapping bucket.
+		if err := c.initializeDBRPMappings(ctx, tx); err != nil {
+			return err
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -176,6 +184,14 @@ func (c *Client) initialize(ctx context.Context) error {
 	return nil
 }
 
+// Backup writes a consistent copy of the database to a new file at path. The
+// copy is written in a read transaction, so the database remains in use.
+func (c *Client) Backup(ctx context.Context, path string) error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(path, 0600)
+	})
+}
+
 // Close the connection to the bolt database
 func (c *Client) Close() error {
 	if c.db != nil {
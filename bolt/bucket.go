@@ -354,6 +354,13 @@ func (c *Client) CreateBucket(ctx context.Context, b *platform.Bucket) error {
 			pe.Op = op
 			err = pe
 		}
+
+		if err := c.createBucketDBRPMapping(ctx, tx, b); err != nil {
+			return &platform.Error{
+				Op:  op,
+				Err: err,
+			}
+		}
 		return nil
 	})
 }
@@ -577,6 +584,18 @@ func (c *Client) deleteBucket(ctx context.Context, tx *bolt.Tx, id platform.ID)
 			Err: err,
 		}
 	}
+
+	if err := c.deleteBucketDBRPMappings(ctx, tx, b); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+
+	if err := c.deleteBucketUsage(ctx, tx, b); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,93 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kv"
+)
+
+var _ platform.DBRPMappingService = (*Client)(nil)
+
+// dbrpMappingBucketsBucket is empty. It exists in the databases whose buckets
+// were given their default dbrp mapping.
+var dbrpMappingBucketsBucket = []byte("dbrpmappingbucketsv1")
+
+// dbrpMappingService returns the kv dbrp mapping service of the client's database.
+func (c *Client) dbrpMappingService() *kv.DBRPMappingService {
+	s := NewKVStore(c.Path)
+	s.WithDB(c.db)
+	return kv.NewDBRPMappingService(s)
+}
+
+func (c *Client) initializeDBRPMappings(ctx context.Context, tx *bolt.Tx) error {
+	if err := kv.InitializeDBRPMappings(&Tx{tx: tx, ctx: ctx}); err != nil {
+		return err
+	}
+	if tx.Bucket(dbrpMappingBucketsBucket) == nil {
+		return c.migrateBucketDBRPMappings(ctx, tx)
+	}
+	return nil
+}
+
+// migrateBucketDBRPMappings creates the default dbrp mapping of the buckets
+// that were created before buckets were given one. It runs once, on the first
+// open of a database that does not have the dbrp mapping buckets bucket.
+func (c *Client) migrateBucketDBRPMappings(ctx context.Context, tx *bolt.Tx) error {
+	var bs []*platform.Bucket
+	cur := tx.Bucket(bucketBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		b := &platform.Bucket{}
+		if err := json.Unmarshal(v, b); err != nil {
+			return err
+		}
+		bs = append(bs, b)
+	}
+
+	for _, b := range bs {
+		if err := c.createBucketDBRPMapping(ctx, tx, b); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.CreateBucket(dbrpMappingBucketsBucket)
+	return err
+}
+
+// FindBy returns a single dbrp mapping of an organization by cluster, db and rp.
+func (c *Client) FindBy(ctx context.Context, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	return c.dbrpMappingService().FindBy(ctx, orgID, cluster, db, rp)
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (c *Client) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+	return c.dbrpMappingService().Find(ctx, filter)
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (c *Client) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+	return c.dbrpMappingService().FindMany(ctx, filter, opt...)
+}
+
+// Create creates a new dbrp mapping. Creating a default mapping unsets the
+// default of the other mappings of the organization, cluster and database.
+func (c *Client) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	return c.dbrpMappingService().Create(ctx, m)
+}
+
+// Delete removes a dbrp mapping of an organization.
+func (c *Client) Delete(ctx context.Context, orgID platform.ID, cluster, db, rp string) error {
+	return c.dbrpMappingService().Delete(ctx, orgID, cluster, db, rp)
+}
+
+// createBucketDBRPMapping creates the dbrp mapping of a new bucket.
+func (c *Client) createBucketDBRPMapping(ctx context.Context, tx *bolt.Tx, b *platform.Bucket) error {
+	return kv.CreateBucketDBRPMapping(ctx, &Tx{tx: tx, ctx: ctx}, b)
+}
+
+// deleteBucketDBRPMappings removes the dbrp mappings of a bucket.
+func (c *Client) deleteBucketDBRPMappings(ctx context.Context, tx *bolt.Tx, b *platform.Bucket) error {
+	return kv.DeleteBucketDBRPMappings(ctx, &Tx{tx: tx, ctx: ctx}, b.OrganizationID, b.ID)
+}
@@ -0,0 +1,179 @@
+package bolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	bbolt "github.com/coreos/bbolt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initDBRPMappingService(f platformtesting.DBRPMappingFields, t *testing.T) (platform.DBRPMappingService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	ctx := context.Background()
+	if err := f.Populate(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	return c, func() {
+		defer closeFn()
+		if err := platformtesting.CleanupDBRPMappings(ctx, c); err != nil {
+			t.Logf("failed to remove dbrp mappings: %v", err)
+		}
+	}
+}
+
+func TestDBRPMappingService_CreateDBRPMapping(t *testing.T) {
+	platformtesting.CreateDBRPMapping(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMappingByKey(t *testing.T) {
+	platformtesting.FindDBRPMappingByKey(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMappings(t *testing.T) {
+	platformtesting.FindDBRPMappings(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_DeleteDBRPMapping(t *testing.T) {
+	platformtesting.DeleteDBRPMapping(initDBRPMappingService, t)
+}
+
+func TestDBRPMappingService_FindDBRPMapping(t *testing.T) {
+	platformtesting.FindDBRPMapping(initDBRPMappingService, t)
+}
+
+func TestClient_CreateBucket_DBRPMapping(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+	ctx := context.Background()
+
+	o := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+
+	buckets := []*platform.Bucket{
+		{Name: "telegraf", OrganizationID: o.ID},
+		{Name: "telegraf/weekly", OrganizationID: o.ID},
+		{Name: "invalid/", OrganizationID: o.ID},
+	}
+	for _, b := range buckets {
+		if err := c.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []*platform.DBRPMapping{
+		{
+			Cluster:         platform.DefaultDBRPCluster,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  o.ID,
+			BucketID:        buckets[0].ID,
+		},
+		{
+			Cluster:         platform.DefaultDBRPCluster,
+			Database:        "telegraf",
+			RetentionPolicy: "weekly",
+			Default:         false,
+			OrganizationID:  o.ID,
+			BucketID:        buckets[1].ID,
+		},
+	}
+	ms, _, err := c.FindMany(ctx, platform.DBRPMappingFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(ms, want); diff != "" {
+		t.Errorf("dbrp mappings are different -got/+want\ndiff %s", diff)
+	}
+
+	if err := c.DeleteBucket(ctx, buckets[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	ms, _, err = c.FindMany(ctx, platform.DBRPMappingFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(ms, want[1:]); diff != "" {
+		t.Errorf("dbrp mappings are different -got/+want\ndiff %s", diff)
+	}
+}
+
+func TestClient_MigrateBucketDBRPMappings(t *testing.T) {
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	c := bolt.NewClient()
+	c.Path = f.Name()
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	o := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	b := &platform.Bucket{Name: "telegraf", OrganizationID: o.ID}
+	if err := c.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	// Remove the mapping and the marker of the migration, the way the
+	// database was before buckets were given a default mapping.
+	db, err := bbolt.Open(f.Name(), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("dbrpmappingsv1")); err != nil {
+			return err
+		}
+		return tx.DeleteBucket([]byte("dbrpmappingbucketsv1"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	c = bolt.NewClient()
+	c.Path = f.Name()
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	want := []*platform.DBRPMapping{
+		{
+			Cluster:         platform.DefaultDBRPCluster,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  o.ID,
+			BucketID:        b.ID,
+		},
+	}
+	ms, _, err := c.FindMany(ctx, platform.DBRPMappingFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(ms, want); diff != "" {
+		t.Errorf("dbrp mappings are different -got/+want\ndiff %s", diff)
+	}
+}
@@ -109,7 +109,10 @@ func (c *Client) initializeKeyValueLog(ctx context.Context, tx *bolt.Tx) error {
 	return nil
 }
 
-var errKeyValueLogBoundsNotFound = fmt.Errorf("oplog not found")
+var errKeyValueLogBoundsNotFound = &platform.Error{
+	Code: platform.ENotFound,
+	Msg:  "oplog not found",
+}
 
 func (c *Client) getKeyValueLogBounds(ctx context.Context, tx *bolt.Tx, key []byte) (*keyValueLogBounds, error) {
 	k := encodeKeyValueIndexKey(key)
@@ -252,6 +255,110 @@ func (c *Client) forEachLogEntry(ctx context.Context, tx *bolt.Tx, k []byte, opt
 
 }
 
+// forEachLogEntryInRange iterates in time order through the log entries at
+// key k with times in the range [start, stop), applying fn to each. Entries
+// are visited in descending time order if descending is true.
+func (c *Client) forEachLogEntryInRange(ctx context.Context, tx *bolt.Tx, k []byte, start, stop int64, descending bool, fn func([]byte, time.Time) error) error {
+	startKey, err := encodeLogEntryKey(k, start)
+	if err != nil {
+		return err
+	}
+	// The entries of the log share the encoded key of any entry without its time.
+	prefix := startKey[:len(startKey)-8]
+
+	cur := tx.Bucket(keyValueLogBucket).Cursor()
+	key, v := cur.Seek(startKey)
+	next := cur.Next
+	if descending {
+		stopKey, err := encodeLogEntryKey(k, stop)
+		if err != nil {
+			return err
+		}
+		// The last entry in the range is the one before the first entry at
+		// or after its stop.
+		if key, _ = cur.Seek(stopKey); key == nil {
+			key, v = cur.Last()
+		} else {
+			key, v = cur.Prev()
+		}
+		next = cur.Prev
+	}
+
+	for ; bytes.HasPrefix(key, prefix); key, v = next() {
+		_, ts, err := decodeLogEntryKey(key)
+		if err != nil {
+			return err
+		}
+
+		if ts.UnixNano() < start || ts.UnixNano() >= stop {
+			break
+		}
+
+		if err := fn(v, ts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteLogEntriesBefore removes the entries of the log at key k with times
+// before t. The bounds of the log start at the first remaining entry, and are
+// removed along with the log once it is empty.
+func (c *Client) deleteLogEntriesBefore(ctx context.Context, tx *bolt.Tx, k []byte, t time.Time) error {
+	bounds, err := c.getKeyValueLogBounds(ctx, tx, k)
+	if err == errKeyValueLogBoundsNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	stop := t.UTC().UnixNano()
+	if bounds.Start >= stop {
+		return nil
+	}
+
+	startKey, err := encodeLogEntryKey(k, bounds.Start)
+	if err != nil {
+		return err
+	}
+	prefix := startKey[:len(startKey)-8]
+
+	// Deleting the entry under a bolt cursor moves it, so the keys are
+	// collected before any are deleted.
+	var keys [][]byte
+	cur := tx.Bucket(keyValueLogBucket).Cursor()
+	for key, _ := cur.Seek(startKey); bytes.HasPrefix(key, prefix); key, _ = cur.Next() {
+		_, ts, err := decodeLogEntryKey(key)
+		if err != nil {
+			return err
+		}
+
+		if ts.UnixNano() >= stop {
+			break
+		}
+		keys = append(keys, append([]byte(nil), key...))
+	}
+
+	for _, key := range keys {
+		if err := tx.Bucket(keyValueLogBucket).Delete(key); err != nil {
+			return err
+		}
+	}
+
+	if bounds.Stop < stop {
+		return tx.Bucket(keyValueLogIndex).Delete(encodeKeyValueIndexKey(k))
+	}
+
+	key, _ := tx.Bucket(keyValueLogBucket).Cursor().Seek(startKey)
+	_, ts, err := decodeLogEntryKey(key)
+	if err != nil {
+		return err
+	}
+	bounds.Start = ts.UnixNano()
+	return c.putKeyValueLogBounds(ctx, tx, k, bounds)
+}
+
 // LogKeyValue logs an keyValue for a particular resource type ID pairing.
 func (c *Client) AddLogEntry(ctx context.Context, k, v []byte, t time.Time) error {
 	return c.db.Update(func(tx *bolt.Tx) error {
@@ -90,3 +90,45 @@ func TestExampleService_FindUser(t *testing.T) {
 func TestExampleService_UpdateUser(t *testing.T) {
 	platformtesting.UpdateUser(initExampleService, t)
 }
+
+func initKVDBRPMappingService(f platformtesting.DBRPMappingFields, t *testing.T) (platform.DBRPMappingService, func()) {
+	s, closeFn, err := NewTestKVStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	svc := kv.NewDBRPMappingService(s)
+	if err := svc.Initialize(); err != nil {
+		t.Fatalf("error initializing dbrp mapping service: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := f.Populate(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	return svc, func() {
+		defer closeFn()
+		if err := platformtesting.CleanupDBRPMappings(ctx, svc); err != nil {
+			t.Logf("failed to remove dbrp mappings: %v", err)
+		}
+	}
+}
+
+func TestKVDBRPMappingService_CreateDBRPMapping(t *testing.T) {
+	platformtesting.CreateDBRPMapping(initKVDBRPMappingService, t)
+}
+
+func TestKVDBRPMappingService_FindDBRPMappingByKey(t *testing.T) {
+	platformtesting.FindDBRPMappingByKey(initKVDBRPMappingService, t)
+}
+
+func TestKVDBRPMappingService_FindDBRPMappings(t *testing.T) {
+	platformtesting.FindDBRPMappings(initKVDBRPMappingService, t)
+}
+
+func TestKVDBRPMappingService_DeleteDBRPMapping(t *testing.T) {
+	platformtesting.DeleteDBRPMapping(initKVDBRPMappingService, t)
+}
+
+func TestKVDBRPMappingService_FindDBRPMapping(t *testing.T) {
+	platformtesting.FindDBRPMapping(initKVDBRPMappingService, t)
+}
@@ -135,6 +135,7 @@ func (c *Client) Generate(ctx context.Context, req *platform.OnboardingRequest)
 		Description: fmt.Sprintf("%s's Token", u.Name),
 		OrgID:       o.ID,
 		Permissions: perms,
+		CreatedBy:   u.ID,
 	}
 	if err = c.CreateAuthorization(ctx, auth); err != nil {
 		return nil, err
@@ -15,6 +15,7 @@ func initOnboardingService(f platformtesting.OnboardingFields, t *testing.T) (pl
 	}
 	c.IDGenerator = f.IDGenerator
 	c.TokenGenerator = f.TokenGenerator
+	c.WithTime(f.NowFn)
 	ctx := context.TODO()
 	if err = c.PutOnboardingStatus(ctx, !f.IsOnboarding); err != nil {
 		t.Fatalf("failed to set new onboarding finished: %v", err)
@@ -382,6 +382,11 @@ func (c *Client) updateOrganization(ctx context.Context, tx *bolt.Tx, id platfor
 // DeleteOrganization deletes a organization and prunes it from the index.
 func (c *Client) DeleteOrganization(ctx context.Context, id platform.ID) error {
 	err := c.db.Update(func(tx *bolt.Tx) error {
+		// The usage log of the organization is removed before its buckets,
+		// so that they have no usage of theirs left to remove from it.
+		if err := c.deleteOrganizationUsage(ctx, tx, id); err != nil {
+			return err
+		}
 		if pe := c.deleteOrganizationsBuckets(ctx, tx, id); pe != nil {
 			return pe
 		}
@@ -9,15 +9,20 @@ import (
 )
 
 var (
-	scraperBucket = []byte("scraperv2")
+	scraperBucket       = []byte("scraperv2")
+	scraperHealthBucket = []byte("scraperhealthv1")
 )
 
 var _ platform.ScraperTargetStoreService = (*Client)(nil)
+var _ platform.ScraperTargetHealthService = (*Client)(nil)
 
 func (c *Client) initializeScraperTargets(ctx context.Context, tx *bolt.Tx) error {
 	if _, err := tx.CreateBucketIfNotExists([]byte(scraperBucket)); err != nil {
 		return err
 	}
+	if _, err := tx.CreateBucketIfNotExists(scraperHealthBucket); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -46,6 +51,12 @@ func (c *Client) ListTargets(ctx context.Context) (list []platform.ScraperTarget
 
 // AddTarget add a new scraper target into storage.
 func (c *Client) AddTarget(ctx context.Context, target *platform.ScraperTarget) (err error) {
+	if err := target.Valid(); err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  OpPrefix + platform.OpAddTarget,
+		}
+	}
 	err = c.db.Update(func(tx *bolt.Tx) error {
 		target.ID = c.IDGenerator.ID()
 		return c.putTarget(ctx, tx, target)
@@ -73,6 +84,9 @@ func (c *Client) RemoveTarget(ctx context.Context, id platform.ID) error {
 				Err:  err,
 			}
 		}
+		if err := tx.Bucket(scraperHealthBucket).Delete(encID); err != nil {
+			return err
+		}
 		return tx.Bucket(scraperBucket).Delete(encID)
 	})
 	if err != nil {
@@ -95,6 +109,12 @@ func (c *Client) UpdateTarget(ctx context.Context, update *platform.ScraperTarge
 			Msg:  "id is invalid",
 		}
 	}
+	if err := update.Valid(); err != nil {
+		return nil, &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
 	err = c.db.Update(func(tx *bolt.Tx) error {
 		target, pe = c.findTargetByID(ctx, tx, update.ID)
 		if pe != nil {
@@ -175,3 +195,57 @@ func (c *Client) PutTarget(ctx context.Context, target *platform.ScraperTarget)
 		return c.putTarget(ctx, tx, target)
 	})
 }
+
+// GetTargetHealth returns the health of a scraper target.
+func (c *Client) GetTargetHealth(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error) {
+	health := new(platform.ScraperTargetHealth)
+	err := c.db.View(func(tx *bolt.Tx) error {
+		encID, err := id.Encode()
+		if err != nil {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}
+		}
+		v := tx.Bucket(scraperHealthBucket).Get(encID)
+		if len(v) == 0 {
+			return &platform.Error{
+				Code: platform.ENotFound,
+				Msg:  "scraper target health is not found",
+			}
+		}
+		return json.Unmarshal(v, health)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  getOp(platform.OpGetTargetHealth),
+			Err: err,
+		}
+	}
+	return health, nil
+}
+
+// PutTargetHealth replaces the health of an existing scraper target.
+func (c *Client) PutTargetHealth(ctx context.Context, id platform.ID, health *platform.ScraperTargetHealth) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if _, pe := c.findTargetByID(ctx, tx, id); pe != nil {
+			return pe
+		}
+		v, err := json.Marshal(health)
+		if err != nil {
+			return err
+		}
+		encID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(scraperHealthBucket).Put(encID, v)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  getOp(platform.OpPutTargetHealth),
+			Err: err,
+		}
+	}
+	return nil
+}
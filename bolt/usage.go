@@ -0,0 +1,240 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UsageService = (*Client)(nil)
+
+const usageLogKeyPrefix = "usage"
+
+func encodeUsageLogKey(id platform.ID) ([]byte, error) {
+	buf, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(usageLogKeyPrefix), buf...), nil
+}
+
+// AddUsage records usage at time t in the usage log of each organization the
+// usage belongs to. Usage without an organization is ignored.
+func (c *Client) AddUsage(ctx context.Context, usage []*platform.Usage, t time.Time) error {
+	byOrg := make(map[platform.ID][]*platform.Usage)
+	for _, u := range usage {
+		if u.OrganizationID == nil {
+			continue
+		}
+		byOrg[*u.OrganizationID] = append(byOrg[*u.OrganizationID], u)
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		for id, us := range byOrg {
+			k, err := encodeUsageLogKey(id)
+			if err != nil {
+				return err
+			}
+
+			v, err := json.Marshal(us)
+			if err != nil {
+				return err
+			}
+
+			if err := c.addLogEntry(ctx, tx, k, v, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteUsageBefore removes the usage recorded before t from the usage log of
+// every organization.
+func (c *Client) DeleteUsageBefore(ctx context.Context, t time.Time) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var orgIDs []platform.ID
+		err := forEachOrganization(ctx, tx, func(o *platform.Organization) bool {
+			orgIDs = append(orgIDs, o.ID)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range orgIDs {
+			k, err := encodeUsageLogKey(id)
+			if err != nil {
+				return err
+			}
+
+			if err := c.deleteLogEntriesBefore(ctx, tx, k, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  getOp(platform.OpDeleteUsage),
+		}
+	}
+	return nil
+}
+
+// deleteOrganizationUsage removes the usage log of the organization.
+func (c *Client) deleteOrganizationUsage(ctx context.Context, tx *bolt.Tx, orgID platform.ID) error {
+	k, err := encodeUsageLogKey(orgID)
+	if err != nil {
+		return err
+	}
+	return c.deleteLogEntriesBefore(ctx, tx, k, time.Unix(0, math.MaxInt64))
+}
+
+// deleteBucketUsage removes the usage of the bucket from the usage log of its
+// organization.
+func (c *Client) deleteBucketUsage(ctx context.Context, tx *bolt.Tx, b *platform.Bucket) error {
+	k, err := encodeUsageLogKey(b.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	// Entries are rewritten once they have all been read, as writing under a
+	// bolt cursor moves it.
+	type entry struct {
+		usage []*platform.Usage
+		t     time.Time
+	}
+	var entries []entry
+	err = c.forEachLogEntryInRange(ctx, tx, k, 0, math.MaxInt64, false, func(v []byte, t time.Time) error {
+		var us []*platform.Usage
+		if err := json.Unmarshal(v, &us); err != nil {
+			return err
+		}
+
+		kept := us[:0]
+		for _, u := range us {
+			if u.BucketID == nil || *u.BucketID != b.ID {
+				kept = append(kept, u)
+			}
+		}
+		if len(kept) < len(us) {
+			entries = append(entries, entry{usage: kept, t: t})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		v, err := json.Marshal(e.usage)
+		if err != nil {
+			return err
+		}
+		if err := c.putLogEntry(ctx, tx, k, v, e.t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetUsage returns the usage recorded within the filter's range for the
+// filter's organization and bucket, or for all organizations and buckets if
+// they are not specified.
+//
+// Usage is summed across the range, with the exception of UsageSeries, which
+// is a gauge. Its value is the sum of the most recent series cardinality
+// recorded within the range for each bucket.
+func (c *Client) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	start, stop := int64(0), int64(math.MaxInt64)
+	if filter.Range != nil {
+		start, stop = filter.Range.Start.UnixNano(), filter.Range.Stop.UnixNano()
+	}
+
+	type bucketKey struct {
+		orgID, bucketID platform.ID
+	}
+
+	sums := make(map[platform.UsageMetric]float64)
+	series := make(map[bucketKey]float64)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var orgIDs []platform.ID
+		if filter.OrgID != nil {
+			orgIDs = append(orgIDs, *filter.OrgID)
+		} else {
+			err := forEachOrganization(ctx, tx, func(o *platform.Organization) bool {
+				orgIDs = append(orgIDs, o.ID)
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range orgIDs {
+			k, err := encodeUsageLogKey(id)
+			if err != nil {
+				return err
+			}
+
+			err = c.forEachLogEntryInRange(ctx, tx, k, start, stop, false, func(v []byte, t time.Time) error {
+				var us []*platform.Usage
+				if err := json.Unmarshal(v, &us); err != nil {
+					return err
+				}
+
+				for _, u := range us {
+					if filter.BucketID != nil && (u.BucketID == nil || *u.BucketID != *filter.BucketID) {
+						continue
+					}
+
+					if u.Type == platform.UsageSeries {
+						var key bucketKey
+						key.orgID = id
+						if u.BucketID != nil {
+							key.bucketID = *u.BucketID
+						}
+						series[key] = u.Value
+						continue
+					}
+					sums[u.Type] += u.Value
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  getOp(platform.OpGetUsage),
+		}
+	}
+
+	for _, n := range series {
+		sums[platform.UsageSeries] += n
+	}
+
+	usage := make(map[platform.UsageMetric]*platform.Usage, len(sums))
+	for m, v := range sums {
+		usage[m] = &platform.Usage{
+			OrganizationID: filter.OrgID,
+			BucketID:       filter.BucketID,
+			Type:           m,
+			Value:          v,
+		}
+	}
+	return usage, nil
+}
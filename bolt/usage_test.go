@@ -0,0 +1,225 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestClient_GetUsage(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+	c.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket1, bucket2 := platform.ID(1), platform.ID(2)
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, n := range []float64{10, 20, 30} {
+		err := c.AddUsage(ctx, []*platform.Usage{
+			{OrganizationID: &org.ID, BucketID: &bucket1, Type: platform.UsageWriteRequestCount, Value: 1},
+			{OrganizationID: &org.ID, BucketID: &bucket1, Type: platform.UsageSeries, Value: n},
+			{OrganizationID: &org.ID, BucketID: &bucket2, Type: platform.UsageSeries, Value: n + 1},
+			{OrganizationID: &org.ID, Type: platform.UsageQueryRequestCount, Value: 2},
+		}, t0.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter platform.UsageFilter
+		want   map[platform.UsageMetric]float64
+	}{
+		{
+			name:   "all usage",
+			filter: platform.UsageFilter{},
+			want: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestCount: 3,
+				platform.UsageSeries:            61,
+				platform.UsageQueryRequestCount: 6,
+			},
+		},
+		{
+			name:   "organization in range",
+			filter: platform.UsageFilter{OrgID: &org.ID, Range: &platform.Timespan{Start: t0, Stop: t0.Add(2 * time.Minute)}},
+			want: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestCount: 2,
+				platform.UsageSeries:            41,
+				platform.UsageQueryRequestCount: 4,
+			},
+		},
+		{
+			name:   "bucket",
+			filter: platform.UsageFilter{OrgID: &org.ID, BucketID: &bucket1},
+			want: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestCount: 3,
+				platform.UsageSeries:            30,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := c.GetUsage(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[platform.UsageMetric]float64)
+			for m, u := range usage {
+				got[m] = u.Value
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Fatalf("unexpected usage -got/+want\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteUsageBefore(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+	c.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	addUsage := func(t *testing.T, ts time.Time) {
+		err := c.AddUsage(ctx, []*platform.Usage{
+			{OrganizationID: &org.ID, Type: platform.UsageWriteRequestCount, Value: 1},
+		}, ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	count := func(t *testing.T) float64 {
+		usage, err := c.GetUsage(ctx, platform.UsageFilter{OrgID: &org.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u, ok := usage[platform.UsageWriteRequestCount]; ok {
+			return u.Value
+		}
+		return 0
+	}
+
+	for i := 0; i < 3; i++ {
+		addUsage(t, t0.Add(time.Duration(i)*time.Minute))
+	}
+
+	if err := c.DeleteUsageBefore(ctx, t0.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := count(t); got != 2 {
+		t.Fatalf("got %v write requests, want 2", got)
+	}
+
+	// The log bounds start at the first remaining entry.
+	k := append([]byte("usage"), []byte(org.ID.String())...)
+	if _, ts, err := c.FirstLogEntry(ctx, k); err != nil {
+		t.Fatal(err)
+	} else if !ts.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("got first entry at %v, want %v", ts, t0.Add(time.Minute))
+	}
+
+	if err := c.DeleteUsageBefore(ctx, t0.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got := count(t); got != 0 {
+		t.Fatalf("got %v write requests, want 0", got)
+	}
+
+	// Usage is recorded again once the log has been emptied.
+	addUsage(t, t0.Add(2*time.Hour))
+	if got := count(t); got != 1 {
+		t.Fatalf("got %v write requests, want 1", got)
+	}
+}
+
+func TestClient_DeleteUsage(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+	c.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	c.IDGenerator = mock.NewIDGenerator("020f755c3c082001", t)
+	bucket1 := &platform.Bucket{Name: "bucket1", OrganizationID: org.ID}
+	if err := c.CreateBucket(ctx, bucket1); err != nil {
+		t.Fatal(err)
+	}
+	c.IDGenerator = mock.NewIDGenerator("020f755c3c082002", t)
+	bucket2 := &platform.Bucket{Name: "bucket2", OrganizationID: org.ID}
+	if err := c.CreateBucket(ctx, bucket2); err != nil {
+		t.Fatal(err)
+	}
+
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = c.AddUsage(ctx, []*platform.Usage{
+		{OrganizationID: &org.ID, BucketID: &bucket1.ID, Type: platform.UsageWriteRequestCount, Value: 1},
+		{OrganizationID: &org.ID, BucketID: &bucket2.ID, Type: platform.UsageWriteRequestCount, Value: 2},
+		{OrganizationID: &org.ID, Type: platform.UsageWriteRequestCount, Value: 4},
+	}, t0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := func(t *testing.T, bucketID *platform.ID) float64 {
+		usage, err := c.GetUsage(ctx, platform.UsageFilter{OrgID: &org.ID, BucketID: bucketID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u, ok := usage[platform.UsageWriteRequestCount]; ok {
+			return u.Value
+		}
+		return 0
+	}
+
+	// Deleting a bucket removes its usage, and only its usage.
+	if err := c.DeleteBucket(ctx, bucket1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := count(t, &bucket1.ID); got != 0 {
+		t.Fatalf("got %v write requests of the deleted bucket, want 0", got)
+	}
+	if got := count(t, &bucket2.ID); got != 2 {
+		t.Fatalf("got %v write requests of the remaining bucket, want 2", got)
+	}
+	if got := count(t, nil); got != 6 {
+		t.Fatalf("got %v write requests of the organization, want 6", got)
+	}
+
+	// Deleting the organization removes its usage log.
+	if err := c.DeleteOrganization(ctx, org.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := count(t, nil); got != 0 {
+		t.Fatalf("got %v write requests of the deleted organization, want 0", got)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
@@ -23,13 +24,17 @@ var authorizationCmd = &cobra.Command{
 
 // AuthorizationCreateFlags are command line args used when creating a authorization
 type AuthorizationCreateFlags struct {
-	user string
+	user      string
+	orgID     string
+	expiresIn time.Duration
 
 	createUserPermission bool
 	deleteUserPermission bool
 
 	readBucketPermissions  []string
 	writeBucketPermissions []string
+
+	permissions []string
 }
 
 var authorizationCreateFlags AuthorizationCreateFlags
@@ -43,12 +48,15 @@ func init() {
 
 	authorizationCreateCmd.Flags().StringVarP(&authorizationCreateFlags.user, "user", "u", "", "user name (required)")
 	authorizationCreateCmd.MarkFlagRequired("user")
+	authorizationCreateCmd.Flags().StringVarP(&authorizationCreateFlags.orgID, "org-id", "", "", "id of the organization of the authorization")
+	authorizationCreateCmd.Flags().DurationVarP(&authorizationCreateFlags.expiresIn, "expires-in", "", 0, "duration after which the authorization expires, it never expires if not set")
 
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createUserPermission, "create-user", "", false, "grants the permission to create users")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteUserPermission, "delete-user", "", false, "grants the permission to delete users")
 
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "bucket id")
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "bucket id")
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.permissions, "permission", "p", []string{}, "permission as action:resource[:id], where action is one of read, write, delete or admin")
 
 	authorizationCmd.AddCommand(authorizationCreateCmd)
 }
@@ -64,7 +72,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 	}
 
 	if authorizationCreateFlags.deleteUserPermission {
-		p, err := platform.NewPermission(platform.WriteAction, platform.UsersResource)
+		p, err := platform.NewPermission(platform.DeleteAction, platform.UsersResource)
 		if err != nil {
 			return err
 		}
@@ -98,10 +106,29 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		permissions = append(permissions, *p)
 	}
 
+	for _, s := range authorizationCreateFlags.permissions {
+		p, err := platform.ParsePermission(s)
+		if err != nil {
+			return err
+		}
+		permissions = append(permissions, *p)
+	}
+
 	authorization := &platform.Authorization{
 		Permissions: permissions,
 	}
 
+	if authorizationCreateFlags.orgID != "" {
+		if err := authorization.OrgID.DecodeFromString(authorizationCreateFlags.orgID); err != nil {
+			return err
+		}
+	}
+
+	if authorizationCreateFlags.expiresIn > 0 {
+		expiresAt := time.Now().Add(authorizationCreateFlags.expiresIn)
+		authorization.ExpiresAt = &expiresAt
+	}
+
 	s, err := newAuthorizationService(flags)
 	if err != nil {
 		return err
@@ -119,6 +146,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		"User",
 		"UserID",
 		"Permissions",
+		"ExpiresAt",
 	)
 
 	ps := []string{}
@@ -132,6 +160,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		"Status":      authorization.Status,
 		"UserID":      authorization.UserID.String(),
 		"Permissions": ps,
+		"ExpiresAt":   formatAuthorizationTime(authorization.ExpiresAt),
 	})
 
 	w.Flush()
@@ -141,9 +170,13 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 
 // AuthorizationFindFlags are command line args used when finding a authorization
 type AuthorizationFindFlags struct {
-	user   string
-	userID string
-	id     string
+	user        string
+	userID      string
+	id          string
+	createdByID string
+
+	unusedFor     time.Duration
+	expiresWithin time.Duration
 }
 
 var authorizationFindFlags AuthorizationFindFlags
@@ -158,6 +191,9 @@ func init() {
 	authorizationFindCmd.Flags().StringVarP(&authorizationFindFlags.user, "user", "u", "", "user")
 	authorizationFindCmd.Flags().StringVarP(&authorizationFindFlags.userID, "user-id", "", "", "user ID")
 	authorizationFindCmd.Flags().StringVarP(&authorizationFindFlags.id, "id", "i", "", "authorization ID")
+	authorizationFindCmd.Flags().StringVarP(&authorizationFindFlags.createdByID, "created-by-id", "", "", "ID of the user who created the authorizations")
+	authorizationFindCmd.Flags().DurationVarP(&authorizationFindFlags.unusedFor, "unused-for", "", 0, "only find the authorizations that have not been used for this long")
+	authorizationFindCmd.Flags().DurationVarP(&authorizationFindFlags.expiresWithin, "expires-within", "", 0, "only find the authorizations that expire, or have expired, within this long")
 
 	authorizationCmd.AddCommand(authorizationFindCmd)
 }
@@ -206,6 +242,21 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 		}
 		filter.UserID = uID
 	}
+	if authorizationFindFlags.createdByID != "" {
+		cID, err := platform.IDFromString(authorizationFindFlags.createdByID)
+		if err != nil {
+			return err
+		}
+		filter.CreatedBy = cID
+	}
+	if cmd.Flags().Changed("unused-for") {
+		unusedSince := time.Now().Add(-authorizationFindFlags.unusedFor)
+		filter.UnusedSince = &unusedSince
+	}
+	if cmd.Flags().Changed("expires-within") {
+		expiresBefore := time.Now().Add(authorizationFindFlags.expiresWithin)
+		filter.ExpiresBefore = &expiresBefore
+	}
 
 	authorizations, _, err := s.FindAuthorizations(context.Background(), filter)
 	if err != nil {
@@ -215,11 +266,14 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
 		"Status",
 		"User",
 		"UserID",
 		"Permissions",
+		"CreatedBy",
+		"CreatedAt",
+		"ExpiresAt",
+		"LastUsedAt",
 	)
 
 	for _, a := range authorizations {
@@ -228,12 +282,20 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 			permissions = append(permissions, p.String())
 		}
 
+		var createdBy string
+		if a.CreatedBy.Valid() {
+			createdBy = a.CreatedBy.String()
+		}
+
 		w.Write(map[string]interface{}{
 			"ID":          a.ID,
-			"Token":       a.Token,
 			"Status":      a.Status,
 			"UserID":      a.UserID.String(),
 			"Permissions": permissions,
+			"CreatedBy":   createdBy,
+			"CreatedAt":   formatAuthorizationTime(&a.CreatedAt),
+			"ExpiresAt":   formatAuthorizationTime(a.ExpiresAt),
+			"LastUsedAt":  formatAuthorizationTime(a.LastUsedAt),
 		})
 	}
 
@@ -242,6 +304,15 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatAuthorizationTime formats the optional times of authorizations,
+// which are empty when unset.
+func formatAuthorizationTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // AuthorizationDeleteFlags are command line args used when deleting a authorization
 type AuthorizationDeleteFlags struct {
 	id string
@@ -286,7 +357,6 @@ func authorizationDeleteF(cmd *cobra.Command, args []string) error {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
 		"User",
 		"UserID",
 		"Permissions",
@@ -300,7 +370,6 @@ func authorizationDeleteF(cmd *cobra.Command, args []string) error {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
 		"UserID":      a.UserID.String(),
 		"Permissions": ps,
 		"Deleted":     true,
@@ -355,7 +424,6 @@ func authorizationActiveF(cmd *cobra.Command, args []string) error {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
 		"Status",
 		"User",
 		"UserID",
@@ -369,7 +437,6 @@ func authorizationActiveF(cmd *cobra.Command, args []string) error {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
 		"Status":      a.Status,
 		"UserID":      a.UserID.String(),
 		"Permissions": ps,
@@ -424,7 +491,6 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) error {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
 		"Status",
 		"User",
 		"UserID",
@@ -438,7 +504,6 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) error {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
 		"Status":      a.Status,
 		"UserID":      a.UserID.String(),
 		"Permissions": ps,
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+// DBRP Command
+var dbrpCmd = &cobra.Command{
+	Use:   "dbrp",
+	Short: "database and retention policy mapping related commands",
+	Run:   dbrpF,
+}
+
+func dbrpF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+func newDBRPMappingService(f Flags) (platform.DBRPMappingService, error) {
+	if flags.local {
+		boltFile, err := fs.BoltFile()
+		if err != nil {
+			return nil, err
+		}
+		c := bolt.NewClient()
+		c.Path = boltFile
+		if err := c.Open(context.Background()); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+	return &http.DBRPMappingService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}, nil
+}
+
+func writeDBRPMappings(ms ...*platform.DBRPMapping) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Cluster",
+		"Database",
+		"RetentionPolicy",
+		"Default",
+		"OrganizationID",
+		"BucketID",
+	)
+	for _, m := range ms {
+		w.Write(map[string]interface{}{
+			"Cluster":         m.Cluster,
+			"Database":        m.Database,
+			"RetentionPolicy": m.RetentionPolicy,
+			"Default":         m.Default,
+			"OrganizationID":  m.OrganizationID.String(),
+			"BucketID":        m.BucketID.String(),
+		})
+	}
+	w.Flush()
+}
+
+// DBRPCreateFlags define the Create Command
+type DBRPCreateFlags struct {
+	cluster   string
+	db        string
+	rp        string
+	isDefault bool
+	orgID     string
+	bucketID  string
+}
+
+var dbrpCreateFlags DBRPCreateFlags
+
+func init() {
+	dbrpCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create database and retention policy mapping",
+		Run:   dbrpCreateF,
+	}
+
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.cluster, "cluster", "c", platform.DefaultDBRPCluster, "cluster of the mapping")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.db, "db", "d", "", "database of the mapping (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.rp, "rp", "r", "", "retention policy of the mapping (required)")
+	dbrpCreateCmd.Flags().BoolVarP(&dbrpCreateFlags.isDefault, "default", "", false, "make the mapping the default retention policy of the database")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.orgID, "org-id", "", "", "id of the organization that owns the bucket (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.bucketID, "bucket-id", "b", "", "id of the bucket the database and retention policy map to (required)")
+	dbrpCreateCmd.MarkFlagRequired("db")
+	dbrpCreateCmd.MarkFlagRequired("rp")
+	dbrpCreateCmd.MarkFlagRequired("org-id")
+	dbrpCreateCmd.MarkFlagRequired("bucket-id")
+
+	dbrpCmd.AddCommand(dbrpCreateCmd)
+}
+
+func dbrpCreateF(cmd *cobra.Command, args []string) {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         dbrpCreateFlags.cluster,
+		Database:        dbrpCreateFlags.db,
+		RetentionPolicy: dbrpCreateFlags.rp,
+		Default:         dbrpCreateFlags.isDefault,
+	}
+
+	if err := m.BucketID.DecodeFromString(dbrpCreateFlags.bucketID); err != nil {
+		fmt.Printf("error parsing bucket id: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := m.OrganizationID.DecodeFromString(dbrpCreateFlags.orgID); err != nil {
+		fmt.Printf("error parsing organization id: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := s.Create(context.Background(), m); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeDBRPMappings(m)
+}
+
+// DBRPFindFlags define the Find Command
+type DBRPFindFlags struct {
+	orgID     string
+	cluster   string
+	db        string
+	rp        string
+	isDefault bool
+}
+
+var dbrpFindFlags DBRPFindFlags
+
+func init() {
+	dbrpFindCmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find database and retention policy mappings",
+		Run:   dbrpFindF,
+	}
+
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.orgID, "org-id", "", "", "id of the organization of the mappings")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.cluster, "cluster", "c", "", "cluster of the mappings")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.db, "db", "d", "", "database of the mappings")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.rp, "rp", "r", "", "retention policy of the mappings")
+	dbrpFindCmd.Flags().BoolVarP(&dbrpFindFlags.isDefault, "default", "", false, "only find the mappings that are, or are not, the default of their database")
+
+	dbrpCmd.AddCommand(dbrpFindCmd)
+}
+
+func dbrpFindF(cmd *cobra.Command, args []string) {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	filter := platform.DBRPMappingFilter{}
+	if dbrpFindFlags.orgID != "" {
+		orgID, err := platform.IDFromString(dbrpFindFlags.orgID)
+		if err != nil {
+			fmt.Printf("error parsing organization id: %v\n", err)
+			os.Exit(1)
+		}
+		filter.OrganizationID = orgID
+	}
+	if dbrpFindFlags.cluster != "" {
+		filter.Cluster = &dbrpFindFlags.cluster
+	}
+	if dbrpFindFlags.db != "" {
+		filter.Database = &dbrpFindFlags.db
+	}
+	if dbrpFindFlags.rp != "" {
+		filter.RetentionPolicy = &dbrpFindFlags.rp
+	}
+	if cmd.Flags().Changed("default") {
+		filter.Default = &dbrpFindFlags.isDefault
+	}
+
+	ms, _, err := s.FindMany(context.Background(), filter)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeDBRPMappings(ms...)
+}
+
+// DBRPDeleteFlags define the Delete command
+type DBRPDeleteFlags struct {
+	orgID   string
+	cluster string
+	db      string
+	rp      string
+}
+
+var dbrpDeleteFlags DBRPDeleteFlags
+
+func init() {
+	dbrpDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete database and retention policy mapping",
+		Run:   dbrpDeleteF,
+	}
+
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.orgID, "org-id", "", "", "id of the organization of the mapping (required)")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.cluster, "cluster", "c", platform.DefaultDBRPCluster, "cluster of the mapping")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.db, "db", "d", "", "database of the mapping (required)")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.rp, "rp", "r", "", "retention policy of the mapping (required)")
+	dbrpDeleteCmd.MarkFlagRequired("org-id")
+	dbrpDeleteCmd.MarkFlagRequired("db")
+	dbrpDeleteCmd.MarkFlagRequired("rp")
+
+	dbrpCmd.AddCommand(dbrpDeleteCmd)
+}
+
+func dbrpDeleteF(cmd *cobra.Command, args []string) {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	orgID, err := platform.IDFromString(dbrpDeleteFlags.orgID)
+	if err != nil {
+		fmt.Printf("error parsing organization id: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	m, err := s.FindBy(ctx, *orgID, dbrpDeleteFlags.cluster, dbrpDeleteFlags.db, dbrpDeleteFlags.rp)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := s.Delete(ctx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeDBRPMappings(m)
+}
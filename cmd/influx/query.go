@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/flux/repl"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -21,7 +26,8 @@ var queryCmd = &cobra.Command{
 }
 
 var queryFlags struct {
-	OrgID string
+	OrgID   string
+	Explain bool
 }
 
 func init() {
@@ -31,6 +37,7 @@ func init() {
 		queryFlags.OrgID = h
 	}
 	queryCmd.MarkPersistentFlagRequired("org-id")
+	queryCmd.PersistentFlags().BoolVar(&queryFlags.Explain, "explain", false, "Describe the storage reads of the query and their costs instead of printing its results")
 }
 
 func fluxQueryF(cmd *cobra.Command, args []string) {
@@ -52,6 +59,14 @@ func fluxQueryF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if queryFlags.Explain {
+		if err := explainFluxQuery(q, orgID); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	r, err := getFluxREPL(flags.host, flags.token, orgID)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -63,3 +78,44 @@ func fluxQueryF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 }
+
+func explainFluxQuery(q string, orgID platform.ID) error {
+	s := &http.FluxQueryService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	exp, err := s.Explain(context.Background(), &query.Request{
+		OrganizationID: orgID,
+		Compiler:       lang.FluxCompiler{Query: q},
+	})
+	if err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Read",
+		"Series",
+		"ScannedValues",
+		"ScannedBytes",
+		"ScannedBlocks",
+		"CacheValues",
+	)
+	for i, r := range exp.Reads {
+		w.Write(map[string]interface{}{
+			"Read":          i,
+			"Series":        r.SeriesCount,
+			"ScannedValues": r.ScannedValues,
+			"ScannedBytes":  r.ScannedBytes,
+			"ScannedBlocks": r.ScannedBlocks,
+			"CacheValues":   r.CacheValues,
+		})
+	}
+	w.Flush()
+
+	for i, r := range exp.Reads {
+		fmt.Printf("\nRead %d:\n%s", i, r.Plan)
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ var writeFlags struct {
 	BucketID  string
 	Bucket    string
 	Precision string
+	Bulk      bool
 }
 
 func init() {
@@ -63,6 +64,8 @@ func init() {
 	if p := viper.GetString("PRECISION"); p != "" {
 		writeFlags.Precision = p
 	}
+
+	writeCmd.PersistentFlags().BoolVar(&writeFlags.Bulk, "bulk", false, "write the lines directly to new TSM files in a single request, for imports of historical data")
 }
 
 func fluxWriteF(cmd *cobra.Command, args []string) error {
@@ -136,12 +139,18 @@ func fluxWriteF(cmd *cobra.Command, args []string) error {
 		r = strings.NewReader(args[0])
 	}
 
-	s := write.Batcher{
-		Service: &http.WriteService{
-			Addr:      flags.host,
-			Token:     flags.token,
-			Precision: writeFlags.Precision,
-		},
+	ws := &http.WriteService{
+		Addr:      flags.host,
+		Token:     flags.token,
+		Precision: writeFlags.Precision,
+		Bulk:      writeFlags.Bulk,
+	}
+
+	// A bulk write must be sent in a single request, as the lines of each
+	// request become readable on their own.
+	var s platform.WriteService = &write.Batcher{Service: ws}
+	if writeFlags.Bulk {
+		s = ws
 	}
 
 	ctx = signals.WithStandardSignals(ctx)
@@ -0,0 +1,408 @@
+// Package export writes the data of an engine that is not in use as line
+// protocol, read directly from its TSM files and WAL segments.
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/internal/fs"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// The comments that precede the lines of each bucket in an export. The bucket
+// names are left out when the bucket is not in the metadata.
+const (
+	BucketIDContext     = "# CONTEXT-BUCKET-ID: "
+	OrganizationContext = "# CONTEXT-ORGANIZATION: "
+	BucketContext       = "# CONTEXT-BUCKET: "
+)
+
+// Command represents the program execution for "influx_inspect export".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+
+	boltPath    string
+	enginePath  string
+	out         string
+	bucketID    string
+	measurement string
+	start       string
+	end         string
+	compress    bool
+
+	bucket     platform.ID
+	startTime  int64
+	endTime    int64
+	buckets    map[platform.ID]*platform.Bucket
+	boltClient *bolt.Client
+
+	// The values of the WAL, with its deletes applied, and the time ranges its
+	// deletes remove from the values of each key in the TSM files.
+	cache   *tsm1.Cache
+	deletes map[string][]tsm1.TimeRange
+
+	// The bucket of the last line written, and the number of lines written.
+	name [16]byte
+	n    int
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr:  os.Stderr,
+		Stdout:  os.Stdout,
+		buckets: make(map[platform.ID]*platform.Bucket),
+		cache:   tsm1.NewCache(0),
+		deletes: make(map[string][]tsm1.TimeRange),
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine influx directory: %v", err)
+	}
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&cmd.boltPath, "bolt-path", filepath.Join(dir, "influxd.bolt"), "path to boltdb database")
+	flags.StringVar(&cmd.enginePath, "engine-path", filepath.Join(dir, "engine"), "path to persistent engine files")
+	flags.StringVar(&cmd.out, "out", "-", "file to write the line protocol to, or - for stdout")
+	flags.StringVar(&cmd.bucketID, "bucket-id", "", "optional: only export the bucket of this ID")
+	flags.StringVar(&cmd.measurement, "measurement", "", "optional: only export this measurement")
+	flags.StringVar(&cmd.start, "start", "", "optional: only export values at or after this RFC3339 time")
+	flags.StringVar(&cmd.end, "end", "", "optional: only export values at or before this RFC3339 time")
+	flags.BoolVar(&cmd.compress, "compress", false, "optional: compress the line protocol with gzip")
+	flags.SetOutput(cmd.Stdout)
+	if err := flags.Parse(args); err != nil {
+		return err
+	} else if flags.NArg() > 0 {
+		flags.Usage()
+		return nil
+	}
+
+	if err := cmd.validate(); err != nil {
+		return err
+	}
+	return cmd.export()
+}
+
+func (cmd *Command) validate() error {
+	if cmd.bucketID != "" {
+		if err := cmd.bucket.DecodeFromString(cmd.bucketID); err != nil {
+			return fmt.Errorf("invalid bucket-id: %v", err)
+		}
+	}
+
+	cmd.startTime, cmd.endTime = math.MinInt64, math.MaxInt64
+	if cmd.start != "" {
+		t, err := time.Parse(time.RFC3339, cmd.start)
+		if err != nil {
+			return fmt.Errorf("invalid start: %v", err)
+		}
+		cmd.startTime = t.UnixNano()
+	}
+	if cmd.end != "" {
+		t, err := time.Parse(time.RFC3339, cmd.end)
+		if err != nil {
+			return fmt.Errorf("invalid end: %v", err)
+		}
+		cmd.endTime = t.UnixNano()
+	}
+	if cmd.startTime > cmd.endTime {
+		return fmt.Errorf("end is before start")
+	}
+	return nil
+}
+
+func (cmd *Command) export() error {
+	cmd.boltClient = bolt.NewClient()
+	cmd.boltClient.Path = cmd.boltPath
+	if err := cmd.boltClient.Open(context.Background()); err != nil {
+		return err
+	}
+	defer cmd.boltClient.Close()
+
+	var w io.Writer = cmd.Stdout
+	if cmd.out != "-" {
+		f, err := os.Create(cmd.out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	w = bw
+	var gw *gzip.Writer
+	if cmd.compress {
+		gw = gzip.NewWriter(bw)
+		w = gw
+	}
+
+	// The WAL is read first, as its deletes also remove values of the TSM
+	// files, which are older than it.
+	start := time.Now()
+	if err := cmd.readWALSegments(); err != nil {
+		return err
+	} else if err := cmd.writeTSMFiles(w); err != nil {
+		return err
+	} else if err := cmd.writeCache(w); err != nil {
+		return err
+	}
+
+	if gw != nil {
+		if err := gw.Close(); err != nil {
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Stderr, "Exported %d values in %s\n", cmd.n, time.Since(start))
+	return nil
+}
+
+func (cmd *Command) writeTSMFiles(w io.Writer) error {
+	paths, err := filepath.Glob(filepath.Join(cmd.enginePath, storage.DefaultEngineDirectoryName, "*."+tsm1.TSMFileExtension))
+	if err != nil {
+		return err
+	}
+
+	// Files are sorted by generation, so later values of a key overwrite
+	// earlier ones when imported.
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := cmd.writeTSMFile(w, path); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// writeTSMFile writes the values of the TSM file at path. The reader applies
+// the tombstones of the file, and the deletes of the WAL are applied here.
+func (cmd *Command) writeTSMFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	if min, max := r.TimeRange(); min > cmd.endTime || max < cmd.startTime {
+		return nil
+	}
+
+	itr := r.Iterator(nil)
+	for itr.Next() {
+		key := itr.Key()
+		if !cmd.matchKey(key) {
+			continue
+		}
+
+		values, err := r.ReadAll(key)
+		if err != nil {
+			return err
+		}
+		for _, tr := range cmd.deletes[string(key)] {
+			values = tsm1.Values(values).Exclude(tr.Min, tr.Max)
+		}
+		if err := cmd.writeValues(w, key, values); err != nil {
+			return err
+		}
+	}
+	return itr.Err()
+}
+
+func (cmd *Command) readWALSegments() error {
+	paths, err := filepath.Glob(filepath.Join(cmd.enginePath, storage.DefaultWALDirectoryName,
+		fmt.Sprintf("%s*.%s", tsm1.WALFilePrefix, tsm1.WALFileExtension)))
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := cmd.readWALSegment(path); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// readWALSegment applies the entries of the WAL segment at path, in order, to
+// the values of the WAL, as the engine does when it loads its cache. Only the
+// keys that match the filters are kept.
+func (cmd *Command) readWALSegment(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r := tsm1.NewWALSegmentReader(f)
+	defer r.Close()
+
+	for r.Next() {
+		entry, err := r.Read()
+		if err != nil {
+			n := r.Count()
+			fmt.Fprintf(cmd.Stderr, "%s: corrupt at byte %d; skipping the rest of the segment: %v\n", path, n, err)
+			return nil
+		}
+
+		switch e := entry.(type) {
+		case *tsm1.WriteWALEntry:
+			for key := range e.Values {
+				if !cmd.matchKey([]byte(key)) {
+					delete(e.Values, key)
+				}
+			}
+			if err := cmd.cache.WriteMulti(e.Values); err != nil {
+				return err
+			}
+		case *tsm1.DeleteRangeWALEntry:
+			cmd.delete(e.Keys, e.Min, e.Max)
+		case *tsm1.DeleteWALEntry:
+			cmd.delete(e.Keys, math.MinInt64, math.MaxInt64)
+		}
+	}
+	return nil
+}
+
+// delete removes the values of the keys between min and max from the values
+// of the WAL read so far, and from the values of the TSM files.
+func (cmd *Command) delete(keys [][]byte, min, max int64) {
+	var matched [][]byte
+	for _, key := range keys {
+		if cmd.matchKey(key) {
+			matched = append(matched, key)
+		}
+	}
+
+	cmd.cache.DeleteRange(matched, min, max)
+	for _, key := range matched {
+		cmd.deletes[string(key)] = append(cmd.deletes[string(key)], tsm1.TimeRange{Min: min, Max: max})
+	}
+}
+
+// writeCache writes the values of the WAL.
+func (cmd *Command) writeCache(w io.Writer) error {
+	for _, key := range cmd.cache.Keys() {
+		if err := cmd.writeValues(w, key, cmd.cache.Values(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchKey returns if the series of the TSM key matches the bucket and
+// measurement filters.
+func (cmd *Command) matchKey(key []byte) bool {
+	seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+	name, tags := models.ParseKeyBytes(seriesKey)
+	if len(name) != len(cmd.name) {
+		return false
+	}
+
+	if cmd.bucket.Valid() {
+		var n [16]byte
+		copy(n[:], name)
+		if _, bucketID := tsdb.DecodeName(n); bucketID != cmd.bucket {
+			return false
+		}
+	}
+	return cmd.measurement == "" || string(tags.Get(tsdb.MeasurementTagKeyBytes)) == cmd.measurement
+}
+
+// writeValues writes the values of the TSM key within the time range as lines
+// of its measurement, preceded by the context of its bucket when it is not the
+// bucket of the last line.
+func (cmd *Command) writeValues(w io.Writer, key []byte, values []tsm1.Value) error {
+	seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+	name, tags := models.ParseKeyBytes(seriesKey)
+
+	var measurement []byte
+	userTags := make(models.Tags, 0, len(tags))
+	for _, t := range tags {
+		switch string(t.Key) {
+		case tsdb.MeasurementTagKey:
+			measurement = t.Value
+		case tsdb.FieldKeyTagKey:
+		default:
+			userTags = append(userTags, t)
+		}
+	}
+
+	var buf []byte
+	for _, v := range values {
+		ts := v.UnixNano()
+		if ts < cmd.startTime || ts > cmd.endTime {
+			continue
+		}
+
+		if !bytes.Equal(name, cmd.name[:]) {
+			copy(cmd.name[:], name)
+			if err := cmd.writeContext(w); err != nil {
+				return err
+			}
+		}
+
+		pt, err := models.NewPoint(string(measurement), userTags, models.Fields{string(field): v.Value()}, time.Unix(0, ts))
+		if err != nil {
+			fmt.Fprintf(cmd.Stderr, "skipping value of %q at %d: %v\n", key, ts, err)
+			continue
+		}
+		buf = append(pt.AppendString(buf[:0]), '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		cmd.n++
+	}
+	return nil
+}
+
+// writeContext writes the context of the bucket of the last line.
+func (cmd *Command) writeContext(w io.Writer) error {
+	_, bucketID := tsdb.DecodeName(cmd.name)
+	b, ok := cmd.buckets[bucketID]
+	if !ok {
+		var err error
+		if b, err = cmd.boltClient.FindBucketByID(context.Background(), bucketID); err != nil {
+			if platform.ErrorCode(err) != platform.ENotFound {
+				return err
+			}
+			fmt.Fprintf(cmd.Stderr, "bucket %s is not in %s; exporting it without its name\n", bucketID, cmd.boltPath)
+		}
+		cmd.buckets[bucketID] = b
+	}
+
+	s := BucketIDContext + bucketID.String() + "\n"
+	if b != nil {
+		s += OrganizationContext + b.Organization + "\n" + BucketContext + b.Name + "\n"
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
@@ -0,0 +1,136 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/cmd/influx_inspect/export"
+	"github.com/influxdata/platform/cmd/influx_inspect/importer"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestCommand_Run_RoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	boltPath := filepath.Join(dir, "influxd.bolt")
+	b := mustCreateBucket(t, boltPath)
+
+	srcPath := filepath.Join(dir, "src")
+	e := storage.NewEngine(srcPath, storage.NewConfig())
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// Write values to a TSM file, and delete one of them with a tombstone.
+	mustWritePoints(t, e, b, "cpu,host=a v=1 10000000000\ncpu,host=a v=2 20000000000\nmem,host=a v=1 20000000000")
+	snapshot, err := e.CreateSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(snapshot)
+	mustDeleteBucketRange(t, e, b, 20000000000)
+
+	// Write values to the WAL, and delete one of them before it is written
+	// again.
+	mustWritePoints(t, e, b, "cpu,host=a v=3 30000000000\ncpu,host=a v=4 40000000000")
+	mustDeleteBucketRange(t, e, b, 40000000000)
+	mustWritePoints(t, e, b, "cpu,host=a v=5 40000000000\nmem,host=a v=2 40000000000")
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	exportPath := filepath.Join(dir, "export.lp.gz")
+	if _, err := runExport(
+		"-bolt-path", boltPath,
+		"-engine-path", srcPath,
+		"-out", exportPath,
+		"-compress",
+		"-bucket-id", b.ID.String(),
+		"-measurement", "cpu",
+		"-start", "1970-01-01T00:00:15Z",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	dstPath := filepath.Join(dir, "dst")
+	imp := importer.NewCommand()
+	imp.Stdout, imp.Stderr = ioutil.Discard, ioutil.Discard
+	if err := imp.Run("-bolt-path", boltPath, "-engine-path", dstPath, exportPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// The imported values are in the WAL of the new engine.
+	out, err := runExport("-bolt-path", boltPath, "-engine-path", dstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := export.BucketIDContext + b.ID.String() + "\n" +
+		export.OrganizationContext + "org\n" +
+		export.BucketContext + "bucket\n" +
+		"cpu,host=a v=3 30000000000\n" +
+		"cpu,host=a v=5 40000000000\n"
+	if string(out) != exp {
+		t.Fatalf("unexpected export:\n%s\nexp:\n%s", out, exp)
+	}
+}
+
+func mustCreateBucket(t *testing.T, boltPath string) *platform.Bucket {
+	ctx := context.Background()
+	c := bolt.NewClient()
+	c.Path = boltPath
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	o := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	b := &platform.Bucket{OrganizationID: o.ID, Name: "bucket"}
+	if err := c.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func mustWritePoints(t *testing.T, e *storage.Engine, b *platform.Bucket, lines string) {
+	points, err := models.ParsePointsString(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exploded, err := tsdb.ExplodePoints(b.OrganizationID, b.ID, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WritePoints(exploded); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustDeleteBucketRange(t *testing.T, e *storage.Engine, b *platform.Bucket, ts int64) {
+	if err := e.DeleteBucketRange(b.OrganizationID, b.ID, ts, ts, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runExport(args ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	cmd := export.NewCommand()
+	cmd.Stdout, cmd.Stderr = &buf, ioutil.Discard
+	err := cmd.Run(args...)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,298 @@
+// Package importer writes the line protocol of an export into the engine of
+// an influxd that is not running.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/cmd/influx_inspect/export"
+	"github.com/influxdata/platform/internal/fs"
+	"github.com/influxdata/platform/logger"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+const (
+	defaultBatchSize = 5000
+
+	// maxLineSize is the size of the longest line that can be imported.
+	maxLineSize = 64 * 1024 * 1024
+
+	progressInterval = 5 * time.Second
+)
+
+// Command represents the program execution for "influx_inspect import".
+type Command struct {
+	Stderr  io.Writer
+	Stdout  io.Writer
+	Verbose bool
+
+	boltPath   string
+	enginePath string
+	bucketID   string
+	batchSize  int
+	compressed bool
+
+	boltClient *bolt.Client
+	engine     *storage.Engine
+
+	// The bucket of -bucket-id, or else the context of the lines and the
+	// bucket it was resolved to.
+	bucket  *platform.Bucket
+	current exportContext
+	target  *platform.Bucket
+
+	batch        []byte
+	batchN       int
+	n            int
+	lastProgress time.Time
+}
+
+// exportContext is the bucket of the lines of an export.
+type exportContext struct {
+	bucketID     platform.ID
+	organization string
+	bucket       string
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine influx directory: %v", err)
+	}
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.StringVar(&cmd.boltPath, "bolt-path", filepath.Join(dir, "influxd.bolt"), "path to boltdb database")
+	flags.StringVar(&cmd.enginePath, "engine-path", filepath.Join(dir, "engine"), "path to persistent engine files")
+	flags.StringVar(&cmd.bucketID, "bucket-id", "", "optional: import all lines into the bucket of this ID, instead of the buckets of the export")
+	flags.IntVar(&cmd.batchSize, "batch-size", defaultBatchSize, "optional: number of lines written to the engine at once")
+	flags.BoolVar(&cmd.compressed, "compressed", false, "optional: the file is compressed with gzip; the default for files ending in .gz")
+	flags.BoolVar(&cmd.Verbose, "v", false, "verbose")
+	flags.SetOutput(cmd.Stdout)
+	flags.Usage = func() {
+		fmt.Fprintln(cmd.Stdout, "usage: influx_inspect import [flags] PATH")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	} else if flags.NArg() != 1 || cmd.batchSize <= 0 {
+		flags.Usage()
+		return nil
+	}
+
+	return cmd.run(flags.Arg(0))
+}
+
+func (cmd *Command) run(path string) error {
+	ctx := context.Background()
+	cmd.boltClient = bolt.NewClient()
+	cmd.boltClient.Path = cmd.boltPath
+	if err := cmd.boltClient.Open(ctx); err != nil {
+		return err
+	}
+	defer cmd.boltClient.Close()
+
+	if cmd.bucketID != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(cmd.bucketID); err != nil {
+			return fmt.Errorf("invalid bucket-id: %v", err)
+		}
+		b, err := cmd.boltClient.FindBucketByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		cmd.bucket = b
+	}
+
+	cmd.engine = storage.NewEngine(cmd.enginePath, storage.NewConfig())
+	if cmd.Verbose {
+		cmd.engine.WithLogger(logger.New(cmd.Stderr))
+	}
+	if err := cmd.engine.Open(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err := cmd.importFile(ctx, path); err != nil {
+		cmd.engine.Close()
+		return err
+	} else if err := cmd.engine.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Imported %d lines in %s\n", cmd.n, time.Since(start))
+	return nil
+}
+
+func (cmd *Command) importFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if cmd.compressed || strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	cmd.lastProgress = time.Now()
+	return cmd.importLines(ctx, r)
+}
+
+// importLines writes the lines of r in batches to the bucket of their context.
+func (cmd *Command) importLines(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		} else if line[0] == '#' {
+			if err := cmd.setContext(ctx, line); err != nil {
+				return err
+			}
+			continue
+		}
+
+		cmd.batch = append(append(cmd.batch, line...), '\n')
+		cmd.batchN++
+		if cmd.batchN >= cmd.batchSize {
+			if err := cmd.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return cmd.flush(ctx)
+}
+
+// setContext sets the context of the lines that follow the comment line. The
+// lines before it, which are in the previous context, are written first.
+func (cmd *Command) setContext(ctx context.Context, line []byte) error {
+	s := string(line)
+	switch {
+	case strings.HasPrefix(s, export.BucketIDContext):
+		if err := cmd.flush(ctx); err != nil {
+			return err
+		}
+		cmd.current, cmd.target = exportContext{}, nil
+		if err := cmd.current.bucketID.DecodeFromString(strings.TrimPrefix(s, export.BucketIDContext)); err != nil {
+			return fmt.Errorf("invalid bucket ID in %q: %v", s, err)
+		}
+	case strings.HasPrefix(s, export.OrganizationContext):
+		cmd.current.organization = strings.TrimPrefix(s, export.OrganizationContext)
+	case strings.HasPrefix(s, export.BucketContext):
+		cmd.current.bucket = strings.TrimPrefix(s, export.BucketContext)
+	}
+	return nil
+}
+
+// flush writes the batch of lines to the bucket of their context.
+func (cmd *Command) flush(ctx context.Context) error {
+	if cmd.batchN == 0 {
+		return nil
+	}
+
+	b, err := cmd.findBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	points, err := models.ParsePointsWithPrecision(cmd.batch, time.Now().UTC(), "n")
+	if err != nil {
+		// The points that could be parsed are still written.
+		fmt.Fprintf(cmd.Stderr, "skipping invalid lines: %v\n", err)
+	}
+	exploded, err := tsdb.ExplodePoints(b.OrganizationID, b.ID, points)
+	if err != nil {
+		return err
+	}
+	if err := cmd.engine.WritePoints(exploded); err != nil {
+		if _, ok := err.(tsdb.PartialWriteError); !ok {
+			return err
+		}
+		fmt.Fprintf(cmd.Stderr, "partial write into bucket %s: %v\n", b.ID, err)
+	}
+
+	cmd.n += len(points)
+	cmd.batch, cmd.batchN = cmd.batch[:0], 0
+	if time.Since(cmd.lastProgress) >= progressInterval {
+		fmt.Fprintf(cmd.Stdout, "Imported %d lines\n", cmd.n)
+		cmd.lastProgress = time.Now()
+	}
+	return nil
+}
+
+// findBucket returns the bucket that the lines are imported into: the one of
+// -bucket-id, or else the one that their context resolves to.
+func (cmd *Command) findBucket(ctx context.Context) (*platform.Bucket, error) {
+	if cmd.bucket != nil {
+		return cmd.bucket, nil
+	} else if cmd.target != nil {
+		return cmd.target, nil
+	}
+
+	b, err := cmd.resolveContext(ctx, cmd.current)
+	if err != nil {
+		return nil, err
+	}
+	cmd.target = b
+	return b, nil
+}
+
+// resolveContext returns the bucket of the ID of the context, or else the one of
+// its names, as the buckets of an export may have other IDs where it is imported.
+func (cmd *Command) resolveContext(ctx context.Context, c exportContext) (*platform.Bucket, error) {
+	if !c.bucketID.Valid() {
+		return nil, fmt.Errorf("lines have no bucket; import them with -bucket-id")
+	}
+
+	b, err := cmd.boltClient.FindBucketByID(ctx, c.bucketID)
+	if err == nil {
+		return b, nil
+	} else if platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+
+	if c.organization == "" || c.bucket == "" {
+		return nil, fmt.Errorf("bucket %s of the export does not exist; import it with -bucket-id", c.bucketID)
+	}
+	b, err = cmd.boltClient.FindBucket(ctx, platform.BucketFilter{
+		Organization: &c.organization,
+		Name:         &c.bucket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bucket %s/%s of the export: %v", c.organization, c.bucket, err)
+	}
+	return b, nil
+}
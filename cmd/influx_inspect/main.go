@@ -0,0 +1,97 @@
+// The influx_inspect command inspects and repairs the files of influxd.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/buildtsi"
+	"github.com/influxdata/platform/cmd/influx_inspect/export"
+	"github.com/influxdata/platform/cmd/influx_inspect/importer"
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/seriesfile"
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/tsm"
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/wal"
+)
+
+func main() {
+	m := NewMain()
+	if err := m.Run(os.Args[1:]...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Main represents the program execution.
+type Main struct {
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// NewMain returns a new instance of Main.
+func NewMain() *Main {
+	return &Main{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run determines and runs the command specified by the CLI args.
+func (m *Main) Run(args ...string) error {
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	switch name {
+	case "", "help":
+		fmt.Fprint(m.Stdout, usage)
+		return nil
+	case "buildtsi":
+		cmd := buildtsi.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	case "export":
+		cmd := export.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	case "import":
+		cmd := importer.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	case "verify":
+		cmd := tsm.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	case "verify-seriesfile":
+		cmd := seriesfile.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	case "verify-wal":
+		cmd := wal.NewCommand()
+		cmd.Stdout, cmd.Stderr = m.Stdout, m.Stderr
+		return cmd.Run(args...)
+	default:
+		return fmt.Errorf(`unknown command "%s"`+"\n"+`Run 'influx_inspect help' for usage`+"\n\n", name)
+	}
+}
+
+const usage = `Usage: influx_inspect [[command] [arguments]]
+
+The commands are:
+
+    buildtsi             builds a TSI index from the TSM files
+    export               exports the data of the TSM files and WAL as line protocol
+    import               imports line protocol written by export
+    verify               verifies the blocks of the TSM files, and that their series are indexed
+    verify-seriesfile    verifies the segments and indexes of the series file
+    verify-wal           verifies the WAL segments
+    help                 displays this help
+
+The export, import and verify commands must only be run while influxd is
+stopped. With -fix, the files that the verify commands replace or modify are
+moved to the quarantine directory of the engine path.
+
+Use "influx_inspect [command] -help" for more information about a command.
+`
@@ -0,0 +1,77 @@
+// Package verify holds what the verify commands of influx_inspect share. The
+// commands themselves are in its subpackages.
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/platform/internal/fs"
+	"github.com/influxdata/platform/pkg/file"
+)
+
+// DefaultEnginePath returns the engine path that influxd uses by default.
+func DefaultEnginePath() string {
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "engine")
+}
+
+// QuarantineDirectoryName is the name of the directory in the engine path that
+// the files moved aside by a fix are kept in.
+const QuarantineDirectoryName = "quarantine"
+
+// Quarantine keeps the files that a fix moves aside or modifies, so that they
+// can be inspected or restored. Each run uses its own directory, in which the
+// paths of the files relative to the engine path are kept.
+type Quarantine struct {
+	EnginePath string
+	Path       string
+}
+
+// NewQuarantine returns the quarantine of a run at time now of a fix of the
+// engine at enginePath.
+func NewQuarantine(enginePath string, now time.Time) *Quarantine {
+	return &Quarantine{
+		EnginePath: enginePath,
+		Path:       filepath.Join(enginePath, QuarantineDirectoryName, now.UTC().Format("20060102T150405Z")),
+	}
+}
+
+// Move moves the file at path, which must be in the engine path, into the
+// quarantine. It returns the new path of the file.
+func (q *Quarantine) Move(path string) (string, error) {
+	dst, err := q.path(path)
+	if err != nil {
+		return "", err
+	}
+	return dst, os.Rename(path, dst)
+}
+
+// Copy copies the file at path, which must be in the engine path, into the
+// quarantine, before it is modified in place. It returns the path of the copy.
+func (q *Quarantine) Copy(path string) (string, error) {
+	dst, err := q.path(path)
+	if err != nil {
+		return "", err
+	}
+	return dst, file.CopyFile(path, dst)
+}
+
+// path returns the path in the quarantine of the file at path, and creates its
+// directory.
+func (q *Quarantine) path(path string) (string, error) {
+	rel, err := filepath.Rel(q.EnginePath, path)
+	if err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(q.Path, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
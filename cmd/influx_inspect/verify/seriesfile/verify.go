@@ -0,0 +1,343 @@
+// Package seriesfile verifies the segments and indexes of the series file of
+// an engine that is not in use.
+package seriesfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/verify"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// Command represents the program execution for "influx_inspect verify-seriesfile".
+type Command struct {
+	Stderr  io.Writer
+	Stdout  io.Writer
+	Verbose bool
+
+	fix bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("verify-seriesfile", flag.ExitOnError)
+	enginePath := fs.String("engine-path", verify.DefaultEnginePath(), "path to persistent engine files")
+	fs.BoolVar(&cmd.fix, "fix", false, "optional: truncate corrupt segments at their last valid entry, and remove bad partition indexes so that they are rebuilt")
+	fs.BoolVar(&cmd.Verbose, "v", false, "verbose")
+	fs.SetOutput(cmd.Stdout)
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if fs.NArg() > 0 || *enginePath == "" {
+		fs.Usage()
+		return nil
+	}
+
+	return cmd.run(*enginePath)
+}
+
+func (cmd *Command) run(enginePath string) error {
+	start := time.Now()
+	q := verify.NewQuarantine(enginePath, start)
+
+	var problems int
+	path := filepath.Join(enginePath, storage.DefaultSeriesFileDirectoryName)
+	for i := 0; i < tsdb.SeriesFilePartitionN; i++ {
+		n, err := cmd.verifyPartition(q, i, filepath.Join(path, fmt.Sprintf("%02x", i)))
+		if err != nil {
+			return err
+		}
+		problems += n
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Found %d problems in %s in %s\n", problems, path, time.Since(start))
+	if problems == 0 {
+		return nil
+	} else if cmd.fix {
+		fmt.Fprintf(cmd.Stdout, "Fixed; the files that were replaced are in %s\n", q.Path)
+		fmt.Fprintln(cmd.Stdout, "Series of truncated segments are lost; run verify -fix to add the series of the TSM files back.")
+		return nil
+	}
+	return fmt.Errorf("found %d problems; run with -fix to repair them", problems)
+}
+
+// entry is the last entry of a series in a partition.
+type entry struct {
+	offset  int64
+	key     []byte
+	deleted bool
+}
+
+// partition holds the state of the verification of the segments of a partition.
+type partition struct {
+	id      int
+	seq     uint64 // the highest raw series ID inserted
+	entries map[tsdb.SeriesID]*entry
+}
+
+// verifyPartition verifies the segments of the partition at path, and then its
+// index. It returns the number of problems found.
+func (cmd *Command) verifyPartition(q *verify.Quarantine, id int, path string) (int, error) {
+	fis, err := ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	p := &partition{id: id, entries: make(map[tsdb.SeriesID]*entry)}
+	var problems int
+	var segments []*tsdb.SeriesSegment
+	defer func() {
+		for _, s := range segments {
+			s.Close()
+		}
+	}()
+	for _, fi := range fis {
+		if !tsdb.IsValidSeriesSegmentFilename(fi.Name()) {
+			continue
+		}
+		segmentID, err := tsdb.ParseSeriesSegmentFilename(fi.Name())
+		if err != nil {
+			return problems, err
+		}
+
+		segmentPath := filepath.Join(path, fi.Name())
+		ok, err := cmd.verifySegment(q, p, segmentID, segmentPath)
+		if err != nil {
+			return problems, err
+		}
+		if !ok {
+			problems++
+			if !cmd.fix {
+				continue
+			}
+		}
+
+		s := tsdb.NewSeriesSegment(segmentID, segmentPath)
+		if err := s.Open(); err != nil {
+			return problems, err
+		}
+		segments = append(segments, s)
+	}
+
+	// The index can only be checked against healthy segments.
+	if problems > 0 && !cmd.fix {
+		return problems, nil
+	}
+
+	indexPath := filepath.Join(path, "index")
+	if msg := p.verifyIndex(indexPath, segments); msg != "" {
+		problems++
+		cmd.report(indexPath, "%s", msg)
+		if cmd.fix {
+			// Partitions rebuild missing indexes from their segments.
+			if _, err := q.Move(indexPath); err != nil {
+				return problems, err
+			}
+		}
+	} else if problems > 0 {
+		// The segments that were fixed no longer hold entries that the index
+		// may refer to.
+		if _, err := q.Move(indexPath); err != nil && !os.IsNotExist(err) {
+			return problems, err
+		}
+	}
+	return problems, nil
+}
+
+// verifySegment verifies the segment at path, and reports the problems found.
+// When fixing, a corrupt segment is truncated at its last valid entry. It
+// returns if the segment is healthy.
+func (cmd *Command) verifySegment(q *verify.Quarantine, p *partition, id uint16, path string) (bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	size := int(tsdb.SeriesSegmentSize(id))
+	pos, msg := p.verifyEntries(id, data)
+	if msg == "" && len(data) == size {
+		if cmd.Verbose {
+			cmd.report(path, "healthy")
+		}
+		return true, nil
+	}
+
+	if msg != "" {
+		cmd.report(path, "corrupt at byte %d: %s", pos, msg)
+	}
+	if len(data) != size {
+		cmd.report(path, "size is %d bytes, expected %d", len(data), size)
+	}
+	if !cmd.fix {
+		return false, nil
+	}
+
+	if _, err := q.Copy(path); err != nil {
+		return false, err
+	}
+	return false, truncateSegment(path, pos, size)
+}
+
+// verifyEntries verifies the header and entries of data, the contents of
+// segment id, and adds the entries to the partition. It returns the position
+// of the first byte after the valid entries, and what is wrong with the ones
+// after it, if anything.
+func (p *partition) verifyEntries(id uint16, data []byte) (pos int, msg string) {
+	if hdr, err := tsdb.ReadSeriesSegmentHeader(data); err != nil {
+		return 0, err.Error()
+	} else if hdr.Version != tsdb.SeriesSegmentVersion {
+		return 0, tsdb.ErrInvalidSeriesSegmentVersion.Error()
+	}
+
+	// Corrupt keys can make the parsing panic.
+	defer func() {
+		if rec := recover(); rec != nil {
+			msg = fmt.Sprintf("invalid series key: %v", rec)
+		}
+	}()
+
+	for pos = tsdb.SeriesSegmentHeaderSize; pos < len(data); {
+		flag := data[pos]
+		if flag == 0 {
+			// The rest of the segment is unused.
+			if i := bytes.IndexFunc(data[pos:], func(r rune) bool { return r != 0 }); i >= 0 {
+				return pos, fmt.Sprintf("unexpected data after the last entry, at byte %d", pos+i)
+			}
+			return pos, ""
+		} else if !tsdb.IsValidSeriesEntryFlag(flag) {
+			return pos, fmt.Sprintf("invalid entry flag %d", flag)
+		} else if len(data)-pos < tsdb.SeriesEntryHeaderSize {
+			return pos, "entry is truncated"
+		}
+
+		raw := binary.BigEndian.Uint64(data[pos+tsdb.SeriesEntryFlagSize:])
+		typed := tsdb.NewSeriesIDTyped(raw)
+		seriesID := typed.SeriesID()
+		offset := tsdb.JoinSeriesOffset(id, uint32(pos))
+
+		switch flag {
+		case tsdb.SeriesEntryInsertFlag:
+			keyData := data[pos+tsdb.SeriesEntryHeaderSize:]
+			sz, n := binary.Uvarint(keyData)
+			if n <= 0 || uint64(len(keyData)-n) < sz {
+				return pos, "series key is truncated"
+			}
+			key := keyData[:n+int(sz)]
+			if name, _ := tsdb.ParseSeriesKey(key); len(name) == 0 {
+				return pos, "series key has no measurement"
+			}
+
+			rawID := seriesID.RawID()
+			if rawID <= p.seq {
+				return pos, fmt.Sprintf("series ID %d is not greater than %d", rawID, p.seq)
+			} else if int((rawID-1)%tsdb.SeriesFilePartitionN) != p.id {
+				return pos, fmt.Sprintf("series ID %d does not belong to partition %d", rawID, p.id)
+			}
+			p.seq = rawID
+			p.entries[seriesID] = &entry{offset: offset, key: key}
+			pos += tsdb.SeriesEntryHeaderSize + len(key)
+
+		case tsdb.SeriesEntryTombstoneFlag:
+			e, ok := p.entries[seriesID]
+			if !ok {
+				return pos, fmt.Sprintf("tombstone of unknown series ID %d", seriesID.RawID())
+			}
+			e.deleted = true
+			pos += tsdb.SeriesEntryHeaderSize
+		}
+	}
+	return pos, ""
+}
+
+// verifyIndex verifies that the index at path, once recovered from segments,
+// finds every entry of the partition. It returns what is wrong with the index,
+// if anything.
+func (p *partition) verifyIndex(path string, segments []*tsdb.SeriesSegment) (msg string) {
+	// A corrupt index can make the lookups panic.
+	defer func() {
+		if rec := recover(); rec != nil {
+			msg = fmt.Sprintf("panic: %v", rec)
+		}
+	}()
+
+	idx := tsdb.NewSeriesIndex(path)
+	if err := idx.Open(); err != nil {
+		return err.Error()
+	}
+	defer idx.Close()
+	if err := idx.Recover(segments); err != nil {
+		return err.Error()
+	}
+
+	// A key may have been inserted again, with a new ID, after being deleted.
+	live := make(map[string]tsdb.SeriesID, len(p.entries))
+	for id, e := range p.entries {
+		if !e.deleted {
+			live[string(e.key)] = id
+		}
+	}
+
+	for id, e := range p.entries {
+		if e.deleted {
+			if !idx.IsDeleted(id) {
+				return fmt.Sprintf("deleted series ID %d is not deleted", id.RawID())
+			}
+			continue
+		}
+
+		if offset := idx.FindOffsetByID(id); offset != e.offset {
+			return fmt.Sprintf("offset of series ID %d is %d, expected %d", id.RawID(), offset, e.offset)
+		}
+		if got := idx.FindIDBySeriesKey(segments, e.key).SeriesID(); got != live[string(e.key)] {
+			return fmt.Sprintf("ID of series %q is %d, expected %d", e.key, got.RawID(), id.RawID())
+		}
+	}
+	return ""
+}
+
+// truncateSegment zeroes the segment at path from pos, and resizes it to size.
+func truncateSegment(path string, pos, size int) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// An invalid header is replaced, which leaves an empty segment.
+	if pos < tsdb.SeriesSegmentHeaderSize {
+		hdr := tsdb.NewSeriesSegmentHeader()
+		if _, err := hdr.WriteTo(f); err != nil {
+			return err
+		}
+		pos = tsdb.SeriesSegmentHeaderSize
+	}
+
+	if err := f.Truncate(int64(pos)); err != nil {
+		return err
+	} else if err := f.Truncate(int64(size)); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (cmd *Command) report(path, format string, a ...interface{}) {
+	fmt.Fprintf(cmd.Stdout, "%s: %s\n", path, fmt.Sprintf(format, a...))
+}
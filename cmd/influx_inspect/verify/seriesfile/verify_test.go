@@ -0,0 +1,136 @@
+package seriesfile_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/seriesfile"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestCommand_Run(t *testing.T) {
+	enginePath, segmentPath := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	if _, err := run(enginePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the segment after its entries.
+	f, err := os.OpenFile(segmentPath, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt([]byte{0xFF, 0xFF}, 1024); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte("unexpected data after the last entry")) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(enginePath); err != nil {
+		t.Fatalf("fix did not repair the segment: %v: %s", err, out)
+	}
+
+	// The segment was copied to the quarantine before being fixed.
+	paths, err := filepath.Glob(filepath.Join(enginePath, "quarantine", "*", "_series", "00", "0000"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 1 {
+		t.Fatalf("expected the segment in the quarantine, got %v", paths)
+	}
+}
+
+func TestCommand_Run_InvalidEntry(t *testing.T) {
+	enginePath, segmentPath := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	// Replace the flag of the second entry.
+	data, err := ioutil.ReadFile(segmentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, sz := tsdb.ReadSeriesEntry(data[tsdb.SeriesSegmentHeaderSize:])
+	pos := tsdb.SeriesSegmentHeaderSize + sz
+	data[pos] = 0x7F
+	if err := ioutil.WriteFile(segmentPath, data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte("invalid entry flag 127")) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the entry before the invalid one is kept.
+	s := tsdb.NewSeriesSegment(0, segmentPath)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if ids := s.AppendSeriesIDs(nil); len(ids) != 1 || ids[0] != tsdb.NewSeriesID(1) {
+		t.Fatalf("unexpected series IDs: %v", ids)
+	}
+}
+
+// mustCreateEngine returns an engine path with a series file partition whose
+// only segment has two series, and the path of the segment.
+func mustCreateEngine(t *testing.T) (string, string) {
+	enginePath, err := ioutil.TempDir("", "verify-seriesfile-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(enginePath, "_series", "00")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "0000")
+	segment, err := tsdb.CreateSeriesSegment(0, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer segment.Close()
+	if err := segment.InitForWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The series IDs of a partition are the number of partitions apart.
+	for i, name := range []string{"cpu", "mem"} {
+		id := tsdb.NewSeriesID(uint64(1 + i*tsdb.SeriesFilePartitionN))
+		key := tsdb.AppendSeriesKey(nil, []byte(name), models.NewTags(map[string]string{"host": "a"}))
+		if _, err := segment.WriteLogEntry(tsdb.AppendSeriesEntry(nil, tsdb.SeriesEntryInsertFlag, id.WithType(models.Float), key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := segment.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return enginePath, path
+}
+
+func run(enginePath string, args ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	cmd := seriesfile.NewCommand()
+	cmd.Stdout = &buf
+	err := cmd.Run(append([]string{"-engine-path", enginePath}, args...)...)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,419 @@
+// Package tsm verifies the TSM files of an engine that is not in use, and that
+// the series of their keys are in the series file and index.
+package tsm
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/buildtsi"
+	"github.com/influxdata/platform/cmd/influx_inspect/verify"
+	"github.com/influxdata/platform/logger"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsi1"
+	"github.com/influxdata/platform/tsdb/tsm1"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBatchSize = 10000
+
+	tombstoneFileExtension = "tombstone"
+)
+
+// Command represents the program execution for "influx_inspect verify".
+type Command struct {
+	Stderr  io.Writer
+	Stdout  io.Writer
+	Verbose bool
+	Logger  *zap.Logger
+
+	fix bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+		Logger: zap.NewNop(),
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	enginePath := fs.String("engine-path", verify.DefaultEnginePath(), "path to persistent engine files")
+	fs.BoolVar(&cmd.fix, "fix", false, "optional: quarantine bad blocks and files, and add missing series to the series file and index")
+	fs.BoolVar(&cmd.Verbose, "v", false, "verbose")
+	fs.SetOutput(cmd.Stdout)
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if fs.NArg() > 0 || *enginePath == "" {
+		fs.Usage()
+		return nil
+	}
+	if cmd.Verbose {
+		cmd.Logger = logger.New(cmd.Stderr)
+	}
+
+	return cmd.run(*enginePath)
+}
+
+func (cmd *Command) run(enginePath string) error {
+	start := time.Now()
+	q := verify.NewQuarantine(enginePath, start)
+
+	dataPath := filepath.Join(enginePath, storage.DefaultEngineDirectoryName)
+	fis, err := ioutil.ReadDir(dataPath)
+	if err != nil {
+		return err
+	}
+
+	var problems int
+	var paths []string
+	for _, fi := range fis {
+		if filepath.Ext(fi.Name()) != "."+tsm1.TSMFileExtension {
+			continue
+		}
+
+		path := filepath.Join(dataPath, fi.Name())
+		n, ok, err := cmd.verifyFile(q, path)
+		if err != nil {
+			return err
+		}
+		problems += n
+		if ok {
+			paths = append(paths, path)
+		}
+	}
+
+	// Tombstones are checked last, as whole TSM files may have been quarantined
+	// with their tombstones.
+	n, err := cmd.verifyTombstones(q, dataPath)
+	if err != nil {
+		return err
+	}
+	problems += n
+
+	n, err = cmd.verifySeries(enginePath, paths)
+	if err != nil {
+		return err
+	}
+	problems += n
+
+	fmt.Fprintf(cmd.Stdout, "Found %d problems in %d TSM files in %s\n", problems, len(paths), time.Since(start))
+	if problems == 0 {
+		return nil
+	} else if cmd.fix {
+		fmt.Fprintf(cmd.Stdout, "Fixed; the files that were replaced are in %s\n", q.Path)
+		return nil
+	}
+	return fmt.Errorf("found %d problems; run with -fix to repair them", problems)
+}
+
+// badBlock is a block of a TSM file that failed verification.
+type badBlock struct {
+	key    []byte
+	offset int64
+	msg    string
+}
+
+// verifyFile verifies the blocks of the TSM file at path, and reports the
+// problems found. When fixing, a file with bad blocks is rewritten without
+// them, and an unreadable file is quarantined. It returns the number of
+// problems, and if the file is still in the engine.
+func (cmd *Command) verifyFile(q *verify.Quarantine, path string) (int, bool, error) {
+	bad, n, err := verifyBlocks(path)
+	if err != nil {
+		cmd.report(path, "unreadable: %v", err)
+		if cmd.fix {
+			return 1, false, quarantineTSMFile(q, path)
+		}
+		return 1, false, nil
+	}
+
+	for _, b := range bad {
+		cmd.report(path, "block of key %q at offset %d: %s", b.key, b.offset, b.msg)
+	}
+	if len(bad) == 0 {
+		if cmd.Verbose {
+			cmd.report(path, "%d blocks are healthy", n)
+		}
+		return 0, true, nil
+	} else if !cmd.fix {
+		return len(bad), true, nil
+	}
+
+	if len(bad) == n {
+		return len(bad), false, quarantineTSMFile(q, path)
+	}
+	return len(bad), true, rewriteFile(q, path, bad)
+}
+
+// verifyBlocks verifies the checksum and encoding of every block of the TSM
+// file at path, and that the index entries of the blocks are within the file.
+// It returns the bad blocks and the number of blocks, or an error if the file
+// can not be read.
+func verifyBlocks(path string) (bad []badBlock, n int, err error) {
+	// Corrupt files can make the reader panic.
+	defer func() {
+		if rec := recover(); rec != nil {
+			bad, n, err = nil, 0, fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	defer r.Close()
+
+	itr := r.Iterator(nil)
+	for itr.Next() {
+		for _, e := range itr.Entries() {
+			n++
+			if err := r.VerifyBlock(&e); err != nil {
+				bad = append(bad, badBlock{
+					key:    append([]byte(nil), itr.Key()...),
+					offset: e.Offset,
+					msg:    err.Error(),
+				})
+			}
+		}
+	}
+	return bad, n, itr.Err()
+}
+
+// rewriteFile replaces the TSM file at path with one without the bad blocks,
+// and quarantines the original.
+func rewriteFile(q *verify.Quarantine, path string, bad []badBlock) error {
+	skip := make(map[int64]struct{}, len(bad))
+	for _, b := range bad {
+		skip[b.offset] = struct{}{}
+	}
+
+	// The writer replaces the stats file of the original.
+	if _, err := q.Move(tsm1.StatsFilename(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	tmpPath := path + "." + tsm1.TmpTSMFileExtension
+	if err := writeFile(tmpPath, path, skip); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := q.Move(path); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// writeFile writes the blocks of the TSM file at path, except those at the
+// offsets of skip, to a new TSM file at dst.
+func writeFile(dst, path string, skip map[int64]struct{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	fd, err := os.OpenFile(dst, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+	w, err := tsm1.NewTSMWriter(fd)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	if err := writeBlocks(w, r, skip); err != nil {
+		w.Remove()
+		return err
+	}
+	return w.Close()
+}
+
+// writeBlocks writes the blocks of r, except those at the offsets of skip, to w.
+func writeBlocks(w tsm1.TSMWriter, r *tsm1.TSMReader, skip map[int64]struct{}) error {
+	itr := r.Iterator(nil)
+	for itr.Next() {
+		key := itr.Key()
+		for _, e := range itr.Entries() {
+			if _, ok := skip[e.Offset]; ok {
+				continue
+			}
+
+			_, buf, err := r.ReadBytes(&e, nil)
+			if err != nil {
+				return err
+			}
+			// The original had no more blocks for the key than are allowed, so
+			// reaching the limit does not lose any.
+			if err := w.WriteBlock(key, e.MinTime, e.MaxTime, buf); err != nil && err != tsm1.ErrMaxBlocksExceeded {
+				return err
+			}
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return err
+	}
+	return w.WriteIndex()
+}
+
+// quarantineTSMFile quarantines the TSM file at path with its tombstone and
+// stats files.
+func quarantineTSMFile(q *verify.Quarantine, path string) error {
+	if _, err := q.Move(path); err != nil {
+		return err
+	}
+	for _, p := range []string{tombstonePath(path), tsm1.StatsFilename(path)} {
+		if _, err := q.Move(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyTombstones reports the tombstone files in dir whose TSM file does not
+// exist, and quarantines them when fixing. It returns the number found.
+func (cmd *Command) verifyTombstones(q *verify.Quarantine, dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*."+tombstoneFileExtension))
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, path := range paths {
+		tsmPath := strings.TrimSuffix(path, tombstoneFileExtension) + tsm1.TSMFileExtension
+		if _, err := os.Stat(tsmPath); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return n, err
+		}
+
+		n++
+		cmd.report(path, "orphaned tombstone; %s does not exist", filepath.Base(tsmPath))
+		if cmd.fix {
+			if _, err := q.Move(path); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func tombstonePath(path string) string {
+	return strings.TrimSuffix(path, tsm1.TSMFileExtension) + tombstoneFileExtension
+}
+
+// verifySeries reports the series of the keys of the TSM files at paths that
+// are missing from the series file or the index. When fixing, the series of
+// every file with missing series are added to both. It returns the number of
+// files with missing series.
+func (cmd *Command) verifySeries(enginePath string, paths []string) (int, error) {
+	sfile := tsdb.NewSeriesFile(filepath.Join(enginePath, storage.DefaultSeriesFileDirectoryName))
+	sfile.Logger = cmd.Logger
+	sfile.DisableMetrics()
+	if err := sfile.Open(); err != nil {
+		return 0, fmt.Errorf("unable to open series file; run verify-seriesfile: %v", err)
+	}
+	defer sfile.Close()
+
+	index := tsi1.NewIndex(sfile, tsi1.NewConfig(),
+		tsi1.WithPath(filepath.Join(enginePath, storage.DefaultIndexDirectoryName)),
+		tsi1.DisableMetrics(),
+	)
+	index.WithLogger(cmd.Logger)
+	if err := index.Open(); err != nil {
+		return 0, fmt.Errorf("unable to open index: %v", err)
+	}
+	defer index.Close()
+
+	ids := index.SeriesIDSet()
+	var problems int
+	for _, path := range paths {
+		missingFile, missingIndex, err := cmd.verifyFileSeries(sfile, ids, path)
+		if err != nil {
+			return problems, err
+		}
+		if missingFile == 0 && missingIndex == 0 {
+			continue
+		}
+
+		problems++
+		cmd.report(path, "%d series are missing from the series file and %d from the index", missingFile, missingIndex)
+		if cmd.fix {
+			if err := buildtsi.IndexTSMFile(index, path, defaultBatchSize, cmd.Logger, cmd.Verbose); err != nil {
+				return problems, err
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// verifyFileSeries returns the number of series of the keys of the TSM file at
+// path that are missing from the series file, and from the index.
+func (cmd *Command) verifyFileSeries(sfile *tsdb.SeriesFile, ids *tsdb.SeriesIDSet, path string) (missingFile, missingIndex int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return 0, 0, err
+	}
+	defer r.Close()
+
+	var prev, buf []byte
+	itr := r.Iterator(nil)
+	for itr.Next() {
+		// Keys are sorted, so the fields of a series are next to each other.
+		seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(itr.Key())
+		if bytes.Equal(seriesKey, prev) {
+			continue
+		}
+		prev = append(prev[:0], seriesKey...)
+
+		name, tags := models.ParseKeyBytes(seriesKey)
+		if id := sfile.SeriesID(name, tags, buf); id.IsZero() {
+			missingFile++
+			if cmd.Verbose {
+				cmd.report(path, "series %q is missing from the series file", seriesKey)
+			}
+		} else if !ids.Contains(id) {
+			missingIndex++
+			if cmd.Verbose {
+				cmd.report(path, "series %q is missing from the index", seriesKey)
+			}
+		}
+	}
+	return missingFile, missingIndex, itr.Err()
+}
+
+func (cmd *Command) report(path, format string, a ...interface{}) {
+	fmt.Fprintf(cmd.Stdout, "%s: %s\n", path, fmt.Sprintf(format, a...))
+}
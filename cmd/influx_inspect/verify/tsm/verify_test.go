@@ -0,0 +1,189 @@
+package tsm_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/tsm"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+func TestCommand_Run(t *testing.T) {
+	enginePath, path := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	// The series of the file are missing from the series file and index.
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte("2 series are missing from the series file")) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(enginePath); err != nil {
+		t.Fatalf("fix did not add the series: %v: %s", err, out)
+	}
+
+	// Adding series does not move the file.
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommand_Run_BadBlock(t *testing.T) {
+	enginePath, path := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	// Corrupt the checksum of the first block, which follows the header.
+	mustWriteAt(t, path, []byte{0xFF, 0xFF, 0xFF, 0xFF}, 5)
+
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte(`block of key "cpu,host=a#!~#value" at offset 5: checksum is`)) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(enginePath); err != nil {
+		t.Fatalf("fix did not repair the file: %v: %s", err, out)
+	}
+
+	// The file was rewritten without the bad block.
+	r := mustOpenTSMReader(t, path)
+	defer r.Close()
+	if err := r.Verify(); err != nil {
+		t.Fatal(err)
+	} else if got := r.KeyCount(); got != 1 {
+		t.Fatalf("unexpected key count: got %d, exp 1", got)
+	} else if values, err := r.ReadAll([]byte("mem,host=a#!~#value")); err != nil {
+		t.Fatal(err)
+	} else if len(values) != 2 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// The original was quarantined.
+	q := mustQuarantined(t, enginePath, filepath.Base(path))
+	qr := mustOpenTSMReader(t, q)
+	defer qr.Close()
+	if got := qr.KeyCount(); got != 2 {
+		t.Fatalf("unexpected key count of the original: got %d, exp 2", got)
+	}
+}
+
+func TestCommand_Run_Unreadable(t *testing.T) {
+	enginePath, path := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	// Corrupt the offset of the index, which ends the file.
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustWriteAt(t, path, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, fi.Size()-8)
+
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte("unreadable")) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(enginePath); err != nil {
+		t.Fatalf("fix did not quarantine the file: %v: %s", err, out)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the file to be removed from the engine, got %v", err)
+	}
+	mustQuarantined(t, enginePath, filepath.Base(path))
+}
+
+// mustCreateEngine returns an engine path with a TSM file of two keys with
+// two values each, and the path of the file.
+func mustCreateEngine(t *testing.T) (string, string) {
+	enginePath, err := ioutil.TempDir("", "verify-tsm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(enginePath, "data")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, tsm1.DefaultFormatFileName(1, 1)+"."+tsm1.TSMFileExtension)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"cpu,host=a#!~#value", "mem,host=a#!~#value"} {
+		if err := w.Write([]byte(key), []tsm1.Value{tsm1.NewValue(1, 1.0), tsm1.NewValue(2, 2.0)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.WriteIndex(); err != nil {
+		t.Fatal(err)
+	} else if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return enginePath, path
+}
+
+func mustWriteAt(t *testing.T, path string, b []byte, off int64) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt(b, off); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustOpenTSMReader(t *testing.T, path string) *tsm1.TSMReader {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		t.Fatal(err)
+	}
+	return r
+}
+
+// mustQuarantined returns the path of the TSM file name in the quarantine.
+func mustQuarantined(t *testing.T, enginePath, name string) string {
+	paths, err := filepath.Glob(filepath.Join(enginePath, "quarantine", "*", "data", name))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 1 {
+		t.Fatalf("expected %s in the quarantine, got %v", name, paths)
+	}
+	return paths[0]
+}
+
+func run(enginePath string, args ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	cmd := tsm.NewCommand()
+	cmd.Stdout = &buf
+	err := cmd.Run(append([]string{"-engine-path", enginePath}, args...)...)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,125 @@
+// Package wal verifies the WAL segments of an engine that is not in use.
+package wal
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/verify"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// Command represents the program execution for "influx_inspect verify-wal".
+type Command struct {
+	Stderr  io.Writer
+	Stdout  io.Writer
+	Verbose bool
+
+	fix bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("verify-wal", flag.ExitOnError)
+	enginePath := fs.String("engine-path", verify.DefaultEnginePath(), "path to persistent engine files")
+	fs.BoolVar(&cmd.fix, "fix", false, "optional: truncate corrupt segments at their last valid entry")
+	fs.BoolVar(&cmd.Verbose, "v", false, "verbose")
+	fs.SetOutput(cmd.Stdout)
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if fs.NArg() > 0 || *enginePath == "" {
+		fs.Usage()
+		return nil
+	}
+
+	return cmd.run(*enginePath)
+}
+
+func (cmd *Command) run(enginePath string) error {
+	start := time.Now()
+	q := verify.NewQuarantine(enginePath, start)
+
+	paths, err := filepath.Glob(filepath.Join(enginePath, storage.DefaultWALDirectoryName,
+		fmt.Sprintf("%s*.%s", tsm1.WALFilePrefix, tsm1.WALFileExtension)))
+	if err != nil {
+		return err
+	}
+
+	var problems int
+	for _, path := range paths {
+		n, entries, err := verifySegment(path)
+		if err != nil {
+			return err
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if n == fi.Size() {
+			if cmd.Verbose {
+				fmt.Fprintf(cmd.Stdout, "%s: %d entries are healthy\n", path, entries)
+			}
+			continue
+		}
+
+		problems++
+		fmt.Fprintf(cmd.Stdout, "%s: corrupt after %d entries, at byte %d of %d\n", path, entries, n, fi.Size())
+		if cmd.fix {
+			if _, err := q.Copy(path); err != nil {
+				return err
+			} else if err := os.Truncate(path, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Found %d corrupt segments of %d in %s\n", problems, len(paths), time.Since(start))
+	if problems == 0 {
+		return nil
+	} else if cmd.fix {
+		fmt.Fprintf(cmd.Stdout, "Truncated; the original segments are in %s\n", q.Path)
+		return nil
+	}
+	return fmt.Errorf("found %d corrupt segments; run with -fix to truncate them", problems)
+}
+
+// verifySegment reads the entries of the WAL segment at path. It returns the
+// number of bytes that are valid, and the number of entries in them.
+func verifySegment(path string) (n int64, entries int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	r := tsm1.NewWALSegmentReader(f)
+	defer r.Close()
+
+	// Corrupt entries can make the decoding panic.
+	defer func() {
+		if rec := recover(); rec != nil {
+			n, err = r.Count(), nil
+		}
+	}()
+
+	for r.Next() {
+		if _, err := r.Read(); err != nil {
+			break
+		}
+		entries++
+	}
+	return r.Count(), entries, nil
+}
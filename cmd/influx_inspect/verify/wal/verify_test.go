@@ -0,0 +1,122 @@
+package wal_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/cmd/influx_inspect/verify/wal"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+func TestCommand_Run(t *testing.T) {
+	enginePath, path := mustCreateEngine(t)
+	defer os.RemoveAll(enginePath)
+
+	if _, err := run(enginePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncate the segment in the middle of its last entry.
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, fi.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run(enginePath)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if !bytes.Contains(out, []byte("corrupt after 1 entries")) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, err := run(enginePath, "-fix"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run(enginePath); err != nil {
+		t.Fatalf("fix did not repair the segment: %v: %s", err, out)
+	}
+
+	// Only the entry before the truncated one is kept.
+	if n := mustReadEntries(t, path); n != 1 {
+		t.Fatalf("unexpected entries: got %d, exp 1", n)
+	}
+
+	// The truncated segment was copied to the quarantine before being fixed.
+	paths, err := filepath.Glob(filepath.Join(enginePath, "quarantine", "*", "wal", filepath.Base(path)))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 1 {
+		t.Fatalf("expected the segment in the quarantine, got %v", paths)
+	}
+	if qfi, err := os.Stat(paths[0]); err != nil {
+		t.Fatal(err)
+	} else if qfi.Size() != fi.Size()-3 {
+		t.Fatalf("unexpected size of the quarantined segment: got %d, exp %d", qfi.Size(), fi.Size()-3)
+	}
+}
+
+// mustCreateEngine returns an engine path with a WAL whose only segment has
+// two entries, and the path of the segment.
+func mustCreateEngine(t *testing.T) (string, string) {
+	enginePath, err := ioutil.TempDir("", "verify-wal-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := tsm1.NewWAL(filepath.Join(enginePath, "wal"))
+	if err := w.Open(); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"cpu,host=a#!~#value", "mem,host=a#!~#value"} {
+		if _, err := w.WriteMulti(map[string][]tsm1.Value{
+			key: {tsm1.NewValue(1, 1.0)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(enginePath, "wal", "*."+tsm1.WALFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 1 {
+		t.Fatalf("expected one segment, got %v", paths)
+	}
+	return enginePath, paths[0]
+}
+
+// mustReadEntries returns the number of entries in the WAL segment at path,
+// which must all be valid.
+func mustReadEntries(t *testing.T, path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := tsm1.NewWALSegmentReader(f)
+	defer r.Close()
+
+	var n int
+	for r.Next() {
+		if _, err := r.Read(); err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	return n
+}
+
+func run(enginePath string, args ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	cmd := wal.NewCommand()
+	cmd.Stdout = &buf
+	err := cmd.Run(append([]string{"-engine-path", enginePath}, args...)...)
+	return buf.Bytes(), err
+}
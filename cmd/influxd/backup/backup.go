@@ -0,0 +1,141 @@
+// Package backup writes an archive of the metadata and data of a running
+// influxd to a file.
+package backup
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	pbackup "github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/internal/fs"
+)
+
+// Command represents the program execution for "influxd backup".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+
+	host       string
+	token      string
+	since      string
+	generation int
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(ctx context.Context, args ...string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	flags.StringVar(&cmd.host, "host", "http://localhost:9999", "HTTP address of influxd")
+	flags.StringVar(&cmd.token, "token", defaultToken(), "API token that may back up; defaults to $INFLUX_TOKEN or the stored credentials")
+	flags.StringVar(&cmd.since, "since", "", "optional: only back up the TSM files modified after this RFC3339 time")
+	flags.IntVar(&cmd.generation, "generation", -1, "optional: only back up the TSM files of generations after this one")
+	flags.SetOutput(cmd.Stdout)
+	flags.Usage = func() {
+		fmt.Fprintln(cmd.Stdout, "usage: influxd backup [flags] PATH")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	} else if flags.NArg() != 1 {
+		flags.Usage()
+		return nil
+	}
+
+	var filter platform.BackupFilter
+	if cmd.since != "" {
+		t, err := time.Parse(time.RFC3339Nano, cmd.since)
+		if err != nil {
+			return fmt.Errorf("invalid since: %v", err)
+		}
+		filter.Since = &t
+	}
+	if cmd.generation >= 0 {
+		filter.Generation = &cmd.generation
+	}
+
+	return cmd.backup(ctx, flags.Arg(0), filter)
+}
+
+func (cmd *Command) backup(ctx context.Context, path string, filter platform.BackupFilter) error {
+	s := &http.BackupService{
+		Addr:  cmd.host,
+		Token: cmd.token,
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Backup(ctx, f, filter); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	} else if err := f.Close(); err != nil {
+		return err
+	}
+
+	m, err := readManifest(path)
+	if err != nil {
+		return err
+	}
+
+	kind := "full"
+	if m.Incremental {
+		kind = "incremental"
+	}
+	fmt.Fprintf(cmd.Stdout, "Wrote %s backup of generation %d to %s\n", kind, m.Generation, path)
+	fmt.Fprintf(cmd.Stdout, "Back up the changes since with -generation %d\n", m.Generation)
+	return nil
+}
+
+func readManifest(path string) (*platform.BackupManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := pbackup.ReadManifest(f)
+	if err == io.EOF {
+		return nil, errors.New("backup archive is empty")
+	}
+	return m, err
+}
+
+// defaultToken returns the token of $INFLUX_TOKEN, or the one stored by the
+// influx command.
+func defaultToken() string {
+	if tok := os.Getenv("INFLUX_TOKEN"); tok != "" {
+		return tok
+	}
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		return ""
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "credentials"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
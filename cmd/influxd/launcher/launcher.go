@@ -15,6 +15,8 @@ import (
 	"github.com/influxdata/flux/control"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	"github.com/influxdata/platform/backup"
 	"github.com/influxdata/platform/bolt"
 	"github.com/influxdata/platform/chronograf/server"
 	protofs "github.com/influxdata/platform/fs"
@@ -27,6 +29,7 @@ import (
 	"github.com/influxdata/platform/nats"
 	"github.com/influxdata/platform/query"
 	pcontrol "github.com/influxdata/platform/query/control"
+	pinputs "github.com/influxdata/platform/query/functions/inputs"
 	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/source"
 	"github.com/influxdata/platform/storage"
@@ -37,7 +40,8 @@ import (
 	"github.com/influxdata/platform/task/backend/coordinator"
 	taskexecutor "github.com/influxdata/platform/task/backend/executor"
 	_ "github.com/influxdata/platform/tsdb/tsi1"
-	_ "github.com/influxdata/platform/tsdb/tsm1"
+	"github.com/influxdata/platform/tsdb/tsm1"
+	"github.com/influxdata/platform/usage"
 	"github.com/influxdata/platform/vault"
 	pzap "github.com/influxdata/platform/zap"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -60,10 +64,26 @@ type Launcher struct {
 	enginePath      string
 	protosPath      string
 
-	secretStore string
+	secretStore    string
+	tokenPepper    string
+	auditPoints    bool
+	auditRetention time.Duration
 
-	boltClient *bolt.Client
-	engine     *storage.Engine
+	maxSeriesPerBucket      int
+	maxPointsPerRequest     int
+	maxBulkPointsPerRequest int
+	maxWriteBytesPerSecond  int
+
+	usageRetention time.Duration
+
+	scraperMaxBodySize int
+
+	compactionColdAge time.Duration
+
+	boltClient    *bolt.Client
+	engine        *storage.Engine
+	usageRecorder *usage.Recorder
+	auditLog      *audit.Log
 
 	queryController *pcontrol.Controller
 
@@ -115,6 +135,16 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 	m.logger.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
 
+	m.logger.Info("Stopping", zap.String("service", "usage"))
+	if err := m.usageRecorder.Close(); err != nil {
+		m.logger.Info("failed flushing usage", zap.Error(err))
+	}
+
+	m.logger.Info("Stopping", zap.String("service", "audit"))
+	if err := m.auditLog.Close(); err != nil {
+		m.logger.Info("failed closing audit log", zap.Error(err))
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "bolt"))
 	if err := m.boltClient.Close(); err != nil {
 		m.logger.Info("failed closing bolt", zap.Error(err))
@@ -191,12 +221,72 @@ func (m *Launcher) Run(ctx context.Context, args ...string) error {
 				Default: "bolt",
 				Desc:    "data store for secrets (bolt or vault)",
 			},
+			{
+				DestP:   &m.tokenPepper,
+				Flag:    "token-pepper",
+				Default: "",
+				Desc:    "secret that API tokens are hashed with; tokens can not be used if it changes",
+			},
+			{
+				DestP:   &m.auditPoints,
+				Flag:    "audit-points",
+				Default: false,
+				Desc:    "mirror the audit log of every organization as points in its system bucket",
+			},
+			{
+				DestP:   &m.auditRetention,
+				Flag:    "audit-retention",
+				Default: audit.DefaultRetention,
+				Desc:    "how long the audit log is kept; 0 keeps the audit log forever",
+			},
 			{
 				DestP:   &m.protosPath,
 				Flag:    "protos-path",
 				Default: filepath.Join(dir, "protos"),
 				Desc:    "path to protos on the filesystem",
 			},
+			{
+				DestP:   &m.maxSeriesPerBucket,
+				Flag:    "max-series-per-bucket",
+				Default: storage.DefaultMaxSeriesPerBucket,
+				Desc:    "maximum number of series in a bucket; 0 is unlimited",
+			},
+			{
+				DestP:   &m.maxPointsPerRequest,
+				Flag:    "max-points-per-request",
+				Default: 0,
+				Desc:    "maximum number of points in a write request; 0 is unlimited",
+			},
+			{
+				DestP:   &m.maxBulkPointsPerRequest,
+				Flag:    "max-bulk-points-per-request",
+				Default: 0,
+				Desc:    "maximum number of points in a bulk write request; 0 is unlimited",
+			},
+			{
+				DestP:   &m.maxWriteBytesPerSecond,
+				Flag:    "max-write-bytes-per-second",
+				Default: 0,
+				Desc:    "maximum bytes of line protocol each organization may write per second; 0 is unlimited",
+			},
+			{
+				DestP:   &m.usageRetention,
+				Flag:    "usage-retention",
+				Default: usage.DefaultRetention,
+				Desc:    "how long write and query usage is kept; 0 keeps usage forever",
+			},
+			{
+				DestP:   &m.scraperMaxBodySize,
+				Flag:    "scraper-max-body-size",
+				Default: gather.DefaultMaxBodySize,
+				Desc:    "maximum size in bytes of the response of a scraper target",
+			},
+			{
+				DestP:   &m.compactionColdAge,
+				Flag:    "compaction-cold-age",
+				Default: time.Duration(0),
+				Desc:    "compact the TSM files of each shard group fully once it has ended for this long, and afterwards only to remove deleted data; 0 disables time-based compaction",
+			},
 		},
 	}
 
@@ -236,6 +326,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 	m.boltClient = bolt.NewClient()
 	m.boltClient.Path = m.boltPath
+	m.boltClient.TokenPepper = []byte(m.tokenPepper)
 	m.boltClient.WithLogger(m.logger.With(zap.String("service", "bolt")))
 
 	if err := m.boltClient.Open(ctx); err != nil {
@@ -259,11 +350,13 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		orgLogSvc        platform.OrganizationOperationLogService = m.boltClient
 		onboardingSvc    platform.OnboardingService               = m.boltClient
 		scraperTargetSvc platform.ScraperTargetStoreService       = m.boltClient
+		scraperHealthSvc platform.ScraperTargetHealthService      = m.boltClient
 		telegrafSvc      platform.TelegrafConfigStore             = m.boltClient
 		userResourceSvc  platform.UserResourceMappingService      = m.boltClient
 		labelSvc         platform.LabelService                    = m.boltClient
 		secretSvc        platform.SecretService                   = m.boltClient
 		lookupSvc        platform.LookupService                   = m.boltClient
+		dbrpMappingSvc   platform.DBRPMappingService              = m.boltClient
 	)
 
 	switch m.secretStore {
@@ -298,7 +391,15 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 	var pointsWriter storage.PointsWriter
 	{
-		m.engine = storage.NewEngine(m.enginePath, storage.NewConfig(), storage.WithRetentionEnforcer(bucketSvc))
+		config := storage.NewConfig()
+		config.MaxSeriesPerBucket = m.maxSeriesPerBucket
+		options := []storage.Option{storage.WithRetentionEnforcer(bucketSvc)}
+		if m.compactionColdAge > 0 {
+			planner := tsm1.NewTimePlanner(nil, time.Duration(config.Engine.Compaction.FullWriteColdDuration),
+				tsm1.DefaultTimePlannerWindow, m.compactionColdAge)
+			options = append(options, storage.WithCompactionPlanner(planner))
+		}
+		m.engine = storage.NewEngine(m.enginePath, config, options...)
 		m.engine.WithLogger(m.logger)
 
 		if err := m.engine.Open(); err != nil {
@@ -310,9 +411,19 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 		pointsWriter = m.engine
 
+		m.usageRecorder = usage.NewRecorder(m.boltClient)
+		m.usageRecorder.Series = m.engine
+		m.usageRecorder.Retention = m.usageRetention
+		m.usageRecorder.Logger = m.logger.With(zap.String("service", "usage"))
+		if err := m.usageRecorder.Open(); err != nil {
+			m.logger.Error("failed to open usage recorder", zap.Error(err))
+			return err
+		}
+
 		const (
 			concurrencyQuota = 10
 			memoryBytesQuota = 1e6
+			queueSize        = 10 * concurrencyQuota
 		)
 
 		cc := control.Config{
@@ -329,7 +440,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			return err
 		}
 
+		// The databases of InfluxQL queries are listed from the dbrp mappings.
+		if err := pinputs.InjectDatabasesDependencies(cc.ExecutorDependencies, pinputs.DatabasesDependencies{
+			DBRP:         dbrpMappingSvc,
+			BucketLookup: bucketSvc,
+		}); err != nil {
+			m.logger.Error("Failed to configure query controller dependencies", zap.Error(err))
+			return err
+		}
+
 		m.queryController = pcontrol.New(cc)
+		m.queryController.QueueSize = queueSize
 		reg.MustRegister(m.queryController.PrometheusCollectors()...)
 	}
 
@@ -368,14 +489,31 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	// TODO(jm): this is an example of using a subscriber to consume from the channel. It should be removed.
 	subscriber := nats.NewQueueSubscriber("nats-subscriber")
 	if err := subscriber.Open(); err != nil {
 		m.logger.Error("failed to connect to streaming server", zap.Error(err))
 		return err
 	}
 
-	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 0, 0)
+	// Persist the metrics gathered by the scrapers into the target's bucket.
+	if err := subscriber.Subscribe(gather.MetricsSubject, "", &gather.StorageHandler{
+		Logger: m.logger.With(zap.String("service", "scraper-storage")),
+		Storage: &gather.PointWriter{
+			Writer:              pointsWriter,
+			OrganizationService: orgSvc,
+			BucketService:       bucketSvc,
+			Logger:              m.logger.With(zap.String("service", "scraper-storage")),
+		},
+		HealthService: scraperHealthSvc,
+	}); err != nil {
+		m.logger.Error("failed to create scraper storage subscriber", zap.Error(err))
+		return err
+	}
+
+	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, &gather.OrganizationSecrets{
+		OrganizationService: orgSvc,
+		SecretService:       secretSvc,
+	}, publisher, subscriber, 0, 0, int64(m.scraperMaxBodySize))
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
 		return err
@@ -395,37 +533,67 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		Addr: m.httpBindAddress,
 	}
 
+	// Record every change made through the API in the audit log.
+	m.auditLog = audit.NewLog(m.boltClient)
+	m.auditLog.Retention = m.auditRetention
+	m.auditLog.Logger = m.logger.With(zap.String("service", "audit"))
+	if m.auditPoints {
+		m.auditLog.PointsWriter = pointsWriter
+		m.auditLog.BucketService = bucketSvc
+	}
+	if err := m.auditLog.Open(); err != nil {
+		m.logger.Error("failed to open audit log", zap.Error(err))
+		return err
+	}
+
+	backupSvc := backup.NewService(m.engine, m.boltClient, bucketSvc)
+	backupSvc.Logger = m.logger.With(zap.String("service", "backup"))
+
 	handlerConfig := &http.APIBackend{
 		DeveloperMode:        m.developerMode,
 		Logger:               m.logger,
 		NewBucketService:     source.NewBucketService,
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
-		AuthorizationService: authSvc,
+		BulkPointsWriter:     m.engine,
+		BucketRangeDeleter:   m.engine,
+		UsageService:         m.boltClient,
+		UsageRecorder:        m.usageRecorder,
+		AuthorizationService: &audit.AuthorizationService{AuthorizationService: authSvc, Log: m.auditLog},
 		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
-		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
+		BucketService:                   &audit.BucketService{BucketService: storage.NewBucketService(bucketSvc, m.engine), Log: m.auditLog},
 		SessionService:                  sessionSvc,
-		UserService:                     userSvc,
-		OrganizationService:             orgSvc,
-		UserResourceMappingService:      userResourceSvc,
-		LabelService:                    labelSvc,
-		DashboardService:                dashboardSvc,
+		AuthorizationUsageRecorder:      m.boltClient,
+		UserService:                     &audit.UserService{UserService: userSvc, Log: m.auditLog},
+		OrganizationService:             &audit.OrganizationService{OrganizationService: orgSvc, Log: m.auditLog},
+		UserResourceMappingService:      &audit.UserResourceMappingService{UserResourceMappingService: userResourceSvc, Log: m.auditLog},
+		LabelService:                    &audit.LabelService{LabelService: labelSvc, Log: m.auditLog},
+		DashboardService:                &audit.DashboardService{DashboardService: dashboardSvc, Log: m.auditLog},
 		DashboardOperationLogService:    dashboardLogSvc,
 		BucketOperationLogService:       bucketLogSvc,
 		UserOperationLogService:         userLogSvc,
 		OrganizationOperationLogService: orgLogSvc,
-		SourceService:                   sourceSvc,
-		MacroService:                    macroSvc,
-		BasicAuthService:                basicAuthSvc,
-		OnboardingService:               onboardingSvc,
+		SourceService:                   &audit.SourceService{SourceService: sourceSvc, Log: m.auditLog},
+		MacroService:                    &audit.MacroService{MacroService: macroSvc, Log: m.auditLog},
+		BasicAuthService:                &audit.BasicAuthService{BasicAuthService: basicAuthSvc, Log: m.auditLog, UserService: userSvc},
+		OnboardingService:               &audit.OnboardingService{OnboardingService: onboardingSvc, Log: m.auditLog},
 		ProxyQueryService:               storageQueryService,
-		TaskService:                     taskSvc,
-		TelegrafService:                 telegrafSvc,
-		ScraperTargetStoreService:       scraperTargetSvc,
+		DBRPMappingService:              &audit.DBRPMappingService{DBRPMappingService: dbrpMappingSvc, Log: m.auditLog},
+		TaskService:                     &audit.TaskService{TaskService: taskSvc, Log: m.auditLog},
+		TelegrafService:                 &audit.TelegrafConfigStore{TelegrafConfigStore: telegrafSvc, Log: m.auditLog},
+		ScraperTargetStoreService:       &audit.ScraperTargetStoreService{ScraperTargetStoreService: scraperTargetSvc, Log: m.auditLog},
+		ScraperTargetHealthService:      scraperHealthSvc,
 		ChronografService:               chronografSvc,
-		SecretService:                   secretSvc,
+		SecretService:                   &audit.SecretService{SecretService: secretSvc, Log: m.auditLog},
+		AuditLogService:                 m.auditLog,
+		BackupService:                   backupSvc,
 		LookupService:                   lookupSvc,
 		ProtoService:                    protoSvc,
+		WriteLimits: http.WriteLimits{
+			MaxPointsPerRequest:     m.maxPointsPerRequest,
+			MaxBulkPointsPerRequest: m.maxBulkPointsPerRequest,
+			MaxWriteBytesPerSecond:  m.maxWriteBytesPerSecond,
+		},
 	}
 
 	// HTTP server
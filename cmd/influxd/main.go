@@ -7,7 +7,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/influxdata/platform/cmd/influxd/backup"
 	"github.com/influxdata/platform/cmd/influxd/launcher"
+	"github.com/influxdata/platform/cmd/influxd/restore"
 	"github.com/influxdata/platform/kit/signals"
 	_ "github.com/influxdata/platform/query/builtin"
 	_ "github.com/influxdata/platform/tsdb/tsi1"
@@ -19,6 +21,16 @@ func main() {
 	ctx := context.Background()
 	ctx = signals.WithStandardSignals(ctx)
 
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			if err := run(ctx, os.Args[2:]...); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	m := launcher.NewLauncher()
 	if err := m.Run(ctx, os.Args[1:]...); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -34,3 +46,9 @@ func main() {
 	defer cancel()
 	m.Shutdown(ctx)
 }
+
+// commands are the programs run by "influxd <command>" instead of the server.
+var commands = map[string]func(ctx context.Context, args ...string) error{
+	"backup":  func(ctx context.Context, args ...string) error { return backup.NewCommand().Run(ctx, args...) },
+	"restore": func(ctx context.Context, args ...string) error { return restore.NewCommand().Run(ctx, args...) },
+}
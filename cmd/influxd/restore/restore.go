@@ -0,0 +1,134 @@
+// Package restore restores the metadata and data of influxd from an archive
+// written by "influxd backup".
+package restore
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/internal/fs"
+)
+
+// Command represents the program execution for "influxd restore".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+
+	boltPath    string
+	enginePath  string
+	bucketID    string
+	newBucketID string
+	host        string
+	token       string
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+//
+// Without -bucket-id, all of the metadata and data are restored into the files
+// of an influxd that must not be running. With it, the data of that bucket is
+// restored into the existing bucket -new-bucket-id of a running influxd.
+func (cmd *Command) Run(ctx context.Context, args ...string) error {
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine influx directory: %v", err)
+	}
+
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	flags.StringVar(&cmd.boltPath, "bolt-path", filepath.Join(dir, "influxd.bolt"), "path to boltdb database")
+	flags.StringVar(&cmd.enginePath, "engine-path", filepath.Join(dir, "engine"), "path to persistent engine files")
+	flags.StringVar(&cmd.bucketID, "bucket-id", "", "optional: only restore the data of the bucket of this ID in the archive")
+	flags.StringVar(&cmd.newBucketID, "new-bucket-id", "", "ID of the existing bucket to restore the data of -bucket-id into")
+	flags.StringVar(&cmd.host, "host", "http://localhost:9999", "HTTP address of influxd, when restoring a bucket")
+	flags.StringVar(&cmd.token, "token", defaultToken(dir), "API token that may write to the new bucket; defaults to $INFLUX_TOKEN or the stored credentials")
+	flags.SetOutput(cmd.Stdout)
+	flags.Usage = func() {
+		fmt.Fprintln(cmd.Stdout, "usage: influxd restore [flags] PATH")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	} else if flags.NArg() != 1 {
+		flags.Usage()
+		return nil
+	}
+
+	if cmd.bucketID == "" {
+		return cmd.restore(flags.Arg(0))
+	}
+	return cmd.restoreBucket(ctx, flags.Arg(0))
+}
+
+func (cmd *Command) restore(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	m, err := backup.Restore(f, cmd.boltPath, cmd.enginePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Restored backup of generation %d taken at %s\n", m.Generation, m.Time)
+	return nil
+}
+
+func (cmd *Command) restoreBucket(ctx context.Context, path string) error {
+	var bucketID, newBucketID platform.ID
+	if err := bucketID.DecodeFromString(cmd.bucketID); err != nil {
+		return fmt.Errorf("invalid bucket-id: %v", err)
+	}
+	if cmd.newBucketID == "" {
+		return fmt.Errorf("new-bucket-id is required to restore a bucket")
+	} else if err := newBucketID.DecodeFromString(cmd.newBucketID); err != nil {
+		return fmt.Errorf("invalid new-bucket-id: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := &http.BackupService{
+		Addr:  cmd.host,
+		Token: cmd.token,
+	}
+	if err := s.RestoreBucket(ctx, f, bucketID, newBucketID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Restored bucket %s into bucket %s\n", bucketID, newBucketID)
+	return nil
+}
+
+// defaultToken returns the token of $INFLUX_TOKEN, or the one stored by the
+// influx command in dir.
+func defaultToken(dir string) string {
+	if tok := os.Getenv("INFLUX_TOKEN"); tok != "" {
+		return tok
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "credentials"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,21 @@
+package context
+
+import (
+	"context"
+)
+
+const (
+	requestSourceCtxKey = contextKey("influx/request-source/v1")
+)
+
+// SetRequestSource sets the source of the request, such as its remote address, on context.
+func SetRequestSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, requestSourceCtxKey, source)
+}
+
+// GetRequestSource retrieves the source of the request from context. It is
+// empty if the context is not that of a request.
+func GetRequestSource(ctx context.Context) string {
+	s, _ := ctx.Value(requestSourceCtxKey).(string)
+	return s
+}
@@ -8,19 +8,49 @@ import (
 	"unicode"
 )
 
+// DefaultDBRPCluster is the cluster of the dbrp mappings resolved by the
+// InfluxDB 1.x compatible endpoints of a single instance.
+const DefaultDBRPCluster = "default"
+
+// DefaultDBRPRetentionPolicy is the retention policy of the dbrp mapping
+// created for a bucket whose name does not name a retention policy.
+const DefaultDBRPRetentionPolicy = "autogen"
+
+// ops for dbrp mappings.
+const (
+	OpCreateDBRPMapping = "CreateDBRPMapping"
+	OpDeleteDBRPMapping = "DeleteDBRPMapping"
+)
+
+var (
+	// ErrDBRPMappingNotFound is the error for a missing dbrp mapping.
+	ErrDBRPMappingNotFound = &Error{
+		Code: ENotFound,
+		Msg:  "dbrp mapping not found",
+	}
+
+	// ErrDBRPMappingExists is the error for creating a dbrp mapping that
+	// differs from the existing mapping of the organization, cluster, db and rp.
+	ErrDBRPMappingExists = &Error{
+		Code: EConflict,
+		Msg:  "dbrp mapping already exists",
+	}
+)
+
 // DBRPMappingService provides a mapping of cluster, database and retention policy to an organization ID and bucket ID.
+// The cluster, database and retention policy of a mapping are unique within its organization.
 type DBRPMappingService interface {
-	// FindBy returns the dbrp mapping the for cluster, db and rp.
-	FindBy(ctx context.Context, cluster, db, rp string) (*DBRPMapping, error)
+	// FindBy returns the dbrp mapping of the organization for cluster, db and rp.
+	FindBy(ctx context.Context, orgID ID, cluster, db, rp string) (*DBRPMapping, error)
 	// Find returns the first dbrp mapping the matches the filter.
 	Find(ctx context.Context, filter DBRPMappingFilter) (*DBRPMapping, error)
 	// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
 	FindMany(ctx context.Context, filter DBRPMappingFilter, opt ...FindOptions) ([]*DBRPMapping, int, error)
 	// Create creates a new dbrp mapping, if a different mapping exists an error is returned.
 	Create(ctx context.Context, dbrpMap *DBRPMapping) error
-	// Delete removes a dbrp mapping.
+	// Delete removes a dbrp mapping of the organization.
 	// Deleting a mapping that does not exists is not an error.
-	Delete(ctx context.Context, cluster, db, rp string) error
+	Delete(ctx context.Context, orgID ID, cluster, db, rp string) error
 }
 
 // DBRPMapping represents a mapping of a cluster, database and retention policy to an organization ID and bucket ID.
@@ -29,7 +59,7 @@ type DBRPMapping struct {
 	Database        string `json:"database"`
 	RetentionPolicy string `json:"retention_policy"`
 
-	// Default indicates if this mapping is the default for the cluster and database.
+	// Default indicates if this mapping is the default for the organization, cluster and database.
 	Default bool `json:"default"`
 
 	OrganizationID ID `json:"organization_id"`
@@ -89,8 +119,9 @@ func (m *DBRPMapping) Equal(o *DBRPMapping) bool {
 		m.BucketID == o.BucketID
 }
 
-// DBRPMappingFilter represents a set of filters that restrict the returned results by cluster, database and retention policy.
+// DBRPMappingFilter represents a set of filters that restrict the returned results by organization, cluster, database and retention policy.
 type DBRPMappingFilter struct {
+	OrganizationID  *ID
 	Cluster         *string
 	Database        *string
 	RetentionPolicy *string
@@ -101,7 +132,13 @@ func (f DBRPMappingFilter) String() string {
 	var s strings.Builder
 	s.WriteString("{")
 
-	s.WriteString("cluster:")
+	s.WriteString("org:")
+	if f.OrganizationID != nil {
+		s.WriteString(f.OrganizationID.String())
+	} else {
+		s.WriteString("<nil>")
+	}
+	s.WriteString(" cluster:")
 	if f.Cluster != nil {
 		s.WriteString(*f.Cluster)
 	} else {
@@ -17,7 +17,10 @@ const (
 	EEmptyValue       = "empty value"
 	EUnavailable      = "unavailable"
 	EForbidden        = "forbidden"
+	EUnauthorized     = "unauthorized" // the credentials are not valid, or no longer are
 	EMethodNotAllowed = "method not allowed"
+	ETooManyRequests  = "too many requests" // a limit or quota was exceeded
+	ETooLarge         = "request too large"
 )
 
 // Error is the error struct of platform.
@@ -0,0 +1,257 @@
+package gather
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// SecretLoader loads the secrets referenced by the authentication of scraper targets.
+type SecretLoader interface {
+	LoadSecret(ctx context.Context, target platform.ScraperTarget, key string) (string, error)
+}
+
+// OrganizationSecrets implements SecretLoader by loading
+// the secrets of the organization of the scraper target.
+type OrganizationSecrets struct {
+	OrganizationService platform.OrganizationService
+	SecretService       platform.SecretService
+}
+
+// LoadSecret loads the secret for key from the organization of the target.
+func (s *OrganizationSecrets) LoadSecret(ctx context.Context, target platform.ScraperTarget, key string) (string, error) {
+	org, err := s.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &target.OrgName})
+	if err != nil {
+		return "", err
+	}
+	return s.SecretService.LoadSecret(ctx, org.ID, key)
+}
+
+// clientIdleTimeout is how long the http client of a target
+// is kept after its last scrape.
+const clientIdleTimeout = time.Hour
+
+// DefaultMaxBodySize is the default maximum size of the response of a scraper target.
+const DefaultMaxBodySize = 10 << 20
+
+// ErrBodyTooLarge is returned when reading a response larger than the maximum body size.
+var ErrBodyTooLarge = &platform.Error{
+	Code: platform.EInvalid,
+	Msg:  "scraper target response exceeds the maximum body size",
+}
+
+// client requests the metrics of scraper targets. It keeps an http client per target,
+// which is rebuilt when the TLS or authentication configuration of the target changes.
+type client struct {
+	// Secrets loads the credentials and private keys of targets.
+	Secrets SecretLoader
+	// MaxBodySize is the maximum size of a response, DefaultMaxBodySize if zero.
+	MaxBodySize int64
+
+	mu      sync.Mutex
+	clients map[platform.ID]*targetClient
+}
+
+// targetClient is the http client of a target, and the configuration it was built from.
+type targetClient struct {
+	tls  platform.ScraperTLSConfig
+	auth platform.ScraperAuth
+	key  string
+
+	client   *http.Client
+	lastUsed time.Time
+}
+
+// get requests the metrics of target, accepting the given content types.
+// An error is returned if the target does not respond with a 2xx status code,
+// and when reading more than the maximum body size from the response.
+func (c *client) get(ctx context.Context, target platform.ScraperTarget, accept string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	client, err := c.httpClient(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status from scraper target: %s", resp.Status)
+	}
+
+	max := c.MaxBodySize
+	if max <= 0 {
+		max = DefaultMaxBodySize
+	}
+	resp.Body = &limitedBody{
+		Reader: io.LimitReader(resp.Body, max+1),
+		Closer: resp.Body,
+		max:    max,
+	}
+	return resp, nil
+}
+
+// limitedBody is a response body failing with ErrBodyTooLarge
+// once more than max bytes are read.
+type limitedBody struct {
+	io.Reader // limited to max+1 bytes, to detect larger bodies
+	io.Closer
+
+	max  int64
+	read int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.read += int64(n)
+	if b.read > b.max {
+		return n - int(b.read-b.max), ErrBodyTooLarge
+	}
+	return n, err
+}
+
+// loadSecret loads the secret of target for key.
+func (c *client) loadSecret(ctx context.Context, target platform.ScraperTarget, key string) (string, error) {
+	if c.Secrets == nil {
+		return "", fmt.Errorf("unable to load secret %q: no secret loader configured", key)
+	}
+	secret, err := c.Secrets.LoadSecret(ctx, target, key)
+	if err != nil {
+		return "", fmt.Errorf("unable to load secret %q: %v", key, err)
+	}
+	return secret, nil
+}
+
+// newRequest creates the GET request to scrape target,
+// authenticated as configured by the target.
+func (c *client) newRequest(ctx context.Context, target platform.ScraperTarget) (*http.Request, error) {
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if target.Auth == nil {
+		return req, nil
+	}
+	secret, err := c.loadSecret(ctx, target, target.Auth.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch target.Auth.Type {
+	case platform.BearerScraperAuth:
+		req.Header.Set("Authorization", "Bearer "+secret)
+	case platform.BasicScraperAuth:
+		req.SetBasicAuth(target.Auth.Username, secret)
+	default:
+		return nil, fmt.Errorf("unsupported scraper auth type: %q", target.Auth.Type)
+	}
+	return req, nil
+}
+
+// httpClient returns the http client used to scrape target, reusing the client
+// of its previous scrape unless the configuration of the target changed.
+func (c *client) httpClient(ctx context.Context, target platform.ScraperTarget) (*http.Client, error) {
+	if target.TLS == nil {
+		return http.DefaultClient, nil
+	}
+
+	var key string
+	if target.TLS.KeySecretKey != "" {
+		var err error
+		if key, err = c.loadSecret(ctx, target, target.TLS.KeySecretKey); err != nil {
+			return nil, err
+		}
+	}
+	tc := &targetClient{tls: *target.TLS, key: key, lastUsed: time.Now()}
+	if target.Auth != nil {
+		tc.auth = *target.Auth
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if prev, ok := c.clients[target.ID]; ok {
+		if prev.tls == tc.tls && prev.auth == tc.auth && prev.key == tc.key {
+			prev.lastUsed = tc.lastUsed
+			return prev.client, nil
+		}
+		closeIdleConnections(prev.client)
+	}
+
+	config, err := newTLSConfig(&tc.tls, key)
+	if err != nil {
+		return nil, err
+	}
+	tc.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+		},
+	}
+
+	if c.clients == nil {
+		c.clients = make(map[platform.ID]*targetClient)
+	}
+	c.clients[target.ID] = tc
+	c.evictIdle(tc.lastUsed)
+	return tc.client, nil
+}
+
+// evictIdle removes the clients of the targets that were not scraped since
+// clientIdleTimeout, such as removed targets. c.mu must be held.
+func (c *client) evictIdle(now time.Time) {
+	for id, tc := range c.clients {
+		if now.Sub(tc.lastUsed) > clientIdleTimeout {
+			closeIdleConnections(tc.client)
+			delete(c.clients, id)
+		}
+	}
+}
+
+func closeIdleConnections(c *http.Client) {
+	if t, ok := c.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// newTLSConfig creates the TLS configuration of c, whose client certificate has the PEM encoded private key.
+func newTLSConfig(c *platform.ScraperTLSConfig, key string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CA)) {
+			return nil, fmt.Errorf("unable to parse CA certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	if c.Cert != "" {
+		cert, err := tls.X509KeyPair([]byte(c.Cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
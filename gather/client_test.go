@@ -0,0 +1,138 @@
+package gather
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClient_TLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("cpu value=1"))
+	}))
+	defer ts.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+	target := platform.ScraperTarget{
+		ID:  platform.ID(1),
+		URL: ts.URL,
+		TLS: &platform.ScraperTLSConfig{CA: ca},
+	}
+
+	var c client
+	resp, err := c.get(context.Background(), target, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// An unknown authority fails verification.
+	target.TLS = &platform.ScraperTLSConfig{}
+	if _, err := c.get(context.Background(), target, ""); err == nil {
+		t.Fatal("expected the certificate of the target to be rejected")
+	}
+}
+
+func TestClient_Reuse(t *testing.T) {
+	target := platform.ScraperTarget{
+		ID:  platform.ID(1),
+		TLS: &platform.ScraperTLSConfig{InsecureSkipVerify: true},
+	}
+
+	var c client
+	first, err := c.httpClient(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.httpClient(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("expected the client of the target to be reused")
+	}
+
+	target.TLS = &platform.ScraperTLSConfig{ServerName: "example.com"}
+	third, err := c.httpClient(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == second {
+		t.Fatal("expected a new client after the TLS configuration changed")
+	}
+
+	target.Auth = &platform.ScraperAuth{Type: platform.BearerScraperAuth, SecretKey: "token"}
+	fourth, err := c.httpClient(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fourth == third {
+		t.Fatal("expected a new client after the authentication changed")
+	}
+
+	other := platform.ScraperTarget{ID: platform.ID(2), TLS: target.TLS, Auth: target.Auth}
+	if got, err := c.httpClient(context.Background(), other); err != nil {
+		t.Fatal(err)
+	} else if got == fourth {
+		t.Fatal("expected each target to have its own client")
+	}
+}
+
+func TestClient_MaxBodySize(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), 100)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		max     int64
+		wantErr bool
+	}{
+		{max: 99, wantErr: true},
+		{max: 100},
+		{max: 0},
+	} {
+		c := client{MaxBodySize: tt.max}
+		resp, err := c.get(context.Background(), platform.ScraperTarget{URL: ts.URL}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if tt.wantErr {
+			if err != ErrBodyTooLarge {
+				t.Fatalf("max %d: expected ErrBodyTooLarge, got %v", tt.max, err)
+			}
+			if len(got) != int(tt.max) {
+				t.Fatalf("max %d: read %d bytes", tt.max, len(got))
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("max %d: unexpected error: %v", tt.max, err)
+		}
+		if !bytes.Equal(got, body) {
+			t.Fatalf("max %d: unexpected body %q", tt.max, got)
+		}
+	}
+}
+
+func TestLineProtocolScraper_MaxBodySize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("cpu value=1\ncpu value=2\n"))
+	}))
+	defer ts.Close()
+
+	s := &lineProtocolScraper{client: client{MaxBodySize: 12}}
+	if _, err := s.Gather(context.Background(), platform.ScraperTarget{URL: ts.URL}); err != ErrBodyTooLarge {
+		t.Fatalf("expected ErrBodyTooLarge, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/nats"
@@ -29,15 +30,45 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	ctx := context.Background()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	ms, err := h.Scraper.Gather(ctx, *req)
+	if err == nil {
+		ms, err = Relabel(ms, req.RelabelRules)
+	}
+	health := &platform.ScraperTargetHealth{
+		Up:                 err == nil,
+		LastScrape:         start,
+		LastScrapeDuration: time.Since(start),
+	}
 	if err != nil {
 		h.Logger.Error("unable to gather", zap.Error(err))
-		return
+		health.LastError = err.Error()
+		ms = nil
+	}
+	for _, m := range ms {
+		health.SampleCount += len(m.Fields)
+	}
+
+	// a failed scrape is still published, so its health is recorded.
+	collected := MetricsCollection{
+		OrgName:      req.OrgName,
+		BucketName:   req.BucketName,
+		MetricsSlice: ms,
+		TargetID:     req.ID,
+		TargetName:   req.Name,
+		Health:       health,
 	}
 
 	// send metrics to storage queue
 	buf := new(bytes.Buffer)
-	if err := json.NewEncoder(buf).Encode(ms); err != nil {
+	if err := json.NewEncoder(buf).Encode(collected); err != nil {
 		h.Logger.Error("unable to marshal json", zap.Error(err))
 		return
 	}
@@ -0,0 +1,186 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// jsonScraper maps the values of a JSON document to metrics.
+// implements Scraper interfaces.
+type jsonScraper struct {
+	// client requests the metrics of targets, loading their credentials from its Secrets.
+	client
+}
+
+// Gather maps the JSON document served by the scraper target url
+// to metrics, as configured by the JSON mapping of the target.
+func (p *jsonScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	if target.JSONMapping == nil {
+		return ms, fmt.Errorf("json scraper target %q has no json mapping", target.Name)
+	}
+
+	resp, err := p.get(ctx, target, "application/json")
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	return p.parse(resp.Body, target.JSONMapping, time.Now())
+}
+
+func (p *jsonScraper) parse(r io.Reader, mapping *platform.ScraperJSONMapping, now time.Time) ([]Metrics, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("reading json failed: %s", err)
+	}
+
+	v, ok := lookupJSONPath(doc, mapping.Path)
+	if !ok {
+		return nil, fmt.Errorf("json path %q not found", mapping.Path)
+	}
+	objs, ok := v.([]interface{})
+	if !ok {
+		objs = []interface{}{v}
+	}
+
+	ms := make([]Metrics, 0, len(objs))
+	for _, obj := range objs {
+		m := Metrics{
+			Name:      mapping.Name,
+			Tags:      make(map[string]string, len(mapping.Tags)),
+			Fields:    make(map[string]interface{}, len(mapping.Fields)),
+			Timestamp: now.UnixNano(),
+			Type:      MetricTypeUntyped,
+		}
+
+		for k, path := range mapping.Tags {
+			v, ok := lookupJSONPath(obj, path)
+			if !ok || v == nil {
+				continue
+			}
+			switch v := v.(type) {
+			case string:
+				m.Tags[k] = v
+			case json.Number:
+				m.Tags[k] = v.String()
+			case bool:
+				m.Tags[k] = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("json value at %q is not a valid tag value", path)
+			}
+		}
+
+		for k, path := range mapping.Fields {
+			v, ok := lookupJSONPath(obj, path)
+			if !ok || v == nil {
+				continue
+			}
+			switch v := v.(type) {
+			case string, bool:
+				m.Fields[k] = v
+			case json.Number:
+				f, err := v.Float64()
+				if err != nil {
+					return nil, err
+				}
+				m.Fields[k] = f
+			default:
+				return nil, fmt.Errorf("json value at %q is not a valid field value", path)
+			}
+		}
+		if len(m.Fields) == 0 {
+			continue
+		}
+
+		if mapping.TimePath != "" {
+			v, ok := lookupJSONPath(obj, mapping.TimePath)
+			if !ok {
+				return nil, fmt.Errorf("json path %q not found", mapping.TimePath)
+			}
+			ts, err := parseJSONTime(v, mapping.TimeFormat)
+			if err != nil {
+				return nil, err
+			}
+			m.Timestamp = ts.UnixNano()
+		}
+
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// lookupJSONPath returns the value at the dot separated path in v.
+func lookupJSONPath(v interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = vv[key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(vv) {
+				return nil, false
+			}
+			v = vv[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func parseJSONTime(v interface{}, format string) (time.Time, error) {
+	if format == "" || format == "rfc3339" {
+		s, ok := v.(string)
+		if !ok {
+			return time.Time{}, fmt.Errorf("json time %v is not a string", v)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	}
+
+	var unit time.Duration
+	switch format {
+	case "unix":
+		unit = time.Second
+	case "unix_ms":
+		unit = time.Millisecond
+	case "unix_us":
+		unit = time.Microsecond
+	case "unix_ns":
+		unit = time.Nanosecond
+	default:
+		return time.Time{}, fmt.Errorf("unsupported json time format: %q", format)
+	}
+
+	var n json.Number
+	switch v := v.(type) {
+	case json.Number:
+		n = v
+	case string:
+		n = json.Number(v)
+	default:
+		return time.Time{}, fmt.Errorf("json time %v is not a number", v)
+	}
+	if i, err := n.Int64(); err == nil {
+		return time.Unix(0, i*int64(unit)), nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("json time %v is not a number", v)
+	}
+	return time.Unix(0, int64(f*float64(unit))), nil
+}
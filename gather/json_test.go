@@ -0,0 +1,118 @@
+package gather
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestJSONScraper_parse(t *testing.T) {
+	now := time.Unix(1536716240, 0)
+
+	cases := []struct {
+		name    string
+		input   string
+		mapping platform.ScraperJSONMapping
+		ms      []Metrics
+		hasErr  bool
+	}{
+		{
+			name:  "array of objects",
+			input: `{"data": {"hosts": [{"name": "a", "load": [0.5, 0.25], "up": true, "ts": 1536716230}, {"name": "b", "load": [1, 2], "up": false, "ts": 1536716231}]}}`,
+			mapping: platform.ScraperJSONMapping{
+				Name:       "system",
+				Path:       "data.hosts",
+				Tags:       map[string]string{"host": "name"},
+				Fields:     map[string]string{"load1": "load.0", "up": "up"},
+				TimePath:   "ts",
+				TimeFormat: "unix",
+			},
+			ms: []Metrics{
+				{
+					Name:      "system",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"host": "a"},
+					Fields:    map[string]interface{}{"load1": 0.5, "up": true},
+					Timestamp: time.Unix(1536716230, 0).UnixNano(),
+				},
+				{
+					Name:      "system",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"host": "b"},
+					Fields:    map[string]interface{}{"load1": float64(1), "up": false},
+					Timestamp: time.Unix(1536716231, 0).UnixNano(),
+				},
+			},
+		},
+		{
+			name:  "single object",
+			input: `{"status": "ok", "queue": {"depth": 12}, "time": "2018-09-12T01:37:20Z"}`,
+			mapping: platform.ScraperJSONMapping{
+				Name:     "queue",
+				Tags:     map[string]string{"status": "status", "missing": "nope"},
+				Fields:   map[string]string{"depth": "queue.depth"},
+				TimePath: "time",
+			},
+			ms: []Metrics{
+				{
+					Name:      "queue",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"status": "ok"},
+					Fields:    map[string]interface{}{"depth": float64(12)},
+					Timestamp: time.Date(2018, 9, 12, 1, 37, 20, 0, time.UTC).UnixNano(),
+				},
+			},
+		},
+		{
+			name:  "default time",
+			input: `[{"v": 1}]`,
+			mapping: platform.ScraperJSONMapping{
+				Name:   "m",
+				Fields: map[string]string{"v": "v"},
+			},
+			ms: []Metrics{
+				{
+					Name:      "m",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{},
+					Fields:    map[string]interface{}{"v": float64(1)},
+					Timestamp: now.UnixNano(),
+				},
+			},
+		},
+		{
+			name:  "missing path",
+			input: `{"data": []}`,
+			mapping: platform.ScraperJSONMapping{
+				Name:   "m",
+				Path:   "items",
+				Fields: map[string]string{"v": "v"},
+			},
+			hasErr: true,
+		},
+		{
+			name:  "object field",
+			input: `{"v": {"a": 1}}`,
+			mapping: platform.ScraperJSONMapping{
+				Name:   "m",
+				Fields: map[string]string{"v": "v"},
+			},
+			hasErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ms, err := new(jsonScraper).parse(strings.NewReader(c.input), &c.mapping, now)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(ms, c.ms); diff != "" {
+				t.Errorf("parsed metrics are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package gather
+
+import (
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+)
+
+// lineProtocolScraper handles parsing points from an endpoint serving line protocol.
+// implements Scraper interfaces.
+type lineProtocolScraper struct {
+	// client requests the metrics of targets, loading their credentials from its Secrets.
+	client
+}
+
+// Gather parses the points served by the scraper target url.
+// Points without a timestamp get the time of the scrape.
+func (p *lineProtocolScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	resp, err := p.get(ctx, target, "text/plain")
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ms, err
+	}
+	return p.parse(data, time.Now())
+}
+
+func (p *lineProtocolScraper) parse(data []byte, now time.Time) ([]Metrics, error) {
+	points, err := models.ParsePointsWithPrecision(data, now, "n")
+	if err != nil {
+		return nil, err
+	}
+
+	ms := make([]Metrics, 0, len(points))
+	for _, pt := range points {
+		fields, err := pt.Fields()
+		if err != nil {
+			return nil, err
+		}
+		tags := make(map[string]string, len(pt.Tags()))
+		for _, t := range pt.Tags() {
+			tags[string(t.Key)] = string(t.Value)
+		}
+		ms = append(ms, Metrics{
+			Name:      string(pt.Name()),
+			Tags:      tags,
+			Fields:    fields,
+			Timestamp: pt.UnixNano(),
+			Type:      MetricTypeUntyped,
+		})
+	}
+	return ms, nil
+}
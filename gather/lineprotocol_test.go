@@ -0,0 +1,57 @@
+package gather
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLineProtocolScraper_parse(t *testing.T) {
+	now := time.Unix(1536716240, 0)
+
+	cases := []struct {
+		name   string
+		input  string
+		ms     []Metrics
+		hasErr bool
+	}{
+		{
+			name:  "points",
+			input: "cpu,host=a usage_idle=98.5,cores=8i 1536716230000000000\n# comment\nmem,host=a used=true\n",
+			ms: []Metrics{
+				{
+					Name:      "cpu",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"host": "a"},
+					Fields:    map[string]interface{}{"usage_idle": 98.5, "cores": int64(8)},
+					Timestamp: 1536716230000000000,
+				},
+				{
+					Name:      "mem",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{"host": "a"},
+					Fields:    map[string]interface{}{"used": true},
+					Timestamp: now.UnixNano(),
+				},
+			},
+		},
+		{
+			name:   "invalid",
+			input:  "cpu,host=a\n",
+			hasErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ms, err := new(lineProtocolScraper).parse([]byte(c.input), now)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(ms, c.ms); diff != "" {
+				t.Errorf("parsed metrics are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -1,9 +1,68 @@
 package gather
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
 )
 
+// MetricsCollection is the collection of metrics gathered from a scraper target,
+// along with the organization and bucket they are to be written to.
+type MetricsCollection struct {
+	OrgName      string       `json:"org"`
+	BucketName   string       `json:"bucket"`
+	MetricsSlice MetricsSlice `json:"metrics"`
+
+	TargetID   platform.ID `json:"targetID,omitempty"`
+	TargetName string      `json:"targetName,omitempty"`
+	// Health is the result of the scrape. The metrics are empty if it failed.
+	Health *platform.ScraperTargetHealth `json:"health,omitempty"`
+}
+
+// HealthMetrics returns the synthetic metrics describing the health of the scrape:
+// up, scrape_duration_seconds and scrape_samples_scraped, tagged with the target.
+func (c MetricsCollection) HealthMetrics() MetricsSlice {
+	if c.Health == nil {
+		return nil
+	}
+
+	up := float64(0)
+	if c.Health.Up {
+		up = 1
+	}
+	ts := c.Health.LastScrape.UnixNano()
+	values := []struct {
+		name  string
+		value float64
+	}{
+		{name: "up", value: up},
+		{name: "scrape_duration_seconds", value: c.Health.LastScrapeDuration.Seconds()},
+		{name: "scrape_samples_scraped", value: float64(c.Health.SampleCount)},
+	}
+
+	ms := make(MetricsSlice, 0, len(values))
+	for _, v := range values {
+		tags := map[string]string{"target": c.TargetName}
+		if c.TargetID.Valid() {
+			tags["target_id"] = c.TargetID.String()
+		}
+		ms = append(ms, Metrics{
+			Name:      v.name,
+			Tags:      tags,
+			Fields:    map[string]interface{}{"gauge": v.value},
+			Timestamp: ts,
+			Type:      MetricTypeGauge,
+		})
+	}
+	return ms
+}
+
 // Metrics is the default influx based metrics.
 type Metrics struct {
 	Name      string                 `json:"name"`
@@ -13,6 +72,93 @@ type Metrics struct {
 	Type      MetricType             `json:"type"`
 }
 
+// The types of the integer fields of metrics encoded as JSON, whose numbers
+// are otherwise decoded as floats.
+const (
+	integerFieldType  = "integer"
+	unsignedFieldType = "unsigned"
+)
+
+// MarshalJSON encodes the metrics along with the types of their integer
+// fields, so that they are decoded as integers rather than floats.
+func (m Metrics) MarshalJSON() ([]byte, error) {
+	type metrics Metrics
+	var types map[string]string
+	for k, v := range m.Fields {
+		var typ string
+		switch v.(type) {
+		case int, int64:
+			typ = integerFieldType
+		case uint64:
+			typ = unsignedFieldType
+		default:
+			continue
+		}
+		if types == nil {
+			types = make(map[string]string)
+		}
+		types[k] = typ
+	}
+	return json.Marshal(struct {
+		metrics
+		FieldTypes map[string]string `json:"fieldTypes,omitempty"`
+	}{metrics(m), types})
+}
+
+// UnmarshalJSON decodes the metrics, keeping the type of their integer fields
+// and the precision of their values.
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	type metrics Metrics
+	var v struct {
+		metrics
+		FieldTypes map[string]string `json:"fieldTypes"`
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+
+	for k, f := range v.Fields {
+		n, ok := f.(json.Number)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch v.FieldTypes[k] {
+		case integerFieldType:
+			v.Fields[k], err = n.Int64()
+		case unsignedFieldType:
+			v.Fields[k], err = strconv.ParseUint(n.String(), 10, 64)
+		default:
+			v.Fields[k], err = n.Float64()
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value of field %q: %v", k, err)
+		}
+	}
+
+	*m = Metrics(v.metrics)
+	return nil
+}
+
+// MetricsSlice is a slice of Metrics.
+type MetricsSlice []Metrics
+
+// Points converts the metrics into points.
+func (ms MetricsSlice) Points() (models.Points, error) {
+	ps := make([]models.Point, 0, len(ms))
+	for _, m := range ms {
+		pt, err := models.NewPoint(m.Name, models.NewTags(m.Tags), m.Fields, time.Unix(0, m.Timestamp))
+		if err != nil {
+			return ps, err
+		}
+		ps = append(ps, pt)
+	}
+	return ps, nil
+}
+
 // MetricType is prometheus metrics type.
 type MetricType int
 
@@ -3,8 +3,11 @@ package gather
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
 )
 
 func TestMetrics(t *testing.T) {
@@ -82,3 +85,98 @@ func TestMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricsCollection_HealthMetrics(t *testing.T) {
+	last := time.Unix(1536716240, 0)
+	id := platformtesting.MustIDBase16("020f755c3c082000")
+
+	cases := []struct {
+		name      string
+		collected MetricsCollection
+		ms        MetricsSlice
+	}{
+		{
+			name:      "without health",
+			collected: MetricsCollection{TargetID: id, TargetName: "t1"},
+		},
+		{
+			name: "failed scrape",
+			collected: MetricsCollection{
+				TargetID:   id,
+				TargetName: "t1",
+				Health: &platform.ScraperTargetHealth{
+					LastScrape:         last,
+					LastScrapeDuration: 250 * time.Millisecond,
+					LastError:          "connection refused",
+				},
+			},
+			ms: MetricsSlice{
+				{
+					Name:      "up",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": float64(0)},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+				{
+					Name:      "scrape_duration_seconds",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": 0.25},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+				{
+					Name:      "scrape_samples_scraped",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": float64(0)},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+			},
+		},
+		{
+			name: "successful scrape",
+			collected: MetricsCollection{
+				TargetID:   id,
+				TargetName: "t1",
+				Health: &platform.ScraperTargetHealth{
+					Up:                 true,
+					LastScrape:         last,
+					LastScrapeDuration: time.Second,
+					SampleCount:        12,
+				},
+			},
+			ms: MetricsSlice{
+				{
+					Name:      "up",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": float64(1)},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+				{
+					Name:      "scrape_duration_seconds",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": float64(1)},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+				{
+					Name:      "scrape_samples_scraped",
+					Tags:      map[string]string{"target": "t1", "target_id": id.String()},
+					Fields:    map[string]interface{}{"gauge": float64(12)},
+					Timestamp: last.UnixNano(),
+					Type:      MetricTypeGauge,
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if diff := cmp.Diff(c.collected.HealthMetrics(), c.ms); diff != "" {
+				t.Errorf("health metrics are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package gather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+const openMetricsAccept = "application/openmetrics-text; version=0.0.1"
+
+// openMetricsScraper handles parsing metrics in the OpenMetrics text format.
+// implements Scraper interfaces.
+type openMetricsScraper struct {
+	// client requests the metrics of targets, loading their credentials from its Secrets.
+	client
+}
+
+// Gather parses metrics from a scraper target url.
+func (p *openMetricsScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	resp, err := p.get(ctx, target, openMetricsAccept)
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	return p.parse(resp.Body, time.Now())
+}
+
+// openMetricsFamily is the metadata of the metric family being parsed.
+type openMetricsFamily struct {
+	name string
+	typ  string
+	unit string
+}
+
+// field returns the field key of a sample of the family,
+// or false if the sample does not belong to the family.
+func (f *openMetricsFamily) field(name string, labels map[string]string) (string, bool) {
+	if !strings.HasPrefix(name, f.name) {
+		return "", false
+	}
+	suffix := name[len(f.name):]
+
+	switch f.typ {
+	case "counter":
+		switch suffix {
+		case "_total":
+			return "counter", true
+		case "_created":
+			return "created", true
+		}
+	case "gauge":
+		if suffix == "" {
+			return "gauge", true
+		}
+	case "histogram", "gaugehistogram":
+		switch suffix {
+		case "_bucket":
+			le, err := strconv.ParseFloat(labels["le"], 64)
+			if err != nil {
+				return "", false
+			}
+			return fmt.Sprint(le), true
+		case "_count", "_gcount":
+			return "count", true
+		case "_sum", "_gsum":
+			return "sum", true
+		case "_created":
+			return "created", true
+		}
+	case "summary":
+		switch suffix {
+		case "":
+			q, err := strconv.ParseFloat(labels["quantile"], 64)
+			if err != nil {
+				return "", false
+			}
+			return fmt.Sprint(q), true
+		case "_count":
+			return "count", true
+		case "_sum":
+			return "sum", true
+		case "_created":
+			return "created", true
+		}
+	case "info":
+		if suffix == "_info" {
+			return "info", true
+		}
+	case "stateset":
+		if suffix == "" {
+			state, ok := labels[f.name]
+			return state, ok
+		}
+	default:
+		if suffix == "" {
+			return "value", true
+		}
+	}
+	return "", false
+}
+
+// tags returns the tags of a sample of the family.
+// Labels identifying the field of the sample are removed.
+func (f *openMetricsFamily) tags(labels map[string]string) map[string]string {
+	tags := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		tags[k] = v
+	}
+	switch f.typ {
+	case "histogram", "gaugehistogram":
+		delete(tags, "le")
+	case "summary":
+		delete(tags, "quantile")
+	case "stateset":
+		delete(tags, f.name)
+	}
+	if _, ok := tags["unit"]; !ok && f.unit != "" {
+		tags["unit"] = f.unit
+	}
+	return tags
+}
+
+func (f *openMetricsFamily) metricType() MetricType {
+	switch f.typ {
+	case "counter":
+		return MetricTypeCounter
+	case "gauge", "info", "stateset":
+		return MetricTypeGauge
+	case "histogram", "gaugehistogram":
+		return MetricTypeHistogrm
+	case "summary":
+		return MetricTypeSummary
+	default:
+		return MetricTypeUntyped
+	}
+}
+
+// parse reads the OpenMetrics text format.
+// Samples of the same metric and time are gathered as the fields of a single metric.
+// Exemplars are kept as fields suffixed by _exemplar, and units as the unit tag.
+func (p *openMetricsScraper) parse(r io.Reader, now time.Time) ([]Metrics, error) {
+	var (
+		ms     []Metrics
+		index  = make(map[string]int)
+		family *openMetricsFamily
+		eof    bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := scanner.Text()
+		if eof && line != "" {
+			return nil, fmt.Errorf("line %d: unexpected content after # EOF", lineno)
+		}
+		if line == "# EOF" {
+			eof = true
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "# ") {
+			parts := strings.SplitN(line[2:], " ", 3)
+			if len(parts) < 2 {
+				return nil, fmt.Errorf("line %d: invalid metadata %q", lineno, line)
+			}
+			if family == nil || family.name != parts[1] {
+				family = &openMetricsFamily{name: parts[1], typ: "unknown"}
+			}
+			if len(parts) < 3 {
+				continue
+			}
+			switch parts[0] {
+			case "TYPE":
+				family.typ = parts[2]
+			case "UNIT":
+				family.unit = parts[2]
+			}
+			continue
+		}
+
+		s, err := parseOpenMetricsSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+
+		if family == nil {
+			family = &openMetricsFamily{name: s.name, typ: "unknown"}
+		}
+		key, ok := family.field(s.name, s.labels)
+		if !ok {
+			// A sample without metadata is a family of unknown type.
+			family = &openMetricsFamily{name: s.name, typ: "unknown"}
+			key, _ = family.field(s.name, s.labels)
+		}
+		if math.IsNaN(s.value) {
+			continue
+		}
+
+		ts := now.UnixNano()
+		if s.timestamp != nil {
+			ts = *s.timestamp
+		}
+		tags := family.tags(s.labels)
+		id := metricID(family.name, tags, ts)
+		i, ok := index[id]
+		if !ok {
+			i = len(ms)
+			index[id] = i
+			ms = append(ms, Metrics{
+				Name:      family.name,
+				Tags:      tags,
+				Fields:    make(map[string]interface{}),
+				Timestamp: ts,
+				Type:      family.metricType(),
+			})
+		}
+
+		fields := ms[i].Fields
+		fields[key] = s.value
+		if s.exemplar != nil {
+			fields[key+"_exemplar"] = s.exemplar.value
+			for k, v := range s.exemplar.labels {
+				fields[key+"_exemplar_"+k] = v
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !eof {
+		return nil, fmt.Errorf("reading openmetrics failed: missing # EOF")
+	}
+
+	if ms == nil {
+		ms = make([]Metrics, 0)
+	}
+	return ms, nil
+}
+
+// metricID identifies the metric of a family, label set and time.
+func metricID(name string, tags map[string]string, ts int64) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(tags[k])
+	}
+	b.WriteByte(0)
+	b.WriteString(strconv.FormatInt(ts, 10))
+	return b.String()
+}
+
+type openMetricsExemplar struct {
+	labels map[string]string
+	value  float64
+}
+
+type openMetricsSample struct {
+	name      string
+	labels    map[string]string
+	value     float64
+	timestamp *int64
+	exemplar  *openMetricsExemplar
+}
+
+// parseOpenMetricsSample parses a sample line:
+//
+//	name{label="value",...} value [timestamp] [# {label="value",...} value [timestamp]]
+func parseOpenMetricsSample(line string) (*openMetricsSample, error) {
+	s := &openMetricsSample{labels: map[string]string{}}
+
+	i := strings.IndexAny(line, "{ ")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid sample %q", line)
+	}
+	s.name, line = line[:i], line[i:]
+
+	var err error
+	if strings.HasPrefix(line, "{") {
+		if s.labels, line, err = parseOpenMetricsLabels(line); err != nil {
+			return nil, err
+		}
+	}
+
+	var exemplar string
+	if i := strings.Index(line, " # "); i >= 0 {
+		line, exemplar = line[:i], line[i+3:]
+	}
+
+	parts := strings.Fields(line)
+	if len(parts) < 1 || len(parts) > 2 {
+		return nil, fmt.Errorf("invalid sample value %q", line)
+	}
+	if s.value, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return nil, fmt.Errorf("invalid sample value %q", parts[0])
+	}
+	if len(parts) == 2 {
+		ts, err := parseOpenMetricsTimestamp(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		s.timestamp = &ts
+	}
+
+	if exemplar != "" {
+		e := &openMetricsExemplar{}
+		if e.labels, exemplar, err = parseOpenMetricsLabels(exemplar); err != nil {
+			return nil, err
+		}
+		parts := strings.Fields(exemplar)
+		if len(parts) < 1 || len(parts) > 2 {
+			return nil, fmt.Errorf("invalid exemplar value %q", exemplar)
+		}
+		if e.value, err = strconv.ParseFloat(parts[0], 64); err != nil {
+			return nil, fmt.Errorf("invalid exemplar value %q", parts[0])
+		}
+		s.exemplar = e
+	}
+
+	return s, nil
+}
+
+// parseOpenMetricsLabels parses the label set at the start of s,
+// and returns the remainder of s.
+func parseOpenMetricsLabels(s string) (map[string]string, string, error) {
+	if !strings.HasPrefix(s, "{") {
+		return nil, s, fmt.Errorf("invalid label set %q", s)
+	}
+	labels := make(map[string]string)
+	s = s[1:]
+	for {
+		if strings.HasPrefix(s, "}") {
+			return labels, s[1:], nil
+		}
+
+		i := strings.Index(s, "=\"")
+		if i <= 0 {
+			return nil, s, fmt.Errorf("invalid label %q", s)
+		}
+		name := s[:i]
+		s = s[i+2:]
+
+		var value strings.Builder
+		for {
+			if s == "" {
+				return nil, s, fmt.Errorf("unterminated value of label %q", name)
+			}
+			c := s[0]
+			s = s[1:]
+			if c == '"' {
+				break
+			}
+			if c == '\\' && s != "" {
+				switch s[0] {
+				case 'n':
+					c = '\n'
+				default:
+					c = s[0]
+				}
+				s = s[1:]
+			}
+			value.WriteByte(c)
+		}
+		labels[name] = value.String()
+
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		}
+	}
+}
+
+// parseOpenMetricsTimestamp parses a timestamp in seconds, returning unix nanoseconds.
+func parseOpenMetricsTimestamp(s string) (int64, error) {
+	if i := strings.IndexByte(s, '.'); i < 0 {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		return sec * int64(time.Second), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return int64(f * float64(time.Second)), nil
+}
@@ -0,0 +1,165 @@
+package gather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestOpenMetricsScraper_parse(t *testing.T) {
+	now := time.Unix(1536716240, 0)
+
+	cases := []struct {
+		name   string
+		input  string
+		ms     []Metrics
+		hasErr bool
+	}{
+		{
+			name:  "empty",
+			input: "# EOF\n",
+			ms:    []Metrics{},
+		},
+		{
+			name:   "missing eof",
+			input:  "# TYPE foo gauge\nfoo 1\n",
+			hasErr: true,
+		},
+		{
+			name: "counter with unit, created and exemplar",
+			input: `# TYPE http_request_duration_seconds counter
+# UNIT http_request_duration_seconds seconds
+# HELP http_request_duration_seconds Total time spent serving requests.
+http_request_duration_seconds_total{code="200"} 17.5 # {trace_id="abc123"} 0.67
+http_request_duration_seconds_created{code="200"} 1536716000
+# EOF
+`,
+			ms: []Metrics{
+				{
+					Name: "http_request_duration_seconds",
+					Type: MetricTypeCounter,
+					Tags: map[string]string{"code": "200", "unit": "seconds"},
+					Fields: map[string]interface{}{
+						"counter":                   17.5,
+						"counter_exemplar":          0.67,
+						"counter_exemplar_trace_id": "abc123",
+						"created":                   float64(1536716000),
+					},
+					Timestamp: now.UnixNano(),
+				},
+			},
+		},
+		{
+			name: "histogram with timestamp",
+			input: `# TYPE rpc_latency histogram
+rpc_latency_bucket{le="0.5"} 3 1536716250.5
+rpc_latency_bucket{le="+Inf"} 5 1536716250.5
+rpc_latency_count 5 1536716250.5
+rpc_latency_sum 2.25 1536716250.5
+# EOF
+`,
+			ms: []Metrics{
+				{
+					Name: "rpc_latency",
+					Type: MetricTypeHistogrm,
+					Tags: map[string]string{},
+					Fields: map[string]interface{}{
+						"0.5":   float64(3),
+						"+Inf":  float64(5),
+						"count": float64(5),
+						"sum":   2.25,
+					},
+					Timestamp: time.Unix(1536716250, 500000000).UnixNano(),
+				},
+			},
+		},
+		{
+			name: "stateset, info and unknown",
+			input: `# TYPE service_state stateset
+service_state{service_state="up"} 1
+service_state{service_state="down"} 0
+# TYPE build info
+build_info{version="1.2.3"} 1
+uptime_seconds 10
+# EOF
+`,
+			ms: []Metrics{
+				{
+					Name:      "service_state",
+					Type:      MetricTypeGauge,
+					Tags:      map[string]string{},
+					Fields:    map[string]interface{}{"up": float64(1), "down": float64(0)},
+					Timestamp: now.UnixNano(),
+				},
+				{
+					Name:      "build",
+					Type:      MetricTypeGauge,
+					Tags:      map[string]string{"version": "1.2.3"},
+					Fields:    map[string]interface{}{"info": float64(1)},
+					Timestamp: now.UnixNano(),
+				},
+				{
+					Name:      "uptime_seconds",
+					Type:      MetricTypeUntyped,
+					Tags:      map[string]string{},
+					Fields:    map[string]interface{}{"value": float64(10)},
+					Timestamp: now.UnixNano(),
+				},
+			},
+		},
+		{
+			name:   "invalid label set",
+			input:  "foo{bar=\"baz} 1\n# EOF\n",
+			hasErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ms, err := new(openMetricsScraper).parse(strings.NewReader(c.input), now)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(ms, c.ms); diff != "" {
+				t.Errorf("parsed metrics are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenMetricsScraper_Gather(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Accept"), "application/openmetrics-text") {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", openMetricsAccept)
+		w.Write([]byte("# TYPE go_goroutines gauge\ngo_goroutines 36\n# EOF\n"))
+	}))
+	defer ts.Close()
+
+	ms, err := new(openMetricsScraper).Gather(context.Background(), platform.ScraperTarget{
+		URL:  ts.URL + "/metrics",
+		Type: platform.OpenMetricsScraperType,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Metrics{
+		{
+			Name:   "go_goroutines",
+			Type:   MetricTypeGauge,
+			Tags:   map[string]string{},
+			Fields: map[string]interface{}{"gauge": float64(36)},
+		},
+	}
+	if diff := cmp.Diff(ms, want, metricsCmpOption); diff != "" {
+		t.Errorf("gathered metrics are different -got/+want\ndiff %s", diff)
+	}
+}
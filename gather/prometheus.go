@@ -17,11 +17,14 @@ import (
 
 // prometheusScraper handles parsing prometheus metrics.
 // implements Scraper interfaces.
-type prometheusScraper struct{}
+type prometheusScraper struct {
+	// client requests the metrics of targets, loading their credentials from its Secrets.
+	client
+}
 
 // Gather parse metrics from a scraper target url.
 func (p *prometheusScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
-	resp, err := http.Get(target.URL)
+	resp, err := p.get(ctx, target, "")
 	if err != nil {
 		return ms, err
 	}
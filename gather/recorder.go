@@ -0,0 +1,64 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+// PointWriter implements the Storage interface by writing
+// the gathered metrics into the storage engine.
+type PointWriter struct {
+	Writer              storage.PointsWriter
+	OrganizationService platform.OrganizationService
+	BucketService       platform.BucketService
+	// Logger, if set, logs the metrics that are skipped because they can't be
+	// written as points.
+	Logger *zap.Logger
+}
+
+// Record converts the collected metrics, and the metrics describing the
+// health of the scrape, into points and writes them into the bucket of the scraper target.
+// Metrics that can't be represented as points, such as those without fields,
+// are skipped so that they don't fail the whole collection.
+func (s *PointWriter) Record(collected MetricsCollection) error {
+	ctx := context.Background()
+
+	org, err := s.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &collected.OrgName})
+	if err != nil {
+		return fmt.Errorf("unable to find organization %q: %v", collected.OrgName, err)
+	}
+
+	bucket, err := s.BucketService.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &org.ID,
+		Name:           &collected.BucketName,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to find bucket %q: %v", collected.BucketName, err)
+	}
+
+	ms := append(collected.MetricsSlice, collected.HealthMetrics()...)
+	ps := make(models.Points, 0, len(ms))
+	for _, m := range ms {
+		p, err := MetricsSlice{m}.Points()
+		if err != nil {
+			if s.Logger != nil {
+				s.Logger.Info("skipping scraped metric", zap.String("name", m.Name), zap.Error(err))
+			}
+			continue
+		}
+		ps = append(ps, p...)
+	}
+
+	exploded, err := tsdb.ExplodePoints(org.ID, bucket.ID, ps)
+	if err != nil {
+		return err
+	}
+
+	return s.Writer.WritePoints(exploded)
+}
@@ -0,0 +1,120 @@
+package gather
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestPointWriter_Record(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	bucketID := platformtesting.MustIDBase16("020f755c3c082001")
+
+	orgSvc := &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+			if filter.Name == nil || *filter.Name != "org1" {
+				return nil, &platform.Error{Code: platform.ENotFound, Msg: "organization not found"}
+			}
+			return &platform.Organization{ID: orgID, Name: *filter.Name}, nil
+		},
+	}
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		if filter.Name == nil || *filter.Name != "bucket1" || filter.OrganizationID == nil || *filter.OrganizationID != orgID {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+		}
+		return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: *filter.Name}, nil
+	}
+
+	ms := MetricsSlice{
+		{
+			Name:      "go_goroutines",
+			Tags:      map[string]string{"host": "a"},
+			Fields:    map[string]interface{}{"gauge": float64(36)},
+			Timestamp: 1536716240000000000,
+			Type:      MetricTypeGauge,
+		},
+		{
+			Name:      "http_requests_total",
+			Tags:      map[string]string{"code": "200", "host": "a"},
+			Fields:    map[string]interface{}{"counter": float64(1027)},
+			Timestamp: 1536716240000000000,
+			Type:      MetricTypeCounter,
+		},
+	}
+
+	t.Run("writes to target bucket", func(t *testing.T) {
+		pw := new(mock.PointsWriter)
+		s := &PointWriter{Writer: pw, OrganizationService: orgSvc, BucketService: bucketSvc}
+
+		err := s.Record(MetricsCollection{OrgName: "org1", BucketName: "bucket1", MetricsSlice: ms})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ps, err := ms.Points()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exploded, err := tsdb.ExplodePoints(orgID, bucketID, ps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got, exp []string
+		for _, p := range pw.Points {
+			got = append(got, p.String())
+		}
+		for _, p := range exploded {
+			exp = append(exp, p.String())
+		}
+		if !cmp.Equal(got, exp) {
+			t.Errorf("unexpected points; -got/+exp\n%s", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("skips invalid metrics", func(t *testing.T) {
+		pw := new(mock.PointsWriter)
+		s := &PointWriter{Writer: pw, OrganizationService: orgSvc, BucketService: bucketSvc}
+
+		invalid := MetricsSlice{
+			{Name: "no_fields", Fields: map[string]interface{}{}, Timestamp: 1536716240000000000},
+			{Name: "infinite", Fields: map[string]interface{}{"gauge": math.Inf(1)}, Timestamp: 1536716240000000000},
+		}
+		err := s.Record(MetricsCollection{OrgName: "org1", BucketName: "bucket1", MetricsSlice: append(invalid, ms...)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ps, err := ms.Points()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exploded, err := tsdb.ExplodePoints(orgID, bucketID, ps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, exp := len(pw.Points), len(exploded); got != exp {
+			t.Errorf("unexpected number of points written: got %d, exp %d", got, exp)
+		}
+	})
+
+	t.Run("unknown bucket", func(t *testing.T) {
+		pw := new(mock.PointsWriter)
+		s := &PointWriter{Writer: pw, OrganizationService: orgSvc, BucketService: bucketSvc}
+
+		err := s.Record(MetricsCollection{OrgName: "org1", BucketName: "missing", MetricsSlice: ms})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if len(pw.Points) != 0 {
+			t.Errorf("expected no points to be written, got %d", len(pw.Points))
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package gather
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform"
+)
+
+// relabeler applies relabel rules to metrics.
+type relabeler struct {
+	rules []platform.RelabelRule
+	res   []*regexp.Regexp
+}
+
+func newRelabeler(rules []platform.RelabelRule) (*relabeler, error) {
+	r := &relabeler{
+		rules: rules,
+		res:   make([]*regexp.Regexp, len(rules)),
+	}
+	for i, rule := range rules {
+		if err := rule.Valid(); err != nil {
+			return nil, err
+		}
+		re, err := rule.CompileRegex()
+		if err != nil {
+			return nil, err
+		}
+		r.res[i] = re
+	}
+	return r, nil
+}
+
+// Relabel applies the relabel rules in order to each of the metrics.
+// Metrics dropped by a rule are not returned.
+func Relabel(ms []Metrics, rules []platform.RelabelRule) ([]Metrics, error) {
+	if len(rules) == 0 {
+		return ms, nil
+	}
+
+	r, err := newRelabeler(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	out := ms[:0]
+	for _, m := range ms {
+		if r.relabel(&m) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// relabel rewrites the name and tags of m, and returns false if m is dropped.
+func (r *relabeler) relabel(m *Metrics) bool {
+	labels := make(map[string]string, len(m.Tags)+1)
+	for k, v := range m.Tags {
+		labels[k] = v
+	}
+	labels[platform.MetricNameLabel] = m.Name
+
+	for i, rule := range r.rules {
+		if !applyRule(rule, r.res[i], labels) {
+			return false
+		}
+	}
+
+	name := labels[platform.MetricNameLabel]
+	if name == "" {
+		return false
+	}
+	delete(labels, platform.MetricNameLabel)
+	m.Name = name
+	m.Tags = labels
+	return true
+}
+
+func applyRule(rule platform.RelabelRule, re *regexp.Regexp, labels map[string]string) bool {
+	separator := rule.Separator
+	if separator == "" {
+		separator = platform.DefaultRelabelSeparator
+	}
+	replacement := rule.Replacement
+	if replacement == "" {
+		replacement = platform.DefaultRelabelReplacement
+	}
+
+	values := make([]string, 0, len(rule.SourceLabels))
+	for _, l := range rule.SourceLabels {
+		values = append(values, labels[l])
+	}
+	value := strings.Join(values, separator)
+
+	switch rule.EffectiveAction() {
+	case platform.RelabelKeep:
+		return re.MatchString(value)
+	case platform.RelabelDrop:
+		return !re.MatchString(value)
+	case platform.RelabelReplace:
+		indexes := re.FindStringSubmatchIndex(value)
+		if indexes == nil {
+			return true
+		}
+		target := string(re.ExpandString(nil, rule.TargetLabel, value, indexes))
+		res := string(re.ExpandString(nil, replacement, value, indexes))
+		if res == "" {
+			delete(labels, target)
+		} else {
+			labels[target] = res
+		}
+	case platform.RelabelLabelMap:
+		for _, k := range sortedLabelNames(labels) {
+			if k == platform.MetricNameLabel || !re.MatchString(k) {
+				continue
+			}
+			labels[re.ReplaceAllString(k, replacement)] = labels[k]
+		}
+	case platform.RelabelLabelDrop:
+		for k := range labels {
+			if k != platform.MetricNameLabel && re.MatchString(k) {
+				delete(labels, k)
+			}
+		}
+	case platform.RelabelLabelKeep:
+		for k := range labels {
+			if k != platform.MetricNameLabel && !re.MatchString(k) {
+				delete(labels, k)
+			}
+		}
+	}
+	return true
+}
+
+// sortedLabelNames returns the label names in order, so label maps
+// writing to the same label have a deterministic result.
+func sortedLabelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,125 @@
+package gather
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestRelabel(t *testing.T) {
+	metrics := func() []Metrics {
+		return []Metrics{
+			{
+				Name:   "go_goroutines",
+				Tags:   map[string]string{"instance": "host1:9100", "job": "node"},
+				Fields: map[string]interface{}{"gauge": float64(36)},
+				Type:   MetricTypeGauge,
+			},
+			{
+				Name:   "http_requests_total",
+				Tags:   map[string]string{"instance": "host1:9100", "code": "200"},
+				Fields: map[string]interface{}{"counter": float64(1027)},
+				Type:   MetricTypeCounter,
+			},
+		}
+	}
+
+	cases := []struct {
+		name  string
+		rules []platform.RelabelRule
+		want  []Metrics
+		err   bool
+	}{
+		{
+			name: "no rules",
+			want: metrics(),
+		},
+		{
+			name: "drop by name",
+			rules: []platform.RelabelRule{
+				{SourceLabels: []string{platform.MetricNameLabel}, Regex: "go_.*", Action: platform.RelabelDrop},
+			},
+			want: metrics()[1:],
+		},
+		{
+			name: "keep by name and label",
+			rules: []platform.RelabelRule{
+				{SourceLabels: []string{platform.MetricNameLabel, "job"}, Regex: "go_.*;node", Action: platform.RelabelKeep},
+			},
+			want: metrics()[:1],
+		},
+		{
+			name: "rename metric",
+			rules: []platform.RelabelRule{
+				{SourceLabels: []string{platform.MetricNameLabel}, Regex: "go_(.*)", TargetLabel: platform.MetricNameLabel, Replacement: "golang_$1"},
+			},
+			want: func() []Metrics {
+				ms := metrics()
+				ms[0].Name = "golang_goroutines"
+				return ms
+			}(),
+		},
+		{
+			name: "replace label",
+			rules: []platform.RelabelRule{
+				{SourceLabels: []string{"instance"}, Regex: "(.*):.*", TargetLabel: "host"},
+			},
+			want: func() []Metrics {
+				ms := metrics()
+				ms[0].Tags["host"] = "host1"
+				ms[1].Tags["host"] = "host1"
+				return ms
+			}(),
+		},
+		{
+			name: "rename label with labelmap and labeldrop",
+			rules: []platform.RelabelRule{
+				{Regex: "instance", Replacement: "endpoint", Action: platform.RelabelLabelMap},
+				{Regex: "instance", Action: platform.RelabelLabelDrop},
+			},
+			want: func() []Metrics {
+				ms := metrics()
+				for _, m := range ms {
+					m.Tags["endpoint"] = m.Tags["instance"]
+					delete(m.Tags, "instance")
+				}
+				return ms
+			}(),
+		},
+		{
+			name: "labelkeep",
+			rules: []platform.RelabelRule{
+				{Regex: "code|job", Action: platform.RelabelLabelKeep},
+			},
+			want: func() []Metrics {
+				ms := metrics()
+				delete(ms[0].Tags, "instance")
+				delete(ms[1].Tags, "instance")
+				return ms
+			}(),
+		},
+		{
+			name: "invalid regex",
+			rules: []platform.RelabelRule{
+				{SourceLabels: []string{platform.MetricNameLabel}, Regex: "go_(", Action: platform.RelabelDrop},
+			},
+			err: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Relabel(metrics(), c.rules)
+			if (err != nil) != c.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.err {
+				return
+			}
+			if diff := cmp.Diff(got, c.want); diff != "" {
+				t.Errorf("relabeled metrics are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -14,16 +14,28 @@ import (
 
 // nats subjects
 const (
-	MetricsSubject    = "metrics"
-	promTargetSubject = "promTarget"
+	MetricsSubject            = "metrics"
+	promTargetSubject         = "promTarget"
+	openMetricsTargetSubject  = "openMetricsTarget"
+	lineProtocolTargetSubject = "lineProtocolTarget"
+	jsonTargetSubject         = "jsonTarget"
 )
 
+// scheduleResolution is the maximum time between two checks for targets due to be scraped.
+const scheduleResolution = time.Second
+
+// targetsRefreshInterval is how often the targets are listed, so that the
+// changes to them are scheduled.
+const targetsRefreshInterval = 10 * time.Second
+
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets platform.ScraperTargetStoreService
-	// Interval is between each metrics gathering event.
+	// Interval is between each metrics gathering event,
+	// for targets not configuring their own interval.
 	Interval time.Duration
-	// Timeout is the maxisium time duration allowed by each TCP request
+	// Timeout is the maxisium time duration allowed by each TCP request,
+	// for targets not configuring their own timeout.
 	Timeout time.Duration
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
@@ -32,17 +44,23 @@ type Scheduler struct {
 	Logger *zap.Logger
 
 	gather chan struct{}
+
+	// next is the time each target is next due to be scraped.
+	next map[platform.ID]time.Time
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
+// The scrapers fail to read responses larger than maxBodySize bytes, or DefaultMaxBodySize if zero.
 func NewScheduler(
 	numScrapers int,
 	l *zap.Logger,
 	targets platform.ScraperTargetStoreService,
+	secrets SecretLoader,
 	p nats.Publisher,
 	s nats.Subscriber,
 	interval time.Duration,
 	timeout time.Duration,
+	maxBodySize int64,
 ) (*Scheduler, error) {
 	if interval == 0 {
 		interval = 60 * time.Second
@@ -57,16 +75,25 @@ func NewScheduler(
 		Publisher: p,
 		Logger:    l,
 		gather:    make(chan struct{}, 100),
+		next:      make(map[platform.ID]time.Time),
 	}
 
-	for i := 0; i < numScrapers; i++ {
-		err := s.Subscribe(promTargetSubject, "", &handler{
-			Scraper:   new(prometheusScraper),
-			Publisher: p,
-			Logger:    l,
-		})
-		if err != nil {
-			return nil, err
+	scrapers := map[string]Scraper{
+		promTargetSubject:         &prometheusScraper{client: client{Secrets: secrets, MaxBodySize: maxBodySize}},
+		openMetricsTargetSubject:  &openMetricsScraper{client: client{Secrets: secrets, MaxBodySize: maxBodySize}},
+		lineProtocolTargetSubject: &lineProtocolScraper{client: client{Secrets: secrets, MaxBodySize: maxBodySize}},
+		jsonTargetSubject:         &jsonScraper{client: client{Secrets: secrets, MaxBodySize: maxBodySize}},
+	}
+	for subject, scraper := range scrapers {
+		for i := 0; i < numScrapers; i++ {
+			err := s.Subscribe(subject, "", &handler{
+				Scraper:   scraper,
+				Publisher: p,
+				Logger:    l,
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -74,14 +101,21 @@ func NewScheduler(
 }
 
 // Run will retrieve scraper targets from the target storage,
-// and publish them to nats job queue for gather.
+// and publish the targets due to be scraped to nats job queue for gather.
+// Each target is scraped at its own interval.
 func (s *Scheduler) Run(ctx context.Context) error {
+	resolution := scheduleResolution
+	if s.Interval < resolution {
+		resolution = s.Interval
+	}
 	go func(s *Scheduler, ctx context.Context) {
+		ticker := time.NewTicker(resolution)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-ticker.C:
 				s.gather <- struct{}{}
 			}
 		}
@@ -90,19 +124,28 @@ func (s *Scheduler) Run(ctx context.Context) error {
 }
 
 func (s *Scheduler) run(ctx context.Context) error {
+	var (
+		targets []platform.ScraperTarget
+		listed  time.Time
+	)
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-s.gather:
-			ctx, cancel := context.WithTimeout(ctx, s.Timeout)
-			defer cancel()
-			targets, err := s.Targets.ListTargets(ctx)
-			if err != nil {
-				s.Logger.Error("cannot list targets", zap.Error(err))
-				continue
+			now := time.Now()
+			if listed.IsZero() || now.Sub(listed) >= targetsRefreshInterval {
+				lctx, cancel := context.WithTimeout(ctx, s.Timeout)
+				ts, err := s.Targets.ListTargets(lctx)
+				cancel()
+				if err != nil {
+					// The targets listed last are scraped until they can be listed again.
+					s.Logger.Error("cannot list targets", zap.Error(err))
+				} else {
+					targets, listed = ts, now
+				}
 			}
-			for _, target := range targets {
+			for _, target := range s.due(targets, now) {
 				if err := requestScrape(target, s.Publisher); err != nil {
 					s.Logger.Error("json encoding error", zap.Error(err))
 				}
@@ -111,6 +154,41 @@ func (s *Scheduler) run(ctx context.Context) error {
 	}
 }
 
+// due returns the targets due to be scraped at now, with their effective interval and timeout,
+// and schedules their next scrape. Targets no longer listed are forgotten.
+func (s *Scheduler) due(targets []platform.ScraperTarget, now time.Time) []platform.ScraperTarget {
+	listed := make(map[platform.ID]struct{}, len(targets))
+	due := make([]platform.ScraperTarget, 0, len(targets))
+	for _, target := range targets {
+		listed[target.ID] = struct{}{}
+
+		if target.Interval <= 0 {
+			target.Interval = s.Interval
+		}
+		if target.Timeout <= 0 {
+			target.Timeout = s.Timeout
+		}
+
+		next, ok := s.next[target.ID]
+		if ok && now.Before(next) {
+			continue
+		}
+		// Keep the cadence of the target, unless a scrape was missed.
+		if next = next.Add(target.Interval); !ok || !now.Before(next) {
+			next = now.Add(target.Interval)
+		}
+		s.next[target.ID] = next
+		due = append(due, target)
+	}
+
+	for id := range s.next {
+		if _, ok := listed[id]; !ok {
+			delete(s.next, id)
+		}
+	}
+	return due
+}
+
 func requestScrape(t platform.ScraperTarget, publisher nats.Publisher) error {
 	buf := new(bytes.Buffer)
 	err := json.NewEncoder(buf).Encode(t)
@@ -120,6 +198,12 @@ func requestScrape(t platform.ScraperTarget, publisher nats.Publisher) error {
 	switch t.Type {
 	case platform.PrometheusScraperType:
 		return publisher.Publish(promTargetSubject, buf)
+	case platform.OpenMetricsScraperType:
+		return publisher.Publish(openMetricsTargetSubject, buf)
+	case platform.LineProtocolScraperType:
+		return publisher.Publish(lineProtocolTargetSubject, buf)
+	case platform.JSONScraperType:
+		return publisher.Publish(jsonTargetSubject, buf)
 	}
 	return fmt.Errorf("unsupported target scrape type: %s", t.Type)
 }
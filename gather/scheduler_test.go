@@ -12,6 +12,7 @@ import (
 	influxlogger "github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
+	"go.uber.org/zap"
 )
 
 func TestScheduler(t *testing.T) {
@@ -46,7 +47,7 @@ func TestScheduler(t *testing.T) {
 	})
 
 	scheduler, err := NewScheduler(10, logger,
-		storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+		storage, nil, publisher, subscriber, time.Millisecond, time.Second, 0)
 
 	go func() {
 		err = scheduler.run(ctx)
@@ -90,6 +91,97 @@ func TestScheduler(t *testing.T) {
 	ts.Close()
 }
 
+func TestScheduler_run_ListTargets(t *testing.T) {
+	var listed int
+	s := &Scheduler{
+		Targets: &mock.ScraperTargetStoreService{
+			ListTargetsF: func(ctx context.Context) ([]platform.ScraperTarget, error) {
+				listed++
+				return nil, nil
+			},
+		},
+		Interval: time.Second,
+		Timeout:  time.Second,
+		Logger:   zap.NewNop(),
+		gather:   make(chan struct{}),
+		next:     make(map[platform.ID]time.Time),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.run(ctx)
+	}()
+
+	// The gather channel is unbuffered, so the first ticks are handled once
+	// the last one is received.
+	for i := 0; i < 4; i++ {
+		s.gather <- struct{}{}
+	}
+	cancel()
+	<-done
+
+	if listed != 1 {
+		t.Fatalf("got targets listed %d times, want once", listed)
+	}
+}
+
+func TestScheduler_due(t *testing.T) {
+	s := &Scheduler{
+		Interval: 10 * time.Second,
+		Timeout:  5 * time.Second,
+		next:     make(map[platform.ID]time.Time),
+	}
+	fast := platform.ScraperTarget{
+		ID:       platformtesting.MustIDBase16("3a0d0a6365646120"),
+		Interval: time.Second,
+		Timeout:  500 * time.Millisecond,
+	}
+	slow := platform.ScraperTarget{
+		ID: platformtesting.MustIDBase16("3a0d0a6365646121"),
+	}
+	targets := []platform.ScraperTarget{fast, slow}
+
+	ids := func(targets []platform.ScraperTarget) []platform.ID {
+		ids := make([]platform.ID, 0, len(targets))
+		for _, target := range targets {
+			ids = append(ids, target.ID)
+		}
+		return ids
+	}
+
+	start := time.Unix(0, 0)
+	due := s.due(targets, start)
+	if diff := cmp.Diff(ids(due), []platform.ID{fast.ID, slow.ID}); diff != "" {
+		t.Fatalf("unexpected due targets -got/+want\ndiff %s", diff)
+	}
+	if due[0].Interval != time.Second || due[0].Timeout != 500*time.Millisecond {
+		t.Errorf("unexpected schedule of target with own interval: %v, %v", due[0].Interval, due[0].Timeout)
+	}
+	if due[1].Interval != s.Interval || due[1].Timeout != s.Timeout {
+		t.Errorf("unexpected schedule of target with default interval: %v, %v", due[1].Interval, due[1].Timeout)
+	}
+
+	for i := 1; i < 10; i++ {
+		due = s.due(targets, start.Add(time.Duration(i)*time.Second))
+		if diff := cmp.Diff(ids(due), []platform.ID{fast.ID}); diff != "" {
+			t.Fatalf("unexpected due targets after %ds -got/+want\ndiff %s", i, diff)
+		}
+	}
+
+	due = s.due(targets, start.Add(10*time.Second))
+	if diff := cmp.Diff(ids(due), []platform.ID{fast.ID, slow.ID}); diff != "" {
+		t.Fatalf("unexpected due targets after 10s -got/+want\ndiff %s", diff)
+	}
+
+	// removed targets are forgotten
+	s.due(targets[:1], start.Add(11*time.Second))
+	if _, ok := s.next[slow.ID]; ok {
+		t.Error("expected removed target to be unscheduled")
+	}
+}
+
 const sampleRespSmall = `
 # HELP go_goroutines Number of goroutines that currently exist.
 # TYPE go_goroutines gauge
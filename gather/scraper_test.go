@@ -149,6 +149,75 @@ func TestPrometheusScraper(t *testing.T) {
 	}
 }
 
+func TestPrometheusScraper_Auth(t *testing.T) {
+	cases := []struct {
+		name   string
+		auth   *platform.ScraperAuth
+		hasErr bool
+	}{
+		{
+			name: "bearer",
+			auth: &platform.ScraperAuth{Type: platform.BearerScraperAuth, SecretKey: "token"},
+		},
+		{
+			name: "basic",
+			auth: &platform.ScraperAuth{Type: platform.BasicScraperAuth, Username: "user1", SecretKey: "password"},
+		},
+		{
+			name:   "missing secret",
+			auth:   &platform.ScraperAuth{Type: platform.BearerScraperAuth, SecretKey: "missing"},
+			hasErr: true,
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if r.Header.Get("Authorization") != "Bearer secret-token" && (!ok || user != "user1" || pass != "secret-password") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(sampleRespSmall))
+	}))
+	defer ts.Close()
+
+	scraper := &prometheusScraper{client: client{
+		Secrets: &mockSecretLoader{
+			secrets: map[string]string{
+				"token":    "secret-token",
+				"password": "secret-password",
+			},
+		},
+	}}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ms, err := scraper.Gather(context.Background(), platform.ScraperTarget{
+				URL:     ts.URL + "/metrics",
+				OrgName: "org1",
+				Auth:    c.auth,
+			})
+			if (err != nil) != c.hasErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !c.hasErr && len(ms) != 1 {
+				t.Fatalf("expected 1 metric, got %d", len(ms))
+			}
+		})
+	}
+}
+
+type mockSecretLoader struct {
+	secrets map[string]string
+}
+
+func (s *mockSecretLoader) LoadSecret(ctx context.Context, target platform.ScraperTarget, key string) (string, error) {
+	v, ok := s.secrets[key]
+	if !ok {
+		return "", &platform.Error{Code: platform.ENotFound, Msg: "secret not found"}
+	}
+	return v, nil
+}
+
 const sampleResp = `
 # 	HELP go_gc_duration_seconds A summary of the GC invocation durations.
 # TYPE go_gc_duration_seconds summary
@@ -191,10 +260,10 @@ type mockStorage struct {
 	Targets         []platform.ScraperTarget
 }
 
-func (s *mockStorage) Record(ms []Metrics) error {
+func (s *mockStorage) Record(collected MetricsCollection) error {
 	s.Lock()
 	defer s.Unlock()
-	for _, m := range ms {
+	for _, m := range collected.MetricsSlice {
 		s.Metrics[m.Timestamp] = m
 	}
 	s.TotalGatherJobs <- struct{}{}
@@ -1,9 +1,11 @@
 package gather
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/nats"
 	"go.uber.org/zap"
 )
@@ -11,26 +13,40 @@ import (
 // Storage stores the metrics of a time based.
 type Storage interface {
 	//Subscriber nats.Subscriber
-	Record([]Metrics) error
+	Record(MetricsCollection) error
 }
 
 // StorageHandler implements nats.Handler interface.
 type StorageHandler struct {
 	Storage Storage
-	Logger  *zap.Logger
+	// HealthService, if set, stores the health of the scraped targets.
+	HealthService platform.ScraperTargetHealthService
+	Logger        *zap.Logger
 }
 
 // Process consumes job queue, and use storage to record.
 func (h *StorageHandler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
-	ms := make([]Metrics, 0)
-	err := json.Unmarshal(m.Data(), &ms)
+	collected := new(MetricsCollection)
+	err := json.Unmarshal(m.Data(), collected)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler process err: %v", err))
 		return
 	}
-	err = h.Storage.Record(ms)
+	err = h.Storage.Record(*collected)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler store err: %v", err))
+		// the target is not up if its metrics could not be written.
+		if collected.Health != nil {
+			collected.Health.Up = false
+			collected.Health.LastError = fmt.Sprintf("unable to record metrics: %v", err)
+		}
+	}
+
+	if h.HealthService == nil || collected.Health == nil || !collected.TargetID.Valid() {
+		return
+	}
+	if err := h.HealthService.PutTargetHealth(context.Background(), collected.TargetID, collected.Health); err != nil {
+		h.Logger.Error(fmt.Sprintf("storage handler health err: %v", err))
 	}
 }
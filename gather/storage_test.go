@@ -0,0 +1,107 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+type recordFunc func(MetricsCollection) error
+
+func (f recordFunc) Record(c MetricsCollection) error { return f(c) }
+
+type testMessage []byte
+
+func (m testMessage) Data() []byte { return m }
+func (m testMessage) Ack() error   { return nil }
+
+func TestStorageHandler_Health(t *testing.T) {
+	tests := []struct {
+		name      string
+		recordErr error
+		up        bool
+		lastError string
+	}{
+		{
+			name: "recorded",
+			up:   true,
+		},
+		{
+			name:      "record failed",
+			recordErr: errors.New("bucket not found"),
+			lastError: "unable to record metrics: bucket not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *platform.ScraperTargetHealth
+			h := &StorageHandler{
+				Storage: recordFunc(func(MetricsCollection) error { return tt.recordErr }),
+				HealthService: &mock.ScraperTargetHealthService{
+					PutTargetHealthF: func(ctx context.Context, id platform.ID, h *platform.ScraperTargetHealth) error {
+						got = h
+						return nil
+					},
+				},
+				Logger: zap.NewNop(),
+			}
+
+			b, err := json.Marshal(MetricsCollection{
+				TargetID: platform.ID(1),
+				Health:   &platform.ScraperTargetHealth{Up: true, LastScrape: time.Unix(0, 0).UTC()},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Process(nil, testMessage(b))
+
+			if got == nil {
+				t.Fatal("expected the health of the target to be stored")
+			}
+			if got.Up != tt.up || got.LastError != tt.lastError {
+				t.Fatalf("unexpected health: got up %v, error %q; want up %v, error %q", got.Up, got.LastError, tt.up, tt.lastError)
+			}
+		})
+	}
+}
+
+func TestStorageHandler_FieldTypes(t *testing.T) {
+	fields := map[string]interface{}{
+		"i": int64(1<<62 + 1),
+		"u": uint64(1<<63 + 1),
+		"f": float64(1),
+		"s": "string",
+		"b": true,
+	}
+
+	var got MetricsCollection
+	h := &StorageHandler{
+		Storage: recordFunc(func(c MetricsCollection) error {
+			got = c
+			return nil
+		}),
+		Logger: zap.NewNop(),
+	}
+
+	b, err := json.Marshal(MetricsCollection{
+		MetricsSlice: MetricsSlice{{Name: "m", Fields: fields, Timestamp: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Process(nil, testMessage(b))
+
+	if len(got.MetricsSlice) != 1 {
+		t.Fatalf("unexpected metrics: %v", got.MetricsSlice)
+	}
+	if diff := cmp.Diff(got.MetricsSlice[0].Fields, fields); diff != "" {
+		t.Fatalf("unexpected fields -got/+want\ndiff %s", diff)
+	}
+}
@@ -18,15 +18,22 @@ type APIHandler struct {
 	OrgHandler           *OrgHandler
 	AuthorizationHandler *AuthorizationHandler
 	DashboardHandler     *DashboardHandler
+	DBRPMappingHandler   *DBRPMappingHandler
 	AssetHandler         *AssetHandler
 	ChronografHandler    *ChronografHandler
 	SourceHandler        *SourceHandler
 	MacroHandler         *MacroHandler
+	ScraperHandler       *ScraperHandler
 	TaskHandler          *TaskHandler
 	TelegrafHandler      *TelegrafHandler
 	QueryHandler         *FluxHandler
+	InfluxQLHandler      *InfluxQLHandler
 	ProtoHandler         *ProtoHandler
 	WriteHandler         *WriteHandler
+	DeleteHandler        *DeleteHandler
+	UsageHandler         *UsageHandler
+	AuditHandler         *AuditHandler
+	BackupHandler        *BackupHandler
 	SetupHandler         *SetupHandler
 	SessionHandler       *SessionHandler
 }
@@ -41,7 +48,15 @@ type APIBackend struct {
 	NewQueryService  func(*platform.Source) (query.ProxyQueryService, error)
 
 	PointsWriter                    storage.PointsWriter
+	BulkPointsWriter                storage.BulkPointsWriter
+	BucketRangeDeleter              storage.BucketRangeDeleter
+	WriteLimits                     WriteLimits
+	UsageService                    platform.UsageService
+	UsageRecorder                   platform.UsageRecorder
+	AuditLogService                 platform.AuditLogService
+	BackupService                   platform.BackupService
 	AuthorizationService            platform.AuthorizationService
+	AuthorizationUsageRecorder      platform.AuthorizationUsageRecorder
 	BucketService                   platform.BucketService
 	SessionService                  platform.SessionService
 	UserService                     platform.UserService
@@ -58,9 +73,11 @@ type APIBackend struct {
 	BasicAuthService                platform.BasicAuthService
 	OnboardingService               platform.OnboardingService
 	ProxyQueryService               query.ProxyQueryService
+	DBRPMappingService              platform.DBRPMappingService
 	TaskService                     platform.TaskService
 	TelegrafService                 platform.TelegrafConfigStore
 	ScraperTargetStoreService       platform.ScraperTargetStoreService
+	ScraperTargetHealthService      platform.ScraperTargetHealthService
 	SecretService                   platform.SecretService
 	LookupService                   platform.LookupService
 	ChronografService               *server.Service
@@ -76,6 +93,7 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.BucketHandler = NewBucketHandler(b.UserResourceMappingService, b.LabelService, b.UserService)
 	h.BucketHandler.BucketService = b.BucketService
 	h.BucketHandler.BucketOperationLogService = b.BucketOperationLogService
+	h.BucketHandler.OrganizationService = b.OrganizationService
 
 	h.OrgHandler = NewOrgHandler(b.UserResourceMappingService, b.LabelService, b.UserService)
 	h.OrgHandler.OrganizationService = b.OrganizationService
@@ -94,6 +112,12 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	h.MacroHandler = NewMacroHandler()
 	h.MacroHandler.MacroService = b.MacroService
+	h.MacroHandler.UserResourceMappingService = b.UserResourceMappingService
+
+	h.DBRPMappingHandler = NewDBRPMappingHandler()
+	h.DBRPMappingHandler.DBRPMappingService = b.DBRPMappingService
+	h.DBRPMappingHandler.BucketService = b.BucketService
+	h.DBRPMappingHandler.Logger = b.Logger.With(zap.String("handler", "dbrp"))
 
 	h.AuthorizationHandler = NewAuthorizationHandler(b.UserService)
 	h.AuthorizationHandler.OrganizationService = b.OrganizationService
@@ -122,15 +146,50 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 		b.UserService,
 	)
 
+	h.ScraperHandler = NewScraperHandler()
+	h.ScraperHandler.ScraperStorageService = b.ScraperTargetStoreService
+	h.ScraperHandler.ScraperHealthService = b.ScraperTargetHealthService
+	h.ScraperHandler.OrganizationService = b.OrganizationService
+	h.ScraperHandler.Logger = b.Logger.With(zap.String("handler", "scraper"))
+
 	h.WriteHandler = NewWriteHandler(b.PointsWriter)
+	h.WriteHandler.BulkPointsWriter = b.BulkPointsWriter
 	h.WriteHandler.OrganizationService = b.OrganizationService
 	h.WriteHandler.BucketService = b.BucketService
 	h.WriteHandler.Logger = b.Logger.With(zap.String("handler", "write"))
+	h.WriteHandler.UsageRecorder = b.UsageRecorder
+	h.WriteHandler.Limits = b.WriteLimits
+	h.WriteHandler.DBRPMappingService = b.DBRPMappingService
+
+	h.DeleteHandler = NewDeleteHandler(b.BucketRangeDeleter)
+	h.DeleteHandler.OrganizationService = b.OrganizationService
+	h.DeleteHandler.BucketService = b.BucketService
+	h.DeleteHandler.Logger = b.Logger.With(zap.String("handler", "delete"))
 
 	h.QueryHandler = NewFluxHandler()
 	h.QueryHandler.OrganizationService = b.OrganizationService
 	h.QueryHandler.Logger = b.Logger.With(zap.String("handler", "query"))
 	h.QueryHandler.ProxyQueryService = b.ProxyQueryService
+	h.QueryHandler.UsageRecorder = b.UsageRecorder
+
+	h.InfluxQLHandler = NewInfluxQLHandler()
+	h.InfluxQLHandler.DBRPMappingService = b.DBRPMappingService
+	h.InfluxQLHandler.Logger = b.Logger.With(zap.String("handler", "influxql"))
+	h.InfluxQLHandler.ProxyQueryService = b.ProxyQueryService
+	h.InfluxQLHandler.UsageRecorder = b.UsageRecorder
+
+	h.UsageHandler = NewUsageHandler()
+	h.UsageHandler.UsageService = b.UsageService
+	h.UsageHandler.Logger = b.Logger.With(zap.String("handler", "usage"))
+
+	h.AuditHandler = NewAuditHandler()
+	h.AuditHandler.AuditLogService = b.AuditLogService
+	h.AuditHandler.Logger = b.Logger.With(zap.String("handler", "audit"))
+
+	h.BackupHandler = NewBackupHandler()
+	h.BackupHandler.BackupService = b.BackupService
+	h.BackupHandler.BucketService = b.BucketService
+	h.BackupHandler.Logger = b.Logger.With(zap.String("handler", "backup"))
 
 	h.ProtoHandler = NewProtoHandler(NewProtoBackend(b))
 
@@ -142,9 +201,13 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
+	"audit":          "/api/v2/audit",
 	"authorizations": "/api/v2/authorizations",
+	"backup":         "/api/v2/backup",
 	"buckets":        "/api/v2/buckets",
 	"dashboards":     "/api/v2/dashboards",
+	"dbrps":          "/api/v2/dbrps",
+	"delete":         "/api/v2/delete",
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
@@ -159,10 +222,11 @@ var apiLinks = map[string]interface{}{
 		"spec":        "/api/v2/query/spec",
 		"suggestions": "/api/v2/query/suggestions",
 	},
-	"setup":   "/api/v2/setup",
-	"signin":  "/api/v2/signin",
-	"signout": "/api/v2/signout",
-	"sources": "/api/v2/sources",
+	"scrapers": "/api/v2/scrapers",
+	"setup":    "/api/v2/setup",
+	"signin":   "/api/v2/signin",
+	"signout":  "/api/v2/signout",
+	"sources":  "/api/v2/sources",
 	"system": map[string]string{
 		"metrics": "/metrics",
 		"debug":   "/debug/pprof",
@@ -170,6 +234,7 @@ var apiLinks = map[string]interface{}{
 	},
 	"tasks":     "/api/v2/tasks",
 	"telegrafs": "/api/v2/telegrafs",
+	"usage":     "/api/v2/usage",
 	"users":     "/api/v2/users",
 	"write":     "/api/v2/write",
 }
@@ -210,6 +275,23 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The InfluxDB 1.x compatible endpoints, which are only served once
+	// databases and retention policies can be mapped to buckets.
+	if r.URL.Path == "/write" && h.WriteHandler.DBRPMappingService != nil {
+		h.WriteHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if r.URL.Path == "/query" && h.InfluxQLHandler.DBRPMappingService != nil {
+		h.InfluxQLHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/delete") {
+		h.DeleteHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/query") {
 		h.QueryHandler.ServeHTTP(w, r)
 		return
@@ -245,6 +327,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/dbrps") {
+		h.DBRPMappingHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/sources") {
 		h.SourceHandler.ServeHTTP(w, r)
 		return
@@ -260,6 +347,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/scrapers") || strings.HasPrefix(r.URL.Path, "/api/v2/scrapertargets") {
+		h.ScraperHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/macros") {
 		h.MacroHandler.ServeHTTP(w, r)
 		return
@@ -270,6 +362,21 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/usage") {
+		h.UsageHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/audit") {
+		h.AuditHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/backup") {
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.ChronografHandler.ServeHTTP(w, r)
 		return
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	auditPath = "/api/v2/audit"
+)
+
+// AuditHandler represents an HTTP API handler for the audit log.
+type AuditHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuditLogService platform.AuditLogService
+}
+
+// NewAuditHandler returns a new instance of AuditHandler.
+func NewAuditHandler() *AuditHandler {
+	h := &AuditHandler{
+		Router: NewRouter(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", auditPath, h.handleGetAuditEvents)
+	return h
+}
+
+type auditEventsResponse struct {
+	Events []*platform.AuditEvent `json:"events"`
+	Links  *platform.PagingLinks  `json:"links"`
+}
+
+// handleGetAuditEvents is the HTTP handler for the GET /api/v2/audit route.
+// Reading the events of an organization requires the orgID filter, unless the
+// audit log of every organization may be read.
+func (h *AuditHandler) handleGetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetAuditEventsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var ids []platform.ID
+	if req.filter.OrgID != nil {
+		ids = append(ids, *req.filter.OrgID)
+	}
+	if err := authorize(ctx, platform.ReadAction, platform.AuditResource, ids...); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	es, _, err := h.AuditLogService.FindAuditEvents(ctx, req.filter, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := auditEventsResponse{
+		Events: es,
+		Links:  newPagingLinks(auditPath, req.opts, req.filter, len(es)),
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type getAuditEventsRequest struct {
+	filter platform.AuditFilter
+	opts   platform.FindOptions
+}
+
+func decodeGetAuditEventsRequest(ctx context.Context, r *http.Request) (*getAuditEventsRequest, error) {
+	req := &getAuditEventsRequest{}
+	qp := r.URL.Query()
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	req.opts = *opts
+
+	ids := []struct {
+		param string
+		id    **platform.ID
+	}{
+		{param: "userID", id: &req.filter.UserID},
+		{param: "authorizationID", id: &req.filter.AuthorizationID},
+		{param: "resourceID", id: &req.filter.ResourceID},
+		{param: "orgID", id: &req.filter.OrgID},
+	}
+	for _, p := range ids {
+		if v := qp.Get(p.param); v != "" {
+			id, err := platform.IDFromString(v)
+			if err != nil {
+				return nil, &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("invalid %s %q", p.param, v),
+					Err:  err,
+				}
+			}
+			*p.id = id
+		}
+	}
+
+	if operation := qp.Get("operation"); operation != "" {
+		req.filter.Operation = &operation
+	}
+
+	if resource := qp.Get("resource"); resource != "" {
+		rt := platform.Resource(resource)
+		if err := rt.Valid(); err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid resource %q", resource),
+				Err:  err,
+			}
+		}
+		req.filter.Resource = &rt
+	}
+
+	times := []struct {
+		param string
+		t     **time.Time
+	}{
+		{param: "since", t: &req.filter.Since},
+		{param: "until", t: &req.filter.Until},
+	}
+	for _, p := range times {
+		if v := qp.Get(p.param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("%s must be an RFC3339 time", p.param),
+					Err:  err,
+				}
+			}
+			*p.t = &t
+		}
+	}
+
+	return req, nil
+}
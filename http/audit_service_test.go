@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+func TestAuditHandler_handleGetAuditEvents(t *testing.T) {
+	orgID := platform.ID(2)
+	since := time.Date(2006, 5, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		query       string
+		permissions []platform.Permission
+		wantStatus  int
+		wantFilter  platform.AuditFilter
+		wantOpts    platform.FindOptions
+	}{
+		{
+			name:        "operator reads every event",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+			wantOpts:    platform.FindOptions{Limit: platform.DefaultPageSize},
+		},
+		{
+			name:        "org admin reads the events of the org",
+			query:       "?orgID=" + orgID.String() + "&since=2006-05-04T00:00:00Z&descending=true",
+			permissions: platform.OrgAdminPermissions(orgID),
+			wantStatus:  200,
+			wantFilter:  platform.AuditFilter{OrgID: &orgID, Since: &since},
+			wantOpts:    platform.FindOptions{Limit: platform.DefaultPageSize, Descending: true},
+		},
+		{
+			name:        "org admin reads every event",
+			permissions: platform.OrgAdminPermissions(orgID),
+			wantStatus:  403,
+		},
+		{
+			name:        "invalid resource",
+			query:       "?resource=cookies",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "invalid time",
+			query:       "?until=yesterday",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter platform.AuditFilter
+			var gotOpts platform.FindOptions
+			h := NewAuditHandler()
+			h.Logger = zap.NewNop()
+			h.AuditLogService = &mock.AuditLogService{
+				FindAuditEventsFn: func(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+					gotFilter, gotOpts = filter, opt[0]
+					return []*platform.AuditEvent{
+						{Operation: platform.OpCreateBucket, Resource: platform.BucketsResource, ResourceID: 1, OrgID: orgID},
+					}, 1, nil
+				},
+			}
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("GET", auditPath+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != 200 {
+				return
+			}
+
+			if diff := cmp.Diff(gotFilter, tt.wantFilter); diff != "" {
+				t.Errorf("filters are different -got/+want\ndiff %s", diff)
+			}
+			if diff := cmp.Diff(gotOpts, tt.wantOpts); diff != "" {
+				t.Errorf("find options are different -got/+want\ndiff %s", diff)
+			}
+
+			var res auditEventsResponse
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Events) != 1 || res.Events[0].Operation != platform.OpCreateBucket {
+				t.Errorf("unexpected events %v", res.Events)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -45,7 +46,7 @@ func NewAuthorizationHandler(userService platform.UserService) *AuthorizationHan
 
 type authResponse struct {
 	ID          platform.ID          `json:"id"`
-	Token       string               `json:"token"`
+	Token       string               `json:"token,omitempty"`
 	Status      platform.Status      `json:"status"`
 	Description string               `json:"description"`
 	OrgID       platform.ID          `json:"orgID"`
@@ -53,6 +54,10 @@ type authResponse struct {
 	UserID      platform.ID          `json:"userID"`
 	User        string               `json:"user"`
 	Permissions []permissionResponse `json:"permissions"`
+	CreatedBy   platform.ID          `json:"createdBy,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	ExpiresAt   *time.Time           `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time           `json:"lastUsedAt,omitempty"`
 	Links       map[string]string    `json:"links"`
 }
 
@@ -67,6 +72,10 @@ func newAuthResponse(a *platform.Authorization, org *platform.Organization, user
 		User:        user.Name,
 		Org:         org.Name,
 		Permissions: ps,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt,
+		ExpiresAt:   a.ExpiresAt,
+		LastUsedAt:  a.LastUsedAt,
 		Links: map[string]string{
 			"self": fmt.Sprintf("/api/v2/authorizations/%s", a.ID),
 			"user": fmt.Sprintf("/api/v2/users/%s", a.UserID),
@@ -83,6 +92,10 @@ func (a *authResponse) toPlatform() *platform.Authorization {
 		Description: a.Description,
 		OrgID:       a.OrgID,
 		UserID:      a.UserID,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt,
+		ExpiresAt:   a.ExpiresAt,
+		LastUsedAt:  a.LastUsedAt,
 	}
 	for _, p := range a.Permissions {
 		res.Permissions = append(res.Permissions, p.Permission)
@@ -138,6 +151,11 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 		return
 	}
 
+	if err := authorizeCreateAuthorization(ctx, req); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	user, err := getAuthorizedUser(r, h.UserService)
 	if err != nil {
 		EncodeError(ctx, platform.ErrUnableToCreateToken, w)
@@ -145,6 +163,7 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 	}
 
 	auth := req.toPlatform(user.ID)
+	auth.CreatedBy = user.ID
 
 	org, err := h.OrganizationService.FindOrganizationByID(ctx, auth.OrgID)
 	if err != nil {
@@ -169,11 +188,37 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 	}
 }
 
+// authorizeCreateAuthorization authorizes the creation of an authorization in the
+// organization of the request. Tokens can not be used to grant permissions their
+// creator does not have, so each of the requested permissions must be allowed too.
+func authorizeCreateAuthorization(ctx context.Context, req *postAuthorizationRequest) error {
+	if err := authorize(ctx, platform.WriteAction, platform.AuthorizationsResource, req.OrgID); err != nil {
+		return err
+	}
+
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range req.Permissions {
+		if !a.Allowed(p) {
+			return &platform.Error{
+				Code: platform.EForbidden,
+				Msg:  fmt.Sprintf("permission %s can not be granted", p),
+			}
+		}
+	}
+
+	return nil
+}
+
 type postAuthorizationRequest struct {
 	Status      platform.Status       `json:"status"`
 	OrgID       platform.ID           `json:"orgID"`
 	Description string                `json:"description"`
 	Permissions []platform.Permission `json:"permissions"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
 }
 
 func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Authorization {
@@ -182,6 +227,7 @@ func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Auth
 		Status:      p.Status,
 		Description: p.Description,
 		Permissions: p.Permissions,
+		ExpiresAt:   p.ExpiresAt,
 		UserID:      userID,
 	}
 }
@@ -192,6 +238,7 @@ func newPostAuthorizationRequest(a *platform.Authorization) (*postAuthorizationR
 		Description: a.Description,
 		Permissions: a.Permissions,
 		Status:      a.Status,
+		ExpiresAt:   a.ExpiresAt,
 	}
 
 	res.SetDefaults()
@@ -229,6 +276,13 @@ func (p *postAuthorizationRequest) Validate() error {
 		}
 	}
 
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "expiration time must be in the future",
+		}
+	}
+
 	if p.Status == "" {
 		p.Status = platform.Active
 	}
@@ -267,13 +321,26 @@ func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r
 		return
 	}
 
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.AuthorizationsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	opts := platform.FindOptions{}
-	as, _, err := h.AuthorizationService.FindAuthorizations(ctx, req.filter, opts)
+	found, _, err := h.AuthorizationService.FindAuthorizations(ctx, req.filter, opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	as := make([]*platform.Authorization, 0, len(found))
+	for _, a := range found {
+		if allowed(a.ID, a.OrgID) {
+			as = append(as, a)
+		}
+	}
+
 	auths := make([]*authResponse, len(as))
 	for i, a := range as {
 		o, err := h.OrganizationService.FindOrganizationByID(ctx, a.OrgID)
@@ -335,6 +402,39 @@ func decodeGetAuthorizationsRequest(ctx context.Context, r *http.Request) (*getA
 		req.filter.ID = id
 	}
 
+	createdBy := qp.Get("createdBy")
+	if createdBy != "" {
+		id, err := platform.IDFromString(createdBy)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.CreatedBy = id
+	}
+
+	if unusedSince := qp.Get("unusedSince"); unusedSince != "" {
+		t, err := time.Parse(time.RFC3339, unusedSince)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "unusedSince must be an RFC3339 time",
+				Err:  err,
+			}
+		}
+		req.filter.UnusedSince = &t
+	}
+
+	if expiresBefore := qp.Get("expiresBefore"); expiresBefore != "" {
+		t, err := time.Parse(time.RFC3339, expiresBefore)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "expiresBefore must be an RFC3339 time",
+				Err:  err,
+			}
+		}
+		req.filter.ExpiresBefore = &t
+	}
+
 	return req, nil
 }
 
@@ -356,6 +456,11 @@ func (h *AuthorizationHandler) handleGetAuthorization(w http.ResponseWriter, r *
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.AuthorizationsResource, a.ID, a.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	o, err := h.OrganizationService.FindOrganizationByID(ctx, a.OrgID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -421,6 +526,11 @@ func (h *AuthorizationHandler) handleSetAuthorizationStatus(w http.ResponseWrite
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.AuthorizationsResource, a.ID, a.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if req.Status != a.Status {
 		a.Status = req.Status
 		if err := h.AuthorizationService.SetAuthorizationStatus(ctx, a.ID, a.Status); err != nil {
@@ -495,6 +605,11 @@ func (h *AuthorizationHandler) handleDeleteAuthorization(w http.ResponseWriter,
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.AuthorizationsResource, req.ID, h.authorizationIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.AuthorizationService.DeleteAuthorization(ctx, req.ID); err != nil {
 		// Don't log here, it should already be handled by the service
 		EncodeError(ctx, err, w)
@@ -528,6 +643,16 @@ func decodeDeleteAuthorizationRequest(ctx context.Context, r *http.Request) (*de
 	}, nil
 }
 
+// authorizationIDs returns the ids that authorize access to an authorization: its own and that of its organization.
+func (h *AuthorizationHandler) authorizationIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	a, err := h.AuthorizationService.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []platform.ID{a.ID, a.OrgID}, nil
+}
+
 func getAuthorizedUser(r *http.Request, svc platform.UserService) (*platform.User, error) {
 	ctx := r.Context()
 
@@ -612,6 +737,18 @@ func (s *AuthorizationService) FindAuthorizations(ctx context.Context, filter pl
 		query.Add("user", *filter.User)
 	}
 
+	if filter.CreatedBy != nil {
+		query.Add("createdBy", filter.CreatedBy.String())
+	}
+
+	if filter.UnusedSince != nil {
+		query.Add("unusedSince", filter.UnusedSince.Format(time.RFC3339))
+	}
+
+	if filter.ExpiresBefore != nil {
+		query.Add("expiresBefore", filter.ExpiresBefore.Format(time.RFC3339))
+	}
+
 	req.URL.RawQuery = query.Encode()
 	SetToken(s.Token, req)
 
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
@@ -107,7 +108,8 @@ func TestService_handleGetAuthorizations(t *testing.T) {
       "status": "",
 	  "token": "hello",
 	  "description": "t1",
-	  "permissions": %s
+	  "permissions": %s,
+	  "createdAt": "0001-01-01T00:00:00Z"
     },
     {
       "links": {
@@ -122,7 +124,8 @@ func TestService_handleGetAuthorizations(t *testing.T) {
       "status": "",
       "token": "example",
 	  "description": "t2",
-	  "permissions": %s
+	  "permissions": %s,
+	  "createdAt": "0001-01-01T00:00:00Z"
     }
   ]
 }
@@ -172,6 +175,7 @@ func TestService_handleGetAuthorizations(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetAuthorizations(w, r)
 
 			res := w.Result()
@@ -234,7 +238,13 @@ func TestService_handleGetAuthorization(t *testing.T) {
 										}(),
 									},
 								},
-								Token: "hello",
+								Token:     "hello",
+								CreatedBy: platformtesting.MustIDBase16("020f755c3c082000"),
+								CreatedAt: time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+								ExpiresAt: func() *time.Time {
+									t := time.Date(2010, time.November, 10, 23, 0, 0, 0, time.UTC)
+									return &t
+								}(),
 							}, nil
 						}
 
@@ -283,7 +293,10 @@ func TestService_handleGetAuthorization(t *testing.T) {
   "token": "hello",
   "status": "",
   "description": "",
-  "permissions": [{"action": "read","id": "020f755c3c084000", "name": "b1", "resource": "buckets"}]
+  "permissions": [{"action": "read","id": "020f755c3c084000", "name": "b1", "resource": "buckets"}],
+  "createdBy": "020f755c3c082000",
+  "createdAt": "2009-11-10T23:00:00Z",
+  "expiresAt": "2010-11-10T23:00:00Z"
 }
 `,
 			},
@@ -334,6 +347,7 @@ func TestService_handleGetAuthorization(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetAuthorization(w, r)
 
 			res := w.Result()
@@ -383,6 +397,7 @@ func TestService_handlePostAuthorization(t *testing.T) {
 					CreateAuthorizationFn: func(ctx context.Context, c *platform.Authorization) error {
 						c.ID = platformtesting.MustIDBase16("020f755c3c082000")
 						c.Token = "new-test-token"
+						c.CreatedAt = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
 						return nil
 					},
 				},
@@ -406,15 +421,20 @@ func TestService_handlePostAuthorization(t *testing.T) {
 			args: args{
 				session: &platform.Authorization{
 					Token:       "session-token",
+					Status:      platform.Active,
 					ID:          platformtesting.MustIDBase16("020f755c3c082000"),
 					UserID:      platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
 					OrgID:       platformtesting.MustIDBase16("020f755c3c083000"),
-					Description: "can write to authorization resource",
+					Description: "can write to authorization resource and read dashboards",
 					Permissions: []platform.Permission{
 						{
 							Action:   platform.WriteAction,
 							Resource: platform.AuthorizationsResource,
 						},
+						{
+							Action:   platform.ReadAction,
+							Resource: platform.DashboardsResource,
+						},
 					},
 				},
 				authorization: &platform.Authorization{
@@ -447,11 +467,52 @@ func TestService_handlePostAuthorization(t *testing.T) {
   "token": "new-test-token",
   "status": "active",
   "description": "only read dashboards sucka",
-  "permissions": [{"action": "read", "resource": "dashboards"}]
+  "permissions": [{"action": "read", "resource": "dashboards"}],
+  "createdBy": "aaaaaaaaaaaaaaaa",
+  "createdAt": "2009-11-10T23:00:00Z"
 }
 `,
 			},
 		},
+		{
+			name: "create an authorization with permissions the session lacks",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{},
+				UserService:          &mock.UserService{},
+				OrganizationService:  &mock.OrganizationService{},
+			},
+			args: args{
+				session: &platform.Authorization{
+					Token:       "session-token",
+					Status:      platform.Active,
+					ID:          platformtesting.MustIDBase16("020f755c3c082000"),
+					UserID:      platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+					OrgID:       platformtesting.MustIDBase16("020f755c3c083000"),
+					Description: "can write to authorization resource",
+					Permissions: []platform.Permission{
+						{
+							Action:   platform.WriteAction,
+							Resource: platform.AuthorizationsResource,
+						},
+					},
+				},
+				authorization: &platform.Authorization{
+					ID:          platformtesting.MustIDBase16("020f755c3c082000"),
+					UserID:      platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+					OrgID:       platformtesting.MustIDBase16("020f755c3c083000"),
+					Description: "delete every bucket",
+					Permissions: []platform.Permission{
+						{
+							Action:   platform.DeleteAction,
+							Resource: platform.BucketsResource,
+						},
+					},
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -580,6 +641,7 @@ func TestService_handleDeleteAuthorization(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteAuthorization(w, r)
 
 			res := w.Result()
@@ -617,6 +679,7 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 	svc := inmem.NewService()
 	svc.IDGenerator = f.IDGenerator
 	svc.TokenGenerator = f.TokenGenerator
+	svc.WithTime(f.NowFn)
 
 	ctx := context.Background()
 
@@ -649,7 +712,7 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 
 	authN := NewAuthenticationHandler()
 	authN.AuthorizationService = svc
-	authN.Handler = authZ
+	authN.Handler = operatorHandler(authZ)
 
 	server := httptest.NewServer(authN)
 	client := AuthorizationService{
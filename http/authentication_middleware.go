@@ -2,8 +2,12 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/influxdata/platform"
 	platcontext "github.com/influxdata/platform/context"
@@ -17,10 +21,27 @@ type AuthenticationHandler struct {
 
 	AuthorizationService platform.AuthorizationService
 	SessionService       platform.SessionService
+	// BasicAuthService, if set, authenticates the username and password
+	// credentials of InfluxDB 1.x clients.
+	BasicAuthService platform.BasicAuthService
+	// AuthorizationUsageRecorder, if set, records when authorizations were last used.
+	AuthorizationUsageRecorder platform.AuthorizationUsageRecorder
 
 	// This is only really used for it's lookup method the specific http
 	// hanlder used to register routes does not matter.
 	noAuthRouter *httprouter.Router
+	v1AuthRouter *httprouter.Router
+
+	// v1Sessions are the sessions of the users authenticated by 1.x
+	// credentials, which are reused until they expire.
+	mu         sync.Mutex
+	v1Sessions map[string]v1Session
+
+	// usage is when the use of each authorization was last recorded, so that
+	// concurrent requests with the same token record it once.
+	usageMu    sync.Mutex
+	usage      map[platform.ID]authorizationUsage
+	usageSwept time.Time
 
 	Handler http.Handler
 }
@@ -31,6 +52,9 @@ func NewAuthenticationHandler() *AuthenticationHandler {
 		Logger:       zap.NewNop(),
 		Handler:      http.DefaultServeMux,
 		noAuthRouter: httprouter.New(),
+		v1AuthRouter: httprouter.New(),
+		v1Sessions:   make(map[string]v1Session),
+		usage:        make(map[platform.ID]authorizationUsage),
 	}
 }
 
@@ -40,9 +64,17 @@ func (h *AuthenticationHandler) RegisterNoAuthRoute(method, path string) {
 	h.noAuthRouter.HandlerFunc(method, path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 }
 
+// RegisterV1AuthRoute allows routes to be authenticated by the credentials of InfluxDB 1.x clients,
+// given either as basic auth or as the u and p query parameters.
+func (h *AuthenticationHandler) RegisterV1AuthRoute(method, path string) {
+	// the handler specified here does not matter.
+	h.v1AuthRouter.HandlerFunc(method, path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+}
+
 const (
 	tokenAuthScheme   = "token"
 	sessionAuthScheme = "session"
+	v1AuthScheme      = "v1"
 )
 
 // ProbeAuthScheme probes the http request for the requests for token or cookie session.
@@ -61,8 +93,11 @@ func ProbeAuthScheme(r *http.Request) (string, error) {
 	return sessionAuthScheme, nil
 }
 
-// ServeHTTP extracts the session or token from the http request and places the resulting authorizer on the request context.
+// ServeHTTP extracts the session or token from the http request and places the resulting authorizer on the request context,
+// along with the remote address of the request as its source.
 func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(platcontext.SetRequestSource(r.Context(), r.RemoteAddr))
+
 	if handler, _, _ := h.noAuthRouter.Lookup(r.Method, r.URL.Path); handler != nil {
 		h.Handler.ServeHTTP(w, r)
 		return
@@ -70,8 +105,11 @@ func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	ctx := r.Context()
 	scheme, err := ProbeAuthScheme(r)
+	if err != nil && h.acceptsV1Credentials(r) {
+		scheme, err = v1AuthScheme, nil
+	}
 	if err != nil {
-		ForbiddenError(ctx, err, w)
+		h.forbidden(ctx, r, err, w)
 		return
 	}
 
@@ -92,9 +130,45 @@ func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		r = r.WithContext(ctx)
 		h.Handler.ServeHTTP(w, r)
 		return
+	case v1AuthScheme:
+		ctx, err = h.extractV1Authorization(ctx, r)
+		if err != nil {
+			break
+		}
+		r = r.WithContext(ctx)
+		h.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	// Only expired credentials are reported as such, other failures are not
+	// detailed so that they do not reveal which credentials exist.
+	if platform.ErrorCode(err) != platform.EUnauthorized {
+		err = fmt.Errorf("unauthorized")
 	}
+	h.forbidden(ctx, r, err, w)
+}
 
-	ForbiddenError(ctx, fmt.Errorf("unauthorized"), w)
+// forbidden encodes the authentication error of the request, which is
+// unauthorized for expired credentials and forbidden otherwise, in the
+// shape of InfluxDB 1.x errors for the 1.x compatible routes.
+func (h *AuthenticationHandler) forbidden(ctx context.Context, r *http.Request, err error, w http.ResponseWriter) {
+	if platform.ErrorCode(err) == platform.EUnauthorized {
+		if h.isV1Route(r) {
+			encodeV1Error(ctx, err, w)
+			return
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if h.isV1Route(r) {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  "authorization failed",
+			Err:  err,
+		}, w)
+		return
+	}
+	ForbiddenError(ctx, err, w)
 }
 
 func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *http.Request) (context.Context, error) {
@@ -108,9 +182,82 @@ func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *htt
 		return ctx, err
 	}
 
+	if err := a.Expired(); err != nil {
+		return ctx, err
+	}
+
+	h.recordUsage(a)
 	return platcontext.SetAuthorizer(ctx, a), nil
 }
 
+// lastUsedResolution is how often the last use of an authorization is recorded,
+// so that a busy token does not cause a write on every request.
+const lastUsedResolution = time.Minute
+
+// authorizationUsage is when the use of an authorization was last recorded,
+// and whether it is being recorded.
+type authorizationUsage struct {
+	recorded  time.Time
+	recording bool
+}
+
+// recordUsage records that the authorization was used, without blocking the request.
+func (h *AuthenticationHandler) recordUsage(a *platform.Authorization) {
+	if h.AuthorizationUsageRecorder == nil {
+		return
+	}
+
+	now := time.Now()
+	if a.LastUsedAt != nil && now.Sub(*a.LastUsedAt) < lastUsedResolution {
+		return
+	}
+	if !h.startRecordingUsage(a.ID, now) {
+		return
+	}
+
+	go func() {
+		err := h.AuthorizationUsageRecorder.SetAuthorizationLastUsed(context.Background(), a.ID, now)
+		if err != nil {
+			h.Logger.Info("failed to record authorization usage", zap.String("authorization", a.ID.String()), zap.Error(err))
+		}
+
+		h.usageMu.Lock()
+		u := h.usage[a.ID]
+		u.recording = false
+		if err != nil {
+			// Let the next request try again.
+			u.recorded = time.Time{}
+		}
+		h.usage[a.ID] = u
+		h.usageMu.Unlock()
+	}()
+}
+
+// startRecordingUsage reports whether the use of the authorization at time now
+// should be recorded, which is when it is not being recorded already and was
+// not recorded within lastUsedResolution. If so, it marks it as being recorded.
+func (h *AuthenticationHandler) startRecordingUsage(id platform.ID, now time.Time) bool {
+	h.usageMu.Lock()
+	defer h.usageMu.Unlock()
+
+	// Forget the authorizations that are not being used, so that the usage
+	// does not grow with every authorization ever used.
+	if now.Sub(h.usageSwept) > lastUsedResolution {
+		for id, u := range h.usage {
+			if !u.recording && now.Sub(u.recorded) >= lastUsedResolution {
+				delete(h.usage, id)
+			}
+		}
+		h.usageSwept = now
+	}
+
+	if u, ok := h.usage[id]; ok && (u.recording || now.Sub(u.recorded) < lastUsedResolution) {
+		return false
+	}
+	h.usage[id] = authorizationUsage{recorded: now, recording: true}
+	return true
+}
+
 func (h *AuthenticationHandler) extractSession(ctx context.Context, r *http.Request) (context.Context, error) {
 	k, err := decodeCookieSession(ctx, r)
 	if err != nil {
@@ -124,3 +271,125 @@ func (h *AuthenticationHandler) extractSession(ctx context.Context, r *http.Requ
 
 	return platcontext.SetAuthorizer(ctx, s), nil
 }
+
+// v1Credentials returns the username and password of an InfluxDB 1.x client.
+func v1Credentials(r *http.Request) (string, string, bool) {
+	if u, p, ok := r.BasicAuth(); ok {
+		return u, p, true
+	}
+
+	qp := r.URL.Query()
+	if p := qp.Get("p"); p != "" {
+		return qp.Get("u"), p, true
+	}
+	return "", "", false
+}
+
+// isV1Route returns true if the request is to one of the InfluxDB 1.x compatible routes.
+func (h *AuthenticationHandler) isV1Route(r *http.Request) bool {
+	handler, _, _ := h.v1AuthRouter.Lookup(r.Method, r.URL.Path)
+	return handler != nil
+}
+
+func (h *AuthenticationHandler) acceptsV1Credentials(r *http.Request) bool {
+	if !h.isV1Route(r) {
+		return false
+	}
+	_, _, ok := v1Credentials(r)
+	return ok
+}
+
+// extractV1Authorization authenticates the credentials of an InfluxDB 1.x client.
+// The password may be a token, otherwise it is compared to the password of the user.
+func (h *AuthenticationHandler) extractV1Authorization(ctx context.Context, r *http.Request) (context.Context, error) {
+	u, p, _ := v1Credentials(r)
+
+	if a, err := h.AuthorizationService.FindAuthorizationByToken(ctx, p); err == nil {
+		if err := a.Expired(); err != nil {
+			return ctx, err
+		}
+		h.recordUsage(a)
+		return platcontext.SetAuthorizer(ctx, a), nil
+	}
+
+	if h.BasicAuthService == nil {
+		return ctx, fmt.Errorf("username and password authentication is not supported")
+	}
+
+	// The password was checked recently, so reuse the session of the user
+	// rather than comparing it again, which is deliberately slow.
+	if s := h.v1CachedSession(ctx, u, p); s != nil {
+		return platcontext.SetAuthorizer(ctx, s), nil
+	}
+
+	if err := h.BasicAuthService.ComparePassword(ctx, u, p); err != nil {
+		return ctx, err
+	}
+
+	s, err := h.v1Session(ctx, u, p)
+	if err != nil {
+		return ctx, err
+	}
+	return platcontext.SetAuthorizer(ctx, s), nil
+}
+
+// v1PasswordTTL is how long a password of a user authenticated by 1.x
+// credentials is trusted without being compared again.
+const v1PasswordTTL = time.Minute
+
+// v1Session is the session of a user authenticated by 1.x credentials, and the
+// hash of the password that was last checked for it.
+type v1Session struct {
+	key      string
+	password [sha256.Size]byte
+	checked  time.Time
+}
+
+// v1CachedSession returns the session of a user whose password was checked
+// less than v1PasswordTTL ago, or nil if there is none or it has expired.
+func (h *AuthenticationHandler) v1CachedSession(ctx context.Context, user, password string) *platform.Session {
+	h.mu.Lock()
+	cached, ok := h.v1Sessions[user]
+	h.mu.Unlock()
+
+	hash := sha256.Sum256([]byte(password))
+	if !ok || time.Since(cached.checked) > v1PasswordTTL || subtle.ConstantTimeCompare(hash[:], cached.password[:]) != 1 {
+		return nil
+	}
+
+	s, err := h.SessionService.FindSession(ctx, cached.key)
+	if err != nil || s.Expired() != nil {
+		return nil
+	}
+	return s
+}
+
+// v1Session returns a session of a user authenticated by 1.x credentials,
+// so that each request of 1.x clients does not create a session.
+func (h *AuthenticationHandler) v1Session(ctx context.Context, user, password string) (*platform.Session, error) {
+	h.mu.Lock()
+	cached, ok := h.v1Sessions[user]
+	h.mu.Unlock()
+
+	var s *platform.Session
+	if ok {
+		if found, err := h.SessionService.FindSession(ctx, cached.key); err == nil && found.Expired() == nil {
+			s = found
+		}
+	}
+	if s == nil {
+		var err error
+		if s, err = h.SessionService.CreateSession(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	h.mu.Lock()
+	h.v1Sessions[user] = v1Session{
+		key:      s.Key,
+		password: sha256.Sum256([]byte(password)),
+		checked:  time.Now(),
+	}
+	h.mu.Unlock()
+	return s, nil
+}
@@ -2,10 +2,13 @@ package http_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 	platformhttp "github.com/influxdata/platform/http"
@@ -82,6 +85,24 @@ func TestAuthenticationHandler(t *testing.T) {
 				code: http.StatusOK,
 			},
 		},
+		{
+			name: "token expired",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+						expiresAt := time.Now().Add(-time.Hour)
+						return &platform.Authorization{ExpiresAt: &expiresAt}, nil
+					},
+				},
+				SessionService: mock.NewSessionService(),
+			},
+			args: args{
+				token: "abc123",
+			},
+			wants: wants{
+				code: http.StatusUnauthorized,
+			},
+		},
 		{
 			name: "token does not exist",
 			fields: fields{
@@ -143,6 +164,68 @@ func TestAuthenticationHandler(t *testing.T) {
 	}
 }
 
+func TestAuthenticationHandler_RecordsUsage(t *testing.T) {
+	recentlyUsed := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name       string
+		lastUsedAt *time.Time
+		wantRecord bool
+	}{
+		{
+			name:       "never used",
+			wantRecord: true,
+		},
+		{
+			name:       "recently used",
+			lastUsedAt: &recentlyUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorded := make(chan platform.ID, 1)
+
+			h := platformhttp.NewAuthenticationHandler()
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+					return &platform.Authorization{ID: 1, LastUsedAt: tt.lastUsedAt}, nil
+				},
+			}
+			h.AuthorizationUsageRecorder = &mock.AuthorizationService{
+				SetAuthorizationLastUsedFn: func(ctx context.Context, id platform.ID, t time.Time) error {
+					recorded <- id
+					return nil
+				},
+			}
+			h.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://any.url", nil)
+			platformhttp.SetToken("abc123", r)
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, http.StatusOK; got != want {
+				t.Fatalf("expected status code to be %d got %d", want, got)
+			}
+
+			select {
+			case id := <-recorded:
+				if !tt.wantRecord {
+					t.Fatalf("unexpected usage recorded for authorization %s", id)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.wantRecord {
+					t.Fatal("expected usage of the authorization to be recorded")
+				}
+			}
+		})
+	}
+}
+
 func TestProbeAuthScheme(t *testing.T) {
 	type args struct {
 		token   string
@@ -210,6 +293,58 @@ func TestProbeAuthScheme(t *testing.T) {
 	}
 }
 
+func TestAuthenticationHandler_RecordsUsageOnce(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		recorded int
+		release  = make(chan struct{})
+	)
+
+	h := platformhttp.NewAuthenticationHandler()
+	h.AuthorizationService = &mock.AuthorizationService{
+		FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+			return &platform.Authorization{ID: 1}, nil
+		},
+	}
+	h.AuthorizationUsageRecorder = &mock.AuthorizationService{
+		SetAuthorizationLastUsedFn: func(ctx context.Context, id platform.ID, t time.Time) error {
+			mu.Lock()
+			recorded++
+			mu.Unlock()
+			<-release
+			return nil
+		},
+	}
+	h.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Requests made while the usage is being recorded, and right after it was
+	// recorded, do not record it again.
+	serve := func() {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "http://any.url", nil)
+		platformhttp.SetToken("abc123", r)
+		h.ServeHTTP(w, r)
+		if got, want := w.Code, http.StatusOK; got != want {
+			t.Fatalf("expected status code to be %d got %d", want, got)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		serve()
+	}
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+	serve()
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if recorded != 1 {
+		t.Fatalf("expected the usage to be recorded once got %d", recorded)
+	}
+}
+
 func TestAuthenticationHandler_NoAuthRoutes(t *testing.T) {
 	type route struct {
 		method string
@@ -291,3 +426,179 @@ func TestAuthenticationHandler_NoAuthRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthenticationHandler_V1AuthRoutes(t *testing.T) {
+	type fields struct {
+		AuthorizationService platform.AuthorizationService
+		BasicAuthService     platform.BasicAuthService
+	}
+	type args struct {
+		path      string
+		basicAuth bool
+		username  string
+		password  string
+	}
+	type wants struct {
+		code int
+		// v1Error is the error of a 1.x compatible route, in the shape of 1.x errors.
+		v1Error string
+	}
+
+	authorizationService := &mock.AuthorizationService{
+		FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+			if token != "token1" {
+				return nil, fmt.Errorf("authorization not found")
+			}
+			return &platform.Authorization{}, nil
+		},
+	}
+	basicAuthService := &mock.BasicAuthService{
+		ComparePasswordFn: func(ctx context.Context, name, password string) error {
+			if name != "user1" || password != "password1" {
+				return fmt.Errorf("your username or password is incorrect")
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "token as basic auth password",
+			fields: fields{
+				AuthorizationService: authorizationService,
+			},
+			args: args{
+				path:      "/query",
+				basicAuth: true,
+				username:  "anyone",
+				password:  "token1",
+			},
+			wants: wants{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "username and password query parameters",
+			fields: fields{
+				AuthorizationService: authorizationService,
+				BasicAuthService:     basicAuthService,
+			},
+			args: args{
+				path:     "/query",
+				username: "user1",
+				password: "password1",
+			},
+			wants: wants{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "wrong password",
+			fields: fields{
+				AuthorizationService: authorizationService,
+				BasicAuthService:     basicAuthService,
+			},
+			args: args{
+				path:      "/query",
+				basicAuth: true,
+				username:  "user1",
+				password:  "password2",
+			},
+			wants: wants{
+				code:    http.StatusForbidden,
+				v1Error: "authorization failed",
+			},
+		},
+		{
+			name: "route is not a v1 auth route",
+			fields: fields{
+				AuthorizationService: authorizationService,
+				BasicAuthService:     basicAuthService,
+			},
+			args: args{
+				path:      "/api/v2/query",
+				basicAuth: true,
+				username:  "anyone",
+				password:  "token1",
+			},
+			wants: wants{
+				code: http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			var created int
+			sessionService := &mock.SessionService{
+				FindSessionFn: func(ctx context.Context, key string) (*platform.Session, error) {
+					return &platform.Session{Key: key, ExpiresAt: time.Now().Add(time.Hour)}, nil
+				},
+				CreateSessionFn: func(ctx context.Context, user string) (*platform.Session, error) {
+					created++
+					return &platform.Session{Key: "session1", ExpiresAt: time.Now().Add(time.Hour)}, nil
+				},
+			}
+
+			var compared int
+			h := platformhttp.NewAuthenticationHandler()
+			h.AuthorizationService = tt.fields.AuthorizationService
+			h.SessionService = sessionService
+			if tt.fields.BasicAuthService != nil {
+				h.BasicAuthService = &mock.BasicAuthService{
+					ComparePasswordFn: func(ctx context.Context, name, password string) error {
+						compared++
+						return tt.fields.BasicAuthService.ComparePassword(ctx, name, password)
+					},
+				}
+			}
+			h.Handler = handler
+			h.RegisterV1AuthRoute("GET", "/query")
+
+			// Each request of a user authenticated by a password shares a session.
+			for i := 0; i < 2; i++ {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", tt.args.path, nil)
+				if tt.args.basicAuth {
+					r.SetBasicAuth(tt.args.username, tt.args.password)
+				} else {
+					qp := r.URL.Query()
+					qp.Set("u", tt.args.username)
+					qp.Set("p", tt.args.password)
+					r.URL.RawQuery = qp.Encode()
+				}
+
+				h.ServeHTTP(w, r)
+
+				if got, want := w.Code, tt.wants.code; got != want {
+					t.Errorf("expected status code to be %d got %d", want, got)
+				}
+				if tt.wants.v1Error != "" {
+					var body map[string]string
+					if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+						t.Fatal(err)
+					}
+					if got, want := body["error"], tt.wants.v1Error; got != want || len(body) != 1 {
+						t.Errorf("expected error %q got %s", want, w.Body.String())
+					}
+				}
+			}
+			if created > 1 {
+				t.Errorf("expected at most one session to be created got %d", created)
+			}
+			// A password that was checked is not compared again by the next request.
+			if tt.wants.code == http.StatusOK && compared > 1 {
+				t.Errorf("expected the password to be compared at most once got %d", compared)
+			}
+		})
+	}
+}
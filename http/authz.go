@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+)
+
+// authorize returns a forbidden error unless the authorizer of the request may perform
+// the action on every resource of the type, or on the resource of one of the ids.
+// The ids are usually those of a resource and of the organization that owns it.
+func authorize(ctx context.Context, a platform.Action, rt platform.Resource, ids ...platform.ID) error {
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !isAllowed(auth, a, rt, ids...) {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  fmt.Sprintf("insufficient permissions to %s %s", a, rt),
+		}
+	}
+
+	return nil
+}
+
+func isAllowed(auth platform.Authorizer, a platform.Action, rt platform.Resource, ids ...platform.ID) bool {
+	if auth.Allowed(platform.Permission{Action: a, Resource: rt}) {
+		return true
+	}
+
+	for i := range ids {
+		if !ids[i].Valid() {
+			continue
+		}
+		if auth.Allowed(platform.Permission{Action: a, Resource: rt, ID: &ids[i]}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorizeFilter returns a function that reports if the authorizer of the request
+// may perform the action on a resource of the ids. It is used to filter lists of resources.
+func authorizeFilter(ctx context.Context, a platform.Action, rt platform.Resource) (func(ids ...platform.ID) bool, error) {
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ids ...platform.ID) bool {
+		return isAllowed(auth, a, rt, ids...)
+	}, nil
+}
+
+// resourceIDsFunc returns the ids that authorize access to the resource of id,
+// such as id itself and the id of the organization of the resource.
+type resourceIDsFunc func(ctx context.Context, id platform.ID) ([]platform.ID, error)
+
+// ownResourceIDs authorizes a resource by its own id only.
+func ownResourceIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	return []platform.ID{id}, nil
+}
+
+// authorizeResource authorizes the action on the resource of id, which is of type rt.
+// The ids of resourceIDs are only looked up when the resource itself is not allowed.
+func authorizeResource(ctx context.Context, a platform.Action, rt platform.Resource, id platform.ID, resourceIDs resourceIDsFunc) error {
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAllowed(auth, a, rt, id) {
+		return nil
+	}
+
+	ids, err := resourceIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return authorize(ctx, a, rt, ids...)
+}
+
+// authorizeOwnedCreate authorizes the creation of a resource that belongs to no
+// organization. Creating one requires the action on every resource of the type,
+// unless the request is made in a user session, in which case the user becomes
+// the owner of the new resource. It returns the id of that user, or an invalid id.
+func authorizeOwnedCreate(ctx context.Context, rt platform.Resource) (platform.ID, error) {
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	if auth.Allowed(platform.Permission{Action: platform.WriteAction, Resource: rt}) {
+		return auth.GetUserID(), nil
+	}
+
+	if s, ok := auth.(*platform.Session); ok && s.Expired() == nil && s.UserID.Valid() {
+		return s.UserID, nil
+	}
+
+	return platform.InvalidID(), &platform.Error{
+		Code: platform.EForbidden,
+		Msg:  fmt.Sprintf("insufficient permissions to %s %s", platform.WriteAction, rt),
+	}
+}
+
+// createOwnerMapping makes the user the owner of a resource it created.
+func createOwnerMapping(ctx context.Context, s platform.UserResourceMappingService, rt platform.Resource, id, userID platform.ID) error {
+	if s == nil || !userID.Valid() {
+		return nil
+	}
+
+	return s.CreateUserResourceMapping(ctx, &platform.UserResourceMapping{
+		UserID:     userID,
+		UserType:   platform.Owner,
+		Resource:   rt,
+		ResourceID: id,
+	})
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+)
+
+// withOperator authorizes the request as an operator, who is allowed everything.
+// The operator is the user of the authorizer already on the request, if any.
+func withOperator(r *http.Request) *http.Request {
+	auth := &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: platform.OperPermissions(),
+	}
+	if a, err := pcontext.GetAuthorizer(r.Context()); err == nil {
+		auth.UserID = a.GetUserID()
+	}
+	return r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+}
+
+// operatorHandler serves every request with h as an operator. It is used by
+// tests of the services over HTTP rather than of their authorization.
+func operatorHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, withOperator(r))
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	orgID, bucketID := platform.ID(1), platform.ID(2)
+
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		action      platform.Action
+		ids         []platform.ID
+		wantCode    string
+	}{
+		{
+			name:        "type-wide permission",
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.BucketsResource}},
+			action:      platform.ReadAction,
+			ids:         []platform.ID{bucketID, orgID},
+		},
+		{
+			name:        "permission on the organization",
+			permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.BucketsResource, ID: &orgID}},
+			action:      platform.WriteAction,
+			ids:         []platform.ID{bucketID, orgID},
+		},
+		{
+			name:        "admin permission on the resource",
+			permissions: []platform.Permission{{Action: platform.AdminAction, Resource: platform.BucketsResource, ID: &bucketID}},
+			action:      platform.DeleteAction,
+			ids:         []platform.ID{bucketID, orgID},
+		},
+		{
+			name:        "write does not allow delete",
+			permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.BucketsResource, ID: &orgID}},
+			action:      platform.DeleteAction,
+			ids:         []platform.ID{bucketID, orgID},
+			wantCode:    platform.EForbidden,
+		},
+		{
+			name:        "permission on another resource type",
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DashboardsResource, ID: &orgID}},
+			action:      platform.ReadAction,
+			ids:         []platform.ID{bucketID, orgID},
+			wantCode:    platform.EForbidden,
+		},
+		{
+			name:        "invalid ids require a type-wide permission",
+			permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.BucketsResource, ID: &orgID}},
+			action:      platform.WriteAction,
+			ids:         []platform.ID{platform.InvalidID()},
+			wantCode:    platform.EForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			ctx := pcontext.SetAuthorizer(context.Background(), auth)
+
+			err := authorize(ctx, tt.action, platform.BucketsResource, tt.ids...)
+			if code := platform.ErrorCode(err); err != nil && code != tt.wantCode || err == nil && tt.wantCode != "" {
+				t.Fatalf("got error %v, want code %q", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuthorizeResource(t *testing.T) {
+	orgID := platform.ID(1)
+	auth := &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.BucketsResource, ID: &orgID}},
+	}
+	ctx := pcontext.SetAuthorizer(context.Background(), auth)
+
+	var looked bool
+	resourceIDs := func(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+		looked = true
+		return []platform.ID{id, orgID}, nil
+	}
+
+	if err := authorizeResource(ctx, platform.ReadAction, platform.BucketsResource, 2, resourceIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !looked {
+		t.Fatal("expected the ids of the resource to be looked up")
+	}
+
+	looked = false
+	if err := authorizeResource(ctx, platform.ReadAction, platform.BucketsResource, orgID, resourceIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if looked {
+		t.Fatal("expected the ids of an allowed resource not to be looked up")
+	}
+
+	if err := authorizeResource(ctx, platform.WriteAction, platform.BucketsResource, 2, resourceIDs); platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("got error %v, want forbidden", err)
+	}
+}
+
+func TestAuthorizeOwnedCreate(t *testing.T) {
+	userID := platform.ID(3)
+
+	tests := []struct {
+		name       string
+		authorizer platform.Authorizer
+		wantUserID platform.ID
+		wantCode   string
+	}{
+		{
+			name: "type-wide write",
+			authorizer: &platform.Authorization{
+				Status:      platform.Active,
+				UserID:      userID,
+				Permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.DashboardsResource}},
+			},
+			wantUserID: userID,
+		},
+		{
+			name: "user session",
+			authorizer: &platform.Session{
+				UserID:    userID,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			wantUserID: userID,
+		},
+		{
+			name: "expired user session",
+			authorizer: &platform.Session{
+				UserID:    userID,
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			wantCode: platform.EForbidden,
+		},
+		{
+			name: "token without write",
+			authorizer: &platform.Authorization{
+				Status:      platform.Active,
+				UserID:      userID,
+				Permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DashboardsResource}},
+			},
+			wantCode: platform.EForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := pcontext.SetAuthorizer(context.Background(), tt.authorizer)
+
+			id, err := authorizeOwnedCreate(ctx, platform.DashboardsResource)
+			if code := platform.ErrorCode(err); err != nil && code != tt.wantCode || err == nil && tt.wantCode != "" {
+				t.Fatalf("got error %v, want code %q", err, tt.wantCode)
+			}
+			if err == nil && id != tt.wantUserID {
+				t.Fatalf("got user id %s, want %s", id, tt.wantUserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	backupPath        = "/api/v2/backup"
+	backupRestorePath = "/api/v2/backup/restore"
+)
+
+// BackupHandler represents an HTTP API handler for backups.
+type BackupHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	BackupService platform.BackupService
+	BucketService platform.BucketService
+}
+
+// NewBackupHandler returns a new instance of BackupHandler.
+func NewBackupHandler() *BackupHandler {
+	h := &BackupHandler{
+		Router: NewRouter(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", backupPath, h.handleGetBackup)
+	h.HandlerFunc("POST", backupRestorePath, h.handlePostBackupRestore)
+	return h
+}
+
+// handleGetBackup is the HTTP handler for the GET /api/v2/backup route.
+// The archive is streamed as it is written, so an error after the response
+// has started can only be logged; the client is left with a truncated archive.
+func (h *BackupHandler) handleGetBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeBackupFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorize(ctx, platform.ReadAction, platform.BackupResource); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", `attachment; filename="influxd-backup.tar"`)
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.BackupService.Backup(ctx, w, filter); err != nil {
+		h.Logger.Info("Failed to write backup", zap.Error(err))
+		return
+	}
+}
+
+func decodeBackupFilter(r *http.Request) (platform.BackupFilter, error) {
+	var filter platform.BackupFilter
+	qp := r.URL.Query()
+
+	if v := qp.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "since must be an RFC3339 time",
+				Err:  err,
+			}
+		}
+		filter.Since = &t
+	}
+
+	if v := qp.Get("generation"); v != "" {
+		gen, err := strconv.Atoi(v)
+		if err != nil || gen < 0 {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid generation %q", v),
+			}
+		}
+		filter.Generation = &gen
+	}
+
+	return filter, nil
+}
+
+// handlePostBackupRestore is the HTTP handler for the POST /api/v2/backup/restore route.
+// The body is the archive to restore the bucket from.
+func (h *BackupHandler) handlePostBackupRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackupRestoreRequest(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, req.NewBucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.BucketsResource, b.ID, b.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.BackupService.RestoreBucket(ctx, r.Body, req.BucketID, req.NewBucketID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type postBackupRestoreRequest struct {
+	BucketID    platform.ID
+	NewBucketID platform.ID
+}
+
+func decodePostBackupRestoreRequest(r *http.Request) (*postBackupRestoreRequest, error) {
+	req := &postBackupRestoreRequest{}
+	qp := r.URL.Query()
+
+	ids := []struct {
+		param string
+		id    *platform.ID
+	}{
+		{param: "bucketID", id: &req.BucketID},
+		{param: "newBucketID", id: &req.NewBucketID},
+	}
+	for _, p := range ids {
+		v := qp.Get(p.param)
+		if v == "" {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("url missing %s", p.param),
+			}
+		}
+		if err := p.id.DecodeFromString(v); err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid %s %q", p.param, v),
+				Err:  err,
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// BackupService connects to Influx via HTTP using tokens to back up and restore.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// Backup writes an archive of the metadata and data selected by the filter to w.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	params := req.URL.Query()
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			params.Add(k, v)
+		}
+	}
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// RestoreBucket writes the data of the bucket bucketID in the archive read
+// from r into the existing bucket newBucketID.
+func (s *BackupService) RestoreBucket(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error {
+	u, err := newURL(s.Addr, backupRestorePath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	SetToken(s.Token, req)
+
+	params := req.URL.Query()
+	params.Set("bucketID", bucketID.String())
+	params.Set("newBucketID", newBucketID.String())
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp, true)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+func TestBackupHandler_handleGetBackup(t *testing.T) {
+	since := time.Date(2006, 5, 4, 0, 0, 0, 0, time.UTC)
+	gen := 3
+
+	tests := []struct {
+		name        string
+		query       string
+		permissions []platform.Permission
+		wantStatus  int
+		wantFilter  platform.BackupFilter
+	}{
+		{
+			name:        "full backup",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+		},
+		{
+			name:        "incremental backup",
+			query:       "?since=2006-05-04T00:00:00Z&generation=3",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+			wantFilter:  platform.BackupFilter{Since: &since, Generation: &gen},
+		},
+		{
+			name:        "org admin may not back up",
+			permissions: platform.OrgAdminPermissions(platform.ID(2)),
+			wantStatus:  403,
+		},
+		{
+			name:        "invalid time",
+			query:       "?since=yesterday",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "invalid generation",
+			query:       "?generation=-1",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter platform.BackupFilter
+			h := NewBackupHandler()
+			h.Logger = zap.NewNop()
+			h.BackupService = &mock.BackupService{
+				BackupFn: func(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+					gotFilter = filter
+					_, err := io.WriteString(w, "archive")
+					return err
+				},
+			}
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("GET", backupPath+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != 200 {
+				return
+			}
+
+			if diff := cmp.Diff(gotFilter, tt.wantFilter); diff != "" {
+				t.Errorf("filters are different -got/+want\ndiff %s", diff)
+			}
+			if got, want := w.Header().Get("Content-Type"), "application/x-tar"; got != want {
+				t.Errorf("got content type %q, want %q", got, want)
+			}
+			if got, want := w.Body.String(), "archive"; got != want {
+				t.Errorf("got body %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBackupHandler_handlePostBackupRestore(t *testing.T) {
+	orgID := platform.ID(2)
+	bucketID := platform.ID(3)
+	newBucketID := platform.ID(4)
+	query := "?bucketID=" + bucketID.String() + "&newBucketID=" + newBucketID.String()
+
+	tests := []struct {
+		name        string
+		query       string
+		permissions []platform.Permission
+		wantStatus  int
+	}{
+		{
+			name:        "org admin restores into a bucket of the org",
+			query:       query,
+			permissions: platform.OrgAdminPermissions(orgID),
+			wantStatus:  204,
+		},
+		{
+			name:        "org admin of another org",
+			query:       query,
+			permissions: platform.OrgAdminPermissions(platform.ID(5)),
+			wantStatus:  403,
+		},
+		{
+			name:        "missing new bucket",
+			query:       "?bucketID=" + bucketID.String(),
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "invalid bucket",
+			query:       "?bucketID=x&newBucketID=" + newBucketID.String(),
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArchive string
+			var gotBucketID, gotNewBucketID platform.ID
+			h := NewBackupHandler()
+			h.Logger = zap.NewNop()
+			h.BucketService = &mock.BucketService{
+				FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+					return &platform.Bucket{ID: id, OrganizationID: orgID}, nil
+				},
+			}
+			h.BackupService = &mock.BackupService{
+				RestoreBucketFn: func(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error {
+					b, err := ioutil.ReadAll(r)
+					gotArchive, gotBucketID, gotNewBucketID = string(b), bucketID, newBucketID
+					return err
+				},
+			}
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("POST", backupRestorePath+tt.query, strings.NewReader("archive"))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != 204 {
+				return
+			}
+
+			if gotArchive != "archive" || gotBucketID != bucketID || gotNewBucketID != newBucketID {
+				t.Errorf("unexpected restore of %q from bucket %s into %s", gotArchive, gotBucketID, gotNewBucketID)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ type BucketHandler struct {
 
 	BucketService              platform.BucketService
 	BucketOperationLogService  platform.BucketOperationLogService
+	OrganizationService        platform.OrganizationService
 	UserResourceMappingService platform.UserResourceMappingService
 	LabelService               platform.LabelService
 	UserService                platform.UserService
@@ -58,22 +59,46 @@ func NewBucketHandler(mappingService platform.UserResourceMappingService, labelS
 	h.HandlerFunc("PATCH", bucketsIDPath, h.handlePatchBucket)
 	h.HandlerFunc("DELETE", bucketsIDPath, h.handleDeleteBucket)
 
-	h.HandlerFunc("POST", bucketsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Member))
-	h.HandlerFunc("GET", bucketsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Member))
-	h.HandlerFunc("DELETE", bucketsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", bucketsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Member, h.bucketIDs))
+	h.HandlerFunc("GET", bucketsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Member, h.bucketIDs))
+	h.HandlerFunc("DELETE", bucketsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.BucketsResource, platform.Member, h.bucketIDs))
 
-	h.HandlerFunc("POST", bucketsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Owner))
-	h.HandlerFunc("GET", bucketsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Owner))
-	h.HandlerFunc("DELETE", bucketsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", bucketsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Owner, h.bucketIDs))
+	h.HandlerFunc("GET", bucketsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.BucketsResource, platform.Owner, h.bucketIDs))
+	h.HandlerFunc("DELETE", bucketsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.BucketsResource, platform.Owner, h.bucketIDs))
 
-	h.HandlerFunc("GET", bucketsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", bucketsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", bucketsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", bucketsIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", bucketsIDLabelsPath, newGetLabelsHandler(h.LabelService, h.bucketIDs))
+	h.HandlerFunc("POST", bucketsIDLabelsPath, newPostLabelHandler(h.LabelService, h.bucketIDs))
+	h.HandlerFunc("DELETE", bucketsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, h.bucketIDs))
+	h.HandlerFunc("PATCH", bucketsIDLabelsNamePath, newPatchLabelHandler(h.LabelService, h.bucketIDs))
 
 	return h
 }
 
+// bucketIDs returns the ids that authorize access to a bucket: its own and that of its organization.
+func (h *BucketHandler) bucketIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	b, err := h.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []platform.ID{b.ID, b.OrganizationID}, nil
+}
+
+// bucketOrganizationID returns the id of the organization a new bucket is created in.
+func (h *BucketHandler) bucketOrganizationID(ctx context.Context, b *platform.Bucket) (platform.ID, error) {
+	if b.OrganizationID.Valid() || h.OrganizationService == nil {
+		return b.OrganizationID, nil
+	}
+
+	o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &b.Organization})
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	return o.ID, nil
+}
+
 // bucket is used for serialization/deserialization with duration string syntax.
 type bucket struct {
 	ID                  platform.ID     `json:"id,omitempty"`
@@ -237,6 +262,17 @@ func (h *BucketHandler) handlePostBucket(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	orgID, err := h.bucketOrganizationID(ctx, req.Bucket)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.BucketsResource, orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.BucketService.CreateBucket(ctx, req.Bucket); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -293,6 +329,11 @@ func (h *BucketHandler) handleGetBucket(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.BucketsResource, b.ID, b.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	labels, err := h.LabelService.FindLabels(ctx, platform.LabelFilter{ResourceID: b.ID})
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -337,6 +378,11 @@ func (h *BucketHandler) handleDeleteBucket(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.BucketsResource, req.BucketID, h.bucketIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.BucketService.DeleteBucket(ctx, req.BucketID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -377,12 +423,25 @@ func (h *BucketHandler) handleGetBuckets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	bs, _, err := h.BucketService.FindBuckets(ctx, req.filter, req.opts)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.BucketsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	found, _, err := h.BucketService.FindBuckets(ctx, req.filter, req.opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	bs := make([]*platform.Bucket, 0, len(found))
+	for _, b := range found {
+		if allowed(b.ID, b.OrganizationID) {
+			bs = append(bs, b)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newBucketsResponse(ctx, req.opts, req.filter, bs, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -434,6 +493,11 @@ func (h *BucketHandler) handlePatchBucket(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.BucketsResource, req.BucketID, h.bucketIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.BucketService.UpdateBucket(ctx, req.BucketID, req.Update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -729,6 +793,11 @@ func (h *BucketHandler) handleGetBucketLog(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.ReadAction, platform.BucketsResource, req.BucketID, h.bucketIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	log, _, err := h.BucketOperationLogService.GetBucketOperationLog(ctx, req.BucketID, req.opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
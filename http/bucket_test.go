@@ -185,6 +185,7 @@ func TestService_handleGetBuckets(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetBuckets(w, r)
 
 			res := w.Result()
@@ -307,6 +308,7 @@ func TestService_handleGetBucket(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetBucket(w, r)
 
 			res := w.Result()
@@ -400,6 +402,7 @@ func TestService_handlePostBucket(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.url?org=30", bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostBucket(w, r)
 
 			res := w.Result()
@@ -502,6 +505,7 @@ func TestService_handleDeleteBucket(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteBucket(w, r)
 
 			res := w.Result()
@@ -798,6 +802,7 @@ func TestService_handlePatchBucket(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchBucket(w, r)
 
 			res := w.Result()
@@ -886,6 +891,7 @@ func TestService_handlePostBucketMember(t *testing.T) {
 			r := httptest.NewRequest("POST", path, bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.ServeHTTP(w, r)
 
 			res := w.Result()
@@ -974,6 +980,7 @@ func TestService_handlePostBucketOwner(t *testing.T) {
 			r := httptest.NewRequest("POST", path, bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.ServeHTTP(w, r)
 
 			res := w.Result()
@@ -1014,7 +1021,7 @@ func initBucketService(f platformtesting.BucketFields, t *testing.T) (platform.B
 	userService := mock.NewUserService()
 	handler := NewBucketHandler(mappingService, labelService, userService)
 	handler.BucketService = svc
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(operatorHandler(handler))
 	client := BucketService{
 		Addr:     server.URL,
 		OpPrefix: inmem.OpPrefix,
@@ -69,18 +69,18 @@ func NewDashboardHandler(mappingService platform.UserResourceMappingService, lab
 	h.HandlerFunc("GET", dashboardsIDCellsIDViewPath, h.handleGetDashboardCellView)
 	h.HandlerFunc("PATCH", dashboardsIDCellsIDViewPath, h.handlePatchDashboardCellView)
 
-	h.HandlerFunc("POST", dashboardsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member))
-	h.HandlerFunc("GET", dashboardsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member))
-	h.HandlerFunc("DELETE", dashboardsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", dashboardsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("GET", dashboardsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("DELETE", dashboardsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.DashboardsResource, platform.Member, ownResourceIDs))
 
-	h.HandlerFunc("POST", dashboardsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner))
-	h.HandlerFunc("GET", dashboardsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner))
-	h.HandlerFunc("DELETE", dashboardsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", dashboardsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("GET", dashboardsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("DELETE", dashboardsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.DashboardsResource, platform.Owner, ownResourceIDs))
 
-	h.HandlerFunc("GET", dashboardsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", dashboardsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", dashboardsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", dashboardsIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", dashboardsIDLabelsPath, newGetLabelsHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("POST", dashboardsIDLabelsPath, newPostLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("DELETE", dashboardsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("PATCH", dashboardsIDLabelsNamePath, newPatchLabelHandler(h.LabelService, ownResourceIDs))
 
 	return h
 }
@@ -248,12 +248,25 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	dashboards, _, err := h.DashboardService.FindDashboards(ctx, req.filter, req.opts)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.DashboardsResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, _, err := h.DashboardService.FindDashboards(ctx, req.filter, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	dashboards := make([]*platform.Dashboard, 0, len(found))
+	for _, d := range found {
+		if allowed(d.ID) {
+			dashboards = append(dashboards, d)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newGetDashboardsResponse(ctx, dashboards, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -339,11 +352,23 @@ func (h *DashboardHandler) handlePostDashboard(w http.ResponseWriter, r *http.Re
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	userID, err := authorizeOwnedCreate(ctx, platform.DashboardsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.CreateDashboard(ctx, req.Dashboard); err != nil {
 		EncodeError(ctx, errors.InternalErrorf("Error loading dashboards: %v", err), w)
 		return
 	}
 
+	if err := createOwnerMapping(ctx, h.UserResourceMappingService, platform.DashboardsResource, req.Dashboard.ID, userID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardResponse(req.Dashboard, []*platform.Label{})); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -374,6 +399,11 @@ func (h *DashboardHandler) handleGetDashboard(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.DashboardsResource, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	dashboard, err := h.DashboardService.FindDashboardByID(ctx, req.DashboardID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -423,6 +453,11 @@ func (h *DashboardHandler) handleGetDashboardLog(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.DashboardsResource, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	log, _, err := h.DashboardOperationLogService.GetDashboardOperationLog(ctx, req.DashboardID, req.opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -488,6 +523,11 @@ func (h *DashboardHandler) handleDeleteDashboard(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := authorize(ctx, platform.DeleteAction, platform.DashboardsResource, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.DeleteDashboard(ctx, req.DashboardID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -526,6 +566,12 @@ func (h *DashboardHandler) handlePatchDashboard(w http.ResponseWriter, r *http.R
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.DashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	dashboard, err := h.DashboardService.UpdateDashboard(ctx, req.DashboardID, req.Upd)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -631,6 +677,12 @@ func (h *DashboardHandler) handlePostDashboardCell(w http.ResponseWriter, r *htt
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.AddDashboardCell(ctx, req.dashboardID, req.cell, req.opts); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -677,6 +729,11 @@ func (h *DashboardHandler) handlePutDashboardCells(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.ReplaceDashboardCells(ctx, req.dashboardID, req.cells); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -753,6 +810,11 @@ func (h *DashboardHandler) handleGetDashboardCellView(w http.ResponseWriter, r *
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	view, err := h.DashboardService.GetDashboardCellView(ctx, req.dashboardID, req.cellID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -807,6 +869,11 @@ func (h *DashboardHandler) handlePatchDashboardCellView(w http.ResponseWriter, r
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	view, err := h.DashboardService.UpdateDashboardCellView(ctx, req.dashboardID, req.cellID, req.upd)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -828,6 +895,12 @@ func (h *DashboardHandler) handleDeleteDashboardCell(w http.ResponseWriter, r *h
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.DashboardService.RemoveDashboardCell(ctx, req.dashboardID, req.cellID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -891,6 +964,12 @@ func (h *DashboardHandler) handlePatchDashboardCell(w http.ResponseWriter, r *ht
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.DashboardsResource, req.dashboardID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	cell, err := h.DashboardService.UpdateDashboardCell(ctx, req.dashboardID, req.cellID, req.upd)
 	if err != nil {
 		EncodeError(ctx, err, w)
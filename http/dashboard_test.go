@@ -214,6 +214,7 @@ func TestService_handleGetDashboards(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetDashboards(w, r)
 
 			res := w.Result()
@@ -363,6 +364,7 @@ func TestService_handleGetDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetDashboard(w, r)
 
 			res := w.Result()
@@ -485,6 +487,7 @@ func TestService_handlePostDashboard(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostDashboard(w, r)
 
 			res := w.Result()
@@ -586,6 +589,7 @@ func TestService_handleDeleteDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteDashboard(w, r)
 
 			res := w.Result()
@@ -770,6 +774,7 @@ func TestService_handlePatchDashboard(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchDashboard(w, r)
 
 			res := w.Result()
@@ -874,6 +879,7 @@ func TestService_handlePostDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostDashboardCell(w, r)
 
 			res := w.Result()
@@ -958,6 +964,7 @@ func TestService_handleDeleteDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteDashboardCell(w, r)
 
 			res := w.Result()
@@ -1089,6 +1096,7 @@ func TestService_handlePatchDashboardCell(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchDashboardCell(w, r)
 
 			res := w.Result()
@@ -1148,7 +1156,7 @@ func initDashboardService(f platformtesting.DashboardFields, t *testing.T) (plat
 	userService := mock.NewUserService()
 	h := NewDashboardHandler(mappingService, labelService, userService)
 	h.DashboardService = svc
-	server := httptest.NewServer(h)
+	server := httptest.NewServer(operatorHandler(h))
 	client := DashboardService{
 		Addr:     server.URL,
 		OpPrefix: inmem.OpPrefix,
@@ -0,0 +1,592 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	dbrpMappingPath = "/api/v2/dbrps"
+)
+
+// DBRPMappingHandler is the handler for the dbrp mapping service. Mappings
+// are authorized by the dbrps permissions on their organization or on their bucket.
+type DBRPMappingHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	BucketService      platform.BucketService
+}
+
+// NewDBRPMappingHandler returns a new instance of DBRPMappingHandler.
+func NewDBRPMappingHandler() *DBRPMappingHandler {
+	h := &DBRPMappingHandler{
+		Router: NewRouter(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", dbrpMappingPath, h.handleGetDBRPMappings)
+	h.HandlerFunc("POST", dbrpMappingPath, h.handlePostDBRPMapping)
+	h.HandlerFunc("GET", dbrpMappingPath+"/:orgID/:cluster/:db/:rp", h.handleGetDBRPMapping)
+	h.HandlerFunc("DELETE", dbrpMappingPath+"/:orgID/:cluster/:db/:rp", h.handleDeleteDBRPMapping)
+	return h
+}
+
+type dbrpMappingLinks struct {
+	Self         string `json:"self"`
+	Bucket       string `json:"bucket"`
+	Organization string `json:"org"`
+}
+
+type dbrpMappingResponse struct {
+	*platform.DBRPMapping
+	Links dbrpMappingLinks `json:"links"`
+}
+
+func newDBRPMappingResponse(m *platform.DBRPMapping) dbrpMappingResponse {
+	return dbrpMappingResponse{
+		DBRPMapping: m,
+		Links: dbrpMappingLinks{
+			Self:         dbrpMappingKeyPath(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy),
+			Bucket:       fmt.Sprintf("/api/v2/buckets/%s", m.BucketID),
+			Organization: fmt.Sprintf("/api/v2/orgs/%s", m.OrganizationID),
+		},
+	}
+}
+
+type dbrpMappingsLinks struct {
+	Self string `json:"self"`
+}
+
+type getDBRPMappingsResponse struct {
+	DBRPMappings []dbrpMappingResponse `json:"dbrps"`
+	Links        dbrpMappingsLinks     `json:"links"`
+}
+
+func newGetDBRPMappingsResponse(ms []*platform.DBRPMapping) getDBRPMappingsResponse {
+	resp := getDBRPMappingsResponse{
+		DBRPMappings: make([]dbrpMappingResponse, 0, len(ms)),
+		Links: dbrpMappingsLinks{
+			Self: dbrpMappingPath,
+		},
+	}
+
+	for _, m := range ms {
+		resp.DBRPMappings = append(resp.DBRPMappings, newDBRPMappingResponse(m))
+	}
+
+	return resp
+}
+
+// authorizeDBRPMapping returns a forbidden error unless the authorizer may perform the action
+// on all mappings, on the mappings of the organization of the mapping, or on those of its bucket.
+func authorizeDBRPMapping(ctx context.Context, action platform.Action, m *platform.DBRPMapping) error {
+	return authorize(ctx, action, platform.DBRPsResource, m.OrganizationID, m.BucketID)
+}
+
+// handleGetDBRPMappings is the HTTP handler for the GET /api/v2/dbrps route.
+// Only the mappings the request may read are returned.
+func (h *DBRPMappingHandler) handleGetDBRPMappings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeGetDBRPMappingsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ms, _, err := h.DBRPMappingService.FindMany(ctx, *filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	allowed := make([]*platform.DBRPMapping, 0, len(ms))
+	for _, m := range ms {
+		if err := authorizeDBRPMapping(ctx, platform.ReadAction, m); err != nil {
+			if platform.ErrorCode(err) == platform.EForbidden {
+				continue
+			}
+			EncodeError(ctx, err, w)
+			return
+		}
+		allowed = append(allowed, m)
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newGetDBRPMappingsResponse(allowed)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeGetDBRPMappingsRequest(ctx context.Context, r *http.Request) (*platform.DBRPMappingFilter, error) {
+	qp := r.URL.Query()
+	filter := &platform.DBRPMappingFilter{}
+
+	if id := qp.Get("orgID"); id != "" {
+		orgID, err := platform.IDFromString(id)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid orgID %q", id),
+				Err:  err,
+			}
+		}
+		filter.OrganizationID = orgID
+	}
+
+	if cluster := qp.Get("cluster"); cluster != "" {
+		filter.Cluster = &cluster
+	}
+
+	if db := qp.Get("db"); db != "" {
+		filter.Database = &db
+	}
+
+	if rp := qp.Get("rp"); rp != "" {
+		filter.RetentionPolicy = &rp
+	}
+
+	if d := qp.Get("default"); d != "" {
+		isDefault, err := strconv.ParseBool(d)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid default %q", d),
+				Err:  err,
+			}
+		}
+		filter.Default = &isDefault
+	}
+
+	return filter, nil
+}
+
+// handlePostDBRPMapping is the HTTP handler for the POST /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handlePostDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	m, err := decodePostDBRPMappingRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The bucket is only looked up once the request may write the mappings of its
+	// organization or of the bucket, so that it does not reveal which buckets exist.
+	if err := authorizeDBRPMapping(ctx, platform.WriteAction, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, m.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if m.OrganizationID != b.OrganizationID {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("bucket %s does not belong to organization %s", b.ID, m.OrganizationID),
+		}, w)
+		return
+	}
+
+	if err := m.Validate(); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.DBRPMappingService.Create(ctx, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDBRPMappingResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// decodePostDBRPMappingRequest decodes the mapping of the request, which
+// is in the default cluster unless it names one.
+func decodePostDBRPMappingRequest(ctx context.Context, r *http.Request) (*platform.DBRPMapping, error) {
+	m := &platform.DBRPMapping{}
+	if err := json.NewDecoder(r.Body).Decode(m); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "malformed dbrp mapping",
+			Err:  err,
+		}
+	}
+
+	if m.Cluster == "" {
+		m.Cluster = platform.DefaultDBRPCluster
+	}
+
+	if !m.OrganizationID.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "organization_id is required",
+		}
+	}
+
+	if !m.BucketID.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "bucket_id is required",
+		}
+	}
+
+	return m, nil
+}
+
+type dbrpMappingKey struct {
+	OrganizationID                     platform.ID
+	Cluster, Database, RetentionPolicy string
+}
+
+func decodeDBRPMappingKey(ctx context.Context) (*dbrpMappingKey, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("orgID")
+	orgID, err := platform.IDFromString(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("invalid orgID %q", id),
+			Err:  err,
+		}
+	}
+
+	return &dbrpMappingKey{
+		OrganizationID:  *orgID,
+		Cluster:         params.ByName("cluster"),
+		Database:        params.ByName("db"),
+		RetentionPolicy: params.ByName("rp"),
+	}, nil
+}
+
+// handleGetDBRPMapping is the HTTP handler for the GET /api/v2/dbrps/:orgID/:cluster/:db/:rp route.
+func (h *DBRPMappingHandler) handleGetDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	k, err := decodeDBRPMappingKey(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	m, err := h.DBRPMappingService.FindBy(ctx, k.OrganizationID, k.Cluster, k.Database, k.RetentionPolicy)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeDBRPMapping(ctx, platform.ReadAction, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDBRPMappingResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteDBRPMapping is the HTTP handler for the DELETE /api/v2/dbrps/:orgID/:cluster/:db/:rp route.
+func (h *DBRPMappingHandler) handleDeleteDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	k, err := decodeDBRPMappingKey(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	m, err := h.DBRPMappingService.FindBy(ctx, k.OrganizationID, k.Cluster, k.Database, k.RetentionPolicy)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeDBRPMapping(ctx, platform.DeleteAction, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.DBRPMappingService.Delete(ctx, k.OrganizationID, k.Cluster, k.Database, k.RetentionPolicy); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func dbrpMappingKeyPath(orgID platform.ID, cluster, db, rp string) string {
+	return path.Join(dbrpMappingPath, orgID.String(), url.PathEscape(cluster), url.PathEscape(db), url.PathEscape(rp))
+}
+
+// DBRPMappingService connects to Influx via HTTP using tokens to manage dbrp mappings.
+type DBRPMappingService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// FindBy returns the dbrp mapping of the organization for the cluster, db and rp.
+func (s *DBRPMappingService) FindBy(ctx context.Context, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	u, err := newURL(s.Addr, dbrpMappingKeyPath(orgID, cluster, db, rp))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, err
+	}
+
+	var mr dbrpMappingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, err
+	}
+
+	return mr.DBRPMapping, nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		return s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+	}
+
+	ms, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, platform.ErrDBRPMappingNotFound
+	}
+
+	return ms[0], nil
+}
+
+// FindMany returns the dbrp mappings that match filter and the number of matching mappings.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+	u, err := newURL(s.Addr, dbrpMappingPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	if filter.OrganizationID != nil {
+		query.Add("orgID", filter.OrganizationID.String())
+	}
+	if filter.Cluster != nil {
+		query.Add("cluster", *filter.Cluster)
+	}
+	if filter.Database != nil {
+		query.Add("db", *filter.Database)
+	}
+	if filter.RetentionPolicy != nil {
+		query.Add("rp", *filter.RetentionPolicy)
+	}
+	if filter.Default != nil {
+		query.Add("default", strconv.FormatBool(*filter.Default))
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.URL.RawQuery = query.Encode()
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, 0, err
+	}
+
+	var mr getDBRPMappingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, 0, err
+	}
+
+	ms := make([]*platform.DBRPMapping, 0, len(mr.DBRPMappings))
+	for _, m := range mr.DBRPMappings {
+		ms = append(ms, m.DBRPMapping)
+	}
+
+	return ms, len(ms), nil
+}
+
+// Create creates a new dbrp mapping.
+func (s *DBRPMappingService) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	u, err := newURL(s.Addr, dbrpMappingPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(m)
+}
+
+// Delete removes the dbrp mapping of the organization for the cluster, db and rp.
+// Deleting a mapping that does not exist is not an error.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID platform.ID, cluster, db, rp string) error {
+	u, err := newURL(s.Addr, dbrpMappingKeyPath(orgID, cluster, db, rp))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil && platform.ErrorCode(err) != platform.ENotFound {
+		return err
+	}
+	return nil
+}
+
+// authorizerOrganization returns the organization of the authorizer, which is
+// invalid unless the authorizer is the token of an organization.
+func authorizerOrganization(a platform.Authorizer) platform.ID {
+	if auth, ok := a.(*platform.Authorization); ok {
+		return auth.OrgID
+	}
+	return platform.InvalidID()
+}
+
+// findDBRPMapping returns the mapping of a database and retention policy, or
+// of the default retention policy of the database if rp is empty. Only the
+// mappings of the organization are found if orgID is valid.
+func findDBRPMapping(ctx context.Context, svc platform.DBRPMappingService, orgID platform.ID, db, rp string) (*platform.DBRPMapping, error) {
+	if db == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "database is required",
+		}
+	}
+
+	cluster := platform.DefaultDBRPCluster
+	filter := platform.DBRPMappingFilter{
+		Cluster:  &cluster,
+		Database: &db,
+	}
+	if orgID.Valid() {
+		filter.OrganizationID = &orgID
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	} else {
+		defaultRP := true
+		filter.Default = &defaultRP
+	}
+
+	m, err := svc.Find(ctx, filter)
+	if platform.ErrorCode(err) == platform.ENotFound {
+		msg := fmt.Sprintf("database not found: %q", db)
+		if rp != "" {
+			msg = fmt.Sprintf("retention policy not found: %q", rp)
+		}
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  msg,
+			Err:  err,
+		}
+	}
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Msg:  fmt.Sprintf("unable to find database %q", db),
+			Err:  err,
+		}
+	}
+	return m, nil
+}
+
+// mappingsOrganization returns the organization of the buckets of the mappings, which
+// is invalid if there are none. Queries may not read from the buckets of several organizations.
+func mappingsOrganization(mappings []*platform.DBRPMapping) (platform.ID, error) {
+	var orgID platform.ID
+	for _, m := range mappings {
+		if orgID.Valid() && m.OrganizationID != orgID {
+			return 0, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("query reads databases of several organizations: %q and %q", mappings[0].Database, m.Database),
+			}
+		}
+		orgID = m.OrganizationID
+	}
+	return orgID, nil
+}
@@ -0,0 +1,341 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/kv"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+func newDBRPMappingTestHandler(t *testing.T, ms ...*platform.DBRPMapping) *DBRPMappingHandler {
+	t.Helper()
+
+	s := kv.NewDBRPMappingService(inmem.NewKVStore())
+	if err := s.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range ms {
+		if err := s.Create(context.Background(), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := NewDBRPMappingHandler()
+	h.Logger = zap.NewNop()
+	h.DBRPMappingService = s
+	h.BucketService = &mock.BucketService{
+		FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+			return &platform.Bucket{ID: id, OrganizationID: id + 1, Name: "bucket"}, nil
+		},
+	}
+	return h
+}
+
+func TestDBRPMappingHandler_handleGetDBRPMappings(t *testing.T) {
+	ms := []*platform.DBRPMapping{
+		{Cluster: "default", Database: "db", RetentionPolicy: "autogen", Default: true, OrganizationID: 2, BucketID: 1},
+		{Cluster: "default", Database: "db", RetentionPolicy: "weekly", OrganizationID: 2, BucketID: 3},
+		{Cluster: "default", Database: "other", RetentionPolicy: "autogen", Default: true, OrganizationID: 5, BucketID: 4},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		permissions []platform.Permission
+		wantStatus  int
+		wantRPs     []string
+	}{
+		{
+			name:        "operator reads every mapping",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+			wantRPs:     []string{"db/autogen", "db/weekly", "other/autogen"},
+		},
+		{
+			name:        "org admin reads the mappings of the org",
+			permissions: platform.OrgAdminPermissions(2),
+			wantStatus:  200,
+			wantRPs:     []string{"db/autogen", "db/weekly"},
+		},
+		{
+			name:        "reader of the mappings of the bucket",
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DBRPsResource, ID: idPtr(3)}},
+			wantStatus:  200,
+			wantRPs:     []string{"db/weekly"},
+		},
+		{
+			name:        "filter by organization",
+			query:       "?orgID=0000000000000005",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+			wantRPs:     []string{"other/autogen"},
+		},
+		{
+			name:        "invalid organization",
+			query:       "?orgID=invalid",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "filter by default",
+			query:       "?db=db&default=true",
+			permissions: platform.OperPermissions(),
+			wantStatus:  200,
+			wantRPs:     []string{"db/autogen"},
+		},
+		{
+			name:        "invalid default",
+			query:       "?default=maybe",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDBRPMappingTestHandler(t, ms...)
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("GET", dbrpMappingPath+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != 200 {
+				return
+			}
+
+			var res struct {
+				DBRPMappings []*platform.DBRPMapping `json:"dbrps"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, m := range res.DBRPMappings {
+				got = append(got, m.Database+"/"+m.RetentionPolicy)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantRPs, ",") {
+				t.Fatalf("got mappings %v, want %v", got, tt.wantRPs)
+			}
+		})
+	}
+}
+
+func TestDBRPMappingHandler_handlePostDBRPMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		permissions []platform.Permission
+		wantStatus  int
+		wantMapping *platform.DBRPMapping
+	}{
+		{
+			name:        "cluster is inferred",
+			body:        `{"database":"db","retention_policy":"autogen","default":true,"organization_id":"0000000000000002","bucket_id":"0000000000000001"}`,
+			permissions: platform.OrgAdminPermissions(2),
+			wantStatus:  201,
+			wantMapping: &platform.DBRPMapping{Cluster: "default", Database: "db", RetentionPolicy: "autogen", Default: true, OrganizationID: 2, BucketID: 1},
+		},
+		{
+			name:        "organization of another bucket",
+			body:        `{"database":"db","retention_policy":"autogen","organization_id":"0000000000000005","bucket_id":"0000000000000001"}`,
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "missing organization",
+			body:        `{"database":"db","retention_policy":"autogen","bucket_id":"0000000000000001"}`,
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "missing bucket",
+			body:        `{"database":"db","retention_policy":"autogen","organization_id":"0000000000000002"}`,
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "read only on bucket",
+			body:        `{"database":"db","retention_policy":"autogen","organization_id":"0000000000000002","bucket_id":"0000000000000001"}`,
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DBRPsResource, ID: idPtr(1)}},
+			wantStatus:  403,
+		},
+		{
+			name:        "admin of another organization",
+			body:        `{"database":"db","retention_policy":"autogen","organization_id":"0000000000000002","bucket_id":"0000000000000001"}`,
+			permissions: platform.OrgAdminPermissions(5),
+			wantStatus:  403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDBRPMappingTestHandler(t)
+			var lookups int
+			h.BucketService.(*mock.BucketService).FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+				lookups++
+				return &platform.Bucket{ID: id, OrganizationID: id + 1, Name: "bucket"}, nil
+			}
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("POST", dbrpMappingPath, strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if w.Code == 403 && lookups != 0 {
+				t.Fatal("the bucket was looked up before the request was authorized")
+			}
+
+			ms, _, err := h.DBRPMappingService.FindMany(context.Background(), platform.DBRPMappingFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantMapping == nil {
+				if len(ms) != 0 {
+					t.Fatalf("unexpected mappings %+v", ms)
+				}
+				return
+			}
+			if len(ms) != 1 || !ms[0].Equal(tt.wantMapping) {
+				t.Fatalf("got mappings %+v, want %+v", ms, tt.wantMapping)
+			}
+		})
+	}
+}
+
+func TestDBRPMappingHandler_handleDeleteDBRPMapping(t *testing.T) {
+	m := &platform.DBRPMapping{Cluster: "default", Database: "db", RetentionPolicy: "autogen", Default: true, OrganizationID: 2, BucketID: 1}
+
+	tests := []struct {
+		name        string
+		path        string
+		permissions []platform.Permission
+		wantStatus  int
+		wantDeleted bool
+	}{
+		{
+			name:        "delete mapping",
+			path:        dbrpMappingKeyPath(2, "default", "db", "autogen"),
+			permissions: platform.OrgAdminPermissions(2),
+			wantStatus:  204,
+			wantDeleted: true,
+		},
+		{
+			name:        "mapping not found",
+			path:        dbrpMappingKeyPath(2, "default", "db", "weekly"),
+			permissions: platform.OperPermissions(),
+			wantStatus:  404,
+		},
+		{
+			name:        "mapping of another organization",
+			path:        dbrpMappingKeyPath(5, "default", "db", "autogen"),
+			permissions: platform.OperPermissions(),
+			wantStatus:  404,
+		},
+		{
+			name:        "invalid organization",
+			path:        dbrpMappingPath + "/invalid/default/db/autogen",
+			permissions: platform.OperPermissions(),
+			wantStatus:  400,
+		},
+		{
+			name:        "read only on bucket",
+			path:        dbrpMappingKeyPath(2, "default", "db", "autogen"),
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DBRPsResource, ID: idPtr(1)}},
+			wantStatus:  403,
+		},
+		{
+			name:        "write does not allow delete",
+			path:        dbrpMappingKeyPath(2, "default", "db", "autogen"),
+			permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.DBRPsResource, ID: idPtr(2)}},
+			wantStatus:  403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDBRPMappingTestHandler(t, m)
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("DELETE", tt.path, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			_, err := h.DBRPMappingService.FindBy(context.Background(), m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
+			if deleted := platform.ErrorCode(err) == platform.ENotFound; deleted != tt.wantDeleted {
+				t.Fatalf("got deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func idPtr(id platform.ID) *platform.ID {
+	return &id
+}
+
+func TestMappingsOrganization(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []*platform.DBRPMapping
+		want     platform.ID
+		wantErr  bool
+	}{
+		{
+			name: "no mappings",
+		},
+		{
+			name: "single organization",
+			mappings: []*platform.DBRPMapping{
+				{Database: "db1", OrganizationID: 1, BucketID: 10},
+				{Database: "db2", OrganizationID: 1, BucketID: 20},
+			},
+			want: 1,
+		},
+		{
+			name: "several organizations",
+			mappings: []*platform.DBRPMapping{
+				{Database: "db1", OrganizationID: 1, BucketID: 10},
+				{Database: "db2", OrganizationID: 2, BucketID: 20},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mappingsOrganization(tt.mappings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("expected an invalid error, got %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got organization %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/storage"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// DeleteHandler receives a delete request with a predicate and sends it to
+// the storage engine.
+type DeleteHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+
+	Deleter storage.BucketRangeDeleter
+}
+
+const (
+	deletePath = "/api/v2/delete"
+)
+
+// NewDeleteHandler creates a new handler at /api/v2/delete to delete data
+// from buckets.
+func NewDeleteHandler(deleter storage.BucketRangeDeleter) *DeleteHandler {
+	h := &DeleteHandler{
+		Router:  NewRouter(),
+		Logger:  zap.NewNop(),
+		Deleter: deleter,
+	}
+
+	h.HandlerFunc("POST", deletePath, h.handleDelete)
+	return h
+}
+
+func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeDeleteRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+
+	var org *platform.Organization
+	if id, err := platform.IDFromString(req.Org); err == nil {
+		// Decoded ID successfully. Make sure it's a real org.
+		o, err := h.OrganizationService.FindOrganizationByID(ctx, *id)
+		if err == nil {
+			org = o
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+	if org == nil {
+		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Org})
+		if err != nil {
+			logger.Info("Failed to find organization", zap.Error(err))
+			EncodeError(ctx, &platform.Error{
+				Code: platform.ENotFound,
+				Op:   "http/handleDelete",
+				Err:  err,
+				Msg:  fmt.Sprintf("organization %q not found", req.Org),
+			}, w)
+			return
+		}
+
+		org = o
+	}
+
+	var bucket *platform.Bucket
+	if id, err := platform.IDFromString(req.Bucket); err == nil {
+		// Decoded ID successfully. Make sure it's a real bucket.
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			ID:             id,
+		})
+		if err == nil {
+			bucket = b
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+	if bucket == nil {
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			Name:           &req.Bucket,
+		})
+		if err != nil {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.ENotFound,
+				Op:   "http/handleDelete",
+				Err:  err,
+				Msg:  fmt.Sprintf("bucket %q not found", req.Bucket),
+			}, w)
+			return
+		}
+
+		bucket = b
+	}
+
+	p, err := platform.NewPermissionAtID(bucket.ID, platform.DeleteAction, platform.BucketsResource)
+	if err != nil {
+		EncodeError(ctx, fmt.Errorf("could not create permission for bucket: %v", err), w)
+		return
+	}
+
+	if !a.Allowed(*p) {
+		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for delete"), w)
+		return
+	}
+
+	// The stop time is exclusive, whereas the engine deletes inclusive ranges.
+	min, max := req.Start.UnixNano(), req.Stop.UnixNano()-1
+	if err := h.Deleter.DeleteBucketRange(org.ID, bucket.ID, min, max, req.Expr); err != nil {
+		logger.Info("Error deleting data", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logger.Info("Deleted data", zap.Time("start", req.Start), zap.Time("stop", req.Stop), zap.String("predicate", req.Predicate))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type deleteRequest struct {
+	Org       string
+	Bucket    string
+	Start     time.Time
+	Stop      time.Time
+	Predicate string
+	Expr      influxql.Expr
+}
+
+// deleteRequestBody is the JSON body of a delete request.
+type deleteRequestBody struct {
+	Start     time.Time `json:"start"`
+	Stop      time.Time `json:"stop"`
+	Predicate string    `json:"predicate,omitempty"`
+}
+
+func decodeDeleteRequest(ctx context.Context, r *http.Request) (*deleteRequest, error) {
+	qp := r.URL.Query()
+	req := &deleteRequest{
+		Org:    qp.Get("org"),
+		Bucket: qp.Get("bucket"),
+	}
+
+	var body deleteRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "invalid request body",
+			Err:  err,
+		}
+	}
+
+	if body.Start.IsZero() || body.Stop.IsZero() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "start and stop are required",
+		}
+	}
+	if !body.Start.Before(body.Stop) {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "start must be before stop",
+		}
+	}
+	req.Start, req.Stop = body.Start, body.Stop
+
+	pred, err := storage.ParseDeletePredicate(body.Predicate)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "invalid predicate",
+			Err:  err,
+		}
+	}
+	req.Predicate, req.Expr = body.Predicate, pred
+
+	return req, nil
+}
+
+// DeleteService deletes data from buckets via the HTTP API.
+type DeleteService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// DeleteBucketRange deletes the data in the bucket between start inclusive
+// and stop exclusive, for all series matching predicate. An empty predicate
+// matches all series in the bucket.
+func (s *DeleteService) DeleteBucketRange(ctx context.Context, orgID, bucketID platform.ID, start, stop time.Time, predicate string) error {
+	u, err := newURL(s.Addr, deletePath)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(deleteRequestBody{
+		Start:     start,
+		Stop:      stop,
+		Predicate: predicate,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	org, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+
+	bucket, err := bucketID.Encode()
+	if err != nil {
+		return err
+	}
+
+	params := req.URL.Query()
+	params.Set("org", string(org))
+	params.Set("bucket", string(bucket))
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	return CheckError(resp, true)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+type fakeBucketRangeDeleter struct {
+	DeleteBucketRangeFn func(orgID, bucketID platform.ID, min, max int64, pred influxql.Expr) error
+}
+
+func (d *fakeBucketRangeDeleter) DeleteBucketRange(orgID, bucketID platform.ID, min, max int64, pred influxql.Expr) error {
+	return d.DeleteBucketRangeFn(orgID, bucketID, min, max, pred)
+}
+
+func TestDeleteHandler_handleDelete(t *testing.T) {
+	const (
+		orgID    platform.ID = 1
+		bucketID platform.ID = 2
+	)
+
+	type called struct {
+		min, max int64
+		pred     string
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		permission platform.Action
+		wantStatus int
+		wantCalled *called
+	}{
+		{
+			name:       "delete with predicate",
+			body:       `{"start":"2018-01-01T00:00:00Z","stop":"2018-01-02T00:00:00Z","predicate":"host = 'a'"}`,
+			permission: platform.DeleteAction,
+			wantStatus: http.StatusNoContent,
+			wantCalled: &called{
+				min:  time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+				max:  time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC).UnixNano() - 1,
+				pred: "host = 'a'",
+			},
+		},
+		{
+			name:       "delete without predicate",
+			body:       `{"start":"2018-01-01T00:00:00Z","stop":"2018-01-02T00:00:00Z"}`,
+			permission: platform.DeleteAction,
+			wantStatus: http.StatusNoContent,
+			wantCalled: &called{
+				min: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+				max: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC).UnixNano() - 1,
+			},
+		},
+		{
+			name:       "read only permission",
+			body:       `{"start":"2018-01-01T00:00:00Z","stop":"2018-01-02T00:00:00Z"}`,
+			permission: platform.ReadAction,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "write permission",
+			body:       `{"start":"2018-01-01T00:00:00Z","stop":"2018-01-02T00:00:00Z"}`,
+			permission: platform.WriteAction,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid predicate",
+			body:       `{"start":"2018-01-01T00:00:00Z","stop":"2018-01-02T00:00:00Z","predicate":"_value > 1"}`,
+			permission: platform.DeleteAction,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "stop before start",
+			body:       `{"start":"2018-01-02T00:00:00Z","stop":"2018-01-01T00:00:00Z"}`,
+			permission: platform.DeleteAction,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *called
+			h := NewDeleteHandler(&fakeBucketRangeDeleter{
+				DeleteBucketRangeFn: func(o, b platform.ID, min, max int64, pred influxql.Expr) error {
+					if o != orgID || b != bucketID {
+						t.Errorf("got org %v and bucket %v, want %v and %v", o, b, orgID, bucketID)
+					}
+					got = &called{min: min, max: max}
+					if pred != nil {
+						got.pred = pred.String()
+					}
+					return nil
+				},
+			})
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+					return &platform.Organization{ID: id, Name: "org"}, nil
+				},
+			}
+			h.BucketService = &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+					return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID, Name: "bucket"}, nil
+				},
+			}
+
+			p, err := platform.NewPermissionAtID(bucketID, tt.permission, platform.BucketsResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{*p}}
+
+			r := httptest.NewRequest("POST", "/api/v2/delete?org="+orgID.String()+"&bucket="+bucketID.String(), strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			if tt.wantCalled == nil {
+				if got != nil {
+					t.Fatalf("unexpected delete: %+v", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.wantCalled {
+				t.Fatalf("got delete %+v, want %+v", got, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestDeleteService_DeleteBucketRange(t *testing.T) {
+	var (
+		org, bucket *platform.ID
+		body        deleteRequestBody
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, _ = platform.IDFromString(r.URL.Query().Get("org"))
+		bucket, _ = platform.IDFromString(r.URL.Query().Get("bucket"))
+		defer r.Body.Close()
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	s := &DeleteService{Addr: ts.URL}
+	if err := s.DeleteBucketRange(context.Background(), 1, 2, start, stop, "host = 'a'"); err != nil {
+		t.Fatal(err)
+	}
+
+	if org == nil || *org != 1 {
+		t.Errorf("got org %v, want %v", org, platform.ID(1))
+	}
+	if bucket == nil || *bucket != 2 {
+		t.Errorf("got bucket %v, want %v", bucket, platform.ID(2))
+	}
+	if !body.Start.Equal(start) || !body.Stop.Equal(stop) || body.Predicate != "host = 'a'" {
+		t.Errorf("unexpected request body %+v", body)
+	}
+}
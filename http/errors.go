@@ -156,6 +156,34 @@ func encodeKError(e kerrors.Error, w http.ResponseWriter) {
 	w.WriteHeader(code)
 }
 
+// v1Error is the body of the error responses of the InfluxDB 1.x compatible endpoints.
+type v1Error struct {
+	Err string `json:"error"`
+}
+
+// encodeV1Error encodes err in the shape of the errors of InfluxDB 1.x,
+// which 1.x clients decode from the error key of the response.
+// Errors that are neither platform nor kit errors, such as those of
+// InfluxQL queries or of parsing line protocol, are client errors.
+func encodeV1Error(ctx context.Context, err error, w http.ResponseWriter) {
+	httpCode, msg := http.StatusBadRequest, err.Error()
+	switch e := err.(type) {
+	case *platform.Error:
+		code := platform.ErrorCode(e)
+		if c, ok := statusCodePlatformError[code]; ok {
+			httpCode = c
+		}
+		msg = platform.ErrorMessage(e)
+		w.Header().Set(PlatformErrorCodeHeader, code)
+	case kerrors.Error:
+		httpCode, msg = statusCode(e), e.Err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	_ = json.NewEncoder(w).Encode(v1Error{Err: msg})
+}
+
 // ForbiddenError encodes error with a forbidden status code.
 func ForbiddenError(ctx context.Context, err error, w http.ResponseWriter) {
 	EncodeError(ctx, &platform.Error{
@@ -194,5 +222,8 @@ var statusCodePlatformError = map[string]int{
 	platform.ENotFound:         http.StatusNotFound,
 	platform.EUnavailable:      http.StatusServiceUnavailable,
 	platform.EForbidden:        http.StatusForbidden,
+	platform.EUnauthorized:     http.StatusUnauthorized,
 	platform.EMethodNotAllowed: http.StatusMethodNotAllowed,
+	platform.ETooManyRequests:  http.StatusTooManyRequests,
+	platform.ETooLarge:         http.StatusRequestEntityTooLarge,
 }
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/query"
+	pinfluxql "github.com/influxdata/platform/query/influxql"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	v1QueryPath = "/query"
+)
+
+// InfluxQLHandler implements the InfluxDB 1.x compatible /query endpoint.
+// InfluxQL queries are transpiled to run against the buckets mapped to their
+// databases and retention policies.
+type InfluxQLHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	ProxyQueryService  query.ProxyQueryService
+
+	// UsageRecorder, if set, records the usage of queries.
+	UsageRecorder platform.UsageRecorder
+}
+
+// NewInfluxQLHandler returns a new handler at /query for InfluxQL queries.
+func NewInfluxQLHandler() *InfluxQLHandler {
+	h := &InfluxQLHandler{
+		Router: NewRouter(),
+		Logger: zap.NewNop(),
+	}
+
+	h.HandlerFunc("GET", v1QueryPath, h.handleQuery)
+	h.HandlerFunc("POST", v1QueryPath, h.handleQuery)
+	return h
+}
+
+// handleQuery is the HTTP handler for the GET and POST /query routes.
+func (h *InfluxQLHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	req, err := decodeInfluxQLRequest(ctx, r)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	mappings, err := h.findQueryMappings(ctx, authorizerOrganization(a), req)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	orgID, err := mappingsOrganization(mappings)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	for _, m := range mappings {
+		p, err := platform.NewPermissionAtID(m.BucketID, platform.ReadAction, platform.BucketsResource)
+		if err != nil {
+			encodeV1Error(ctx, fmt.Errorf("could not create permission for bucket: %v", err), w)
+			return
+		}
+		if !a.Allowed(*p) {
+			encodeV1Error(ctx, &platform.Error{
+				Code: platform.EForbidden,
+				Msg:  "insufficient permissions for read",
+			}, w)
+			return
+		}
+	}
+
+	compiler := pinfluxql.NewCompiler(h.DBRPMappingService)
+	compiler.Cluster = platform.DefaultDBRPCluster
+	compiler.DB = req.Database
+	compiler.RP = req.RetentionPolicy
+	compiler.Query = req.Query
+
+	pr := &query.ProxyRequest{
+		Request: query.Request{
+			OrganizationID: orgID,
+			Compiler:       compiler,
+		},
+		Dialect: req.Dialect,
+	}
+	if auth, ok := a.(*platform.Authorization); ok {
+		pr.Request.Authorization = auth
+		if !orgID.Valid() {
+			// Queries reading no bucket, such as SHOW DATABASES, are scoped to the org of the token.
+			pr.Request.OrganizationID = auth.OrgID
+		}
+	}
+	// The transpiler resolves the databases of the query in the organization of the request.
+	compiler.OrganizationID = pr.Request.OrganizationID
+
+	req.Dialect.SetHeaders(w)
+	n, err := h.ProxyQueryService.Query(ctx, w, pr)
+	if orgID := pr.Request.OrganizationID; h.UsageRecorder != nil && orgID.Valid() {
+		// Queries are not attributed to the buckets they read from.
+		h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestCount, 1)
+		h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestBytes, float64(n))
+	}
+	if err != nil {
+		if n == 0 {
+			// Only record the error headers IFF nothing has been written to w.
+			encodeV1Error(ctx, err, w)
+			return
+		}
+		h.Logger.Info("Error writing response to client",
+			zap.String("handler", "influxql"),
+			zap.Error(err),
+		)
+	}
+}
+
+// findQueryMappings returns the dbrp mappings of the buckets read by the query,
+// in the organization if orgID is valid. Measurements without a database or
+// retention policy read from those of the request.
+func (h *InfluxQLHandler) findQueryMappings(ctx context.Context, orgID platform.ID, req *influxqlRequest) ([]*platform.DBRPMapping, error) {
+	var sources []*influxql.Measurement
+	for _, stmt := range req.Statements {
+		switch stmt := stmt.(type) {
+		case *influxql.SelectStatement:
+			influxql.WalkFunc(stmt, func(n influxql.Node) {
+				if m, ok := n.(*influxql.Measurement); ok {
+					sources = append(sources, m)
+				}
+			})
+		case *influxql.ShowTagValuesStatement:
+			sources = append(sources, &influxql.Measurement{Database: stmt.Database})
+		}
+	}
+
+	mappings := make([]*platform.DBRPMapping, 0, len(sources))
+	for _, s := range sources {
+		db, rp := s.Database, s.RetentionPolicy
+		if db == "" {
+			db = req.Database
+		}
+		if rp == "" {
+			rp = req.RetentionPolicy
+		}
+
+		m, err := findDBRPMapping(ctx, h.DBRPMappingService, orgID, db, rp)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+type influxqlRequest struct {
+	Query           string
+	Statements      []influxql.Statement
+	Database        string
+	RetentionPolicy string
+	Dialect         *pinfluxql.Dialect
+}
+
+func decodeInfluxQLRequest(ctx context.Context, r *http.Request) (*influxqlRequest, error) {
+	q := r.FormValue("q")
+	if q == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  `missing required parameter "q"`,
+		}
+	}
+
+	stmts, err := influxql.ParseQuery(q)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("error parsing query: %v", err),
+		}
+	}
+
+	tf, err := decodeTimeFormat(r.FormValue("epoch"))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &pinfluxql.Dialect{
+		TimeFormat: tf,
+		Encoding:   pinfluxql.JSON,
+	}
+	switch r.Header.Get("Accept") {
+	case "application/csv", "text/csv":
+		d.Encoding = pinfluxql.CSV
+	default:
+		if r.FormValue("pretty") == "true" {
+			d.Encoding = pinfluxql.JSONPretty
+		}
+	}
+
+	return &influxqlRequest{
+		Query:           q,
+		Statements:      stmts.Statements,
+		Database:        r.FormValue("db"),
+		RetentionPolicy: r.FormValue("rp"),
+		Dialect:         d,
+	}, nil
+}
+
+// decodeTimeFormat returns the time format of the 1.x epoch parameter.
+func decodeTimeFormat(epoch string) (pinfluxql.TimeFormat, error) {
+	switch epoch {
+	case "":
+		return pinfluxql.RFC3339Nano, nil
+	case "h":
+		return pinfluxql.Hour, nil
+	case "m":
+		return pinfluxql.Minute, nil
+	case "s":
+		return pinfluxql.Second, nil
+	case "ms":
+		return pinfluxql.Millisecond, nil
+	case "u", "µ":
+		return pinfluxql.Microsecond, nil
+	case "n", "ns":
+		return pinfluxql.Nanosecond, nil
+	default:
+		return 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("invalid epoch %q; valid epochs are h, m, s, ms, u, and ns", epoch),
+		}
+	}
+}
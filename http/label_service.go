@@ -50,8 +50,9 @@ func newLabelsResponse(opts plat.FindOptions, f plat.LabelFilter, ls []*plat.Lab
 	}
 }
 
-// newGetLabelsHandler returns a handler func for a GET to /labels endpoints
-func newGetLabelsHandler(s plat.LabelService) http.HandlerFunc {
+// newGetLabelsHandler returns a handler func for a GET to /labels endpoints.
+// The labels of a resource are authorized by the ids of the resource.
+func newGetLabelsHandler(s plat.LabelService, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -61,6 +62,11 @@ func newGetLabelsHandler(s plat.LabelService) http.HandlerFunc {
 			return
 		}
 
+		if err := authorizeResource(ctx, plat.ReadAction, plat.LabelsResource, req.filter.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		opts := plat.FindOptions{}
 		labels, err := s.FindLabels(ctx, req.filter)
 		if err != nil {
@@ -106,7 +112,7 @@ func decodeGetLabelsRequest(ctx context.Context, r *http.Request) (*getLabelsReq
 }
 
 // newPostLabelHandler returns a handler func for a POST to /labels endpoints
-func newPostLabelHandler(s plat.LabelService) http.HandlerFunc {
+func newPostLabelHandler(s plat.LabelService, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -116,6 +122,11 @@ func newPostLabelHandler(s plat.LabelService) http.HandlerFunc {
 			return
 		}
 
+		if err := authorizeResource(ctx, plat.WriteAction, plat.LabelsResource, req.Label.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		if err := req.Label.Validate(); err != nil {
 			EncodeError(ctx, err, w)
 			return
@@ -210,7 +221,7 @@ func decodePatchLabelRequest(ctx context.Context, r *http.Request) (*patchLabelR
 }
 
 // newPatchLabelHandler returns a handler func for a PATCH to /labels endpoints
-func newPatchLabelHandler(s plat.LabelService) http.HandlerFunc {
+func newPatchLabelHandler(s plat.LabelService, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -220,6 +231,11 @@ func newPatchLabelHandler(s plat.LabelService) http.HandlerFunc {
 			return
 		}
 
+		if err := authorizeResource(ctx, plat.WriteAction, plat.LabelsResource, req.label.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		label, err := s.UpdateLabel(ctx, req.label, req.upd)
 		if err != nil {
 			EncodeError(ctx, err, w)
@@ -237,7 +253,7 @@ func newPatchLabelHandler(s plat.LabelService) http.HandlerFunc {
 }
 
 // newDeleteLabelHandler returns a handler func for a DELETE to /labels endpoints
-func newDeleteLabelHandler(s plat.LabelService) http.HandlerFunc {
+func newDeleteLabelHandler(s plat.LabelService, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -247,6 +263,11 @@ func newDeleteLabelHandler(s plat.LabelService) http.HandlerFunc {
 			return
 		}
 
+		if err := authorizeResource(ctx, plat.DeleteAction, plat.LabelsResource, req.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		label := plat.Label{
 			ResourceID: req.ResourceID,
 			Name:       req.Name,
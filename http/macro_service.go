@@ -24,7 +24,8 @@ type MacroHandler struct {
 
 	Logger *zap.Logger
 
-	MacroService platform.MacroService
+	MacroService               platform.MacroService
+	UserResourceMappingService platform.UserResourceMappingService
 }
 
 // NewMacroHandler creates a new MacroHandler
@@ -79,12 +80,25 @@ func newGetMacrosResponse(macros []*platform.Macro) getMacrosResponse {
 func (h *MacroHandler) handleGetMacros(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	macros, err := h.MacroService.FindMacros(ctx)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.MacrosResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	found, err := h.MacroService.FindMacros(ctx)
 	if err != nil {
 		EncodeError(ctx, kerrors.InternalErrorf("could not read macros: %v", err), w)
 		return
 	}
 
+	macros := make([]*platform.Macro, 0, len(found))
+	for _, m := range found {
+		if allowed(m.ID) {
+			macros = append(macros, m)
+		}
+	}
+
 	err = encodeResponse(ctx, w, http.StatusOK, newGetMacrosResponse(macros))
 	if err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -119,6 +133,11 @@ func (h *MacroHandler) handleGetMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.MacrosResource, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	macro, err := h.MacroService.FindMacroByID(ctx, id)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -159,12 +178,23 @@ func (h *MacroHandler) handlePostMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, err := authorizeOwnedCreate(ctx, platform.MacrosResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.CreateMacro(ctx, req.macro)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	if err := createOwnerMapping(ctx, h.UserResourceMappingService, platform.MacrosResource, req.macro.ID, userID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = encodeResponse(ctx, w, http.StatusCreated, newMacroResponse(req.macro))
 	if err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -208,6 +238,11 @@ func (h *MacroHandler) handlePatchMacro(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.MacrosResource, req.id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	macro, err := h.MacroService.UpdateMacro(ctx, req.id, req.macroUpdate)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -264,6 +299,11 @@ func (h *MacroHandler) handlePutMacro(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.MacrosResource, req.macro.ID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.ReplaceMacro(ctx, req.macro)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -313,6 +353,11 @@ func (h *MacroHandler) handleDeleteMacro(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.DeleteAction, platform.MacrosResource, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.MacroService.DeleteMacro(ctx, id)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -74,6 +74,7 @@ func TestMacroService_handleGetMacros(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://howdy.tld", nil)
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetMacros(w, r)
 
 			res := w.Result()
@@ -195,6 +196,7 @@ func TestMacroService_handleGetMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetMacro(w, r)
 
 			res := w.Result()
@@ -316,6 +318,7 @@ func TestMacroService_handlePostMacro(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://howdy.tld", bytes.NewReader([]byte(tt.args.macro)))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostMacro(w, r)
 
 			res := w.Result()
@@ -417,6 +420,7 @@ func TestMacroService_handlePatchMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchMacro(w, r)
 
 			res := w.Result()
@@ -504,6 +508,7 @@ func TestMacroService_handleDeleteMacro(t *testing.T) {
 				}))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteMacro(w, r)
 
 			statusCode := w.Result().StatusCode
@@ -529,7 +534,7 @@ func initMacroService(f platformtesting.MacroFields, t *testing.T) (platform.Mac
 
 	handler := NewMacroHandler()
 	handler.MacroService = svc
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(operatorHandler(handler))
 	client := MacroService{
 		Addr: server.URL,
 	}
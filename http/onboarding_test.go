@@ -15,6 +15,7 @@ func initOnboardingService(f platformtesting.OnboardingFields, t *testing.T) (pl
 	svc := inmem.NewService()
 	svc.IDGenerator = f.IDGenerator
 	svc.TokenGenerator = f.TokenGenerator
+	svc.WithTime(f.NowFn)
 
 	ctx := context.Background()
 	if err := svc.PutOnboardingStatus(ctx, !f.IsOnboarding); err != nil {
@@ -63,23 +63,23 @@ func NewOrgHandler(mappingService platform.UserResourceMappingService,
 	h.HandlerFunc("PATCH", organizationsIDPath, h.handlePatchOrg)
 	h.HandlerFunc("DELETE", organizationsIDPath, h.handleDeleteOrg)
 
-	h.HandlerFunc("POST", organizationsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Member))
-	h.HandlerFunc("GET", organizationsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Member))
-	h.HandlerFunc("DELETE", organizationsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", organizationsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("GET", organizationsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("DELETE", organizationsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.OrgsResource, platform.Member, ownResourceIDs))
 
-	h.HandlerFunc("POST", organizationsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Owner))
-	h.HandlerFunc("GET", organizationsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Owner))
-	h.HandlerFunc("DELETE", organizationsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", organizationsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("GET", organizationsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.OrgsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("DELETE", organizationsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.OrgsResource, platform.Owner, ownResourceIDs))
 
 	h.HandlerFunc("GET", organizationsIDSecretsPath, h.handleGetSecrets)
 	h.HandlerFunc("PATCH", organizationsIDSecretsPath, h.handlePatchSecrets)
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
 	h.HandlerFunc("POST", organizationsIDSecretsDeletePath, h.handleDeleteSecrets)
 
-	h.HandlerFunc("GET", organizationsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", organizationsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", organizationsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", organizationsIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", organizationsIDLabelsPath, newGetLabelsHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("POST", organizationsIDLabelsPath, newPostLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("DELETE", organizationsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("PATCH", organizationsIDLabelsNamePath, newPatchLabelHandler(h.LabelService, ownResourceIDs))
 
 	return h
 }
@@ -156,11 +156,22 @@ func (h *OrgHandler) handlePostOrg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, err := authorizeOwnedCreate(ctx, platform.OrgsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.OrganizationService.CreateOrganization(ctx, req.Org); err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	if err := createOwnerMapping(ctx, h.UserResourceMappingService, platform.OrgsResource, req.Org.ID, userID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusCreated, newOrgResponse(req.Org)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -192,6 +203,11 @@ func (h *OrgHandler) handleGetOrg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.OrgsResource, req.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.OrganizationService.FindOrganizationByID(ctx, req.OrgID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -237,12 +253,25 @@ func (h *OrgHandler) handleGetOrgs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orgs, _, err := h.OrganizationService.FindOrganizations(ctx, req.filter)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.OrgsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	found, _, err := h.OrganizationService.FindOrganizations(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	orgs := make([]*platform.Organization, 0, len(found))
+	for _, o := range found {
+		if allowed(o.ID) {
+			orgs = append(orgs, o)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newOrgsResponse(orgs)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -282,6 +311,11 @@ func (h *OrgHandler) handleDeleteOrg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.DeleteAction, platform.OrgsResource, req.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.OrganizationService.DeleteOrganization(ctx, req.OrganizationID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -322,6 +356,11 @@ func (h *OrgHandler) handlePatchOrg(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.OrgsResource, req.OrgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	o, err := h.OrganizationService.UpdateOrganization(ctx, req.OrgID, req.Update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -372,6 +411,11 @@ func (h *OrgHandler) handleGetSecrets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.SecretsResource, req.orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	ks, err := h.SecretService.GetSecretKeys(ctx, req.orgID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -415,6 +459,11 @@ func (h *OrgHandler) handlePatchSecrets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.SecretsResource, req.orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SecretService.PatchSecrets(ctx, req.orgID, req.secrets); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -460,6 +509,11 @@ func (h *OrgHandler) handleDeleteSecrets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.DeleteAction, platform.SecretsResource, req.orgID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SecretService.DeleteSecret(ctx, req.orgID, req.secrets...); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -704,6 +758,11 @@ func (h *OrgHandler) handleGetOrgLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.OrgsResource, req.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	log, _, err := h.OrganizationOperationLogService.GetOrganizationOperationLog(ctx, req.OrganizationID, req.opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
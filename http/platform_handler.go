@@ -27,6 +27,8 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	h.Handler = NewAPIHandler(b)
 	h.AuthorizationService = b.AuthorizationService
 	h.SessionService = b.SessionService
+	h.BasicAuthService = b.BasicAuthService
+	h.AuthorizationUsageRecorder = b.AuthorizationUsageRecorder
 
 	h.RegisterNoAuthRoute("GET", "/api/v2")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signin")
@@ -34,6 +36,10 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	h.RegisterNoAuthRoute("POST", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 
+	h.RegisterV1AuthRoute("POST", "/write")
+	h.RegisterV1AuthRoute("GET", "/query")
+	h.RegisterV1AuthRoute("POST", "/query")
+
 	assetHandler := NewAssetHandler()
 	assetHandler.DeveloperMode = b.DeveloperMode
 
@@ -51,8 +57,10 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
-	// of the platform API.
+	// of the platform API, or is not an InfluxDB 1.x compatible endpoint.
 	if !strings.HasPrefix(r.URL.Path, "/v1") &&
+		r.URL.Path != "/write" &&
+		r.URL.Path != "/query" &&
 		!strings.HasPrefix(r.URL.Path, "/api/v2") &&
 		!strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.AssetHandler.ServeHTTP(w, r)
@@ -15,9 +15,10 @@ import (
 // prebuilt resources (dashboards, tasks, etcd).
 type ProtoHandler struct {
 	*httprouter.Router
-	Logger       *zap.Logger
-	ProtoService platform.ProtoService
-	LabelService platform.LabelService
+	Logger                     *zap.Logger
+	ProtoService               platform.ProtoService
+	LabelService               platform.LabelService
+	UserResourceMappingService platform.UserResourceMappingService
 }
 
 const (
@@ -46,27 +47,30 @@ func extractProtoID(ctx context.Context) (platform.ID, error) {
 
 // ProtoBackend is the backend for the proto handler.
 type ProtoBackend struct {
-	Logger       *zap.Logger
-	ProtoService platform.ProtoService
-	LabelService platform.LabelService
+	Logger                     *zap.Logger
+	ProtoService               platform.ProtoService
+	LabelService               platform.LabelService
+	UserResourceMappingService platform.UserResourceMappingService
 }
 
 // NewProtoBackend creates an instance of the Protobackend from the APIBackend.
 func NewProtoBackend(b *APIBackend) *ProtoBackend {
 	return &ProtoBackend{
-		Logger:       b.Logger.With(zap.String("handler", "proto")),
-		ProtoService: b.ProtoService,
-		LabelService: b.LabelService,
+		Logger:                     b.Logger.With(zap.String("handler", "proto")),
+		ProtoService:               b.ProtoService,
+		LabelService:               b.LabelService,
+		UserResourceMappingService: b.UserResourceMappingService,
 	}
 }
 
 // NewProtoHandler creates an instance of a proto handler.
 func NewProtoHandler(b *ProtoBackend) *ProtoHandler {
 	h := &ProtoHandler{
-		Router:       NewRouter(),
-		Logger:       b.Logger,
-		ProtoService: b.ProtoService,
-		LabelService: b.LabelService,
+		Router:                     NewRouter(),
+		Logger:                     b.Logger,
+		ProtoService:               b.ProtoService,
+		LabelService:               b.LabelService,
+		UserResourceMappingService: b.UserResourceMappingService,
 	}
 
 	h.HandlerFunc("GET", protosPath, h.handleGetProtos)
@@ -107,12 +111,25 @@ func newProtoResponse(p *platform.Proto) *protoResponse {
 func (h *ProtoHandler) handleGetProtos(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ps, err := h.ProtoService.FindProtos(ctx)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.ProtosResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, err := h.ProtoService.FindProtos(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ps := make([]*platform.Proto, 0, len(found))
+	for _, p := range found {
+		if allowed(p.ID) {
+			ps = append(ps, p)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newProtosResponse(ps)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -150,12 +167,30 @@ func (h *ProtoHandler) handlePostProtosDashboards(w http.ResponseWriter, r *http
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.ProtosResource, req.ProtoID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	userID, err := authorizeOwnedCreate(ctx, platform.DashboardsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	ds, err := h.ProtoService.CreateDashboardsFromProto(ctx, req.ProtoID, req.OrganizationID)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	for _, d := range ds {
+		if err := createOwnerMapping(ctx, h.UserResourceMappingService, platform.DashboardsResource, d.ID, userID); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusCreated, newGetDashboardsResponse(ctx, ds, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
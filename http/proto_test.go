@@ -217,6 +217,7 @@ func TestProtoHandler(t *testing.T) {
 
 			r := httptest.NewRequest(tt.args.method, "http://localhost:9999"+tt.args.endpoint, bdy)
 			w := httptest.NewRecorder()
+			r = withOperator(r)
 			h.ServeHTTP(w, r)
 
 			res := w.Result()
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/encoding"
 )
 
 // QueryRequest is a flux query request.
@@ -36,6 +38,9 @@ type QueryRequest struct {
 
 // QueryDialect is the formatting options for the query response.
 type QueryDialect struct {
+	// Type is the format of the response: csv, json, ndjson or arrow.
+	// It defaults to csv, and the remaining options only apply to csv.
+	Type           string   `json:"type,omitempty"`
 	Header         *bool    `json:"header"`
 	Delimiter      string   `json:"delimiter"`
 	CommentPrefix  string   `json:"commentPrefix"`
@@ -71,6 +76,12 @@ func (r QueryRequest) Validate() error {
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
+	switch r.Dialect.Type {
+	case "", csvDialectType, encoding.JSONDialectType, encoding.NDJSONDialectType, encoding.ArrowDialectType:
+	default:
+		return fmt.Errorf(`unknown dialect type: %s`, r.Dialect.Type)
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -259,28 +270,66 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 		}
 	}
 
-	delimiter, _ := utf8.DecodeRuneInString(r.Dialect.Delimiter)
+	return &query.ProxyRequest{
+		Request: query.Request{
+			OrganizationID: r.Org.ID,
+			Compiler:       compiler,
+		},
+		Dialect: r.Dialect.dialect(),
+	}, nil
+}
+
+// dialect returns the flux dialect for the dialect options.
+func (d QueryDialect) dialect() flux.Dialect {
+	switch d.Type {
+	case encoding.JSONDialectType:
+		return &encoding.JSONDialect{}
+	case encoding.NDJSONDialectType:
+		return &encoding.NDJSONDialect{}
+	case encoding.ArrowDialectType:
+		return &encoding.ArrowDialect{}
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(d.Delimiter)
 
 	noHeader := false
-	if r.Dialect.Header != nil {
-		noHeader = !*r.Dialect.Header
+	if d.Header != nil {
+		noHeader = !*d.Header
 	}
 
 	// TODO(nathanielc): Use commentPrefix and dateTimeFormat
 	// once they are supported.
-	return &query.ProxyRequest{
-		Request: query.Request{
-			OrganizationID: r.Org.ID,
-			Compiler:       compiler,
+	return &csv.Dialect{
+		ResultEncoderConfig: csv.ResultEncoderConfig{
+			NoHeader:    noHeader,
+			Delimiter:   delimiter,
+			Annotations: d.Annotations,
 		},
-		Dialect: &csv.Dialect{
-			ResultEncoderConfig: csv.ResultEncoderConfig{
-				NoHeader:    noHeader,
-				Delimiter:   delimiter,
-				Annotations: r.Dialect.Annotations,
-			},
-		},
-	}, nil
+	}
+}
+
+const csvDialectType = "csv"
+
+// dialectTypeFromAccept returns the dialect type of the first media type in an
+// Accept header that has a dialect, or the empty string if none does.
+func dialectTypeFromAccept(accept string) string {
+	for _, t := range strings.Split(accept, ",") {
+		if i := strings.IndexByte(t, ';'); i >= 0 {
+			t = t[:i]
+		}
+
+		switch strings.TrimSpace(t) {
+		case "text/csv":
+			return csvDialectType
+		case "application/json":
+			return encoding.JSONDialectType
+		case encoding.NDJSONContentType, "application/jsonl":
+			return encoding.NDJSONDialectType
+		case encoding.ArrowContentType, "application/vnd.influx.arrow":
+			return encoding.ArrowDialectType
+		}
+	}
+	return ""
 }
 
 // QueryRequestFromProxyRequest converts a query.ProxyRequest into a QueryRequest.
@@ -305,6 +354,8 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Dialect.CommentPrefix = "#"
 		qr.Dialect.DateTimeFormat = "RFC3339"
 		qr.Dialect.Annotations = d.ResultEncoderConfig.Annotations
+	case *encoding.JSONDialect, *encoding.NDJSONDialect, *encoding.ArrowDialect:
+		qr.Dialect.Type = string(d.DialectType())
 	default:
 		return nil, fmt.Errorf("unsupported dialect %T", d)
 	}
@@ -328,6 +379,11 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc platform.Organ
 		}
 	}
 
+	// A dialect type in the request takes precedence over the Accept header.
+	if req.Dialect.Type == "" {
+		req.Dialect.Type = dialectTypeFromAccept(r.Header.Get("Accept"))
+	}
+
 	req = req.WithDefaults()
 	err := req.Validate()
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"time"
@@ -19,6 +20,7 @@ import (
 	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/functions/inputs/storage"
 	"github.com/julienschmidt/httprouter"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -37,6 +39,9 @@ type FluxHandler struct {
 	Now                 func() time.Time
 	OrganizationService platform.OrganizationService
 	ProxyQueryService   query.ProxyQueryService
+
+	// UsageRecorder, if set, records the usage of queries.
+	UsageRecorder platform.UsageRecorder
 }
 
 // NewFluxHandler returns a new handler at /api/v2/query for flux queries.
@@ -72,6 +77,11 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("explain") == "true" {
+		h.explainQuery(w, r, req)
+		return
+	}
+
 	hd, ok := req.Dialect.(HTTPDialect)
 	if !ok {
 		EncodeError(ctx, fmt.Errorf("unsupported dialect over HTTP %T", req.Dialect), w)
@@ -80,6 +90,7 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 	hd.SetHeaders(w)
 
 	n, err := h.ProxyQueryService.Query(ctx, w, req)
+	h.recordUsage(ctx, req, n)
 	if err != nil {
 		if n == 0 {
 			// Only record the error headers IFF nothing has been written to w.
@@ -93,6 +104,47 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// QueryExplain describes the storage reads of a query and their costs.
+type QueryExplain struct {
+	Reads []storage.ReadExplain `json:"reads"`
+}
+
+// explainQuery executes the query, discarding its results, and responds
+// with the explanations of the storage reads of the query.
+func (h *FluxHandler) explainQuery(w http.ResponseWriter, r *http.Request, req *query.ProxyRequest) {
+	c := &storage.ExplainCollector{}
+	ctx := storage.ContextWithExplainCollector(r.Context(), c)
+
+	// The query reads storage as any other does, so it is recorded as one
+	// even though its results are discarded.
+	n, err := h.ProxyQueryService.Query(ctx, ioutil.Discard, req)
+	h.recordUsage(ctx, req, n)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := QueryExplain{Reads: c.Reads()}
+	if res.Reads == nil {
+		res.Reads = []storage.ReadExplain{}
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// recordUsage records the usage of a query that produced n bytes of results.
+func (h *FluxHandler) recordUsage(ctx context.Context, req *query.ProxyRequest, n int64) {
+	if h.UsageRecorder == nil {
+		return
+	}
+	// Queries are not attributed to the buckets they read from.
+	orgID := req.Request.OrganizationID
+	h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestCount, 1)
+	h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestBytes, float64(n))
+}
+
 type langRequest struct {
 	Query string `json:"query"`
 }
@@ -364,3 +416,56 @@ func (s *FluxQueryService) Query(ctx context.Context, r *query.Request) (flux.Re
 	decoder := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{})
 	return decoder.Decode(resp.Body)
 }
+
+// Explain runs a flux query against a influx server, discarding its results,
+// and returns the explanations of the storage reads of the query.
+func (s *FluxQueryService) Explain(ctx context.Context, r *query.Request) (*QueryExplain, error) {
+	u, err := newURL(s.Addr, fluxPath)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set(OrgID, r.OrganizationID.String())
+	params.Set("explain", "true")
+	u.RawQuery = params.Encode()
+
+	preq := &query.ProxyRequest{
+		Request: *r,
+		Dialect: csv.DefaultDialect(),
+	}
+	qreq, err := QueryRequestFromProxyRequest(preq)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(qreq); err != nil {
+		return nil, err
+	}
+
+	hreq, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(s.Token, hreq)
+
+	hreq.Header.Set("Content-Type", "application/json")
+	hreq = hreq.WithContext(ctx)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, err
+	}
+
+	var exp QueryExplain
+	if err := json.NewDecoder(resp.Body).Decode(&exp); err != nil {
+		return nil, err
+	}
+	return &exp, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -14,6 +15,8 @@ import (
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/query"
 )
 
@@ -190,6 +193,47 @@ func TestFluxQueryService_Query(t *testing.T) {
 	}
 }
 
+type usageRecorder map[platform.UsageMetric]float64
+
+func (r usageRecorder) RecordUsage(ctx context.Context, orgID, bucketID platform.ID, m platform.UsageMetric, value float64) {
+	r[m] += value
+}
+
+func TestFluxHandler_handleQuery_explainUsage(t *testing.T) {
+	orgID := platform.ID(1)
+	usage := usageRecorder{}
+
+	h := NewFluxHandler()
+	h.OrganizationService = &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+			return &platform.Organization{ID: orgID}, nil
+		},
+	}
+	h.ProxyQueryService = &mock.ProxyQueryService{
+		QueryFn: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+			n, err := io.WriteString(w, "results")
+			return int64(n), err
+		},
+	}
+	h.UsageRecorder = usage
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v2/query?explain=true&orgID="+orgID.String(), bytes.NewBufferString(`{"query": "from(bucket: \"b\")"}`))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{OrgID: orgID, Status: platform.Active}))
+	h.handleQuery(w, r)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+	}
+	want := usageRecorder{
+		platform.UsageQueryRequestCount: 1,
+		platform.UsageQueryRequestBytes: 7,
+	}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("unexpected usage: got %v, want %v", usage, want)
+	}
+}
+
 func TestFluxHandler_postFluxAST(t *testing.T) {
 	tests := []struct {
 		name   string
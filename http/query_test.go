@@ -510,3 +510,26 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_dialectTypeFromAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: ""},
+		{accept: "*/*", want: ""},
+		{accept: "text/csv", want: "csv"},
+		{accept: "application/json", want: "json"},
+		{accept: "application/json; charset=utf-8", want: "json"},
+		{accept: "application/x-ndjson", want: "ndjson"},
+		{accept: "application/vnd.apache.arrow.stream", want: "arrow"},
+		{accept: "text/html, application/x-ndjson;q=0.9, text/csv", want: "ndjson"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := dialectTypeFromAccept(tt.accept); got != tt.want {
+				t.Errorf("dialectTypeFromAccept(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
@@ -18,10 +18,17 @@ type ScraperHandler struct {
 	*httprouter.Router
 	Logger                *zap.Logger
 	ScraperStorageService platform.ScraperTargetStoreService
+	// ScraperHealthService, if set, provides the health of the latest scrape of a target.
+	ScraperHealthService platform.ScraperTargetHealthService
+	// OrganizationService, if set, finds the organization of a target, whose
+	// scrapers permissions then apply to the target.
+	OrganizationService platform.OrganizationService
 }
 
 const (
-	targetPath = "/api/v2/scrapertargets"
+	targetPath = "/api/v2/scrapers"
+	// legacyTargetPath is the former path of the scraper targets, kept as an alias of targetPath.
+	legacyTargetPath = "/api/v2/scrapertargets"
 )
 
 // NewScraperHandler returns a new instance of ScraperHandler.
@@ -30,15 +37,72 @@ func NewScraperHandler() *ScraperHandler {
 		Router: NewRouter(),
 		Logger: zap.NewNop(),
 	}
-	h.HandlerFunc("POST", targetPath, h.handlePostScraperTarget)
-	h.HandlerFunc("GET", targetPath, h.handleGetScraperTargets)
-	h.HandlerFunc("GET", targetPath+"/:id", h.handleGetScraperTarget)
-	h.HandlerFunc("PATCH", targetPath+"/:id", h.handlePatchScraperTarget)
-	h.HandlerFunc("DELETE", targetPath+"/:id", h.handleDeleteScraperTarget)
+	for _, path := range []string{targetPath, legacyTargetPath} {
+		h.HandlerFunc("POST", path, h.handlePostScraperTarget)
+		h.HandlerFunc("GET", path, h.handleGetScraperTargets)
+		h.HandlerFunc("GET", path+"/:id", h.handleGetScraperTarget)
+		h.HandlerFunc("PATCH", path+"/:id", h.handlePatchScraperTarget)
+		h.HandlerFunc("DELETE", path+"/:id", h.handleDeleteScraperTarget)
+	}
 	return h
 }
 
-// handlePostScraperTarget is HTTP handler for the POST /api/v2/scrapertargets route.
+// targetIDs returns the ids that authorize access to a target: its own and that of its organization.
+func (h *ScraperHandler) targetIDs(ctx context.Context, t *platform.ScraperTarget) ([]platform.ID, error) {
+	ids := []platform.ID{t.ID}
+	if h.OrganizationService == nil || t.OrgName == "" {
+		return ids, nil
+	}
+
+	o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &t.OrgName})
+	if platform.ErrorCode(err) == platform.ENotFound {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ids, o.ID), nil
+}
+
+// authorizeTarget authorizes the action on a target.
+func (h *ScraperHandler) authorizeTarget(ctx context.Context, a platform.Action, t *platform.ScraperTarget) error {
+	ids, err := h.targetIDs(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	return authorize(ctx, a, platform.ScrapersResource, ids...)
+}
+
+// authorizeTargetSecrets authorizes reading the secrets of the organization of
+// a target that scrapes with them. The scraper sends those secrets to the url
+// of the target, which the writer of the target chooses.
+func (h *ScraperHandler) authorizeTargetSecrets(ctx context.Context, t *platform.ScraperTarget) error {
+	if (t.Auth == nil || t.Auth.SecretKey == "") && (t.TLS == nil || t.TLS.KeySecretKey == "") {
+		return nil
+	}
+
+	ids, err := h.targetIDs(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	// The first id is that of the target, which secrets do not belong to.
+	return authorize(ctx, platform.ReadAction, platform.SecretsResource, ids[1:]...)
+}
+
+// scraperIDs returns the ids that authorize access to the target of id.
+func (h *ScraperHandler) scraperIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	t, err := h.ScraperStorageService.GetTargetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.targetIDs(ctx, t)
+}
+
+// handlePostScraperTarget is HTTP handler for the POST /api/v2/scrapers route.
 func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -48,6 +112,16 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := h.authorizeTarget(ctx, platform.WriteAction, req); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.authorizeTargetSecrets(ctx, req); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ScraperStorageService.AddTarget(ctx, req); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -58,7 +132,7 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 	}
 }
 
-// handleDeleteScraperTarget is the HTTP handler for the DELETE /api/v2/scrapertargets/:id route.
+// handleDeleteScraperTarget is the HTTP handler for the DELETE /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -68,6 +142,11 @@ func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.ScrapersResource, *id, h.scraperIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ScraperStorageService.RemoveTarget(ctx, *id); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -76,7 +155,7 @@ func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// handlePatchScraperTarget is the HTTP handler for the PATCH /api/v2/scrapertargets/:id route.
+// handlePatchScraperTarget is the HTTP handler for the PATCH /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -86,6 +165,22 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.ScrapersResource, update.ID, h.scraperIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Moving a target to another organization requires write access there too.
+	if err := h.authorizeTarget(ctx, platform.WriteAction, update); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.authorizeTargetSecrets(ctx, update); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	target, err := h.ScraperStorageService.UpdateTarget(ctx, update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -98,6 +193,7 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 	}
 }
 
+// handleGetScraperTarget is the HTTP handler for the GET /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handleGetScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -112,22 +208,55 @@ func (h *ScraperHandler) handleGetScraperTarget(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, newTargetResponse(*target)); err != nil {
+	if err := h.authorizeTarget(ctx, platform.ReadAction, target); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := newTargetResponse(*target)
+	if h.ScraperHealthService != nil {
+		health, err := h.ScraperHealthService.GetTargetHealth(ctx, *id)
+		if err != nil && platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+		res.Health = health
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
 	}
 }
 
-// handleGetScraperTargets is the HTTP handler for the GET /api/v2/scrapertargets route.
+// handleGetScraperTargets is the HTTP handler for the GET /api/v2/scrapers route.
 func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	targets, err := h.ScraperStorageService.ListTargets(ctx)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.ScrapersResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, err := h.ScraperStorageService.ListTargets(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	targets := make([]platform.ScraperTarget, 0, len(found))
+	for i := range found {
+		ids, err := h.targetIDs(ctx, &found[i])
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		if allowed(ids...) {
+			targets = append(targets, found[i])
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newListTargetsResponse(targets)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -376,7 +505,50 @@ type targetLinks struct {
 
 type targetResponse struct {
 	platform.ScraperTarget
-	Links targetLinks `json:"links"`
+	Links  targetLinks                   `json:"links"`
+	Health *platform.ScraperTargetHealth `json:"health,omitempty"`
+}
+
+// targetResponseExtra holds the fields of a targetResponse that are not part of the target.
+type targetResponseExtra struct {
+	Links  targetLinks                   `json:"links"`
+	Health *platform.ScraperTargetHealth `json:"health,omitempty"`
+}
+
+// MarshalJSON encodes the target along with its links and health,
+// which the promoted JSON methods of the target would leave out.
+func (r targetResponse) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(r.ScraperTarget)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields["links"], err = json.Marshal(r.Links); err != nil {
+		return nil, err
+	}
+	if r.Health != nil {
+		if fields["health"], err = json.Marshal(r.Health); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the target along with its links and health.
+func (r *targetResponse) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &r.ScraperTarget); err != nil {
+		return err
+	}
+	var extra targetResponseExtra
+	if err := json.Unmarshal(b, &extra); err != nil {
+		return err
+	}
+	r.Links, r.Health = extra.Links, extra.Health
+	return nil
 }
 
 func newListTargetsResponse(targets []platform.ScraperTarget) getTargetsResponse {
@@ -9,8 +9,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
 	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -82,7 +84,7 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 					`
 					{
 					  "links": {
-					    "self": "/api/v2/scrapertargets"
+					    "self": "/api/v2/scrapers"
 					  },
 					  "scraper_targets": [
 					    {
@@ -93,7 +95,7 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 						  "type": "prometheus",
 						  "url": "www.one.url",
 						  "links": {
-						    "self": "/api/v2/scrapertargets/0000000000000111"
+						    "self": "/api/v2/scrapers/0000000000000111"
 						  }
 						},
 						{
@@ -104,7 +106,7 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 						  "type": "prometheus",
 						  "url": "www.two.url",
 						  "links": {
-						    "self": "/api/v2/scrapertargets/0000000000000222"
+						    "self": "/api/v2/scrapers/0000000000000222"
 						  }
                         }
 					  ]
@@ -131,7 +133,7 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 				body: `
                 {
                   "links": {
-                    "self": "/api/v2/scrapertargets"
+                    "self": "/api/v2/scrapers"
                   },
                   "scraper_targets": []
                 }
@@ -157,6 +159,7 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetScraperTargets(w, r)
 
 			res := w.Result()
@@ -178,7 +181,8 @@ func TestService_handleGetScraperTargets(t *testing.T) {
 
 func TestService_handleGetScraperTarget(t *testing.T) {
 	type fields struct {
-		Service platform.ScraperTargetStoreService
+		Service       platform.ScraperTargetStoreService
+		HealthService platform.ScraperTargetHealthService
 	}
 
 	type args struct {
@@ -232,7 +236,109 @@ func TestService_handleGetScraperTarget(t *testing.T) {
                       "bucket": "bkt-name",
                       "org": "org-name",
                       "links": {
-                        "self": "/api/v2/scrapertargets/%[1]s"
+                        "self": "/api/v2/scrapers/%[1]s"
+                      }
+                    }
+                    `,
+					targetOneIDString,
+				),
+			},
+		},
+		{
+			name: "get a scraper target with health",
+			fields: fields{
+				Service: &mock.ScraperTargetStoreService{
+					GetTargetByIDF: func(ctx context.Context, id platform.ID) (*platform.ScraperTarget, error) {
+						return &platform.ScraperTarget{
+							ID:         targetOneID,
+							Name:       "target-1",
+							Type:       platform.PrometheusScraperType,
+							URL:        "www.some.url",
+							OrgName:    "org-name",
+							BucketName: "bkt-name",
+						}, nil
+					},
+				},
+				HealthService: &mock.ScraperTargetHealthService{
+					GetTargetHealthF: func(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error) {
+						return &platform.ScraperTargetHealth{
+							Up:                 false,
+							LastScrape:         time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+							LastScrapeDuration: 25 * time.Millisecond,
+							LastError:          "connection refused",
+						}, nil
+					},
+				},
+			},
+			args: args{
+				id: targetOneIDString,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: fmt.Sprintf(
+					`
+                    {
+                      "id": "%[1]s",
+                      "name": "target-1",
+                      "type": "prometheus",
+                      "url": "www.some.url",
+                      "bucket": "bkt-name",
+                      "org": "org-name",
+                      "links": {
+                        "self": "/api/v2/scrapers/%[1]s"
+                      },
+                      "health": {
+                        "up": false,
+                        "lastScrape": "2018-10-01T00:00:00Z",
+                        "lastScrapeDuration": 25000000,
+                        "sampleCount": 0,
+                        "lastError": "connection refused"
+                      }
+                    }
+                    `,
+					targetOneIDString,
+				),
+			},
+		},
+		{
+			name: "get a scraper target never scraped",
+			fields: fields{
+				Service: &mock.ScraperTargetStoreService{
+					GetTargetByIDF: func(ctx context.Context, id platform.ID) (*platform.ScraperTarget, error) {
+						return &platform.ScraperTarget{
+							ID:         targetOneID,
+							Name:       "target-1",
+							Type:       platform.PrometheusScraperType,
+							URL:        "www.some.url",
+							OrgName:    "org-name",
+							BucketName: "bkt-name",
+						}, nil
+					},
+				},
+				HealthService: &mock.ScraperTargetHealthService{
+					GetTargetHealthF: func(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error) {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: "scraper target health is not found"}
+					},
+				},
+			},
+			args: args{
+				id: targetOneIDString,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: fmt.Sprintf(
+					`
+                    {
+                      "id": "%[1]s",
+                      "name": "target-1",
+                      "type": "prometheus",
+                      "url": "www.some.url",
+                      "bucket": "bkt-name",
+                      "org": "org-name",
+                      "links": {
+                        "self": "/api/v2/scrapers/%[1]s"
                       }
                     }
                     `,
@@ -246,6 +352,7 @@ func TestService_handleGetScraperTarget(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewScraperHandler()
 			h.ScraperStorageService = tt.fields.Service
+			h.ScraperHealthService = tt.fields.HealthService
 
 			r := httptest.NewRequest("GET", "http://any.tld", nil)
 
@@ -261,6 +368,7 @@ func TestService_handleGetScraperTarget(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetScraperTarget(w, r)
 
 			res := w.Result()
@@ -361,6 +469,7 @@ func TestService_handleDeleteScraperTarget(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteScraperTarget(w, r)
 
 			res := w.Result()
@@ -433,7 +542,7 @@ func TestService_handlePostScraperTarget(t *testing.T) {
                       "org": "org-name",
                       "bucket": "bkt-name",
                       "links": {
-                        "self": "/api/v2/scrapertargets/%[1]s"
+                        "self": "/api/v2/scrapers/%[1]s"
                       }
                     }
                     `,
@@ -456,6 +565,7 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.tld", bytes.NewReader(st))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostScraperTarget(w, r)
 
 			res := w.Result()
@@ -475,6 +585,93 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 	}
 }
 
+func TestService_handlePostScraperTarget_Secrets(t *testing.T) {
+	orgID := platform.ID(1)
+	scrapers := []platform.Permission{
+		{Action: platform.WriteAction, Resource: platform.ScrapersResource, ID: &orgID},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		auth        *platform.ScraperAuth
+		tls         *platform.ScraperTLSConfig
+		wantStatus  int
+	}{
+		{
+			name:        "no secret",
+			permissions: scrapers,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "auth secret without secrets permission",
+			permissions: scrapers,
+			auth:        &platform.ScraperAuth{Type: platform.BearerScraperAuth, SecretKey: "token"},
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "tls key secret without secrets permission",
+			permissions: scrapers,
+			tls:         &platform.ScraperTLSConfig{Cert: "/etc/ssl/cert.pem", KeySecretKey: "key"},
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name: "auth secret with secrets permission",
+			permissions: append([]platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.SecretsResource, ID: &orgID},
+			}, scrapers...),
+			auth:       &platform.ScraperAuth{Type: platform.BearerScraperAuth, SecretKey: "token"},
+			wantStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added bool
+			h := NewScraperHandler()
+			h.ScraperStorageService = &mock.ScraperTargetStoreService{
+				AddTargetF: func(ctx context.Context, st *platform.ScraperTarget) error {
+					added = true
+					st.ID = targetOneID
+					return nil
+				},
+			}
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+					return &platform.Organization{ID: orgID, Name: *filter.Name}, nil
+				},
+			}
+
+			st, err := json.Marshal(&platform.ScraperTarget{
+				Name:       "hello",
+				Type:       platform.PrometheusScraperType,
+				BucketName: "bkt-name",
+				OrgName:    "org-name",
+				URL:        "www.some.url",
+				Auth:       tt.auth,
+				TLS:        tt.tls,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			auth := &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}
+			r := httptest.NewRequest("POST", "http://any.tld", bytes.NewReader(st))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.handlePostScraperTarget(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if added != (tt.wantStatus == http.StatusCreated) {
+				t.Errorf("got target added %v", added)
+			}
+		})
+	}
+}
+
 func TestService_handlePatchScraperTarget(t *testing.T) {
 	type fields struct {
 		Service platform.ScraperTargetStoreService
@@ -534,7 +731,7 @@ func TestService_handlePatchScraperTarget(t *testing.T) {
 		              "org":"orgg",
 		              "bucket":"buck",
 		              "links":{
-		                "self":"/api/v2/scrapertargets/%[1]s"
+		                "self":"/api/v2/scrapers/%[1]s"
 		              }
 		            }
 		            `,
@@ -599,6 +796,7 @@ func TestService_handlePatchScraperTarget(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchScraperTarget(w, r)
 
 			res := w.Result()
@@ -618,6 +816,34 @@ func TestService_handlePatchScraperTarget(t *testing.T) {
 	}
 }
 
+func TestScraperHandler_LegacyPath(t *testing.T) {
+	svc := inmem.NewService()
+	target := &platform.ScraperTarget{
+		ID:         targetOneID,
+		Name:       "target1",
+		Type:       platform.PrometheusScraperType,
+		URL:        "www.one.url",
+		OrgName:    "org1",
+		BucketName: "bucket1",
+	}
+	if err := svc.PutTarget(context.Background(), target); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewScraperHandler()
+	h.ScraperStorageService = svc
+
+	for _, path := range []string{"/api/v2/scrapertargets", "/api/v2/scrapertargets/" + targetOneIDString} {
+		r := withOperator(httptest.NewRequest("GET", "http://any.tld"+path, nil))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if res := w.Result(); res.StatusCode != http.StatusOK {
+			t.Errorf("GET %s = %v, want %v", path, res.StatusCode, http.StatusOK)
+		}
+	}
+}
+
 func initScraperService(f platformtesting.TargetFields, t *testing.T) (platform.ScraperTargetStoreService, string, func()) {
 	t.Helper()
 	svc := inmem.NewService()
@@ -632,7 +858,7 @@ func initScraperService(f platformtesting.TargetFields, t *testing.T) (platform.
 
 	handler := NewScraperHandler()
 	handler.ScraperStorageService = svc
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(operatorHandler(handler))
 	client := ScraperService{
 		Addr:     server.URL,
 		OpPrefix: inmem.OpPrefix,
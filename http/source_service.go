@@ -160,6 +160,16 @@ func decodeSourceQueryRequest(r *http.Request) (*query.ProxyRequest, error) {
 	return req, nil
 }
 
+// sourceIDs returns the ids that authorize access to a source: its own and that of its organization.
+func (h *SourceHandler) sourceIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	s, err := h.SourceService.FindSourceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []platform.ID{s.ID, s.OrganizationID}, nil
+}
+
 // handlePostSourceQuery is the HTTP handler for POST /api/v2/sources/:id/query
 func (h *SourceHandler) handlePostSourceQuery(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -181,6 +191,11 @@ func (h *SourceHandler) handlePostSourceQuery(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.SourcesResource, s.ID, s.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	querySvc, err := h.NewQueryService(s)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -210,6 +225,11 @@ func (h *SourceHandler) handleGetSourcesBuckets(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.SourcesResource, s.ID, s.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	bucketSvc, err := h.NewBucketService(s)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -257,6 +277,11 @@ func (h *SourceHandler) handlePostSource(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.SourcesResource, req.Source.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SourceService.CreateSource(ctx, req.Source); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -301,6 +326,11 @@ func (h *SourceHandler) handleGetSource(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.SourcesResource, s.ID, s.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	res := newSourceResponse(s)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
@@ -321,7 +351,12 @@ func (h *SourceHandler) handleGetSourceHealth(w http.ResponseWriter, r *http.Req
 		EncodeError(ctx, err, w)
 		return
 	}
-	if _, err := h.SourceService.FindSourceByID(ctx, req.SourceID); err != nil {
+	s, err := h.SourceService.FindSourceByID(ctx, req.SourceID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := authorize(ctx, platform.ReadAction, platform.SourcesResource, s.ID, s.OrganizationID); err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
@@ -368,6 +403,11 @@ func (h *SourceHandler) handleDeleteSource(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.SourcesResource, req.SourceID, h.sourceIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SourceService.DeleteSource(ctx, req.SourceID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -411,12 +451,25 @@ func (h *SourceHandler) handleGetSources(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	srcs, _, err := h.SourceService.FindSources(ctx, req.findOptions)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.SourcesResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, _, err := h.SourceService.FindSources(ctx, req.findOptions)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	srcs := make([]*platform.Source, 0, len(found))
+	for _, s := range found {
+		if allowed(s.ID, s.OrganizationID) {
+			srcs = append(srcs, s)
+		}
+	}
+
 	res := newSourcesResponse(srcs)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
@@ -444,6 +497,11 @@ func (h *SourceHandler) handlePatchSource(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.SourcesResource, req.SourceID, h.sourceIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.SourceService.UpdateSource(ctx, req.SourceID, req.Update)
 	if err != nil {
 		EncodeError(ctx, err, w)
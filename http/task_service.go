@@ -71,13 +71,13 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
 
-	h.HandlerFunc("POST", tasksIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Member))
-	h.HandlerFunc("GET", tasksIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Member))
-	h.HandlerFunc("DELETE", tasksIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", tasksIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Member, h.taskIDs))
+	h.HandlerFunc("GET", tasksIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Member, h.taskIDs))
+	h.HandlerFunc("DELETE", tasksIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.TasksResource, platform.Member, h.taskIDs))
 
-	h.HandlerFunc("POST", tasksIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Owner))
-	h.HandlerFunc("GET", tasksIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Owner))
-	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", tasksIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Owner, h.taskIDs))
+	h.HandlerFunc("GET", tasksIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TasksResource, platform.Owner, h.taskIDs))
+	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.TasksResource, platform.Owner, h.taskIDs))
 
 	h.HandlerFunc("GET", tasksIDRunsPath, h.handleGetRuns)
 	h.HandlerFunc("POST", tasksIDRunsPath, h.handleForceRun)
@@ -85,14 +85,24 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
-	h.HandlerFunc("GET", tasksIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", tasksIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", tasksIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", tasksIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", tasksIDLabelsPath, newGetLabelsHandler(h.LabelService, h.taskIDs))
+	h.HandlerFunc("POST", tasksIDLabelsPath, newPostLabelHandler(h.LabelService, h.taskIDs))
+	h.HandlerFunc("DELETE", tasksIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, h.taskIDs))
+	h.HandlerFunc("PATCH", tasksIDLabelsNamePath, newPatchLabelHandler(h.LabelService, h.taskIDs))
 
 	return h
 }
 
+// taskIDs returns the ids that authorize access to a task: its own and that of its organization.
+func (h *TaskHandler) taskIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	t, err := h.TaskService.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []platform.ID{t.ID, t.Organization}, nil
+}
+
 type taskResponse struct {
 	Links  map[string]string `json:"links"`
 	Labels []platform.Label  `json:"labels"`
@@ -210,12 +220,25 @@ func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tasks, _, err := h.TaskService.FindTasks(ctx, req.filter)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.TasksResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	found, _, err := h.TaskService.FindTasks(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	tasks := make([]*platform.Task, 0, len(found))
+	for _, t := range found {
+		if allowed(t.ID, t.Organization) {
+			tasks = append(tasks, t)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newTasksResponse(ctx, tasks, h.LabelService)); err != nil {
 		logEncodingError(h.logger, r, err)
 		return
@@ -294,6 +317,11 @@ func (h *TaskHandler) handlePostTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.TasksResource, req.Task.Organization); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if !req.Task.Owner.ID.Valid() {
 		req.Task.Owner.ID = auth.GetUserID()
 	}
@@ -336,6 +364,11 @@ func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.ReadAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	task, err := h.TaskService.FindTaskByID(ctx, req.TaskID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -386,6 +419,11 @@ func (h *TaskHandler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	task, err := h.TaskService.UpdateTask(ctx, req.TaskID, req.Update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -441,6 +479,11 @@ func (h *TaskHandler) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.TaskService.DeleteTask(ctx, req.TaskID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -479,6 +522,11 @@ func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.ReadAction, platform.TasksResource, *req.filter.Task, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	logs, _, err := h.TaskService.FindLogs(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -546,6 +594,11 @@ func (h *TaskHandler) handleGetRuns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.ReadAction, platform.TasksResource, *req.filter.Task, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	runs, _, err := h.TaskService.FindRuns(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -648,6 +701,11 @@ func (h *TaskHandler) handleForceRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	run, err := h.TaskService.ForceRun(ctx, req.TaskID, req.Timestamp)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -709,6 +767,11 @@ func (h *TaskHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.ReadAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	run, err := h.TaskService.FindRunByID(ctx, req.TaskID, req.RunID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -791,6 +854,11 @@ func (h *TaskHandler) handleCancelRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	err = h.TaskService.CancelRun(ctx, req.TaskID, req.RunID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -807,6 +875,11 @@ func (h *TaskHandler) handleRetryRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeResource(ctx, platform.WriteAction, platform.TasksResource, req.TaskID, h.taskIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	run, err := h.TaskService.RetryRun(ctx, req.TaskID, req.RunID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -150,6 +150,7 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
 			h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout), mock.NewUserService())
 			h.TaskService = tt.fields.taskService
 			h.LabelService = tt.fields.labelService
+			r = withOperator(r)
 			h.handleGetTasks(w, r)
 
 			res := w.Result()
@@ -356,6 +357,7 @@ func TestTaskHandler_handleGetRun(t *testing.T) {
 			w := httptest.NewRecorder()
 			h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout), mock.NewUserService())
 			h.TaskService = tt.fields.taskService
+			r = withOperator(r)
 			h.handleGetRun(w, r)
 
 			res := w.Result()
@@ -464,6 +466,7 @@ func TestTaskHandler_handleGetRuns(t *testing.T) {
 			w := httptest.NewRecorder()
 			h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout), mock.NewUserService())
 			h.TaskService = tt.fields.taskService
+			r = withOperator(r)
 			h.handleGetRuns(w, r)
 
 			res := w.Result()
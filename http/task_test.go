@@ -48,7 +48,7 @@ func httpTaskServiceFactory(t *testing.T) (*servicetest.System, context.CancelFu
 		t.Fatal(err)
 	}
 
-	server := httptest.NewServer(h)
+	server := httptest.NewServer(operatorHandler(h))
 	go func() {
 		<-ctx.Done()
 		server.Close()
@@ -60,22 +60,32 @@ func NewTelegrafHandler(
 	h.HandlerFunc("DELETE", telegrafsIDPath, h.handleDeleteTelegraf)
 	h.HandlerFunc("PUT", telegrafsIDPath, h.handlePutTelegraf)
 
-	h.HandlerFunc("POST", telegrafsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Member))
-	h.HandlerFunc("GET", telegrafsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Member))
-	h.HandlerFunc("DELETE", telegrafsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", telegrafsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Member, h.telegrafIDs))
+	h.HandlerFunc("GET", telegrafsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Member, h.telegrafIDs))
+	h.HandlerFunc("DELETE", telegrafsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.TelegrafsResource, platform.Member, h.telegrafIDs))
 
-	h.HandlerFunc("POST", telegrafsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Owner))
-	h.HandlerFunc("GET", telegrafsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Owner))
-	h.HandlerFunc("DELETE", telegrafsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", telegrafsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Owner, h.telegrafIDs))
+	h.HandlerFunc("GET", telegrafsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TelegrafsResource, platform.Owner, h.telegrafIDs))
+	h.HandlerFunc("DELETE", telegrafsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.TelegrafsResource, platform.Owner, h.telegrafIDs))
 
-	h.HandlerFunc("GET", telegrafsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", telegrafsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", telegrafsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", telegrafsIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", telegrafsIDLabelsPath, newGetLabelsHandler(h.LabelService, h.telegrafIDs))
+	h.HandlerFunc("POST", telegrafsIDLabelsPath, newPostLabelHandler(h.LabelService, h.telegrafIDs))
+	h.HandlerFunc("DELETE", telegrafsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, h.telegrafIDs))
+	h.HandlerFunc("PATCH", telegrafsIDLabelsNamePath, newPatchLabelHandler(h.LabelService, h.telegrafIDs))
 
 	return h
 }
 
+// telegrafIDs returns the ids that authorize access to a telegraf config: its own and that of its organization.
+func (h *TelegrafHandler) telegrafIDs(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+	tc, err := h.TelegrafService.FindTelegrafConfigByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []platform.ID{tc.ID, tc.OrganizationID}, nil
+}
+
 type telegrafLinks struct {
 	Self   string `json:"self"`
 	Labels string `json:"labels"`
@@ -140,11 +150,22 @@ func (h *TelegrafHandler) handleGetTelegrafs(w http.ResponseWriter, r *http.Requ
 		EncodeError(ctx, err, w)
 		return
 	}
-	tcs, _, err := h.TelegrafService.FindTelegrafConfigs(ctx, *filter)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.TelegrafsResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
+	found, _, err := h.TelegrafService.FindTelegrafConfigs(ctx, *filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	tcs := make([]*platform.TelegrafConfig, 0, len(found))
+	for _, tc := range found {
+		if allowed(tc.ID, tc.OrganizationID) {
+			tcs = append(tcs, tc)
+		}
+	}
 	if err := encodeResponse(ctx, w, http.StatusOK, newTelegrafResponses(ctx, tcs, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -163,6 +184,10 @@ func (h *TelegrafHandler) handleGetTelegraf(w http.ResponseWriter, r *http.Reque
 		EncodeError(ctx, err, w)
 		return
 	}
+	if err := authorize(ctx, platform.ReadAction, platform.TelegrafsResource, tc.ID, tc.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 
 	offers := []string{"application/toml", "application/json", "application/octet-stream"}
 	defaultOffer := "application/toml"
@@ -263,6 +288,10 @@ func (h *TelegrafHandler) handlePostTelegraf(w http.ResponseWriter, r *http.Requ
 		EncodeError(ctx, err, w)
 		return
 	}
+	if err := authorize(ctx, platform.WriteAction, platform.TelegrafsResource, tc.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -289,6 +318,15 @@ func (h *TelegrafHandler) handlePutTelegraf(w http.ResponseWriter, r *http.Reque
 		EncodeError(ctx, err, w)
 		return
 	}
+	if err := authorizeResource(ctx, platform.WriteAction, platform.TelegrafsResource, tc.ID, h.telegrafIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	// Moving a config to another organization requires write access there too.
+	if err := authorize(ctx, platform.WriteAction, platform.TelegrafsResource, tc.ID, tc.OrganizationID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -320,6 +358,10 @@ func (h *TelegrafHandler) handleDeleteTelegraf(w http.ResponseWriter, r *http.Re
 		EncodeError(ctx, err, w)
 		return
 	}
+	if err := authorizeResource(ctx, platform.DeleteAction, platform.TelegrafsResource, i, h.telegrafIDs); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 
 	if err = h.TelegrafService.DeleteTelegrafConfig(ctx, i); err != nil {
 		EncodeError(ctx, err, w)
@@ -156,7 +156,7 @@ func TestTelegrafHandler_handleGetTelegrafs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			h := NewTelegrafHandler(zaptest.NewLogger(t), mock.NewUserResourceMappingService(), mock.NewLabelService(), tt.svc, mock.NewUserService())
-			h.ServeHTTP(w, tt.r)
+			h.ServeHTTP(w, withOperator(tt.r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -683,7 +683,7 @@ func TestTelegrafHandler_handleGetTelegraf(t *testing.T) {
 			w := httptest.NewRecorder()
 			h := NewTelegrafHandler(logger, mapping, labels, tt.svc, users)
 
-			h.ServeHTTP(w, tt.r)
+			h.ServeHTTP(w, withOperator(tt.r))
 
 			res := w.Result()
 			content := res.Header.Get("Content-Type")
@@ -41,6 +41,18 @@ func (h *UsageHandler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var ids []platform.ID
+	if req.filter.OrgID != nil {
+		ids = append(ids, *req.filter.OrgID)
+	}
+	if req.filter.BucketID != nil {
+		ids = append(ids, *req.filter.BucketID)
+	}
+	if err := authorize(ctx, platform.ReadAction, platform.UsageResource, ids...); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.UsageService.GetUsage(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
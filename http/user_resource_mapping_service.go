@@ -52,8 +52,9 @@ func newResourceUsersResponse(opts platform.FindOptions, f platform.UserResource
 	return &rs
 }
 
-// newPostMemberHandler returns a handler func for a POST to /members or /owners endpoints
-func newPostMemberHandler(s platform.UserResourceMappingService, userService platform.UserService, resourceType platform.Resource, userType platform.UserType) http.HandlerFunc {
+// newPostMemberHandler returns a handler func for a POST to /members or /owners endpoints.
+// Adding users to a resource requires the admin action on the resource.
+func newPostMemberHandler(s platform.UserResourceMappingService, userService platform.UserService, resourceType platform.Resource, userType platform.UserType, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -63,6 +64,11 @@ func newPostMemberHandler(s platform.UserResourceMappingService, userService pla
 			return
 		}
 
+		if err := authorizeResource(ctx, platform.AdminAction, resourceType, req.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		user, err := userService.FindUserByID(ctx, req.MemberID)
 		if err != nil {
 			EncodeError(ctx, err, w)
@@ -121,7 +127,7 @@ func decodePostMemberRequest(ctx context.Context, r *http.Request) (*postMemberR
 }
 
 // newGetMembersHandler returns a handler func for a GET to /members or /owners endpoints
-func newGetMembersHandler(s platform.UserResourceMappingService, userService platform.UserService, resourceType platform.Resource, userType platform.UserType) http.HandlerFunc {
+func newGetMembersHandler(s platform.UserResourceMappingService, userService platform.UserService, resourceType platform.Resource, userType platform.UserType, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -131,6 +137,11 @@ func newGetMembersHandler(s platform.UserResourceMappingService, userService pla
 			return
 		}
 
+		if err := authorizeResource(ctx, platform.ReadAction, resourceType, req.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		filter := platform.UserResourceMappingFilter{
 			ResourceID: req.ResourceID,
 			Resource:   resourceType,
@@ -186,8 +197,9 @@ func decodeGetMembersRequest(ctx context.Context, r *http.Request) (*getMembersR
 	return req, nil
 }
 
-// newDeleteMemberHandler returns a handler func for a DELETE to /members or /owners endpoints
-func newDeleteMemberHandler(s platform.UserResourceMappingService, userType platform.UserType) http.HandlerFunc {
+// newDeleteMemberHandler returns a handler func for a DELETE to /members or /owners endpoints.
+// Removing users from a resource requires the admin action on the resource.
+func newDeleteMemberHandler(s platform.UserResourceMappingService, resourceType platform.Resource, userType platform.UserType, resourceIDs resourceIDsFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -197,6 +209,11 @@ func newDeleteMemberHandler(s platform.UserResourceMappingService, userType plat
 			return
 		}
 
+		if err := authorizeResource(ctx, platform.AdminAction, resourceType, req.ResourceID, resourceIDs); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
 		if err := s.DeleteUserResourceMapping(ctx, req.ResourceID, req.MemberID); err != nil {
 			EncodeError(ctx, err, w)
 			return
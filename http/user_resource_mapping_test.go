@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/mock"
 	"github.com/julienschmidt/httprouter"
 )
@@ -179,8 +180,12 @@ func TestUserResourceMappingService_GetMembersHandler(t *testing.T) {
 		for _, resourceType := range resourceTypes {
 			t.Run(tt.name+"_"+string(resourceType), func(t *testing.T) {
 				r := httptest.NewRequest("GET", "http://any.url", nil)
+				ctx := pcontext.SetAuthorizer(context.TODO(), &platform.Authorization{
+					Status:      platform.Active,
+					Permissions: platform.OperPermissions(),
+				})
 				r = r.WithContext(context.WithValue(
-					context.TODO(),
+					ctx,
 					httprouter.ParamsKey,
 					httprouter.Params{
 						{
@@ -190,7 +195,7 @@ func TestUserResourceMappingService_GetMembersHandler(t *testing.T) {
 					}))
 
 				w := httptest.NewRecorder()
-				h := newGetMembersHandler(tt.fields.userResourceMappingService, tt.fields.userService, resourceType, tt.args.userType)
+				h := newGetMembersHandler(tt.fields.userResourceMappingService, tt.fields.userService, resourceType, tt.args.userType, ownResourceIDs)
 				h.ServeHTTP(w, r)
 
 				res := w.Result()
@@ -329,8 +334,12 @@ func TestUserResourceMappingService_PostMembersHandler(t *testing.T) {
 				}
 
 				r := httptest.NewRequest("POST", "http://any.url", bytes.NewReader(b))
+				ctx := pcontext.SetAuthorizer(context.TODO(), &platform.Authorization{
+					Status:      platform.Active,
+					Permissions: platform.OperPermissions(),
+				})
 				r = r.WithContext(context.WithValue(
-					context.TODO(),
+					ctx,
 					httprouter.ParamsKey,
 					httprouter.Params{
 						{
@@ -340,7 +349,7 @@ func TestUserResourceMappingService_PostMembersHandler(t *testing.T) {
 					}))
 
 				w := httptest.NewRecorder()
-				h := newPostMemberHandler(tt.fields.userResourceMappingService, tt.fields.userService, resourceType, tt.args.userType)
+				h := newPostMemberHandler(tt.fields.userResourceMappingService, tt.fields.userService, resourceType, tt.args.userType, ownResourceIDs)
 				h.ServeHTTP(w, r)
 
 				res := w.Result()
@@ -360,3 +369,85 @@ func TestUserResourceMappingService_PostMembersHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestUserResourceMappingService_MemberHandlersAuthorization(t *testing.T) {
+	orgID := platform.ID(2)
+	resourceIDs := func(ctx context.Context, id platform.ID) ([]platform.ID, error) {
+		return []platform.ID{id, orgID}, nil
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		permissions []platform.Permission
+		wantStatus  int
+	}{
+		{
+			name:        "org reader gets members",
+			method:      "GET",
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.BucketsResource, ID: &orgID}},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "org writer can not add members",
+			method:      "POST",
+			permissions: []platform.Permission{{Action: platform.WriteAction, Resource: platform.BucketsResource, ID: &orgID}},
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "org admin adds members",
+			method:      "POST",
+			permissions: []platform.Permission{{Action: platform.AdminAction, Resource: platform.BucketsResource, ID: &orgID}},
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "reader of another resource type",
+			method:      "GET",
+			permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.DashboardsResource, ID: &orgID}},
+			wantStatus:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := &mock.UserService{
+				FindUserByIDFn: func(ctx context.Context, id platform.ID) (*platform.User, error) {
+					return &platform.User{ID: id, Name: "user"}, nil
+				},
+			}
+			mappingService := &mock.UserResourceMappingService{
+				CreateMappingFn: func(ctx context.Context, m *platform.UserResourceMapping) error {
+					return nil
+				},
+				FindMappingsFn: func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+					return nil, 0, nil
+				},
+			}
+
+			var h http.HandlerFunc
+			var body []byte
+			if tt.method == "POST" {
+				h = newPostMemberHandler(mappingService, userService, platform.BucketsResource, platform.Member, resourceIDs)
+				body = []byte(`{"id":"0000000000000001"}`)
+			} else {
+				h = newGetMembersHandler(mappingService, userService, platform.BucketsResource, platform.Member, resourceIDs)
+			}
+
+			r := httptest.NewRequest(tt.method, "http://any.url", bytes.NewReader(body))
+			ctx := pcontext.SetAuthorizer(context.TODO(), &platform.Authorization{
+				Status:      platform.Active,
+				Permissions: tt.permissions,
+			})
+			r = r.WithContext(context.WithValue(ctx, httprouter.ParamsKey, httprouter.Params{
+				{Key: "id", Value: "0000000000000099"},
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
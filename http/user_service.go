@@ -121,6 +121,21 @@ func decodePasswordResetRequest(ctx context.Context, r *http.Request) (*password
 	}, nil
 }
 
+// authorizeUser authorizes the action on the user of id. Users may read and
+// update themselves, but deleting a user always requires the delete action.
+func authorizeUser(ctx context.Context, a platform.Action, id platform.ID) error {
+	auth, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if a != platform.DeleteAction && auth.GetUserID() == id {
+		return nil
+	}
+
+	return authorize(ctx, a, platform.UsersResource, id)
+}
+
 // handlePostUser is the HTTP handler for the POST /api/v2/users route.
 func (h *UserHandler) handlePostUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -131,6 +146,11 @@ func (h *UserHandler) handlePostUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.WriteAction, platform.UsersResource); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.UserService.CreateUser(ctx, req.User); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -197,6 +217,11 @@ func (h *UserHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeUser(ctx, platform.ReadAction, req.UserID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.UserService.FindUserByID(ctx, req.UserID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -242,6 +267,11 @@ func (h *UserHandler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeUser(ctx, platform.DeleteAction, req.UserID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.UserService.DeleteUser(ctx, req.UserID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -322,12 +352,25 @@ func (h *UserHandler) handleGetUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, _, err := h.UserService.FindUsers(ctx, req.filter)
+	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, _, err := h.UserService.FindUsers(ctx, req.filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	users := make([]*platform.User, 0, len(found))
+	for _, u := range found {
+		if u.ID == auth.GetUserID() || isAllowed(auth, platform.ReadAction, platform.UsersResource, u.ID) {
+			users = append(users, u)
+		}
+	}
+
 	err = encodeResponse(ctx, w, http.StatusOK, newUsersResponse(users))
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -368,6 +411,11 @@ func (h *UserHandler) handlePatchUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeUser(ctx, platform.WriteAction, req.UserID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	b, err := h.UserService.UpdateUser(ctx, req.UserID, req.Update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -661,6 +709,11 @@ func (h *UserHandler) handleGetUserLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeUser(ctx, platform.ReadAction, req.UserID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	log, _, err := h.UserOperationLogService.GetUserOperationLog(ctx, req.UserID, req.opts)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -24,7 +24,7 @@ func initUserService(f platformtesting.UserFields, t *testing.T) (platform.UserS
 
 	handler := NewUserHandler()
 	handler.UserService = svc
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(operatorHandler(handler))
 	client := UserService{
 		Addr:     server.URL,
 		OpPrefix: inmem.OpPrefix,
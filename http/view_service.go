@@ -54,18 +54,18 @@ func NewViewHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("DELETE", viewsIDPath, h.handleDeleteView)
 	h.HandlerFunc("PATCH", viewsIDPath, h.handlePatchView)
 
-	h.HandlerFunc("POST", viewsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member))
-	h.HandlerFunc("GET", viewsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Member))
-	h.HandlerFunc("DELETE", viewsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("POST", viewsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.ViewsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("GET", viewsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.ViewsResource, platform.Member, ownResourceIDs))
+	h.HandlerFunc("DELETE", viewsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.ViewsResource, platform.Member, ownResourceIDs))
 
-	h.HandlerFunc("POST", viewsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner))
-	h.HandlerFunc("GET", viewsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.DashboardsResource, platform.Owner))
-	h.HandlerFunc("DELETE", viewsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("POST", viewsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.ViewsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("GET", viewsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.ViewsResource, platform.Owner, ownResourceIDs))
+	h.HandlerFunc("DELETE", viewsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.ViewsResource, platform.Owner, ownResourceIDs))
 
-	h.HandlerFunc("GET", viewsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", viewsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", viewsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
-	h.HandlerFunc("PATCH", viewsIDLabelsNamePath, newPatchLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", viewsIDLabelsPath, newGetLabelsHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("POST", viewsIDLabelsPath, newPostLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("DELETE", viewsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService, ownResourceIDs))
+	h.HandlerFunc("PATCH", viewsIDLabelsNamePath, newPatchLabelHandler(h.LabelService, ownResourceIDs))
 
 	return h
 }
@@ -111,12 +111,25 @@ func (h *ViewHandler) handleGetViews(w http.ResponseWriter, r *http.Request) {
 
 	req := decodeGetViewsRequest(ctx, r)
 
-	views, _, err := h.ViewService.FindViews(ctx, req.filter)
+	allowed, err := authorizeFilter(ctx, platform.ReadAction, platform.ViewsResource)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	found, _, err := h.ViewService.FindViews(ctx, req.filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	views := make([]*platform.View, 0, len(found))
+	for _, v := range found {
+		if allowed(v.ID) {
+			views = append(views, v)
+		}
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusOK, newGetViewsResponse(views)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -170,11 +183,23 @@ func (h *ViewHandler) handlePostViews(w http.ResponseWriter, r *http.Request) {
 		EncodeError(ctx, err, w)
 		return
 	}
+
+	userID, err := authorizeOwnedCreate(ctx, platform.ViewsResource)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ViewService.CreateView(ctx, req.View); err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
+	if err := createOwnerMapping(ctx, h.UserResourceMappingService, platform.ViewsResource, req.View.ID, userID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := encodeResponse(ctx, w, http.StatusCreated, newViewResponse(req.View)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -205,6 +230,11 @@ func (h *ViewHandler) handleGetView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.ReadAction, platform.ViewsResource, req.ViewID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	view, err := h.ViewService.FindViewByID(ctx, req.ViewID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -251,6 +281,11 @@ func (h *ViewHandler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorize(ctx, platform.DeleteAction, platform.ViewsResource, req.ViewID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ViewService.DeleteView(ctx, req.ViewID); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -292,6 +327,12 @@ func (h *ViewHandler) handlePatchView(w http.ResponseWriter, r *http.Request) {
 		EncodeError(ctx, pe, w)
 		return
 	}
+
+	if err := authorize(ctx, platform.WriteAction, platform.ViewsResource, req.ViewID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	view, err := h.ViewService.UpdateView(ctx, req.ViewID, req.Upd)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -146,6 +146,7 @@ func TestService_handleGetViews(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetViews(w, r)
 
 			res := w.Result()
@@ -259,6 +260,7 @@ func TestService_handleGetView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleGetView(w, r)
 
 			res := w.Result()
@@ -358,6 +360,7 @@ func TestService_handlePostViews(t *testing.T) {
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePostViews(w, r)
 
 			res := w.Result()
@@ -456,6 +459,7 @@ func TestService_handleDeleteView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handleDeleteView(w, r)
 
 			res := w.Result()
@@ -628,6 +632,7 @@ func TestService_handlePatchView(t *testing.T) {
 
 			w := httptest.NewRecorder()
 
+			r = withOperator(r)
 			h.handlePatchView(w, r)
 
 			res := w.Result()
@@ -699,7 +704,7 @@ func initViewService(f platformtesting.ViewFields, t *testing.T) (platform.ViewS
 
 	handler := NewViewHandler()
 	handler.ViewService = svc
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(operatorHandler(handler))
 	client := ViewService{
 		Addr: server.URL,
 	}
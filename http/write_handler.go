@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -29,15 +30,33 @@ type WriteHandler struct {
 	OrganizationService platform.OrganizationService
 
 	PointsWriter storage.PointsWriter
+
+	// BulkPointsWriter, if set, serves bulk writes to /api/v2/write/bulk.
+	BulkPointsWriter storage.BulkPointsWriter
+
+	// DBRPMappingService resolves the database and retention policy
+	// of writes to the InfluxDB 1.x compatible /write endpoint.
+	DBRPMappingService platform.DBRPMappingService
+
+	// UsageRecorder, if set, records the usage of successful writes.
+	UsageRecorder platform.UsageRecorder
+
+	Limits WriteLimits
+
+	orgLimiter orgWriteLimiter
 }
 
 const (
 	writePath            = "/api/v2/write"
+	bulkWritePath        = "/api/v2/write/bulk"
+	v1WritePath          = "/write"
 	errInvalidGzipHeader = "gzipped HTTP body contains an invalid header"
 	errInvalidPrecision  = "invalid precision; valid precision units are ns, us, ms, and s"
 )
 
 // NewWriteHandler creates a new handler at /api/v2/write to receive line protocol.
+// The InfluxDB 1.x compatible /write endpoint is served as well, and bulk writes
+// are served at /api/v2/write/bulk when the BulkPointsWriter is set.
 func NewWriteHandler(writer storage.PointsWriter) *WriteHandler {
 	h := &WriteHandler{
 		Router:       NewRouter(),
@@ -46,28 +65,39 @@ func NewWriteHandler(writer storage.PointsWriter) *WriteHandler {
 	}
 
 	h.HandlerFunc("POST", writePath, h.handleWrite)
+	h.HandlerFunc("POST", bulkWritePath, h.handleBulkWrite)
+	h.HandlerFunc("POST", v1WritePath, h.handleV1Write)
 	return h
 }
 
+// decodeWriteBody returns the body of a write request, decompressing it if gzipped.
+func decodeWriteBody(r *http.Request) (io.ReadCloser, error) {
+	if r.Header.Get("Content-Encoding") != "gzip" {
+		return r.Body, nil
+	}
+
+	in, err := gzip.NewReader(r.Body)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleWrite",
+			Msg:  errInvalidGzipHeader,
+			Err:  err,
+		}
+	}
+	return in, nil
+}
+
 func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	defer r.Body.Close()
 
-	in := r.Body
-	if r.Header.Get("Content-Encoding") == "gzip" {
-		var err error
-		in, err = gzip.NewReader(r.Body)
-		if err != nil {
-			EncodeError(ctx, &platform.Error{
-				Code: platform.EInvalid,
-				Op:   "http/handleWrite",
-				Msg:  errInvalidGzipHeader,
-				Err:  err,
-			}, w)
-			return
-		}
-		defer in.Close()
+	in, err := decodeWriteBody(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
+	defer in.Close()
 
 	a, err := pcontext.GetAuthorizer(ctx)
 	if err != nil {
@@ -82,7 +112,22 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+	org, bucket, err := h.findBucket(ctx, req, logger)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 
+	if err := h.write(ctx, w, a, in, org.ID, bucket.ID, req.Precision, logger); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findBucket returns the organization and bucket of a write request, either of
+// which may be given by ID or by name.
+func (h *WriteHandler) findBucket(ctx context.Context, req *postWriteRequest, logger *zap.Logger) (*platform.Organization, *platform.Bucket, error) {
 	var org *platform.Organization
 	if id, err := platform.IDFromString(req.Org); err == nil {
 		// Decoded ID successfully. Make sure it's a real org.
@@ -90,16 +135,14 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		if err == nil {
 			org = o
 		} else if platform.ErrorCode(err) != platform.ENotFound {
-			EncodeError(ctx, err, w)
-			return
+			return nil, nil, err
 		}
 	}
 	if org == nil {
 		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Org})
 		if err != nil {
 			logger.Info("Failed to find organization", zap.Error(err))
-			EncodeError(ctx, fmt.Errorf("organization %q not found", req.Org), w)
-			return
+			return nil, nil, fmt.Errorf("organization %q not found", req.Org)
 		}
 
 		org = o
@@ -115,8 +158,7 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		if err == nil {
 			bucket = b
 		} else if platform.ErrorCode(err) != platform.ENotFound {
-			EncodeError(ctx, err, w)
-			return
+			return nil, nil, err
 		}
 	}
 
@@ -126,28 +168,66 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 			Name:           &req.Bucket,
 		})
 		if err != nil {
-			EncodeError(ctx, &platform.Error{
+			return nil, nil, &platform.Error{
 				Code: platform.ENotFound,
 				Op:   "http/handleWrite",
 				Err:  err,
 				Msg:  fmt.Sprintf("bucket %q not found", req.Bucket),
-			}, w)
-			return
+			}
 		}
 
 		bucket = b
 	}
 
-	p, err := platform.NewPermissionAtID(bucket.ID, platform.WriteAction, platform.BucketsResource)
+	return org, bucket, nil
+}
+
+// handleV1Write is the HTTP handler for the InfluxDB 1.x compatible POST /write route.
+// The database and retention policy are resolved to a bucket by the dbrp mappings.
+func (h *WriteHandler) handleV1Write(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	in, err := decodeWriteBody(r)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+	defer in.Close()
+
+	a, err := pcontext.GetAuthorizer(ctx)
 	if err != nil {
-		EncodeError(ctx, fmt.Errorf("could not create permission for bucket: %v", err), w)
+		encodeV1Error(ctx, err, w)
 		return
 	}
 
-	if !a.Allowed(*p) {
-		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for write"), w)
+	req, err := decodeV1WriteRequest(ctx, r)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	m, err := findDBRPMapping(ctx, h.DBRPMappingService, authorizerOrganization(a), req.Database, req.RetentionPolicy)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("db", req.Database), zap.String("rp", req.RetentionPolicy))
+	if err := h.write(ctx, w, a, in, m.OrganizationID, m.BucketID, req.Precision, logger); err != nil {
+		encodeV1Error(ctx, err, w)
 		return
 	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// write checks the authorizer may write to the bucket, then writes the line protocol
+// read from in into the bucket. The error is left for the caller to encode, as the
+// InfluxDB 1.x compatible endpoint encodes errors differently.
+func (h *WriteHandler) write(ctx context.Context, w http.ResponseWriter, a platform.Authorizer, in io.Reader, orgID, bucketID platform.ID, precision string, logger *zap.Logger) error {
+	if err := authorizeWrite(a, bucketID); err != nil {
+		return err
+	}
 
 	// TODO(jeff): we should be publishing with the org and bucket instead of
 	// parsing, rewriting, and publishing, but the interface isn't quite there yet.
@@ -155,32 +235,260 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	data, err := ioutil.ReadAll(in)
 	if err != nil {
 		logger.Info("Error reading body", zap.Error(err))
+		return err
+	}
+
+	if max := h.Limits.MaxWriteBytesPerSecond; max > 0 && !h.orgLimiter.allow(orgID, max, len(data), time.Now()) {
+		// The organization's allowance is fully replenished every second.
+		w.Header().Set("Retry-After", "1")
+		return &platform.Error{
+			Code: platform.ETooManyRequests,
+			Op:   "http/handleWrite",
+			Msg:  fmt.Sprintf("organization exceeded the write limit of %d bytes per second", max),
+		}
+	}
+
+	points, err := models.ParsePointsWithPrecision(data, time.Now(), precision)
+	if err != nil {
+		logger.Info("Error parsing points", zap.Error(err))
+		return err
+	}
+
+	if max := h.Limits.MaxPointsPerRequest; max > 0 && len(points) > max {
+		return &platform.Error{
+			Code: platform.ETooLarge,
+			Op:   "http/handleWrite",
+			Msg:  fmt.Sprintf("request contains %d points, exceeding the limit of %d points per request", len(points), max),
+		}
+	}
+
+	exploded, err := tsdb.ExplodePoints(orgID, bucketID, points)
+	if err != nil {
+		logger.Info("Error exploding points", zap.Error(err))
+		return err
+	}
+
+	if err := h.PointsWriter.WritePoints(exploded); err != nil {
+		if pe, ok := err.(*platform.Error); ok {
+			logger.Info("Error writing points", zap.Error(err))
+			return pe
+		}
+		return errors.BadRequestError(err.Error())
+	}
+
+	if h.UsageRecorder != nil {
+		h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageWriteRequestCount, 1)
+		h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageWriteRequestBytes, float64(len(data)))
+		h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageValues, float64(len(exploded)))
+	}
+	return nil
+}
+
+// authorizeWrite returns an error if the authorizer may not write to the bucket.
+func authorizeWrite(a platform.Authorizer, bucketID platform.ID) error {
+	p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResource)
+	if err != nil {
+		return fmt.Errorf("could not create permission for bucket: %v", err)
+	}
+
+	if !a.Allowed(*p) {
+		return errors.Forbiddenf("insufficient permissions for write")
+	}
+	return nil
+}
+
+// handleBulkWrite is the HTTP handler for the POST /api/v2/write/bulk route.
+// The line protocol of the body is written directly to new TSM files, which are
+// added to the engine at once when the whole body has been written. If any line
+// can't be written, none of them are.
+func (h *WriteHandler) handleBulkWrite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	if h.BulkPointsWriter == nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EMethodNotAllowed,
+			Op:   "http/handleBulkWrite",
+			Msg:  "bulk writes are not supported",
+		}, w)
+		return
+	}
+
+	in, err := decodeWriteBody(r)
+	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
+	defer in.Close()
 
-	points, err := models.ParsePointsWithPrecision(data, time.Now(), req.Precision)
+	a, err := pcontext.GetAuthorizer(ctx)
 	if err != nil {
-		logger.Info("Error parsing points", zap.Error(err))
 		EncodeError(ctx, err, w)
 		return
 	}
 
-	exploded, err := tsdb.ExplodePoints(org.ID, bucket.ID, points)
+	req, err := decodeWriteRequest(ctx, r)
 	if err != nil {
-		logger.Info("Error exploding points", zap.Error(err))
 		EncodeError(ctx, err, w)
 		return
 	}
 
-	if err := h.PointsWriter.WritePoints(exploded); err != nil {
-		EncodeError(ctx, errors.BadRequestError(err.Error()), w)
+	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+	org, bucket, err := h.findBucket(ctx, req, logger)
+	if err != nil {
+		EncodeError(ctx, err, w)
 		return
 	}
 
+	if err := authorizeWrite(a, bucket.ID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	loader, err := h.BulkPointsWriter.NewBulkLoader()
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	start := time.Now()
+	bw := &bulkWriter{
+		loader:    loader,
+		orgID:     org.ID,
+		bucketID:  bucket.ID,
+		precision: req.Precision,
+		limits:    h.Limits,
+		limiter:   &h.orgLimiter,
+	}
+	if err := bw.write(ctx, in); err != nil {
+		logger.Info("Error writing bulk points", zap.Error(err))
+		if err := loader.Abort(); err != nil {
+			logger.Info("Error aborting bulk write", zap.Error(err))
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := loader.Commit(); err != nil {
+		logger.Info("Error committing bulk write", zap.Error(err))
+		if err := loader.Abort(); err != nil {
+			logger.Info("Error aborting bulk write", zap.Error(err))
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	logger.Info("Bulk write committed", zap.Int("bytes", bw.bytes), zap.Int("values", bw.values), zap.Duration("elapsed", time.Since(start)))
+
+	if h.UsageRecorder != nil {
+		h.UsageRecorder.RecordUsage(ctx, org.ID, bucket.ID, platform.UsageWriteRequestCount, 1)
+		h.UsageRecorder.RecordUsage(ctx, org.ID, bucket.ID, platform.UsageWriteRequestBytes, float64(bw.bytes))
+		h.UsageRecorder.RecordUsage(ctx, org.ID, bucket.ID, platform.UsageValues, float64(bw.values))
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// bulkWriteBatchSize is the number of bytes of line protocol that a bulk write
+// parses and writes to the engine at once.
+const bulkWriteBatchSize = 4 * 1024 * 1024
+
+// bulkWriter writes line protocol to a bulk load in batches, so that the body
+// of a bulk write is never held in memory at once. Each batch is charged to
+// the write allowance of the organization, waiting for it to be available.
+type bulkWriter struct {
+	loader    storage.BulkLoader
+	orgID     platform.ID
+	bucketID  platform.ID
+	precision string
+	limits    WriteLimits
+	limiter   *orgWriteLimiter
+
+	batch  []byte
+	bytes  int
+	points int
+	values int
+}
+
+// write writes the lines read from in to the bulk load.
+func (bw *bulkWriter) write(ctx context.Context, in io.Reader) error {
+	br := bufio.NewReader(in)
+	for {
+		line, err := br.ReadSlice('\n')
+		bw.batch = append(bw.batch, line...)
+		if err == bufio.ErrBufferFull {
+			// The rest of the line is read next.
+			continue
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+
+		if len(bw.batch) >= bulkWriteBatchSize || err == io.EOF {
+			if err := bw.flush(ctx); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// flush writes the batch of whole lines to the bulk load.
+func (bw *bulkWriter) flush(ctx context.Context) error {
+	if len(bw.batch) == 0 {
+		return nil
+	}
+
+	if max := bw.limits.MaxWriteBytesPerSecond; max > 0 {
+		if err := bw.limiter.wait(ctx, bw.orgID, max, len(bw.batch)); err != nil {
+			return &platform.Error{
+				Code: platform.ETooManyRequests,
+				Op:   "http/handleBulkWrite",
+				Msg:  fmt.Sprintf("no points were written: organization exceeded the write limit of %d bytes per second: %v", max, err),
+			}
+		}
+	}
+
+	points, err := models.ParsePointsWithPrecision(bw.batch, time.Now(), bw.precision)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleBulkWrite",
+			Msg:  fmt.Sprintf("no points were written: %v", err),
+		}
+	}
+
+	bw.points += len(points)
+	if max := bw.limits.MaxBulkPointsPerRequest; max > 0 && bw.points > max {
+		return &platform.Error{
+			Code: platform.ETooLarge,
+			Op:   "http/handleBulkWrite",
+			Msg:  fmt.Sprintf("no points were written: request contains more than the limit of %d points per bulk request", max),
+		}
+	}
+
+	exploded, err := tsdb.ExplodePoints(bw.orgID, bw.bucketID, points)
+	if err != nil {
+		return err
+	}
+
+	if err := bw.loader.WritePoints(exploded); err != nil {
+		if _, ok := err.(*platform.Error); ok {
+			return err
+		}
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleBulkWrite",
+			Msg:  "no points were written",
+			Err:  err,
+		}
+	}
+
+	bw.bytes += len(bw.batch)
+	bw.values += len(exploded)
+	bw.batch = bw.batch[:0]
+	return nil
+}
+
 func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest, error) {
 	qp := r.URL.Query()
 	p := qp.Get("precision")
@@ -209,12 +517,55 @@ type postWriteRequest struct {
 	Precision string
 }
 
+func decodeV1WriteRequest(ctx context.Context, r *http.Request) (*postV1WriteRequest, error) {
+	qp := r.URL.Query()
+	p, ok := v1Precision(qp.Get("precision"))
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeV1WriteRequest",
+			Msg:  "invalid precision; valid precision units are n, ns, u, ms, s, m, and h",
+		}
+	}
+
+	return &postV1WriteRequest{
+		Database:        qp.Get("db"),
+		RetentionPolicy: qp.Get("rp"),
+		Precision:       p,
+	}, nil
+}
+
+// v1Precision returns the precision of line protocol for a 1.x precision.
+func v1Precision(p string) (string, bool) {
+	switch p {
+	case "", "n", "ns":
+		return "ns", true
+	case "u", "us":
+		return "us", true
+	case "ms", "s", "m", "h":
+		return p, true
+	default:
+		return "", false
+	}
+}
+
+type postV1WriteRequest struct {
+	Database        string
+	RetentionPolicy string
+	Precision       string
+}
+
 // WriteService sends data over HTTP to influxdb via line protocol.
 type WriteService struct {
 	Addr               string
 	Token              string
 	Precision          string
 	InsecureSkipVerify bool
+
+	// Bulk sends each write to /api/v2/write/bulk, which writes it directly to
+	// new TSM files. The data of a write only becomes readable once all of it
+	// has been written.
+	Bulk bool
 }
 
 var _ platform.WriteService = (*WriteService)(nil)
@@ -233,7 +584,12 @@ func (s *WriteService) Write(ctx context.Context, orgID, bucketID platform.ID, r
 		}
 	}
 
-	u, err := newURL(s.Addr, writePath)
+	path := writePath
+	if s.Bulk {
+		path = bulkWritePath
+	}
+
+	u, err := newURL(s.Addr, path)
 	if err != nil {
 		return err
 	}
@@ -3,14 +3,22 @@ package http
 import (
 	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
 )
 
 func TestWriteService_Write(t *testing.T) {
@@ -69,3 +77,392 @@ func TestWriteService_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteHandler_handleWrite_limits(t *testing.T) {
+	const (
+		orgID    platform.ID = 1
+		bucketID platform.ID = 2
+	)
+
+	tests := []struct {
+		name       string
+		limits     WriteLimits
+		body       string
+		requests   int
+		writeErr   error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "unlimited",
+			body:       "m,t=a f=1\nm,t=b f=1",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "too many points",
+			limits:     WriteLimits{MaxPointsPerRequest: 1},
+			body:       "m,t=a f=1\nm,t=b f=1",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   platform.ETooLarge,
+		},
+		{
+			name:       "write rate exceeded",
+			limits:     WriteLimits{MaxWriteBytesPerSecond: 10},
+			body:       "m,t=a f=1\nm,t=b f=1",
+			requests:   2,
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   platform.ETooManyRequests,
+		},
+		{
+			name: "series limit exceeded",
+			body: "m,t=a f=1",
+			writeErr: &platform.Error{
+				Code: platform.ETooManyRequests,
+				Msg:  "max series per bucket exceeded: dropped 1 points",
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   platform.ETooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{Err: tt.writeErr}
+			h := NewWriteHandler(pw)
+			h.Limits = tt.limits
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+					return &platform.Organization{ID: id, Name: "org"}, nil
+				},
+			}
+			h.BucketService = &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+					return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID, Name: "bucket"}, nil
+				},
+			}
+
+			p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{*p}}
+
+			// Only the response to the last request is checked.
+			var w *httptest.ResponseRecorder
+			for i := 0; i < tt.requests || i == 0; i++ {
+				r := httptest.NewRequest("POST", "/api/v2/write?org="+orgID.String()+"&bucket="+bucketID.String(), strings.NewReader(tt.body))
+				r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+				w = httptest.NewRecorder()
+
+				h.ServeHTTP(w, r)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get(PlatformErrorCodeHeader); got != tt.wantCode {
+				t.Fatalf("got error code %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWriteHandler_handleV1Write(t *testing.T) {
+	const (
+		orgID    platform.ID = 1
+		bucketID platform.ID = 2
+	)
+
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		wantStatus int
+		wantCode   string
+		wantTime   int64
+	}{
+		{
+			name:       "default retention policy",
+			query:      "db=telegraf",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusNoContent,
+			wantTime:   1,
+		},
+		{
+			name:       "precision",
+			query:      "db=telegraf&rp=autogen&precision=m",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusNoContent,
+			wantTime:   int64(time.Minute),
+		},
+		{
+			name:       "missing database",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   platform.EInvalid,
+		},
+		{
+			name:       "unknown database",
+			query:      "db=nope",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusNotFound,
+			wantCode:   platform.ENotFound,
+		},
+		{
+			name:       "dbrp mapping lookup failure",
+			query:      "db=broken",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   platform.EInternal,
+		},
+		{
+			name:       "unparsable line protocol",
+			query:      "db=telegraf",
+			body:       "m,t=a",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid precision",
+			query:      "db=telegraf&precision=d",
+			body:       "m,t=a f=1 1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   platform.EInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			h := NewWriteHandler(pw)
+			h.DBRPMappingService = &mock.DBRPMappingService{
+				FindFn: func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+					if filter.OrganizationID == nil || *filter.OrganizationID != orgID {
+						t.Fatalf("expected the mappings of the organization of the token to be looked up")
+					}
+					switch *filter.Database {
+					case "telegraf":
+					case "broken":
+						return nil, fmt.Errorf("bolt is closed")
+					default:
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: "dbrp mapping not found"}
+					}
+					if filter.RetentionPolicy == nil && (filter.Default == nil || !*filter.Default) {
+						t.Fatalf("expected the default retention policy to be looked up")
+					}
+					return &platform.DBRPMapping{
+						Cluster:         *filter.Cluster,
+						Database:        "telegraf",
+						RetentionPolicy: "autogen",
+						Default:         true,
+						OrganizationID:  orgID,
+						BucketID:        bucketID,
+					}, nil
+				},
+			}
+
+			p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth := &platform.Authorization{Status: platform.Active, OrgID: orgID, Permissions: []platform.Permission{*p}}
+
+			r := httptest.NewRequest("POST", "/write?"+tt.query, strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get(PlatformErrorCodeHeader); got != tt.wantCode {
+				t.Fatalf("got error code %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantStatus != http.StatusNoContent {
+				// Errors have the shape of InfluxDB 1.x errors.
+				var body map[string]interface{}
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				if msg, ok := body["error"].(string); !ok || msg == "" || len(body) != 1 {
+					t.Fatalf("unexpected error body: %s", w.Body.String())
+				}
+				return
+			}
+
+			if len(pw.Points) != 1 {
+				t.Fatalf("got %d points, want 1", len(pw.Points))
+			}
+			if got := pw.Points[0].UnixNano(); got != tt.wantTime {
+				t.Errorf("got time %d, want %d", got, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestWriteHandler_handleBulkWrite(t *testing.T) {
+	const (
+		orgID    platform.ID = 1
+		bucketID platform.ID = 2
+	)
+
+	tests := []struct {
+		name       string
+		limits     WriteLimits
+		body       string
+		writeErr   error
+		wantStatus int
+		wantCode   string
+		wantPoints int
+	}{
+		{
+			name:       "committed",
+			body:       "m,t=a f=1 2\nm,t=b f=1 1\n",
+			wantStatus: http.StatusNoContent,
+			wantPoints: 2,
+		},
+		{
+			name:       "within limits",
+			limits:     WriteLimits{MaxPointsPerRequest: 1, MaxBulkPointsPerRequest: 2, MaxWriteBytesPerSecond: 100},
+			body:       "m,t=a f=1 2\nm,t=b f=1 1\n",
+			wantStatus: http.StatusNoContent,
+			wantPoints: 2,
+		},
+		{
+			name:       "too many points",
+			limits:     WriteLimits{MaxBulkPointsPerRequest: 1},
+			body:       "m,t=a f=1 2\nm,t=b f=1 1\n",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   platform.ETooLarge,
+		},
+		{
+			// The request times out before the allowance of the second
+			// second is available.
+			name:       "write rate exceeded",
+			limits:     WriteLimits{MaxWriteBytesPerSecond: 10},
+			body:       "m,t=a f=1 2\nm,t=b f=1 1\n",
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   platform.ETooManyRequests,
+		},
+		{
+			name:       "invalid line",
+			body:       "m,t=a f=1 2\nm,t=b\n",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   platform.EInvalid,
+		},
+		{
+			name:       "write error",
+			body:       "m,t=a f=1 2",
+			writeErr:   tsdb.PartialWriteError{Reason: "field type conflict", Dropped: 1},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   platform.EInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bpw := &fakeBulkPointsWriter{err: tt.writeErr}
+			h := NewWriteHandler(&mock.PointsWriter{})
+			h.BulkPointsWriter = bpw
+			h.Limits = tt.limits
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+					return &platform.Organization{ID: id, Name: "org"}, nil
+				},
+			}
+			h.BucketService = &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+					return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID, Name: "bucket"}, nil
+				},
+			}
+
+			p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{*p}}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			r := httptest.NewRequest("POST", "/api/v2/write/bulk?org="+orgID.String()+"&bucket="+bucketID.String(), strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(ctx, auth))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get(PlatformErrorCodeHeader); got != tt.wantCode {
+				t.Fatalf("got error code %q, want %q", got, tt.wantCode)
+			}
+
+			l := bpw.loader
+			if tt.wantStatus != http.StatusNoContent {
+				if l.committed || !l.aborted {
+					t.Fatalf("got committed %v and aborted %v, want the load aborted", l.committed, l.aborted)
+				}
+				return
+			}
+			if !l.committed || l.aborted {
+				t.Fatalf("got committed %v and aborted %v, want the load committed", l.committed, l.aborted)
+			}
+			if len(l.points) != tt.wantPoints {
+				t.Fatalf("got %d points, want %d", len(l.points), tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestOrgWriteLimiter_evict(t *testing.T) {
+	var l orgWriteLimiter
+	now := time.Now()
+	a, b := platform.ID(1), platform.ID(2)
+
+	if !l.allow(a, 10, 10, now) {
+		t.Fatal("expected the first write of a to be allowed")
+	}
+	if !l.allow(b, 10, 10, now.Add(orgWriteLimiterIdle)) {
+		t.Fatal("expected the first write of b to be allowed")
+	}
+
+	// The limiter of a is evicted once it has been idle, while b's is kept.
+	l.allow(b, 10, 1, now.Add(orgWriteLimiterIdle+2*time.Second))
+	if _, ok := l.limiters[a]; ok {
+		t.Fatal("expected the idle limiter to be evicted")
+	} else if _, ok := l.limiters[b]; !ok {
+		t.Fatal("expected the used limiter to be kept")
+	}
+}
+
+type fakeBulkPointsWriter struct {
+	err    error
+	loader *fakeBulkLoader
+}
+
+func (w *fakeBulkPointsWriter) NewBulkLoader() (storage.BulkLoader, error) {
+	w.loader = &fakeBulkLoader{err: w.err}
+	return w.loader, nil
+}
+
+type fakeBulkLoader struct {
+	err       error
+	points    []models.Point
+	committed bool
+	aborted   bool
+}
+
+func (l *fakeBulkLoader) WritePoints(points []models.Point) error {
+	l.points = append(l.points, points...)
+	return l.err
+}
+
+func (l *fakeBulkLoader) Commit() error {
+	l.committed = true
+	return nil
+}
+
+func (l *fakeBulkLoader) Abort() error {
+	l.aborted = true
+	return nil
+}
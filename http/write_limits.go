@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"golang.org/x/time/rate"
+)
+
+// WriteLimits are the limits applied to writes by the WriteHandler. A zero
+// limit is unlimited.
+type WriteLimits struct {
+	// MaxPointsPerRequest is the maximum number of points in a write request.
+	// Bulk writes, which are parsed in batches rather than at once, are exempt
+	// from it and limited by MaxBulkPointsPerRequest instead.
+	MaxPointsPerRequest int
+
+	// MaxBulkPointsPerRequest is the maximum number of points in a bulk write
+	// request.
+	MaxBulkPointsPerRequest int
+
+	// MaxWriteBytesPerSecond is the maximum rate at which each organization
+	// may write line protocol. Writes and bulk writes share the allowance.
+	MaxWriteBytesPerSecond int
+}
+
+// orgWriteLimiterIdle is how long the limiter of an organization is kept after
+// its last write. An idle limiter has its full allowance again, so it is
+// evicted rather than kept for every organization that ever wrote.
+const orgWriteLimiterIdle = time.Minute
+
+// orgWriteLimiter limits the rate at which each organization writes bytes.
+type orgWriteLimiter struct {
+	mu       sync.Mutex
+	limiters map[platform.ID]*orgRateLimiter
+	swept    time.Time
+}
+
+// orgRateLimiter is the limiter of an organization and the time it was last used.
+type orgRateLimiter struct {
+	*rate.Limiter
+	used time.Time
+}
+
+// allow reports whether the organization may write n bytes at time now with
+// the given limit. A request larger than one second's allowance is allowed
+// only when the organization has its full allowance available, and consumes
+// all of it.
+func (l *orgWriteLimiter) allow(orgID platform.ID, bytesPerSec, n int, now time.Time) bool {
+	if n > bytesPerSec {
+		n = bytesPerSec
+	}
+	return l.limiter(orgID, bytesPerSec, now).AllowN(now, n)
+}
+
+// wait blocks until the organization may write n bytes with the given limit.
+// Unlike allow, a request larger than one second's allowance is charged in
+// full, over as many seconds as it takes. It fails if ctx is done first, or
+// if its deadline is too soon to wait for the allowance.
+func (l *orgWriteLimiter) wait(ctx context.Context, orgID platform.ID, bytesPerSec, n int) error {
+	for n > 0 {
+		m := n
+		if m > bytesPerSec {
+			m = bytesPerSec
+		}
+		// The limiter is looked up for every chunk, so that it isn't evicted
+		// while a long write waits for it.
+		if err := l.limiter(orgID, bytesPerSec, time.Now()).WaitN(ctx, m); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
+
+// limiter returns the limiter of the organization with the given limit, used
+// at time now. It evicts the limiters that have been idle for longer than
+// orgWriteLimiterIdle.
+func (l *orgWriteLimiter) limiter(orgID platform.ID, bytesPerSec int, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limiters == nil {
+		l.limiters = make(map[platform.ID]*orgRateLimiter)
+	}
+	if now.Sub(l.swept) > orgWriteLimiterIdle {
+		for id, limiter := range l.limiters {
+			if now.Sub(limiter.used) > orgWriteLimiterIdle {
+				delete(l.limiters, id)
+			}
+		}
+		l.swept = now
+	}
+
+	limiter := l.limiters[orgID]
+	if limiter == nil || limiter.Burst() != bytesPerSec {
+		limiter = &orgRateLimiter{Limiter: rate.NewLimiter(rate.Limit(bytesPerSec), bytesPerSec)}
+		l.limiters[orgID] = limiter
+	}
+	if now.After(limiter.used) {
+		limiter.used = now
+	}
+	return limiter.Limiter
+}
@@ -2,6 +2,7 @@ package inmem
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/platform"
 )
@@ -31,11 +32,30 @@ func (s *Service) loadAuthorization(ctx context.Context, id platform.ID) (*platf
 }
 
 // PutAuthorization overwrites the authorization with the contents of a.
+// Only the hash of the token is kept, the hash of the token already stored is
+// kept if a.Token is empty.
 func (s *Service) PutAuthorization(ctx context.Context, a *platform.Authorization) error {
+	s.authorizationMu.Lock()
+	defer s.authorizationMu.Unlock()
+
+	return s.putAuthorization(ctx, a)
+}
+
+func (s *Service) putAuthorization(ctx context.Context, a *platform.Authorization) error {
 	if a.Status == "" {
 		a.Status = platform.Active
 	}
-	s.authorizationKV.Store(a.ID.String(), *a)
+	if a.Token != "" {
+		h, err := platform.HashToken(a.Token, s.TokenPepper)
+		if err != nil {
+			return err
+		}
+		s.authorizationTokenKV.Store(a.ID.String(), h)
+	}
+
+	stored := *a
+	stored.Token = ""
+	s.authorizationKV.Store(a.ID.String(), stored)
 	return nil
 }
 
@@ -52,25 +72,37 @@ func (s *Service) FindAuthorizationByID(ctx context.Context, id platform.ID) (*p
 
 // FindAuthorizationByToken returns an authorization given a token.
 func (s *Service) FindAuthorizationByToken(ctx context.Context, t string) (*platform.Authorization, error) {
-	var err error
 	op := OpPrefix + platform.OpFindAuthorizationByToken
-	var n int
-	var as []*platform.Authorization
-	as, n, err = s.FindAuthorizations(ctx, platform.AuthorizationFilter{Token: &t})
-	if err != nil {
-		return nil, &platform.Error{
-			Err: err,
-			Op:  op,
+	var id string
+	s.authorizationTokenKV.Range(func(k, v interface{}) bool {
+		if h, ok := v.(*platform.TokenHash); ok && h.Matches(t, s.TokenPepper) {
+			id = k.(string)
+			return false
 		}
-	}
-	if n < 1 {
+		return true
+	})
+	if id == "" {
 		return nil, &platform.Error{
 			Code: platform.ENotFound,
 			Msg:  "authorization not found",
 			Op:   op,
 		}
 	}
-	return as[0], nil
+
+	var authID platform.ID
+	if err := authID.DecodeFromString(id); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Err:  err,
+			Op:   op,
+		}
+	}
+	a, pe := s.loadAuthorization(ctx, authID)
+	if pe != nil {
+		pe.Op = op
+		return nil, pe
+	}
+	return a, nil
 }
 
 func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
@@ -80,19 +112,13 @@ func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platfor
 		}
 	}
 
-	if filter.Token != nil {
-		return func(a *platform.Authorization) bool {
-			return a.Token == *filter.Token
-		}
-	}
-
 	if filter.UserID != nil {
 		return func(a *platform.Authorization) bool {
-			return a.UserID == *filter.UserID
+			return a.UserID == *filter.UserID && filter.Match(a)
 		}
 	}
 
-	return func(a *platform.Authorization) bool { return true }
+	return filter.Match
 }
 
 // FindAuthorizations returns all authorizations matching the filter.
@@ -110,6 +136,18 @@ func (s *Service) FindAuthorizations(ctx context.Context, filter platform.Author
 		return []*platform.Authorization{a}, 1, nil
 	}
 
+	if filter.Token != nil {
+		a, err := s.FindAuthorizationByToken(ctx, *filter.Token)
+		if err != nil {
+			return nil, 0, &platform.Error{
+				Err: err,
+				Op:  op,
+			}
+		}
+
+		return []*platform.Authorization{a}, 1, nil
+	}
+
 	var as []*platform.Authorization
 	if filter.User != nil {
 		u, err := s.findUserByName(ctx, *filter.User)
@@ -173,12 +211,16 @@ func (s *Service) CreateAuthorization(ctx context.Context, a *platform.Authoriza
 
 	a.ID = s.IDGenerator.ID()
 	a.Status = platform.Active
+	a.CreatedAt = s.time()
 
 	return s.PutAuthorization(ctx, a)
 }
 
 // DeleteAuthorization deletes an authorization associated with id.
 func (s *Service) DeleteAuthorization(ctx context.Context, id platform.ID) error {
+	s.authorizationMu.Lock()
+	defer s.authorizationMu.Unlock()
+
 	if _, err := s.FindAuthorizationByID(ctx, id); err != nil {
 		return &platform.Error{
 			Err: err,
@@ -187,11 +229,15 @@ func (s *Service) DeleteAuthorization(ctx context.Context, id platform.ID) error
 	}
 
 	s.authorizationKV.Delete(id.String())
+	s.authorizationTokenKV.Delete(id.String())
 	return nil
 }
 
 // SetAuthorizationStatus updates the status of an authorization associated with id.
 func (s *Service) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
+	s.authorizationMu.Lock()
+	defer s.authorizationMu.Unlock()
+
 	op := OpPrefix + platform.OpSetAuthorizationStatus
 	a, err := s.FindAuthorizationByID(ctx, id)
 	if err != nil {
@@ -216,5 +262,26 @@ func (s *Service) SetAuthorizationStatus(ctx context.Context, id platform.ID, st
 	}
 
 	a.Status = status
-	return s.PutAuthorization(ctx, a)
+	return s.putAuthorization(ctx, a)
+}
+
+// SetAuthorizationLastUsed sets the time the authorization associated with id was last used,
+// unless it was last used later than t.
+func (s *Service) SetAuthorizationLastUsed(ctx context.Context, id platform.ID, t time.Time) error {
+	s.authorizationMu.Lock()
+	defer s.authorizationMu.Unlock()
+
+	a, err := s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  OpPrefix + platform.OpSetAuthorizationLastUsed,
+		}
+	}
+
+	if a.LastUsedAt != nil && a.LastUsedAt.After(t) {
+		return nil
+	}
+	a.LastUsedAt = &t
+	return s.putAuthorization(ctx, a)
 }
@@ -2,7 +2,9 @@ package inmem
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -12,6 +14,7 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 	s := NewService()
 	s.IDGenerator = f.IDGenerator
 	s.TokenGenerator = f.TokenGenerator
+	s.WithTime(f.NowFn)
 	ctx := context.Background()
 
 	for _, u := range f.Users {
@@ -38,3 +41,44 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 func TestAuthorizationService(t *testing.T) {
 	platformtesting.AuthorizationService(initAuthorizationService, t)
 }
+
+func TestService_SetAuthorizationLastUsed(t *testing.T) {
+	s := NewService()
+	ctx := context.Background()
+
+	a := &platform.Authorization{ID: 1, UserID: 2, OrgID: 3, Token: "token", Status: platform.Active}
+	if err := s.PutAuthorization(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+
+	// Usage is recorded in the background, concurrently with changes of the authorization.
+	now := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := s.SetAuthorizationLastUsed(ctx, a.ID, now.Add(time.Duration(i)*time.Second)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if err := s.SetAuthorizationStatus(ctx, a.ID, platform.Inactive); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.FindAuthorizationByID(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != platform.Inactive {
+		t.Errorf("got status %s, want %s", got.Status, platform.Inactive)
+	}
+	if want := now.Add(99 * time.Second); got.LastUsedAt == nil || !got.LastUsedAt.Equal(want) {
+		t.Errorf("got last used at %v, want %v", got.LastUsedAt, want)
+	}
+}
@@ -2,25 +2,20 @@ package inmem
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"path"
 
 	"github.com/influxdata/platform"
 )
 
-var (
-	errDBRPMappingNotFound = fmt.Errorf("dbrp mapping not found")
-)
-
-func encodeDBRPMappingKey(cluster, db, rp string) string {
-	return path.Join(cluster, db, rp)
+func encodeDBRPMappingKey(orgID platform.ID, cluster, db, rp string) string {
+	return path.Join(orgID.String(), cluster, db, rp)
 }
 
-func (c *Service) loadDBRPMapping(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
-	i, ok := c.dbrpMappingKV.Load(encodeDBRPMappingKey(cluster, db, rp))
+func (c *Service) loadDBRPMapping(ctx context.Context, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	i, ok := c.dbrpMappingKV.Load(encodeDBRPMappingKey(orgID, cluster, db, rp))
 	if !ok {
-		return nil, errDBRPMappingNotFound
+		return nil, platform.ErrDBRPMappingNotFound
 	}
 
 	m, ok := i.(platform.DBRPMapping)
@@ -31,9 +26,9 @@ func (c *Service) loadDBRPMapping(ctx context.Context, cluster, db, rp string) (
 	return &m, nil
 }
 
-// FindBy returns a single dbrp mapping by cluster, db and rp.
-func (s *Service) FindBy(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
-	return s.loadDBRPMapping(ctx, cluster, db, rp)
+// FindBy returns a single dbrp mapping of an organization by cluster, db and rp.
+func (s *Service) FindBy(ctx context.Context, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	return s.loadDBRPMapping(ctx, orgID, cluster, db, rp)
 }
 
 func (c *Service) forEachDBRPMapping(ctx context.Context, fn func(m *platform.DBRPMapping) bool) error {
@@ -68,13 +63,13 @@ func (s *Service) filterDBRPMappings(ctx context.Context, fn func(m *platform.DB
 
 // Find returns the first dbrp mapping that matches filter.
 func (s *Service) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
-	if filter.Cluster == nil && filter.Database == nil && filter.RetentionPolicy == nil {
+	if filter.OrganizationID == nil && filter.Cluster == nil && filter.Database == nil && filter.RetentionPolicy == nil {
 		return nil, fmt.Errorf("no filter parameters provided")
 	}
 
 	// filter by dbrpMapping id
-	if filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
-		return s.FindBy(ctx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		return s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
 	}
 
 	mappings, n, err := s.FindMany(ctx, filter)
@@ -83,7 +78,7 @@ func (s *Service) Find(ctx context.Context, filter platform.DBRPMappingFilter) (
 	}
 
 	if n < 1 {
-		return nil, errDBRPMappingNotFound
+		return nil, platform.ErrDBRPMappingNotFound
 	}
 
 	return mappings[0], nil
@@ -93,8 +88,8 @@ func (s *Service) Find(ctx context.Context, filter platform.DBRPMappingFilter) (
 // Additional options provide pagination & sorting.
 func (s *Service) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
 	// filter by dbrpMapping id
-	if filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
-		m, err := s.FindBy(ctx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		m, err := s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -102,7 +97,8 @@ func (s *Service) FindMany(ctx context.Context, filter platform.DBRPMappingFilte
 	}
 
 	filterFunc := func(mapping *platform.DBRPMapping) bool {
-		return (filter.Cluster == nil || (*filter.Cluster) == mapping.Cluster) &&
+		return (filter.OrganizationID == nil || (*filter.OrganizationID) == mapping.OrganizationID) &&
+			(filter.Cluster == nil || (*filter.Cluster) == mapping.Cluster) &&
 			(filter.Database == nil || (*filter.Database) == mapping.Database) &&
 			(filter.RetentionPolicy == nil || (*filter.RetentionPolicy) == mapping.RetentionPolicy) &&
 			(filter.Default == nil || (*filter.Default) == mapping.Default)
@@ -121,16 +117,16 @@ func (s *Service) Create(ctx context.Context, m *platform.DBRPMapping) error {
 	if err := m.Validate(); err != nil {
 		return nil
 	}
-	existing, err := s.loadDBRPMapping(ctx, m.Cluster, m.Database, m.RetentionPolicy)
+	existing, err := s.loadDBRPMapping(ctx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
 	if err != nil {
-		if err == errDBRPMappingNotFound {
+		if err == platform.ErrDBRPMappingNotFound {
 			return s.PutDBRPMapping(ctx, m)
 		}
 		return err
 	}
 
 	if !existing.Equal(m) {
-		return errors.New("dbrp mapping already exists")
+		return platform.ErrDBRPMappingExists
 	}
 
 	return s.PutDBRPMapping(ctx, m)
@@ -138,13 +134,13 @@ func (s *Service) Create(ctx context.Context, m *platform.DBRPMapping) error {
 
 // PutDBRPMapping sets dbrpMapping with the current ID.
 func (s *Service) PutDBRPMapping(ctx context.Context, m *platform.DBRPMapping) error {
-	k := encodeDBRPMappingKey(m.Cluster, m.Database, m.RetentionPolicy)
+	k := encodeDBRPMappingKey(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
 	s.dbrpMappingKV.Store(k, *m)
 	return nil
 }
 
-// Delete removes a dbrp mapping
-func (s *Service) Delete(ctx context.Context, cluster, db, rp string) error {
-	s.dbrpMappingKV.Delete(encodeDBRPMappingKey(cluster, db, rp))
+// Delete removes a dbrp mapping of an organization
+func (s *Service) Delete(ctx context.Context, orgID platform.ID, cluster, db, rp string) error {
+	s.dbrpMappingKV.Delete(encodeDBRPMappingKey(orgID, cluster, db, rp))
 	return nil
 }
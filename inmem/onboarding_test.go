@@ -12,6 +12,7 @@ func initOnboardingService(f platformtesting.OnboardingFields, t *testing.T) (pl
 	s := NewService()
 	s.IDGenerator = f.IDGenerator
 	s.TokenGenerator = f.TokenGenerator
+	s.WithTime(f.NowFn)
 	ctx := context.TODO()
 	if err := s.PutOnboardingStatus(ctx, !f.IsOnboarding); err != nil {
 		t.Fatalf("failed to set new onboarding finished: %v", err)
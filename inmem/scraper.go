@@ -12,6 +12,7 @@ const (
 )
 
 var _ platform.ScraperTargetStoreService = (*Service)(nil)
+var _ platform.ScraperTargetHealthService = (*Service)(nil)
 
 func (s *Service) loadScraperTarget(id platform.ID) (*platform.ScraperTarget, *platform.Error) {
 	i, ok := s.scraperTargetKV.Load(id.String())
@@ -52,6 +53,12 @@ func (s *Service) ListTargets(ctx context.Context) (list []platform.ScraperTarge
 
 // AddTarget add a new scraper target into storage.
 func (s *Service) AddTarget(ctx context.Context, target *platform.ScraperTarget) (err error) {
+	if err := target.Valid(); err != nil {
+		return &platform.Error{
+			Op:  OpPrefix + platform.OpAddTarget,
+			Err: err,
+		}
+	}
 	target.ID = s.IDGenerator.ID()
 	if err := s.PutTarget(ctx, target); err != nil {
 		return &platform.Error{
@@ -70,6 +77,7 @@ func (s *Service) RemoveTarget(ctx context.Context, id platform.ID) error {
 			Op:  OpPrefix + platform.OpRemoveTarget,
 		}
 	}
+	s.scraperHealthKV.Delete(id.String())
 	s.scraperTargetKV.Delete(id.String())
 	return nil
 }
@@ -84,6 +92,12 @@ func (s *Service) UpdateTarget(ctx context.Context, update *platform.ScraperTarg
 			Msg:  "id is invalid",
 		}
 	}
+	if err := update.Valid(); err != nil {
+		return nil, &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
 	_, pe := s.loadScraperTarget(update.ID)
 	if pe != nil {
 		return nil, &platform.Error{
@@ -118,3 +132,29 @@ func (s *Service) PutTarget(ctx context.Context, target *platform.ScraperTarget)
 	s.scraperTargetKV.Store(target.ID.String(), *target)
 	return nil
 }
+
+// GetTargetHealth returns the health of a scraper target.
+func (s *Service) GetTargetHealth(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error) {
+	i, ok := s.scraperHealthKV.Load(id.String())
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   OpPrefix + platform.OpGetTargetHealth,
+			Msg:  "scraper target health is not found",
+		}
+	}
+	h := i.(platform.ScraperTargetHealth)
+	return &h, nil
+}
+
+// PutTargetHealth replaces the health of an existing scraper target.
+func (s *Service) PutTargetHealth(ctx context.Context, id platform.ID, h *platform.ScraperTargetHealth) error {
+	if _, pe := s.loadScraperTarget(id); pe != nil {
+		return &platform.Error{
+			Op:  OpPrefix + platform.OpPutTargetHealth,
+			Err: pe,
+		}
+	}
+	s.scraperHealthKV.Store(id.String(), *h)
+	return nil
+}
@@ -20,6 +20,11 @@ func initScraperTargetStoreService(f platformtesting.TargetFields, t *testing.T)
 	return s, OpPrefix, func() {}
 }
 
+func initScraperTargetHealthService(f platformtesting.TargetFields, t *testing.T) (platform.ScraperTargetHealthService, string, func()) {
+	s, opPrefix, done := initScraperTargetStoreService(f, t)
+	return s.(platform.ScraperTargetHealthService), opPrefix, done
+}
+
 func TestScraperTargetStoreService_AddTarget(t *testing.T) {
 	platformtesting.AddTarget(initScraperTargetStoreService, t)
 }
@@ -39,3 +44,7 @@ func TestScraperTargetStoreService_UpdateTarget(t *testing.T) {
 func TestScraperTargetStoreService_GetTargetByID(t *testing.T) {
 	platformtesting.GetTargetByID(initScraperTargetStoreService, t)
 }
+
+func TestScraperTargetHealthService(t *testing.T) {
+	platformtesting.TargetHealth(initScraperTargetHealthService, t)
+}
@@ -14,7 +14,11 @@ const OpPrefix = "inmem/"
 
 // Service implements various top level services.
 type Service struct {
+	// authorizationMu serializes the changes of authorizations, so that
+	// the updates of a field do not overwrite concurrent changes.
+	authorizationMu       sync.Mutex
 	authorizationKV       sync.Map
+	authorizationTokenKV  sync.Map
 	organizationKV        sync.Map
 	bucketKV              sync.Map
 	userKV                sync.Map
@@ -25,13 +29,16 @@ type Service struct {
 	userResourceMappingKV sync.Map
 	labelKV               sync.Map
 	scraperTargetKV       sync.Map
+	scraperHealthKV       sync.Map
 	telegrafConfigKV      sync.Map
 	onboardingKV          sync.Map
 	basicAuthKV           sync.Map
 
 	TokenGenerator platform.TokenGenerator
 	IDGenerator    platform.IDGenerator
-	time           func() time.Time
+	// TokenPepper is the secret that API tokens are hashed with.
+	TokenPepper []byte
+	time        func() time.Time
 }
 
 // NewService creates an instance of a Service.
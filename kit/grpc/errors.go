@@ -25,6 +25,8 @@ func ToStatus(err *platform.Error) (*status.Status, error) {
 		c = codes.InvalidArgument
 	case platform.EUnavailable:
 		c = codes.Unavailable
+	case platform.ETooManyRequests, platform.ETooLarge:
+		c = codes.ResourceExhausted
 	}
 
 	buf, jerr := json.Marshal(err)
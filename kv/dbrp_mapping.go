@@ -0,0 +1,346 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/influxdata/platform"
+)
+
+var (
+	dbrpMappingBucket = []byte("dbrpmappingsv1")
+)
+
+var _ platform.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// DBRPMappingService is a dbrp mapping service built on a generic kv store.
+type DBRPMappingService struct {
+	kv Store
+}
+
+// NewDBRPMappingService creates an instance of a dbrp mapping service.
+func NewDBRPMappingService(kv Store) *DBRPMappingService {
+	return &DBRPMappingService{
+		kv: kv,
+	}
+}
+
+// Initialize creates the buckets for the dbrp mapping service.
+func (s *DBRPMappingService) Initialize() error {
+	return s.kv.Update(func(tx Tx) error {
+		return InitializeDBRPMappings(tx)
+	})
+}
+
+// InitializeDBRPMappings creates the buckets of the dbrp mappings in tx.
+func InitializeDBRPMappings(tx Tx) error {
+	if _, err := tx.Bucket(dbrpMappingBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// dbrpMappingKey is the key of a mapping. The cluster, database and retention
+// policy of a mapping are unique within its organization.
+func dbrpMappingKey(orgID platform.ID, cluster, db, rp string) []byte {
+	return []byte(path.Join(orgID.String(), cluster, db, rp))
+}
+
+// FindBy returns a single dbrp mapping of an organization by cluster, db and rp.
+func (s *DBRPMappingService) FindBy(ctx context.Context, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	var m *platform.DBRPMapping
+	err := s.kv.View(func(tx Tx) error {
+		mapping, err := findDBRPMappingBy(ctx, tx, orgID, cluster, db, rp)
+		if err != nil {
+			return err
+		}
+		m = mapping
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func findDBRPMappingBy(ctx context.Context, tx Tx, orgID platform.ID, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(dbrpMappingKey(orgID, cluster, db, rp))
+	if err == ErrKeyNotFound {
+		return nil, platform.ErrDBRPMappingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m platform.DBRPMapping
+	if err := json.Unmarshal(v, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		return s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+	}
+
+	filterFn := filterDBRPMappingsFn(filter)
+
+	var m *platform.DBRPMapping
+	err := s.kv.View(func(tx Tx) error {
+		return forEachDBRPMapping(ctx, tx, filter.OrganizationID, func(mapping *platform.DBRPMapping) bool {
+			if filterFn(mapping) {
+				m = mapping
+				return false
+			}
+			return true
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return nil, platform.ErrDBRPMappingNotFound
+	}
+
+	return m, nil
+}
+
+func filterDBRPMappingsFn(filter platform.DBRPMappingFilter) func(m *platform.DBRPMapping) bool {
+	return func(m *platform.DBRPMapping) bool {
+		return (filter.OrganizationID == nil || *filter.OrganizationID == m.OrganizationID) &&
+			(filter.Cluster == nil || *filter.Cluster == m.Cluster) &&
+			(filter.Database == nil || *filter.Database == m.Database) &&
+			(filter.RetentionPolicy == nil || *filter.RetentionPolicy == m.RetentionPolicy) &&
+			(filter.Default == nil || *filter.Default == m.Default)
+	}
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+	ms := []*platform.DBRPMapping{}
+	err := s.kv.View(func(tx Tx) error {
+		mappings, err := findDBRPMappings(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ms = mappings
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ms, len(ms), nil
+}
+
+func findDBRPMappings(ctx context.Context, tx Tx, filter platform.DBRPMappingFilter) ([]*platform.DBRPMapping, error) {
+	ms := []*platform.DBRPMapping{}
+	filterFn := filterDBRPMappingsFn(filter)
+	err := forEachDBRPMapping(ctx, tx, filter.OrganizationID, func(m *platform.DBRPMapping) bool {
+		if filterFn(m) {
+			ms = append(ms, m)
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// forEachDBRPMapping will iterate through the dbrp mappings while fn returns true.
+// Only the mappings of the organization are iterated if orgID is not nil.
+func forEachDBRPMapping(ctx context.Context, tx Tx, orgID *platform.ID, fn func(*platform.DBRPMapping) bool) error {
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	cur, err := b.Cursor()
+	if err != nil {
+		return err
+	}
+
+	var prefix []byte
+	k, v := cur.First()
+	if orgID != nil {
+		prefix = []byte(orgID.String() + "/")
+		k, v = cur.Seek(prefix)
+	}
+
+	for ; k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = cur.Next() {
+		m := &platform.DBRPMapping{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return err
+		}
+		if !fn(m) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Create creates a new dbrp mapping. Creating a default mapping unsets the
+// default of the other mappings of the organization, cluster and database.
+func (s *DBRPMappingService) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	if err := m.Validate(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	return s.kv.Update(func(tx Tx) error {
+		existing, err := findDBRPMappingBy(ctx, tx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
+		if err == nil {
+			if !existing.Equal(m) {
+				return platform.ErrDBRPMappingExists
+			}
+			return nil
+		}
+		if platform.ErrorCode(err) != platform.ENotFound {
+			return err
+		}
+
+		if m.Default {
+			if err := unsetDefaultDBRPMapping(ctx, tx, m.OrganizationID, m.Cluster, m.Database); err != nil {
+				return err
+			}
+		}
+
+		return putDBRPMapping(ctx, tx, m)
+	})
+}
+
+// findDefaultDBRPMappings returns the default mappings of a database of an organization.
+func findDefaultDBRPMappings(ctx context.Context, tx Tx, orgID platform.ID, cluster, db string) ([]*platform.DBRPMapping, error) {
+	isDefault := true
+	return findDBRPMappings(ctx, tx, platform.DBRPMappingFilter{
+		OrganizationID: &orgID,
+		Cluster:        &cluster,
+		Database:       &db,
+		Default:        &isDefault,
+	})
+}
+
+// unsetDefaultDBRPMapping unsets the default of the default mapping of a database of an organization.
+func unsetDefaultDBRPMapping(ctx context.Context, tx Tx, orgID platform.ID, cluster, db string) error {
+	ms, err := findDefaultDBRPMappings(ctx, tx, orgID, cluster, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		m.Default = false
+		if err := putDBRPMapping(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putDBRPMapping(ctx context.Context, tx Tx, m *platform.DBRPMapping) error {
+	v, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Put(dbrpMappingKey(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy), v)
+}
+
+// Delete removes a dbrp mapping of an organization.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID platform.ID, cluster, db, rp string) error {
+	return s.kv.Update(func(tx Tx) error {
+		b, err := tx.Bucket(dbrpMappingBucket)
+		if err != nil {
+			return err
+		}
+
+		return b.Delete(dbrpMappingKey(orgID, cluster, db, rp))
+	})
+}
+
+// CreateBucketDBRPMapping creates the dbrp mapping of a new bucket in the default
+// cluster, in the transaction that creates the bucket. A bucket named "db/rp" is
+// mapped to that database and retention policy, any other bucket to the database
+// of its name and the autogen retention policy. The mapping is the default of its
+// database if the database has none in the organization. Buckets whose mapping
+// is invalid or already exists are not mapped.
+func CreateBucketDBRPMapping(ctx context.Context, tx Tx, b *platform.Bucket) error {
+	db, rp := b.Name, platform.DefaultDBRPRetentionPolicy
+	if i := strings.IndexByte(b.Name, '/'); i >= 0 {
+		db, rp = b.Name[:i], b.Name[i+1:]
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        db,
+		RetentionPolicy: rp,
+		OrganizationID:  b.OrganizationID,
+		BucketID:        b.ID,
+	}
+	if err := m.Validate(); err != nil {
+		return nil
+	}
+
+	if _, err := findDBRPMappingBy(ctx, tx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy); err == nil {
+		return nil
+	}
+
+	defaults, err := findDefaultDBRPMappings(ctx, tx, m.OrganizationID, m.Cluster, m.Database)
+	if err != nil {
+		return err
+	}
+	m.Default = len(defaults) == 0
+
+	return putDBRPMapping(ctx, tx, m)
+}
+
+// DeleteBucketDBRPMappings removes the dbrp mappings of a bucket of an
+// organization, in the transaction that deletes the bucket.
+func DeleteBucketDBRPMappings(ctx context.Context, tx Tx, orgID, bucketID platform.ID) error {
+	ms, err := findDBRPMappings(ctx, tx, platform.DBRPMappingFilter{
+		OrganizationID: &orgID,
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		if m.BucketID != bucketID {
+			continue
+		}
+		if err := b.Delete(dbrpMappingKey(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
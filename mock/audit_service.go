@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.AuditLogService = (*AuditLogService)(nil)
+
+// AuditLogService is a mock implementation of platform.AuditLogService.
+type AuditLogService struct {
+	LogAuditEventFn   func(ctx context.Context, e *platform.AuditEvent) error
+	FindAuditEventsFn func(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error)
+}
+
+// LogAuditEvent adds the event to the audit log.
+func (s *AuditLogService) LogAuditEvent(ctx context.Context, e *platform.AuditEvent) error {
+	return s.LogAuditEventFn(ctx, e)
+}
+
+// FindAuditEvents returns the events that match the filter.
+func (s *AuditLogService) FindAuditEvents(ctx context.Context, filter platform.AuditFilter, opt ...platform.FindOptions) ([]*platform.AuditEvent, int, error) {
+	return s.FindAuditEventsFn(ctx, filter, opt...)
+}
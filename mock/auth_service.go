@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/platform"
 	"go.uber.org/zap"
@@ -22,6 +23,9 @@ type AuthorizationService struct {
 	CreateAuthorizationFn      func(context.Context, *platform.Authorization) error
 	DeleteAuthorizationFn      func(context.Context, platform.ID) error
 	SetAuthorizationStatusFn   func(context.Context, platform.ID, platform.Status) error
+
+	// Methods for an platform.AuthorizationUsageRecorder
+	SetAuthorizationLastUsedFn func(context.Context, platform.ID, time.Time) error
 }
 
 // NewAuthorizationService returns a mock AuthorizationService where its methods will return
@@ -33,9 +37,10 @@ func NewAuthorizationService() *AuthorizationService {
 		FindAuthorizationsFn: func(context.Context, platform.AuthorizationFilter, ...platform.FindOptions) ([]*platform.Authorization, int, error) {
 			return nil, 0, nil
 		},
-		CreateAuthorizationFn:    func(context.Context, *platform.Authorization) error { return nil },
-		DeleteAuthorizationFn:    func(context.Context, platform.ID) error { return nil },
-		SetAuthorizationStatusFn: func(context.Context, platform.ID, platform.Status) error { return nil },
+		CreateAuthorizationFn:      func(context.Context, *platform.Authorization) error { return nil },
+		DeleteAuthorizationFn:      func(context.Context, platform.ID) error { return nil },
+		SetAuthorizationStatusFn:   func(context.Context, platform.ID, platform.Status) error { return nil },
+		SetAuthorizationLastUsedFn: func(context.Context, platform.ID, time.Time) error { return nil },
 	}
 }
 
@@ -66,3 +71,8 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
 	return s.SetAuthorizationStatusFn(ctx, id, status)
 }
+
+// SetAuthorizationLastUsed sets the time the authorization was last used.
+func (s *AuthorizationService) SetAuthorizationLastUsed(ctx context.Context, id platform.ID, t time.Time) error {
+	return s.SetAuthorizationLastUsedFn(ctx, id, t)
+}
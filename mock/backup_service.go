@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// BackupService is a mock implementation of platform.BackupService.
+type BackupService struct {
+	BackupFn        func(ctx context.Context, w io.Writer, filter platform.BackupFilter) error
+	RestoreBucketFn func(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error
+}
+
+// Backup writes an archive of the metadata and data selected by the filter to w.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	return s.BackupFn(ctx, w, filter)
+}
+
+// RestoreBucket restores the data of a bucket in the archive into another bucket.
+func (s *BackupService) RestoreBucket(ctx context.Context, r io.Reader, bucketID, newBucketID platform.ID) error {
+	return s.RestoreBucketFn(ctx, r, bucketID, newBucketID)
+}
@@ -41,3 +41,21 @@ func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id platfor
 func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, t *platform.ScraperTarget) (*platform.ScraperTarget, error) {
 	return s.UpdateTargetF(ctx, t)
 }
+
+var _ platform.ScraperTargetHealthService = &ScraperTargetHealthService{}
+
+// ScraperTargetHealthService is a mock implementation of a platform.ScraperTargetHealthService.
+type ScraperTargetHealthService struct {
+	GetTargetHealthF func(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error)
+	PutTargetHealthF func(ctx context.Context, id platform.ID, h *platform.ScraperTargetHealth) error
+}
+
+// GetTargetHealth retrieves the health of a scraper target.
+func (s *ScraperTargetHealthService) GetTargetHealth(ctx context.Context, id platform.ID) (*platform.ScraperTargetHealth, error) {
+	return s.GetTargetHealthF(ctx, id)
+}
+
+// PutTargetHealth replaces the health of a scraper target.
+func (s *ScraperTargetHealthService) PutTargetHealth(ctx context.Context, id platform.ID, h *platform.ScraperTargetHealth) error {
+	return s.PutTargetHealthF(ctx, id, h)
+}
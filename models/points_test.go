@@ -1804,6 +1804,18 @@ func TestParsePointsWithPrecision(t *testing.T) {
 			precision: "s",
 			exp:       "cpu,host=serverA,region=us-east value=1.0 946730096000000000",
 		},
+		{
+			name:      "minute",
+			line:      `cpu,host=serverA,region=us-east value=1.0 15778834`,
+			precision: "m",
+			exp:       "cpu,host=serverA,region=us-east value=1.0 946730040000000000",
+		},
+		{
+			name:      "hour",
+			line:      `cpu,host=serverA,region=us-east value=1.0 262980`,
+			precision: "h",
+			exp:       "cpu,host=serverA,region=us-east value=1.0 946728000000000000",
+		},
 	}
 	for _, test := range tests {
 		pts, err := models.ParsePointsWithPrecision([]byte(test.line), time.Now().UTC(), test.precision)
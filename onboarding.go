@@ -2,6 +2,11 @@ package platform
 
 import "context"
 
+// ops for onboarding.
+const (
+	OpGenerateOnboarding = "GenerateOnboarding"
+)
+
 // OnboardingResults is a group of elements required for first run.
 type OnboardingResults struct {
 	User   *User          `json:"user"`
@@ -0,0 +1,90 @@
+package file
+
+import (
+	"io"
+	"os"
+)
+
+// CopyFile copies the contents of the file at src to a new file at dst, and
+// syncs the new file to disk. It fails if dst already exists.
+func CopyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// LinkedPrefix is a hard link to a file that is still appended to, of which
+// only the first N bytes, as of when the link was made, are wanted.
+type LinkedPrefix struct {
+	Path string
+	N    int64
+
+	// Len is the length of the copy of the file, which is zero after the
+	// first N bytes. Files that are preallocated are copied at their full
+	// length.
+	Len int64
+}
+
+// LinkPrefix hard links the file at src to dst, and returns the link to the
+// first n bytes of the file. It is cheap, so it can be called while the file
+// can not be appended to, with the copy made by Copy once it can again.
+func LinkPrefix(src, dst string, n, length int64) (*LinkedPrefix, error) {
+	if err := os.Link(src, dst); err != nil {
+		return nil, err
+	}
+	return &LinkedPrefix{Path: dst, N: n, Len: length}, nil
+}
+
+// Copy replaces the link with a copy of the first N bytes of the file, so that
+// it does not change as the file is appended to.
+func (l *LinkedPrefix) Copy() error {
+	in, err := os.Open(l.Path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmpPath := l.Path + ".tmp"
+	out, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(out, in, l.N); err != nil {
+		out.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Truncate(l.Len); err != nil {
+		out.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, l.Path)
+}
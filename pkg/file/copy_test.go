@@ -0,0 +1,59 @@
+package file_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/pkg/file"
+)
+
+func TestLinkedPrefix_Copy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	if err := ioutil.WriteFile(src, []byte("abc"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	prefix, err := file.LinkPrefix(src, filepath.Join(dir, "dst"), 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Appends made after the link are not copied.
+	f, err := os.OpenFile(src, os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("def")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := prefix.Copy(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := ioutil.ReadFile(prefix.Path); err != nil {
+		t.Fatal(err)
+	} else if exp := []byte("abc\x00\x00"); !bytes.Equal(got, exp) {
+		t.Fatalf("unexpected copy: got %q, exp %q", got, exp)
+	}
+	if got, err := ioutil.ReadFile(src); err != nil {
+		t.Fatal(err)
+	} else if exp := []byte("abcdef"); !bytes.Equal(got, exp) {
+		t.Fatalf("unexpected source: got %q, exp %q", got, exp)
+	}
+	if _, err := os.Stat(prefix.Path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("expected the temporary file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package tar streams directories into tar archives, and extracts them again.
+package tar
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Stream is a convenience function for creating a tar of a directory. It walks
+// over the directory and its subdirectories, passing each file to writeFunc.
+// By default StreamFile is used, which results in every file being written. A
+// custom writeFunc can be passed so that each file may be written, modified and
+// written, or skipped.
+func Stream(w io.Writer, dir, relativePath string, writeFunc func(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error) error {
+	tw := tar.NewWriter(w)
+	if err := StreamDir(tw, dir, relativePath, writeFunc); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// StreamDir is like Stream, but writes the files to an existing tar writer so
+// that an archive may hold more than one directory. The tar writer is not
+// closed.
+func StreamDir(tw *tar.Writer, dir, relativePath string, writeFunc func(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error) error {
+	if writeFunc == nil {
+		writeFunc = StreamFile
+	}
+
+	return filepath.Walk(dir, func(path string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Directories are implied by the names of the files in them.
+		if f.IsDir() {
+			return nil
+		}
+
+		// Figure out the full relative path including any sub-dirs
+		subDir, _ := filepath.Split(path)
+		subDir, err = filepath.Rel(dir, subDir)
+		if err != nil {
+			return err
+		}
+
+		return writeFunc(f, filepath.Join(relativePath, subDir), path, tw)
+	})
+}
+
+// SinceFilterTarFile returns a writeFunc that only writes the files modified
+// since the given time.
+func SinceFilterTarFile(since time.Time) func(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error {
+	return func(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error {
+		if f.ModTime().After(since) {
+			return StreamFile(f, relativePath, fullPath, tw)
+		}
+		return nil
+	}
+}
+
+// StreamFile writes the file at fullPath to the tar writer, named by its base
+// name within relativePath.
+func StreamFile(f os.FileInfo, relativePath, fullPath string, tw *tar.Writer) error {
+	return StreamRenameFile(f, f.Name(), relativePath, fullPath, tw)
+}
+
+// StreamRenameFile writes the file at fullPath to the tar writer, named
+// tarHeaderFileName within relativePath.
+func StreamRenameFile(f os.FileInfo, tarHeaderFileName, relativePath, fullPath string, tw *tar.Writer) error {
+	h, err := tar.FileInfoHeader(f, f.Name())
+	if err != nil {
+		return err
+	}
+	h.Name = filepath.ToSlash(filepath.Join(relativePath, tarHeaderFileName))
+
+	if err := tw.WriteHeader(h); err != nil {
+		return err
+	}
+
+	if !f.Mode().IsRegular() {
+		return nil
+	}
+
+	fr, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	_, err = io.CopyN(tw, fr, h.Size)
+	return err
+}
+
+// ErrArchiveTooLarge is returned by RestoreLimit if the files it extracts are
+// larger in total than its limit.
+var ErrArchiveTooLarge = errors.New("tar: archive is too large")
+
+// Restore extracts the files of the tar archive read from r whose names start
+// with prefix into dir, which is created if it does not exist. Every file is
+// extracted if prefix is empty.
+func Restore(r io.Reader, dir, prefix string) error {
+	return RestoreLimit(r, dir, prefix, 0)
+}
+
+// RestoreLimit is like Restore, but returns ErrArchiveTooLarge, without
+// extracting any more files, once the files it extracts are larger than limit
+// bytes in total. A limit of zero is unlimited.
+func RestoreLimit(r io.Reader, dir, prefix string, limit int64) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	var n int64
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if !strings.HasPrefix(h.Name, prefix) {
+			continue
+		}
+
+		if limit > 0 && h.FileInfo().Mode().IsRegular() {
+			if n += h.Size; n > limit {
+				return ErrArchiveTooLarge
+			}
+		}
+		if err := extractFile(tr, h, dir); err != nil {
+			return err
+		}
+	}
+}
+
+// extractFile writes the current file of the tar reader into dir.
+func extractFile(tr *tar.Reader, h *tar.Header, dir string) error {
+	if !h.FileInfo().Mode().IsRegular() {
+		return nil
+	}
+
+	// Refuse names that would be written outside of dir.
+	path := filepath.Join(dir, filepath.FromSlash(h.Name))
+	if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+		return fmt.Errorf("tar: invalid file name %q", h.Name)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, tr); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(path, h.ModTime, h.ModTime)
+}
@@ -3,6 +3,8 @@ package control
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/control"
@@ -16,6 +18,13 @@ const orgLabel = "org"
 
 // Controller implements AsyncQueryService by consuming a control.Controller.
 type Controller struct {
+	queued int64 // Accessed atomically, so kept 64-bit aligned first.
+
+	// QueueSize is the maximum number of queries, running or waiting to,
+	// that are not done. Queries beyond it fail as unavailable, so that their
+	// callers retry them later. It is unlimited when zero.
+	QueueSize int64
+
 	c *control.Controller
 }
 
@@ -32,8 +41,18 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 	ctx = query.ContextWithRequest(ctx, req)
 	// Set the org label value for controller metrics
 	ctx = context.WithValue(ctx, orgLabel, req.OrganizationID.String())
+
+	if n := atomic.AddInt64(&c.queued, 1); c.QueueSize > 0 && n > c.QueueSize {
+		atomic.AddInt64(&c.queued, -1)
+		return nil, &platform.Error{
+			Code: platform.EUnavailable,
+			Msg:  "query queue is full",
+		}
+	}
+
 	q, err := c.c.Query(ctx, req.Compiler)
 	if err != nil {
+		atomic.AddInt64(&c.queued, -1)
 		// If the controller reports an error, it's usually because of a syntax error
 		// or other problem that the client must fix.
 		return q, &platform.Error{
@@ -42,7 +61,22 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 		}
 	}
 
-	return q, nil
+	return &queuedQuery{Query: q, c: c}, nil
+}
+
+// queuedQuery is a query that leaves the queue of its controller when done.
+type queuedQuery struct {
+	flux.Query
+	c    *Controller
+	once sync.Once
+}
+
+// Done releases the resources of the query, and its place in the queue.
+func (q *queuedQuery) Done() {
+	q.Query.Done()
+	q.once.Do(func() {
+		atomic.AddInt64(&q.c.queued, -1)
+	})
 }
 
 // PrometheusCollectors satisifies the prom.PrometheusCollector interface.
@@ -0,0 +1,165 @@
+package encoding
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+)
+
+// Metadata keys describing the tables encoded by ArrowMultiResultEncoder.
+const (
+	// ArrowResultKey is the schema metadata key of the name of the result.
+	ArrowResultKey = "flux.result"
+	// ArrowTableKey is the schema metadata key of the index of the table
+	// within its result.
+	ArrowTableKey = "flux.table"
+	// ArrowTypeKey is the field metadata key of the flux type of a column,
+	// which distinguishes time columns from integer columns.
+	ArrowTypeKey = "flux.type"
+	// ArrowGroupKey is the field metadata key indicating whether a column is
+	// part of the group key of the table.
+	ArrowGroupKey = "flux.group"
+)
+
+// ArrowMultiResultEncoder encodes results as a sequence of Arrow IPC streams,
+// one per table. Each buffer of a table is written as a record batch of its
+// stream as soon as it is read.
+//
+// Time columns are encoded as int64 nanoseconds since the Unix epoch, and
+// strings as binary.
+type ArrowMultiResultEncoder struct{}
+
+// Encode writes the results to w. Errors cannot be represented in an Arrow
+// stream, so the result is truncated if an error occurs.
+func (e *ArrowMultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	defer results.Release()
+
+	wc := &iocounter.Writer{Writer: w}
+
+	var err error
+	for err == nil && results.More() {
+		res := results.Next()
+
+		var table int
+		err = res.Tables().Do(func(tbl flux.Table) error {
+			defer func() { table++ }()
+			return encodeArrowTable(wc, res.Name(), table, tbl)
+		})
+	}
+	if err == nil {
+		err = results.Err()
+	}
+	return wc.Count(), err
+}
+
+// encodeArrowTable writes tbl to w as an Arrow IPC stream.
+func encodeArrowTable(w io.Writer, result string, table int, tbl flux.Table) error {
+	for _, c := range tbl.Cols() {
+		if arrowType(c.Type) == nil {
+			return fmt.Errorf("unsupported column type %s for column %q", c.Type, c.Label)
+		}
+	}
+
+	var (
+		iw     *ipc.Writer
+		schema *arrow.Schema
+	)
+
+	err := tbl.DoArrow(func(cr flux.ArrowColReader) error {
+		cols := make([]array.Interface, len(cr.Cols()))
+		for j, c := range cr.Cols() {
+			cols[j] = arrowColumn(cr, c.Type, j)
+		}
+
+		if iw == nil {
+			schema = arrowSchema(result, table, tbl, cols)
+			iw = ipc.NewWriter(w, ipc.WithSchema(schema))
+		}
+
+		rec := array.NewRecord(schema, cols, int64(cr.Len()))
+		defer rec.Release()
+		return iw.Write(rec)
+	})
+
+	if iw == nil {
+		// Write the schema of tables without any rows.
+		iw = ipc.NewWriter(w, ipc.WithSchema(arrowSchema(result, table, tbl, nil)))
+	}
+	if cerr := iw.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// arrowSchema returns the schema of the stream of a table. The types of the
+// fields are those of cols if given.
+func arrowSchema(result string, table int, tbl flux.Table, cols []array.Interface) *arrow.Schema {
+	key := tbl.Key()
+
+	fields := make([]arrow.Field, len(tbl.Cols()))
+	for j, c := range tbl.Cols() {
+		typ := arrowType(c.Type)
+		if cols != nil {
+			typ = cols[j].DataType()
+		}
+
+		fields[j] = arrow.Field{
+			Name:     c.Label,
+			Type:     typ,
+			Nullable: true,
+			Metadata: arrow.NewMetadata(
+				[]string{ArrowTypeKey, ArrowGroupKey},
+				[]string{c.Type.String(), strconv.FormatBool(key.HasCol(c.Label))},
+			),
+		}
+	}
+
+	md := arrow.NewMetadata(
+		[]string{ArrowResultKey, ArrowTableKey},
+		[]string{result, strconv.Itoa(table)},
+	)
+	return arrow.NewSchema(fields, &md)
+}
+
+// arrowColumn returns the array of column j.
+func arrowColumn(cr flux.ArrowColReader, typ flux.ColType, j int) array.Interface {
+	switch typ {
+	case flux.TFloat:
+		return cr.Floats(j)
+	case flux.TInt:
+		return cr.Ints(j)
+	case flux.TUInt:
+		return cr.UInts(j)
+	case flux.TString:
+		return cr.Strings(j)
+	case flux.TBool:
+		return cr.Bools(j)
+	case flux.TTime:
+		return cr.Times(j)
+	}
+	return nil
+}
+
+// arrowType returns the Arrow type used to encode columns of type typ, or nil
+// if the type cannot be encoded.
+func arrowType(typ flux.ColType) arrow.DataType {
+	switch typ {
+	case flux.TFloat:
+		return arrow.PrimitiveTypes.Float64
+	case flux.TInt, flux.TTime:
+		return arrow.PrimitiveTypes.Int64
+	case flux.TUInt:
+		return arrow.PrimitiveTypes.Uint64
+	case flux.TString:
+		return arrow.BinaryTypes.Binary
+	case flux.TBool:
+		return arrow.FixedWidthTypes.Boolean
+	}
+	return nil
+}
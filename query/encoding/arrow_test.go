@@ -0,0 +1,122 @@
+package encoding_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/platform/query/encoding"
+)
+
+// arrowTable is a table decoded from an Arrow IPC stream.
+type arrowTable struct {
+	Metadata map[string]string
+	Fields   []arrowField
+	Rows     [][]interface{}
+}
+
+type arrowField struct {
+	Name     string
+	Type     string
+	Metadata map[string]string
+}
+
+func metadataMap(md arrow.Metadata) map[string]string {
+	m := make(map[string]string, md.Len())
+	for i, k := range md.Keys() {
+		m[k] = md.Values()[i]
+	}
+	return m
+}
+
+// readArrowTable reads the next stream of r.
+func readArrowTable(t *testing.T, r *bytes.Reader) arrowTable {
+	t.Helper()
+
+	rdr, err := ipc.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rdr.Release()
+
+	schema := rdr.Schema()
+	tbl := arrowTable{Metadata: metadataMap(schema.Metadata())}
+	for _, f := range schema.Fields() {
+		tbl.Fields = append(tbl.Fields, arrowField{
+			Name:     f.Name,
+			Type:     f.Type.Name(),
+			Metadata: metadataMap(f.Metadata),
+		})
+	}
+
+	for rdr.Next() {
+		rec := rdr.Record()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			row := make([]interface{}, rec.NumCols())
+			for j, col := range rec.Columns() {
+				switch col := col.(type) {
+				case *array.Int64:
+					row[j] = col.Value(i)
+				case *array.Float64:
+					row[j] = col.Value(i)
+				case *array.Binary:
+					row[j] = col.ValueString(i)
+				default:
+					t.Fatalf("unexpected column type %s", col.DataType().Name())
+				}
+			}
+			tbl.Rows = append(tbl.Rows, row)
+		}
+	}
+	return tbl
+}
+
+func TestArrowMultiResultEncoder_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	enc := new(encoding.ArrowMultiResultEncoder)
+	n, err := enc.Encode(&buf, flux.NewSliceResultIterator(testResults()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("got %d bytes written, expected %d", n, buf.Len())
+	}
+
+	fields := []arrowField{
+		{Name: "_time", Type: "int64", Metadata: map[string]string{encoding.ArrowTypeKey: "time", encoding.ArrowGroupKey: "false"}},
+		{Name: "_measurement", Type: "binary", Metadata: map[string]string{encoding.ArrowTypeKey: "string", encoding.ArrowGroupKey: "true"}},
+		{Name: "host", Type: "binary", Metadata: map[string]string{encoding.ArrowTypeKey: "string", encoding.ArrowGroupKey: "true"}},
+		{Name: "_value", Type: "float64", Metadata: map[string]string{encoding.ArrowTypeKey: "float", encoding.ArrowGroupKey: "false"}},
+	}
+	exp := []arrowTable{
+		{
+			Metadata: map[string]string{encoding.ArrowResultKey: "_result", encoding.ArrowTableKey: "0"},
+			Fields:   fields,
+			Rows: [][]interface{}{
+				{int64(ts("2018-05-24T09:00:00Z")), "cpu", "a", 2.5},
+				{int64(ts("2018-05-24T09:00:10Z")), "cpu", "a", 3.0},
+			},
+		},
+		{
+			Metadata: map[string]string{encoding.ArrowResultKey: "_result", encoding.ArrowTableKey: "1"},
+			Fields:   fields,
+			Rows: [][]interface{}{
+				{int64(ts("2018-05-24T09:00:00Z")), "cpu", "b\"", 1.0},
+			},
+		},
+	}
+
+	// Each table is a separate stream, read one after the other.
+	r := bytes.NewReader(buf.Bytes())
+	var got []arrowTable
+	for r.Len() > 0 {
+		got = append(got, readArrowTable(t, r))
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("unexpected tables -want/+got\n%s", diff)
+	}
+}
@@ -0,0 +1,86 @@
+// Package encoding provides result encoders and dialects for encoding flux
+// query results as JSON, newline-delimited JSON and Apache Arrow.
+package encoding
+
+import (
+	"net/http"
+
+	"github.com/influxdata/flux"
+)
+
+const (
+	// JSONDialectType is the dialect type of JSONDialect.
+	JSONDialectType = "json"
+	// NDJSONDialectType is the dialect type of NDJSONDialect.
+	NDJSONDialectType = "ndjson"
+	// ArrowDialectType is the dialect type of ArrowDialect.
+	ArrowDialectType = "arrow"
+)
+
+const (
+	// JSONContentType is the content type of results encoded as JSON.
+	JSONContentType = "application/json; charset=utf-8"
+	// NDJSONContentType is the content type of results encoded as
+	// newline-delimited JSON.
+	NDJSONContentType = "application/x-ndjson"
+	// ArrowContentType is the content type of results encoded as Arrow
+	// IPC streams.
+	ArrowContentType = "application/vnd.apache.arrow.stream"
+)
+
+// JSONDialect encodes results as a single JSON document containing every
+// table of every result.
+type JSONDialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *JSONDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", JSONContentType)
+}
+
+// Encoder returns a JSONMultiResultEncoder.
+func (d *JSONDialect) Encoder() flux.MultiResultEncoder {
+	return new(JSONMultiResultEncoder)
+}
+
+// DialectType returns JSONDialectType.
+func (d *JSONDialect) DialectType() flux.DialectType {
+	return JSONDialectType
+}
+
+// NDJSONDialect encodes results as newline-delimited JSON, with one object
+// per row.
+type NDJSONDialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *NDJSONDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", NDJSONContentType)
+}
+
+// Encoder returns a NDJSONMultiResultEncoder.
+func (d *NDJSONDialect) Encoder() flux.MultiResultEncoder {
+	return new(NDJSONMultiResultEncoder)
+}
+
+// DialectType returns NDJSONDialectType.
+func (d *NDJSONDialect) DialectType() flux.DialectType {
+	return NDJSONDialectType
+}
+
+// ArrowDialect encodes results as a sequence of Arrow IPC streams, with one
+// stream per table.
+type ArrowDialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *ArrowDialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ArrowContentType)
+}
+
+// Encoder returns an ArrowMultiResultEncoder.
+func (d *ArrowDialect) Encoder() flux.MultiResultEncoder {
+	return new(ArrowMultiResultEncoder)
+}
+
+// DialectType returns ArrowDialectType.
+func (d *ArrowDialect) DialectType() flux.DialectType {
+	return ArrowDialectType
+}
@@ -0,0 +1,199 @@
+package encoding
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// JSONMultiResultEncoder encodes results as a single JSON document of the form
+//
+//	{"results":[{"name":"_result","tables":[{"groupKey":{...},"columns":[...],"rows":[[...],...]}]}]}
+//
+// Rows are written as each buffer of a table is read, so results are never
+// held in memory in their entirety. If an error occurs after the document has
+// been started, it is reported in the "error" property of the document.
+type JSONMultiResultEncoder struct{}
+
+// Encode writes the results to w.
+func (e *JSONMultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	defer results.Release()
+
+	wc := &iocounter.Writer{Writer: w}
+	b := []byte(`{"results":[`)
+	flush := func() error {
+		_, err := wc.Write(b)
+		b = b[:0]
+		return err
+	}
+
+	var err error
+	for n := 0; err == nil && results.More(); n++ {
+		res := results.Next()
+		if n > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, `{"name":`...)
+		b = appendString(b, res.Name())
+		b = append(b, `,"tables":[`...)
+
+		var nt int
+		err = res.Tables().Do(func(tbl flux.Table) error {
+			if nt > 0 {
+				b = append(b, ',')
+			}
+			nt++
+			b = appendTableHeader(b, tbl)
+
+			var nr int
+			err := tbl.DoArrow(func(cr flux.ArrowColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					if nr > 0 {
+						b = append(b, ',')
+					}
+					nr++
+					b = append(b, '[')
+					for j, c := range cr.Cols() {
+						if j > 0 {
+							b = append(b, ',')
+						}
+						b = appendValue(b, cr, c.Type, i, j)
+					}
+					b = append(b, ']')
+				}
+				return flush()
+			})
+			b = append(b, "]}"...)
+			return err
+		})
+		b = append(b, "]}"...)
+	}
+	if err == nil {
+		err = results.Err()
+	}
+
+	b = append(b, ']')
+	if err != nil {
+		if wc.Count() == 0 {
+			// Nothing has been written, so the caller can report the error.
+			return 0, err
+		}
+		b = append(b, `,"error":`...)
+		b = appendString(b, err.Error())
+	}
+	b = append(b, "}\n"...)
+
+	if ferr := flush(); err == nil {
+		err = ferr
+	}
+	return wc.Count(), err
+}
+
+// appendTableHeader appends the start of the JSON object of a table, up to
+// its first row.
+func appendTableHeader(b []byte, tbl flux.Table) []byte {
+	key := tbl.Key()
+
+	b = append(b, `{"groupKey":{`...)
+	for j, c := range key.Cols() {
+		if j > 0 {
+			b = append(b, ',')
+		}
+		b = appendString(b, c.Label)
+		b = append(b, ':')
+		b = appendKeyValue(b, key.Value(j))
+	}
+
+	b = append(b, `},"columns":[`...)
+	for j, c := range tbl.Cols() {
+		if j > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, `{"label":`...)
+		b = appendString(b, c.Label)
+		b = append(b, `,"datatype":`...)
+		b = appendString(b, c.Type.String())
+		b = append(b, `,"group":`...)
+		b = strconv.AppendBool(b, key.HasCol(c.Label))
+		b = append(b, '}')
+	}
+
+	return append(b, `],"rows":[`...)
+}
+
+// appendValue appends the JSON encoding of the value in row i of column j.
+func appendValue(b []byte, cr flux.ArrowColReader, typ flux.ColType, i, j int) []byte {
+	switch typ {
+	case flux.TFloat:
+		if vs := cr.Floats(j); !vs.IsNull(i) {
+			return appendFloat(b, vs.Value(i))
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); !vs.IsNull(i) {
+			return strconv.AppendInt(b, vs.Value(i), 10)
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); !vs.IsNull(i) {
+			return strconv.AppendUint(b, vs.Value(i), 10)
+		}
+	case flux.TString:
+		if vs := cr.Strings(j); !vs.IsNull(i) {
+			return appendString(b, string(vs.Value(i)))
+		}
+	case flux.TBool:
+		if vs := cr.Bools(j); !vs.IsNull(i) {
+			return strconv.AppendBool(b, vs.Value(i))
+		}
+	case flux.TTime:
+		if vs := cr.Times(j); !vs.IsNull(i) {
+			return appendTime(b, time.Unix(0, vs.Value(i)))
+		}
+	}
+	return append(b, "null"...)
+}
+
+// appendKeyValue appends the JSON encoding of a group key value.
+func appendKeyValue(b []byte, v values.Value) []byte {
+	switch v.Type() {
+	case semantic.Float:
+		return appendFloat(b, v.Float())
+	case semantic.Int:
+		return strconv.AppendInt(b, v.Int(), 10)
+	case semantic.UInt:
+		return strconv.AppendUint(b, v.UInt(), 10)
+	case semantic.String:
+		return appendString(b, v.Str())
+	case semantic.Bool:
+		return strconv.AppendBool(b, v.Bool())
+	case semantic.Time:
+		return appendTime(b, v.Time().Time())
+	}
+	return append(b, "null"...)
+}
+
+// appendFloat appends v, or null if v cannot be represented in JSON.
+func appendFloat(b []byte, v float64) []byte {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return append(b, "null"...)
+	}
+	return strconv.AppendFloat(b, v, 'f', -1, 64)
+}
+
+func appendTime(b []byte, t time.Time) []byte {
+	b = append(b, '"')
+	b = t.UTC().AppendFormat(b, time.RFC3339Nano)
+	return append(b, '"')
+}
+
+func appendString(b []byte, s string) []byte {
+	// Marshaling a string cannot fail.
+	v, _ := json.Marshal(s)
+	return append(b, v...)
+}
@@ -0,0 +1,129 @@
+package encoding_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/platform/query/encoding"
+)
+
+func ts(s string) execute.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic(err)
+	}
+	return execute.Time(t.UnixNano())
+}
+
+// errResultIterator is a flux.ResultIterator without any results that fails
+// with err.
+type errResultIterator struct {
+	err error
+}
+
+func (itr errResultIterator) More() bool                  { return false }
+func (itr errResultIterator) Next() flux.Result           { panic("no results") }
+func (itr errResultIterator) Release()                    {}
+func (itr errResultIterator) Err() error                  { return itr.err }
+func (itr errResultIterator) Statistics() flux.Statistics { return flux.Statistics{} }
+
+func testResults() []flux.Result {
+	return []flux.Result{&executetest.Result{
+		Nm: "_result",
+		Tbls: []*executetest.Table{
+			{
+				KeyCols: []string{"_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts("2018-05-24T09:00:00Z"), "cpu", "a", 2.5},
+					{ts("2018-05-24T09:00:10Z"), "cpu", "a", 3.0},
+				},
+			},
+			{
+				KeyCols: []string{"_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts("2018-05-24T09:00:00Z"), "cpu", "b\"", 1.0},
+				},
+			},
+		},
+	}}
+}
+
+func TestJSONMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   flux.ResultIterator
+		out  string
+		err  bool
+	}{
+		{
+			name: "results",
+			in:   flux.NewSliceResultIterator(testResults()),
+			out: `{"results":[{"name":"_result","tables":[` +
+				`{"groupKey":{"_measurement":"cpu","host":"a"},"columns":[{"label":"_time","datatype":"time","group":false},{"label":"_measurement","datatype":"string","group":true},{"label":"host","datatype":"string","group":true},{"label":"_value","datatype":"float","group":false}],` +
+				`"rows":[["2018-05-24T09:00:00Z","cpu","a",2.5],["2018-05-24T09:00:10Z","cpu","a",3]]},` +
+				`{"groupKey":{"_measurement":"cpu","host":"b\""},"columns":[{"label":"_time","datatype":"time","group":false},{"label":"_measurement","datatype":"string","group":true},{"label":"host","datatype":"string","group":true},{"label":"_value","datatype":"float","group":false}],` +
+				`"rows":[["2018-05-24T09:00:00Z","cpu","b\"",1]]}]}]}` + "\n",
+		},
+		{
+			name: "error before results",
+			in:   errResultIterator{err: errors.New("expected error")},
+			err:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := new(encoding.JSONMultiResultEncoder)
+			n, err := enc.Encode(&buf, tt.in)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err {
+				if n != 0 || buf.Len() != 0 {
+					t.Fatalf("expected nothing to be written, got %q", buf.String())
+				}
+				return
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\n%s", cmp.Diff(exp, got))
+			}
+			if n != int64(buf.Len()) {
+				t.Fatalf("got %d bytes written, expected %d", n, buf.Len())
+			}
+		})
+	}
+}
+
+func TestNDJSONMultiResultEncoder_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	enc := new(encoding.NDJSONMultiResultEncoder)
+	if _, err := enc.Encode(&buf, flux.NewSliceResultIterator(testResults())); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{"result":"_result","table":0,"_time":"2018-05-24T09:00:00Z","_measurement":"cpu","host":"a","_value":2.5}
+{"result":"_result","table":0,"_time":"2018-05-24T09:00:10Z","_measurement":"cpu","host":"a","_value":3}
+{"result":"_result","table":1,"_time":"2018-05-24T09:00:00Z","_measurement":"cpu","host":"b\"","_value":1}
+`
+	if got := buf.String(); got != exp {
+		t.Fatalf("unexpected output:\n%s", cmp.Diff(exp, got))
+	}
+}
@@ -0,0 +1,70 @@
+package encoding
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+)
+
+// NDJSONMultiResultEncoder encodes results as newline-delimited JSON. Each row
+// is written as an object containing the name of its result, the index of its
+// table within the result, and the value of each column, for example
+//
+//	{"result":"_result","table":0,"_time":"2018-01-01T00:00:00Z","_value":1.5,"host":"a"}
+//
+// If an error occurs after the first row has been written, it is reported in
+// a final {"error":"..."} object.
+type NDJSONMultiResultEncoder struct{}
+
+// Encode writes the results to w.
+func (e *NDJSONMultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	defer results.Release()
+
+	wc := &iocounter.Writer{Writer: w}
+
+	var (
+		b   []byte
+		err error
+	)
+	for err == nil && results.More() {
+		res := results.Next()
+		name := appendString(nil, res.Name())
+
+		var table int64
+		err = res.Tables().Do(func(tbl flux.Table) error {
+			defer func() { table++ }()
+
+			return tbl.DoArrow(func(cr flux.ArrowColReader) error {
+				b = b[:0]
+				for i := 0; i < cr.Len(); i++ {
+					b = append(b, `{"result":`...)
+					b = append(b, name...)
+					b = append(b, `,"table":`...)
+					b = strconv.AppendInt(b, table, 10)
+					for j, c := range cr.Cols() {
+						b = append(b, ',')
+						b = appendString(b, c.Label)
+						b = append(b, ':')
+						b = appendValue(b, cr, c.Type, i, j)
+					}
+					b = append(b, "}\n"...)
+				}
+				_, err := wc.Write(b)
+				return err
+			})
+		})
+	}
+	if err == nil {
+		err = results.Err()
+	}
+
+	if err != nil && wc.Count() > 0 {
+		b = append(b[:0], `{"error":`...)
+		b = appendString(b, err.Error())
+		b = append(b, "}\n"...)
+		wc.Write(b)
+	}
+	return wc.Count(), err
+}
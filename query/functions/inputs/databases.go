@@ -84,7 +84,9 @@ func (bd *DatabasesDecoder) Connect() error {
 
 func (bd *DatabasesDecoder) Fetch() (bool, error) {
 
-	b, _, err := bd.deps.DBRP.FindMany(bd.ctx, platform.DBRPMappingFilter{})
+	b, _, err := bd.deps.DBRP.FindMany(bd.ctx, platform.DBRPMappingFilter{
+		OrganizationID: &bd.orgID,
+	})
 	if err != nil {
 		return false, err
 	}
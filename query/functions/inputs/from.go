@@ -27,7 +27,14 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 		return nil, errors.New("nil bounds passed to from")
 	}
 
-	if spec.WindowSet {
+	var windowEvery, windowOffset int64
+	if spec.WindowSet && spec.AggregateMethod != "" && spec.Window.Every == spec.Window.Period && spec.Window.Round == 0 {
+		// storage computes the aggregate of each window, so the range is read once
+		windowEvery = int64(spec.Window.Every)
+		windowOffset = int64(bounds.Start) % windowEvery
+	}
+
+	if spec.WindowSet && windowEvery == 0 {
 		w = execute.Window{
 			Every:  execute.Duration(spec.Window.Every),
 			Period: execute.Duration(spec.Window.Period),
@@ -82,6 +89,8 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 			GroupMode:       storage.ToGroupMode(spec.GroupMode),
 			GroupKeys:       spec.GroupKeys,
 			AggregateMethod: spec.AggregateMethod,
+			WindowEvery:     windowEvery,
+			WindowOffset:    windowOffset,
 		},
 		*bounds,
 		w,
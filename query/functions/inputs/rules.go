@@ -0,0 +1,94 @@
+package inputs
+
+import (
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/plan"
+)
+
+func init() {
+	plan.RegisterLogicalRules(
+		PushDownWindowAggregateRule{Kind: transformations.SumKind},
+		PushDownWindowAggregateRule{Kind: transformations.CountKind},
+		PushDownWindowAggregateRule{Kind: transformations.MeanKind},
+		PushDownWindowAggregateRule{Kind: transformations.MinKind},
+		PushDownWindowAggregateRule{Kind: transformations.MaxKind},
+		PushDownWindowAggregateRule{Kind: transformations.FirstKind},
+		PushDownWindowAggregateRule{Kind: transformations.LastKind},
+	)
+}
+
+// PushDownWindowAggregateRule rewrites from |> range |> window |> agg, where agg
+// is an aggregate or selector of the _value column identified by Kind,
+// so the aggregate of each window is computed by storage in a single read.
+type PushDownWindowAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (r PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule/" + string(r.Kind)
+}
+
+func (r PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(r.Kind, plan.Pat(transformations.WindowKind, plan.Pat(inputs.FromKind)))
+}
+
+func (r PushDownWindowAggregateRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	windowNode := node.Predecessors()[0]
+	fromNode := windowNode.Predecessors()[0]
+
+	fromSpec := fromNode.ProcedureSpec().(*inputs.FromProcedureSpec)
+	if !fromSpec.BoundsSet || fromSpec.WindowSet || fromSpec.AggregateSet ||
+		fromSpec.GroupingSet || fromSpec.LimitSet {
+		return node, false, nil
+	}
+
+	window := windowNode.ProcedureSpec().(*transformations.WindowProcedureSpec).Window
+	if window.Every <= 0 || window.Every != window.Period || window.Round != 0 {
+		// storage only supports contiguous windows
+		return node, false, nil
+	}
+
+	if !aggregatesValueColumn(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*inputs.FromProcedureSpec)
+	newFromSpec.WindowSet = true
+	newFromSpec.Window = window
+	newFromSpec.AggregateSet = true
+	newFromSpec.AggregateMethod = string(r.Kind)
+
+	merged, err := plan.MergeLogicalPlanNodes(windowNode, fromNode, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	merged, err = plan.MergeLogicalPlanNodes(node, merged, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// aggregatesValueColumn reports whether spec only aggregates the _value column.
+func aggregatesValueColumn(spec plan.ProcedureSpec) bool {
+	var columns []string
+	switch spec := spec.(type) {
+	case *transformations.SumProcedureSpec:
+		columns = spec.Columns
+	case *transformations.CountProcedureSpec:
+		columns = spec.Columns
+	case *transformations.MeanProcedureSpec:
+		columns = spec.Columns
+	case *transformations.MinProcedureSpec:
+		columns = []string{spec.Column}
+	case *transformations.MaxProcedureSpec:
+		columns = []string{spec.Column}
+	case *transformations.FirstProcedureSpec:
+		columns = []string{spec.Column}
+	case *transformations.LastProcedureSpec:
+		columns = []string{spec.Column}
+	}
+	return len(columns) == 1 && columns[0] == execute.DefaultValueColLabel
+}
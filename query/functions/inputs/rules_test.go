@@ -0,0 +1,162 @@
+package inputs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/plan"
+	pinputs "github.com/influxdata/platform/query/functions/inputs"
+)
+
+// windowAggregateNode returns the agg node of from |> window |> agg.
+func windowAggregateNode(from *inputs.FromProcedureSpec, window plan.WindowSpec, agg plan.ProcedureSpec) plan.PlanNode {
+	fromNode := plan.CreateLogicalNode("from", from)
+	windowNode := plan.CreateLogicalNode("window", &transformations.WindowProcedureSpec{Window: window})
+	aggNode := plan.CreateLogicalNode("agg", agg)
+
+	fromNode.AddSuccessors(windowNode)
+	windowNode.AddPredecessors(fromNode)
+	windowNode.AddSuccessors(aggNode)
+	aggNode.AddPredecessors(windowNode)
+	return aggNode
+}
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	minute := plan.WindowSpec{
+		Every:  flux.Duration(time.Minute),
+		Period: flux.Duration(time.Minute),
+	}
+	valueColumns := execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}}
+	valueColumn := execute.SelectorConfig{Column: execute.DefaultValueColLabel}
+
+	tests := []struct {
+		name   string
+		kind   plan.ProcedureKind
+		from   *inputs.FromProcedureSpec
+		window plan.WindowSpec
+		agg    plan.ProcedureSpec
+		want   bool
+	}{
+		{
+			name:   "sum",
+			kind:   transformations.SumKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: minute,
+			agg:    &transformations.SumProcedureSpec{AggregateConfig: valueColumns},
+			want:   true,
+		},
+		{
+			name:   "mean",
+			kind:   transformations.MeanKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: minute,
+			agg:    &transformations.MeanProcedureSpec{AggregateConfig: valueColumns},
+			want:   true,
+		},
+		{
+			name:   "first",
+			kind:   transformations.FirstKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: minute,
+			agg:    &transformations.FirstProcedureSpec{SelectorConfig: valueColumn},
+			want:   true,
+		},
+		{
+			name:   "no bounds",
+			kind:   transformations.SumKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b"},
+			window: minute,
+			agg:    &transformations.SumProcedureSpec{AggregateConfig: valueColumns},
+		},
+		{
+			name:   "already grouped",
+			kind:   transformations.SumKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true, GroupingSet: true},
+			window: minute,
+			agg:    &transformations.SumProcedureSpec{AggregateConfig: valueColumns},
+		},
+		{
+			name: "overlapping windows",
+			kind: transformations.SumKind,
+			from: &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: plan.WindowSpec{
+				Every:  flux.Duration(time.Minute),
+				Period: flux.Duration(2 * time.Minute),
+			},
+			agg: &transformations.SumProcedureSpec{AggregateConfig: valueColumns},
+		},
+		{
+			name: "rounded windows",
+			kind: transformations.SumKind,
+			from: &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: plan.WindowSpec{
+				Every:  flux.Duration(time.Minute),
+				Period: flux.Duration(time.Minute),
+				Round:  flux.Duration(time.Second),
+			},
+			agg: &transformations.SumProcedureSpec{AggregateConfig: valueColumns},
+		},
+		{
+			name:   "other column",
+			kind:   transformations.SumKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: minute,
+			agg:    &transformations.SumProcedureSpec{AggregateConfig: execute.AggregateConfig{Columns: []string{"other"}}},
+		},
+		{
+			name:   "other selector column",
+			kind:   transformations.MaxKind,
+			from:   &inputs.FromProcedureSpec{Bucket: "b", BoundsSet: true},
+			window: minute,
+			agg:    &transformations.MaxProcedureSpec{SelectorConfig: execute.SelectorConfig{Column: "other"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := pinputs.PushDownWindowAggregateRule{Kind: tt.kind}
+			node := windowAggregateNode(tt.from, tt.window, tt.agg)
+
+			got, changed, err := rule.Rewrite(node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != tt.want {
+				t.Fatalf("unexpected rewrite: got %v, exp %v", changed, tt.want)
+			}
+			if !changed {
+				if got != node {
+					t.Fatal("expected the node to be returned unchanged")
+				}
+				return
+			}
+
+			if len(got.Predecessors()) != 0 {
+				t.Fatalf("expected the rewritten node to have no predecessors, got %d", len(got.Predecessors()))
+			}
+			spec, ok := got.ProcedureSpec().(*inputs.FromProcedureSpec)
+			if !ok {
+				t.Fatalf("unexpected procedure spec %T", got.ProcedureSpec())
+			}
+			exp := &inputs.FromProcedureSpec{
+				Bucket:          "b",
+				BoundsSet:       true,
+				WindowSet:       true,
+				Window:          tt.window,
+				AggregateSet:    true,
+				AggregateMethod: string(tt.kind),
+			}
+			if diff := cmp.Diff(exp, spec); diff != "" {
+				t.Fatalf("unexpected from spec -want/+got\n%s", diff)
+			}
+			if tt.from.WindowSet || tt.from.AggregateSet {
+				t.Fatal("the original from spec was modified")
+			}
+		})
+	}
+}
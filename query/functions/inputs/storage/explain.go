@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// ReadExplain describes a storage read performed by a query and the costs
+// associated with executing it.
+type ReadExplain struct {
+	// Plan describes how the storage engine executed the read.
+	Plan string `json:"plan"`
+
+	SeriesCount   int64 `json:"seriesCount"`
+	ScannedValues int64 `json:"scannedValues"`
+	ScannedBytes  int64 `json:"scannedBytes"`
+	ScannedBlocks int64 `json:"scannedBlocks"`
+	CacheValues   int64 `json:"cacheValues"`
+}
+
+// ExplainCollector collects a ReadExplain for each storage read of a query.
+// It is safe for concurrent use.
+type ExplainCollector struct {
+	mu    sync.Mutex
+	reads []ReadExplain
+}
+
+// Add records the explanation of a storage read.
+func (c *ExplainCollector) Add(r ReadExplain) {
+	c.mu.Lock()
+	c.reads = append(c.reads, r)
+	c.mu.Unlock()
+}
+
+// Reads returns the explanations of all storage reads recorded so far.
+func (c *ExplainCollector) Reads() []ReadExplain {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ReadExplain(nil), c.reads...)
+}
+
+type explainContextKey struct{}
+
+var explainCollectorKey = explainContextKey{}
+
+// ContextWithExplainCollector returns a new context with c attached.
+// Storage reads executed with the context record their explanations to c.
+func ContextWithExplainCollector(ctx context.Context, c *ExplainCollector) context.Context {
+	return context.WithValue(ctx, explainCollectorKey, c)
+}
+
+// ExplainCollectorFromContext returns the ExplainCollector attached to ctx or nil.
+func ExplainCollectorFromContext(ctx context.Context) *ExplainCollector {
+	c, _ := ctx.Value(explainCollectorKey).(*ExplainCollector)
+	return c
+}
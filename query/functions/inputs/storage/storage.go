@@ -210,8 +210,19 @@ type ReadSpec struct {
 	SeriesOffset int64
 	Descending   bool
 
+	// AggregateMethod is a comma-separated list of the aggregates computed
+	// by storage for each series: sum, count, min, max, first, last or mean.
+	// When more than one aggregate is given, the aggregate of each table is
+	// identified by its _aggregate column.
 	AggregateMethod string
 
+	// WindowEvery is the duration of the windows for which storage computes
+	// the aggregates of AggregateMethod. Each window of a series produces a
+	// separate table. When 0, the aggregates are computed over the entire range.
+	WindowEvery int64
+	// WindowOffset shifts the window boundaries, which are otherwise aligned to the Unix epoch.
+	WindowOffset int64
+
 	// OrderByTime indicates that series reads should produce all
 	// series for a time before producing any series for a larger time.
 	// By default this is false meaning all values of time are produced for a given series,
@@ -18,10 +18,11 @@ func AddCompilerMappings(mappings flux.CompilerMappings, dbrpMappingSvc platform
 
 // Compiler is the transpiler to convert InfluxQL to a Flux specification.
 type Compiler struct {
-	Cluster string `json:"cluster,omitempty"`
-	DB      string `json:"db,omitempty"`
-	RP      string `json:"rp,omitempty"`
-	Query   string `json:"query"`
+	OrganizationID platform.ID `json:"organization_id,omitempty"`
+	Cluster        string      `json:"cluster,omitempty"`
+	DB             string      `json:"db,omitempty"`
+	RP             string      `json:"rp,omitempty"`
+	Query          string      `json:"query"`
 
 	dbrpMappingSvc platform.DBRPMappingService
 }
@@ -37,6 +38,7 @@ func (c *Compiler) Compile(ctx context.Context) (*flux.Spec, error) {
 	transpiler := NewTranspilerWithConfig(
 		c.dbrpMappingSvc,
 		Config{
+			OrganizationID:         c.OrganizationID,
 			Cluster:                c.Cluster,
 			DefaultDatabase:        c.DB,
 			DefaultRetentionPolicy: c.RP,
@@ -2,6 +2,8 @@ package influxql
 
 import (
 	"time"
+
+	"github.com/influxdata/platform"
 )
 
 // Config modifies the behavior of the Transpiler.
@@ -10,4 +12,6 @@ type Config struct {
 	DefaultRetentionPolicy string
 	NowFn                  func() time.Time
 	Cluster                string
+	// OrganizationID restricts the databases to those of the organization, if valid.
+	OrganizationID platform.ID
 }
@@ -0,0 +1,109 @@
+package influxql
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/platform/models"
+)
+
+// CSVMultiResultEncoder encodes results as InfluxQL CSV format.
+type CSVMultiResultEncoder struct {
+	// TimeFormat is the format of the timestamps; defaults to nanoseconds
+	// as CSV has no RFC3339 timestamps in influxdb 1.X.
+	TimeFormat TimeFormat
+}
+
+// Encode writes a collection of results to the influxdb 1.X CSV response format.
+// The columns of each series are prefixed by its name and tags. The header is written
+// again, after an empty line, for each statement and each change of the columns.
+func (e *CSVMultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	format := e.TimeFormat
+	if format == RFC3339Nano {
+		format = Nanosecond
+	}
+	resp := newResponse(results, format)
+
+	wc := &iocounter.Writer{Writer: w}
+	cw := csv.NewWriter(wc)
+	if resp.Err != "" {
+		cw.Write([]string{"error"})
+		cw.Write([]string{resp.Err})
+		cw.Flush()
+		return wc.Count(), cw.Error()
+	}
+
+	var columns []string
+	header := func(row *Row) error {
+		if columns != nil {
+			cw.Flush()
+			if _, err := io.WriteString(wc, "\n"); err != nil {
+				return err
+			}
+		}
+		columns = make([]string, 2+len(row.Columns))
+		columns[0] = "name"
+		columns[1] = "tags"
+		copy(columns[2:], row.Columns)
+		return cw.Write(columns)
+	}
+
+	for _, result := range resp.Results {
+		for i, row := range result.Series {
+			if i == 0 || !equalColumns(result.Series[i-1].Columns, row.Columns) {
+				if err := header(row); err != nil {
+					return wc.Count(), err
+				}
+			}
+
+			columns[0] = row.Name
+			columns[1] = ""
+			if len(row.Tags) > 0 {
+				columns[1] = string(models.NewTags(row.Tags).HashKey()[1:])
+			}
+			for _, values := range row.Values {
+				for i, v := range values {
+					columns[i+2] = formatCSVValue(v)
+				}
+				if err := cw.Write(columns); err != nil {
+					return wc.Count(), err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return wc.Count(), cw.Error()
+}
+
+func formatCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -13,9 +13,27 @@ import (
 )
 
 // MultiResultEncoder encodes results as InfluxQL JSON format.
-type MultiResultEncoder struct{}
+type MultiResultEncoder struct {
+	// TimeFormat is the format of the timestamps; defaults to RFC3339Nano.
+	TimeFormat TimeFormat
+	// Pretty indents the encoded JSON.
+	Pretty bool
+}
 
 // Encode writes a collection of results to the influxdb 1.X http response format.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	resp := newResponse(results, e.TimeFormat)
+
+	wc := &iocounter.Writer{Writer: w}
+	enc := json.NewEncoder(wc)
+	if e.Pretty {
+		enc.SetIndent("", "    ")
+	}
+	err := enc.Encode(resp)
+	return wc.Count(), err
+}
+
+// newResponse collects a collection of results into an influxdb 1.X response.
 // Expectations/Assumptions:
 //  1.  Each result will be published as a 'statement' in the top-level list of results. The result name
 //      will be interpreted as an integer and used as the statement id.
@@ -27,9 +45,8 @@ type MultiResultEncoder struct{}
 //  4.  All other columns are fields and will be output in the order they are found.
 //      TODO(jsternberg): This function currently requires the first column to be a time field, but this isn't
 //      a strict requirement and will be lifted when we begin to work on transpiling meta queries.
-func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+func newResponse(results flux.ResultIterator, format TimeFormat) Response {
 	resp := Response{}
-	wc := &iocounter.Writer{Writer: w}
 
 	for results.More() {
 		res := results.Next()
@@ -137,7 +154,7 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 						}
 					case flux.TTime:
 						for i, v := range cr.Times(idx) {
-							values[i][j] = v.Time().Format(time.RFC3339Nano)
+							values[i][j] = formatTime(v.Time(), format)
 						}
 					default:
 						return fmt.Errorf("unsupported column type: %s", c.Type)
@@ -163,9 +180,28 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 	if err := results.Err(); err != nil && resp.Err == "" {
 		resp.error(err)
 	}
+	return resp
+}
 
-	err := json.NewEncoder(wc).Encode(resp)
-	return wc.Count(), err
+// formatTime returns the value of a timestamp in the time format.
+// Epoch formats are integers truncated to the precision of the format.
+func formatTime(t time.Time, format TimeFormat) interface{} {
+	switch format {
+	case Hour:
+		return t.UnixNano() / int64(time.Hour)
+	case Minute:
+		return t.UnixNano() / int64(time.Minute)
+	case Second:
+		return t.UnixNano() / int64(time.Second)
+	case Millisecond:
+		return t.UnixNano() / int64(time.Millisecond)
+	case Microsecond:
+		return t.UnixNano() / int64(time.Microsecond)
+	case Nanosecond:
+		return t.UnixNano()
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
 }
 func NewMultiResultEncoder() *MultiResultEncoder {
 	return new(MultiResultEncoder)
@@ -106,6 +106,126 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 	}
 }
 
+func TestMultiResultEncoder_Encode_TimeFormat(t *testing.T) {
+	in := flux.NewSliceResultIterator(
+		[]flux.Result{&executetest.Result{
+			Nm: "0",
+			Tbls: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{ts("2018-05-24T09:00:00Z"), "m0", float64(2)},
+				},
+			}},
+		}},
+	)
+
+	var buf bytes.Buffer
+	enc := &influxql.MultiResultEncoder{TimeFormat: influxql.Second}
+	if _, err := enc.Encode(&buf, in); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exp := `{"results":[{"statement_id":0,"series":[{"name":"m0","columns":["time","value"],"values":[[1527152400,2]]}]}]}` + "\n"
+	if got := buf.String(); got != exp {
+		t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+	}
+}
+
+func TestCSVMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   flux.ResultIterator
+		out  string
+	}{
+		{
+			name: "Default",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{
+					&executetest.Result{
+						Nm: "0",
+						Tbls: []*executetest.Table{
+							{
+								KeyCols: []string{"_measurement", "host"},
+								ColMeta: []flux.ColMeta{
+									{Label: "_time", Type: flux.TTime},
+									{Label: "_measurement", Type: flux.TString},
+									{Label: "host", Type: flux.TString},
+									{Label: "value", Type: flux.TFloat},
+								},
+								Data: [][]interface{}{
+									{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+									{ts("2018-05-24T09:00:10Z"), "m0", "server01", float64(3)},
+								},
+							},
+							{
+								KeyCols: []string{"_measurement", "host"},
+								ColMeta: []flux.ColMeta{
+									{Label: "_time", Type: flux.TTime},
+									{Label: "_measurement", Type: flux.TString},
+									{Label: "host", Type: flux.TString},
+									{Label: "value", Type: flux.TFloat},
+								},
+								Data: [][]interface{}{
+									{ts("2018-05-24T09:00:00Z"), "m0", "server02", float64(4)},
+								},
+							},
+						},
+					},
+					&executetest.Result{
+						Nm: "1",
+						Tbls: []*executetest.Table{{
+							KeyCols: []string{},
+							ColMeta: []flux.ColMeta{
+								{Label: "name", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{"telegraf"},
+							},
+						}},
+					},
+				},
+			),
+			out: `name,tags,time,value
+m0,host=server01,1527152400000000000,2
+m0,host=server01,1527152410000000000,3
+m0,host=server02,1527152400000000000,4
+
+name,tags,name
+,,telegraf
+`,
+		},
+		{
+			name: "Error",
+			in:   &resultErrorIterator{Error: "expected"},
+			out: `error
+expected
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := &influxql.CSVMultiResultEncoder{}
+			n, err := enc.Encode(&buf, tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+			}
+		})
+	}
+}
+
 type resultErrorIterator struct {
 	Error string
 }
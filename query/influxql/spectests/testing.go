@@ -39,7 +39,7 @@ func init() {
 		OrganizationID:  organizationID,
 		BucketID:        altBucketID,
 	}
-	dbrpMappingSvc.FindByFn = func(ctx context.Context, cluster string, db string, rp string) (*platform.DBRPMapping, error) {
+	dbrpMappingSvc.FindByFn = func(ctx context.Context, orgID platform.ID, cluster string, db string, rp string) (*platform.DBRPMapping, error) {
 		if rp == "alternate" {
 			return &altMapping, nil
 		}
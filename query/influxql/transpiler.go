@@ -346,6 +346,9 @@ func (t *transpilerState) from(m *influxql.Measurement) (flux.OperationID, error
 	}
 
 	var filter platform.DBRPMappingFilter
+	if t.config.OrganizationID.Valid() {
+		filter.OrganizationID = &t.config.OrganizationID
+	}
 	filter.Cluster = &t.config.Cluster
 	if db != "" {
 		filter.Database = &db
@@ -2,6 +2,12 @@ package platform
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"time"
 )
 
 // ErrScraperTargetNotFound is the error msg for a missing scraper target.
@@ -14,6 +20,9 @@ const (
 	OpGetTargetByID = "GetTargetByID"
 	OpRemoveTarget  = "RemoveTarget"
 	OpUpdateTarget  = "UpdateTarget"
+
+	OpGetTargetHealth = "GetTargetHealth"
+	OpPutTargetHealth = "PutTargetHealth"
 )
 
 // ScraperTarget is a target to scrape
@@ -24,6 +33,141 @@ type ScraperTarget struct {
 	URL        string      `json:"url"`
 	OrgName    string      `json:"org"`
 	BucketName string      `json:"bucket"`
+
+	// Interval is the time between two scrapes of the target.
+	// The scheduler's default interval is used when it is zero.
+	Interval time.Duration `json:"interval,omitempty"`
+	// Timeout is the maximum duration of a single scrape.
+	// The scheduler's default timeout is used when it is zero.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	Auth *ScraperAuth      `json:"auth,omitempty"`
+	TLS  *ScraperTLSConfig `json:"tls,omitempty"`
+
+	// RelabelRules are applied in order to the gathered metrics before they are written.
+	RelabelRules []RelabelRule `json:"relabelRules,omitempty"`
+
+	// JSONMapping is required by targets of type JSONScraperType.
+	JSONMapping *ScraperJSONMapping `json:"jsonMapping,omitempty"`
+}
+
+// Valid returns an error if the schedule, authentication or relabel rules of the target are invalid.
+func (t *ScraperTarget) Valid() error {
+	if t.Interval < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target interval must not be negative",
+		}
+	}
+	if t.Timeout < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target timeout must not be negative",
+		}
+	}
+	if t.Interval > 0 && t.Timeout > t.Interval {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper target timeout must not be greater than its interval",
+		}
+	}
+	if t.Auth != nil {
+		if err := t.Auth.Valid(); err != nil {
+			return err
+		}
+	}
+	if t.TLS != nil {
+		if err := t.TLS.Valid(); err != nil {
+			return err
+		}
+	}
+	if t.Type == JSONScraperType {
+		if t.JSONMapping == nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "json scraper target requires a json mapping",
+			}
+		}
+		if err := t.JSONMapping.Valid(); err != nil {
+			return err
+		}
+	}
+	for i, r := range t.RelabelRules {
+		if err := r.Valid(); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid relabel rule %d: %s", i, ErrorMessage(err)),
+			}
+		}
+	}
+	return nil
+}
+
+// scraperTarget has the fields of ScraperTarget, without its JSON methods.
+type scraperTarget ScraperTarget
+
+// scraperTargetJSON is the JSON encoding of a ScraperTarget,
+// whose interval and timeout are duration strings like "30s".
+type scraperTargetJSON struct {
+	*scraperTarget
+	Interval scraperDuration `json:"interval,omitempty"`
+	Timeout  scraperDuration `json:"timeout,omitempty"`
+}
+
+// MarshalJSON encodes the target, with its interval and timeout as duration strings.
+func (t ScraperTarget) MarshalJSON() ([]byte, error) {
+	st := scraperTarget(t)
+	return json.Marshal(scraperTargetJSON{
+		scraperTarget: &st,
+		Interval:      scraperDuration(t.Interval),
+		Timeout:       scraperDuration(t.Timeout),
+	})
+}
+
+// UnmarshalJSON decodes the target, whose interval and timeout are duration strings.
+func (t *ScraperTarget) UnmarshalJSON(b []byte) error {
+	v := scraperTargetJSON{scraperTarget: (*scraperTarget)(t)}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	t.Interval = time.Duration(v.Interval)
+	t.Timeout = time.Duration(v.Timeout)
+	return nil
+}
+
+// scraperDuration is a duration encoded as a string in JSON.
+type scraperDuration time.Duration
+
+func (d scraperDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string. Integers are decoded as nanoseconds,
+// which is how the durations of targets were stored before.
+func (d *scraperDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var ns int64
+		if err := json.Unmarshal(b, &ns); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid scraper target duration: %s", b),
+			}
+		}
+		*d = scraperDuration(ns)
+		return nil
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid scraper target duration: %q", s),
+			Err:  err,
+		}
+	}
+	*d = scraperDuration(v)
+	return nil
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -35,6 +179,27 @@ type ScraperTargetStoreService interface {
 	UpdateTarget(ctx context.Context, t *ScraperTarget) (*ScraperTarget, error)
 }
 
+// ScraperTargetHealth is the result of the latest scrape of a target.
+type ScraperTargetHealth struct {
+	// Up is true if the latest scrape succeeded.
+	Up                 bool          `json:"up"`
+	LastScrape         time.Time     `json:"lastScrape"`
+	LastScrapeDuration time.Duration `json:"lastScrapeDuration"`
+	// SampleCount is the number of field values gathered by the latest scrape.
+	SampleCount int    `json:"sampleCount"`
+	LastError   string `json:"lastError,omitempty"`
+}
+
+// ScraperTargetHealthService stores the health of scraper targets.
+type ScraperTargetHealthService interface {
+	// GetTargetHealth returns the health of the scraper target with the given id.
+	// An ENotFound error is returned if the target has not been scraped yet.
+	GetTargetHealth(ctx context.Context, id ID) (*ScraperTargetHealth, error)
+
+	// PutTargetHealth replaces the health of the scraper target with the given id.
+	PutTargetHealth(ctx context.Context, id ID, h *ScraperTargetHealth) error
+}
+
 // ScraperTargetFilter represents a set of filter that restrict the returned results.
 type ScraperTargetFilter struct {
 	ID   *ID     `json:"id"`
@@ -48,12 +213,246 @@ type ScraperType string
 const (
 	// PrometheusScraperType parses metrics from a prometheus endpoint.
 	PrometheusScraperType = "prometheus"
+	// OpenMetricsScraperType parses metrics from an OpenMetrics text endpoint.
+	OpenMetricsScraperType = "openmetrics"
+	// LineProtocolScraperType parses points from an endpoint serving line protocol.
+	LineProtocolScraperType = "lineprotocol"
+	// JSONScraperType maps the values of a JSON document to metrics,
+	// as configured by the JSONMapping of the target.
+	JSONScraperType = "json"
+)
+
+// ScraperAuthType defines how a scraper authenticates to its target.
+type ScraperAuthType string
+
+// Scraper authentication types
+const (
+	// BearerScraperAuth sends the secret as a bearer token.
+	BearerScraperAuth ScraperAuthType = "bearer"
+	// BasicScraperAuth sends the username and the secret as password using basic authentication.
+	BasicScraperAuth ScraperAuthType = "basic"
+)
+
+// ScraperAuth is the authentication used to scrape a target.
+// The credential is not stored with the target, it is loaded from
+// the secrets of the target's organization when scraping.
+type ScraperAuth struct {
+	Type     ScraperAuthType `json:"type"`
+	Username string          `json:"username,omitempty"`
+	// SecretKey is the key of the organization secret holding the bearer token or password.
+	SecretKey string `json:"secretKey"`
+}
+
+// Valid returns an error if the authentication is incomplete.
+func (a *ScraperAuth) Valid() error {
+	switch a.Type {
+	case BearerScraperAuth:
+	case BasicScraperAuth:
+		if a.Username == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "basic scraper auth requires a username",
+			}
+		}
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unsupported scraper auth type: %q", a.Type),
+		}
+	}
+	if a.SecretKey == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper auth requires a secret key",
+		}
+	}
+	return nil
+}
+
+// ScraperTLSConfig configures the TLS connection to a scraper target.
+// Like the credentials of ScraperAuth, the private key of the client
+// certificate is loaded from the secrets of the target's organization.
+type ScraperTLSConfig struct {
+	// CA holds the PEM encoded certificates of the authorities verifying the target.
+	// The system roots are used when empty.
+	CA string `json:"ca,omitempty"`
+	// Cert is the PEM encoded client certificate.
+	Cert string `json:"cert,omitempty"`
+	// KeySecretKey is the key of the organization secret holding the PEM encoded private key of Cert.
+	KeySecretKey       string `json:"keySecretKey,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// Valid returns an error if the certificates can not be parsed,
+// or if the client certificate lacks its private key.
+func (c *ScraperTLSConfig) Valid() error {
+	if c.CA != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(c.CA)) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper tls ca contains no PEM encoded certificate",
+		}
+	}
+	if (c.Cert == "") != (c.KeySecretKey == "") {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper tls client certificate requires both a cert and a key secret key",
+		}
+	}
+	if c.Cert != "" {
+		if b, _ := pem.Decode([]byte(c.Cert)); b == nil || b.Type != "CERTIFICATE" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "scraper tls cert is not a PEM encoded certificate",
+			}
+		}
+	}
+	return nil
+}
+
+// ScraperJSONMapping maps the values of a JSON document to metrics.
+// Paths are dot separated object keys or array indexes, like "data.hosts.0".
+type ScraperJSONMapping struct {
+	// Name is the name of the metrics.
+	Name string `json:"name"`
+	// Path selects the object, or array of objects, holding the values of the metrics.
+	// The document root is selected when empty.
+	Path string `json:"path,omitempty"`
+	// Tags maps tag keys to the path of their value in the selected objects.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Fields maps field keys to the path of their value in the selected objects.
+	Fields map[string]string `json:"fields"`
+	// TimePath is the path of the timestamp in the selected objects.
+	// The time of the scrape is used when empty.
+	TimePath string `json:"timePath,omitempty"`
+	// TimeFormat is the format of the timestamp, which is one of
+	// rfc3339, unix, unix_ms, unix_us or unix_ns. It defaults to rfc3339.
+	TimeFormat string `json:"timeFormat,omitempty"`
+}
+
+// Valid returns an error if the mapping can not produce metrics.
+func (m *ScraperJSONMapping) Valid() error {
+	if m.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "json mapping requires a name",
+		}
+	}
+	if len(m.Fields) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "json mapping requires at least one field",
+		}
+	}
+	switch m.TimeFormat {
+	case "", "rfc3339", "unix", "unix_ms", "unix_us", "unix_ns":
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unsupported json mapping time format: %q", m.TimeFormat),
+		}
+	}
+	return nil
+}
+
+// RelabelAction is the action performed by a relabel rule.
+type RelabelAction string
+
+// Relabel actions, following the semantics of prometheus' metric relabeling.
+const (
+	// RelabelReplace sets the target label to the expanded replacement if the regex matches.
+	// Using MetricNameLabel as target label renames the metric.
+	RelabelReplace RelabelAction = "replace"
+	// RelabelKeep drops metrics for which the regex does not match.
+	RelabelKeep RelabelAction = "keep"
+	// RelabelDrop drops metrics for which the regex matches.
+	RelabelDrop RelabelAction = "drop"
+	// RelabelLabelMap copies the values of the labels matching the regex
+	// to the labels named by the expanded replacement.
+	RelabelLabelMap RelabelAction = "labelmap"
+	// RelabelLabelDrop removes the labels matching the regex.
+	RelabelLabelDrop RelabelAction = "labeldrop"
+	// RelabelLabelKeep removes the labels not matching the regex.
+	RelabelLabelKeep RelabelAction = "labelkeep"
+)
+
+// MetricNameLabel is the label holding the metric name while relabeling.
+const MetricNameLabel = "__name__"
+
+// Relabel rule defaults
+const (
+	DefaultRelabelSeparator   = ";"
+	DefaultRelabelRegex       = "(.*)"
+	DefaultRelabelReplacement = "$1"
 )
 
+// RelabelRule rewrites or filters gathered metrics based on their labels.
+// The values of the source labels are joined with the separator and matched
+// against the regex, which is anchored at both ends.
+type RelabelRule struct {
+	SourceLabels []string      `json:"sourceLabels,omitempty"`
+	Separator    string        `json:"separator,omitempty"`
+	Regex        string        `json:"regex,omitempty"`
+	TargetLabel  string        `json:"targetLabel,omitempty"`
+	Replacement  string        `json:"replacement,omitempty"`
+	Action       RelabelAction `json:"action,omitempty"`
+}
+
+// EffectiveAction returns the action of the rule, defaulting to RelabelReplace.
+func (r RelabelRule) EffectiveAction() RelabelAction {
+	if r.Action == "" {
+		return RelabelReplace
+	}
+	return r.Action
+}
+
+// CompileRegex compiles the anchored regex of the rule.
+func (r RelabelRule) CompileRegex() (*regexp.Regexp, error) {
+	re := r.Regex
+	if re == "" {
+		re = DefaultRelabelRegex
+	}
+	return regexp.Compile("^(?:" + re + ")$")
+}
+
+// Valid returns an error if the rule can not be applied.
+func (r RelabelRule) Valid() error {
+	if _, err := r.CompileRegex(); err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid relabel regex: %q", r.Regex),
+			Err:  err,
+		}
+	}
+	switch r.EffectiveAction() {
+	case RelabelReplace:
+		if r.TargetLabel == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "relabel action replace requires a target label",
+			}
+		}
+	case RelabelKeep, RelabelDrop:
+		if len(r.SourceLabels) == 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("relabel action %s requires source labels", r.Action),
+			}
+		}
+	case RelabelLabelMap, RelabelLabelDrop, RelabelLabelKeep:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unsupported relabel action: %q", r.Action),
+		}
+	}
+	return nil
+}
+
 // ValidScraperType returns true is the type string is valid
 func ValidScraperType(s string) bool {
 	switch s {
-	case PrometheusScraperType:
+	case PrometheusScraperType, OpenMetricsScraperType, LineProtocolScraperType, JSONScraperType:
 		return true
 	default:
 		return false
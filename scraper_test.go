@@ -0,0 +1,124 @@
+package platform_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+func TestScraperTarget_MarshalJSON(t *testing.T) {
+	target := platform.ScraperTarget{
+		Name:     "name1",
+		Type:     platform.PrometheusScraperType,
+		URL:      "url1",
+		Interval: 30 * time.Second,
+		Timeout:  1500 * time.Millisecond,
+	}
+
+	b, err := json.Marshal(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["interval"] != "30s" || m["timeout"] != "1.5s" {
+		t.Fatalf("expected duration strings, got %s", b)
+	}
+
+	var got platform.ScraperTarget
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, target) {
+		t.Fatalf("unexpected target: got %+v, want %+v", got, target)
+	}
+}
+
+func TestScraperTarget_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    platform.ScraperTarget
+		wantErr bool
+	}{
+		{
+			name: "duration strings",
+			json: `{"name":"name1","interval":"1m","timeout":"10s"}`,
+			want: platform.ScraperTarget{Name: "name1", Interval: time.Minute, Timeout: 10 * time.Second},
+		},
+		{
+			name: "nanoseconds",
+			json: `{"name":"name1","interval":60000000000,"timeout":10000000000}`,
+			want: platform.ScraperTarget{Name: "name1", Interval: time.Minute, Timeout: 10 * time.Second},
+		},
+		{
+			name: "no durations",
+			json: `{"name":"name1"}`,
+			want: platform.ScraperTarget{Name: "name1"},
+		},
+		{
+			name:    "invalid duration",
+			json:    `{"name":"name1","interval":"often"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got platform.ScraperTarget
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("unexpected target: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperTLSConfig_Valid(t *testing.T) {
+	tests := []struct {
+		name    string
+		tls     platform.ScraperTLSConfig
+		wantErr bool
+	}{
+		{
+			name: "skip verify",
+			tls:  platform.ScraperTLSConfig{InsecureSkipVerify: true},
+		},
+		{
+			name:    "ca is not PEM",
+			tls:     platform.ScraperTLSConfig{CA: "/etc/ssl/ca.pem"},
+			wantErr: true,
+		},
+		{
+			name:    "cert is not PEM",
+			tls:     platform.ScraperTLSConfig{Cert: "/etc/ssl/cert.pem", KeySecretKey: "key"},
+			wantErr: true,
+		},
+		{
+			name:    "cert without key",
+			tls:     platform.ScraperTLSConfig{Cert: "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"},
+			wantErr: true,
+		},
+		{
+			name: "cert with key",
+			tls:  platform.ScraperTLSConfig{Cert: "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n", KeySecretKey: "key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tls.Valid(); (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tt.tls.Valid(); err != nil && platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
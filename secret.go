@@ -2,6 +2,14 @@ package platform
 
 import "context"
 
+// ops for secrets.
+const (
+	OpPutSecret    = "PutSecret"
+	OpPutSecrets   = "PutSecrets"
+	OpPatchSecrets = "PatchSecrets"
+	OpDeleteSecret = "DeleteSecret"
+)
+
 // SecretService a service for storing and retrieving secrets.
 type SecretService interface {
 	// LoadSecret retrieves the secret value v found at key k for organization orgID.
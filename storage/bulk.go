@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// bulkLoader writes points to new TSM files of an engine, bypassing its WAL
+// and cache, so that historical data can be imported without competing with
+// live writes for the cache.
+type bulkLoader struct {
+	e      *Engine
+	loader *tsm1.BulkLoader
+}
+
+// NewBulkLoader returns a BulkLoader that writes points into new TSM files of
+// the engine. The series of the points are added to the index as they are
+// written, but their values are only readable once the load is committed.
+func (e *Engine) NewBulkLoader() (BulkLoader, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+	return &bulkLoader{e: e, loader: e.engine.NewBulkLoader()}, nil
+}
+
+// WritePoints validates points and adds their series to the index like
+// Engine.WritePoints does, and then writes them to the load. The series that
+// are new are recorded, so that Abort can remove them.
+func (l *bulkLoader) WritePoints(points []models.Point) error {
+	return l.e.writePoints(points, l.loader.WritePoints, l.loader.AddNewSeries)
+}
+
+// Commit makes the points of the load readable.
+func (l *bulkLoader) Commit() error {
+	l.e.mu.RLock()
+	defer l.e.mu.RUnlock()
+	if l.e.closing == nil {
+		return ErrEngineClosed
+	}
+	return l.loader.Commit()
+}
+
+// Abort removes the files of the load, and the series it added to the index
+// and series file that have no values.
+func (l *bulkLoader) Abort() error {
+	l.e.mu.RLock()
+	defer l.e.mu.RUnlock()
+	if l.e.closing == nil {
+		return ErrEngineClosed
+	}
+	// The series that the load drops are no longer counted.
+	defer l.e.seriesCounts.reset()
+	return l.loader.Abort()
+}
@@ -13,6 +13,7 @@ const (
 	DefaultRetentionInterval   = 1 * time.Hour
 	DefaultValidateKeys        = false
 	DefaultTraceLoggingEnabled = false
+	DefaultMaxSeriesPerBucket  = 0
 
 	DefaultSeriesFileDirectoryName = "_series"
 	DefaultIndexDirectoryName      = "index"
@@ -31,6 +32,10 @@ type Config struct {
 	// Enables trace logging for the engine.
 	TraceLoggingEnabled bool `toml:"trace-logging-enabled"`
 
+	// Maximum number of series in a bucket. Writes that would create series
+	// above the limit are dropped. Zero disables the limit.
+	MaxSeriesPerBucket int `toml:"max-series-per-bucket"`
+
 	// Series file config.
 	SeriesFilePath string `toml:"series-file-path"` // Overrides the default path.
 
@@ -53,6 +58,7 @@ func NewConfig() Config {
 		RetentionInterval:   toml.Duration(DefaultRetentionInterval),
 		ValidateKeys:        DefaultValidateKeys,
 		TraceLoggingEnabled: DefaultTraceLoggingEnabled,
+		MaxSeriesPerBucket:  DefaultMaxSeriesPerBucket,
 
 		WAL:    tsm1.NewWALConfig(),
 		Engine: tsm1.NewConfig(),
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// BucketRangeDeleter defines the behaviour of deleting a time range of data
+// from a bucket.
+type BucketRangeDeleter interface {
+	// DeleteBucketRange deletes all data in the bucket with timestamps between
+	// min and max inclusive, for series matching pred. A nil pred matches all
+	// series in the bucket.
+	DeleteBucketRange(orgID, bucketID platform.ID, min, max int64, pred influxql.Expr) error
+}
+
+// ParseDeletePredicate parses s into an expression that can be used to
+// select the series data is deleted from.
+//
+// Predicates are InfluxQL boolean expressions made up of comparisons between
+// tag keys and string literals with = and !=, or regular expression literals
+// with =~ and !~, such as
+//
+//	_measurement = 'cpu' AND (host = 'a' OR host =~ /^b/)
+//
+// The measurement and field of a series may be referred to as _measurement and
+// _field respectively. An empty string is parsed as a nil expression.
+func ParseDeletePredicate(s string) (influxql.Expr, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	expr, err := influxql.ParseExpr(s)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid delete predicate syntax",
+			Err:  err,
+		}
+	}
+
+	if err := validateDeletePredicate(expr); err != nil {
+		return nil, err
+	}
+
+	return influxql.RewriteExpr(expr, func(e influxql.Expr) influxql.Expr {
+		if ref, ok := e.(*influxql.VarRef); ok {
+			switch ref.Val {
+			case "_measurement":
+				return &influxql.VarRef{Val: tsdb.MeasurementTagKey}
+			case "_field":
+				return &influxql.VarRef{Val: tsdb.FieldKeyTagKey}
+			}
+		}
+		return e
+	}), nil
+}
+
+// validateDeletePredicate returns an EInvalid error if expr is not a valid
+// delete predicate.
+func validateDeletePredicate(expr influxql.Expr) error {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		return validateDeletePredicate(e.Expr)
+	case *influxql.BinaryExpr:
+		switch e.Op {
+		case influxql.AND, influxql.OR:
+			if err := validateDeletePredicate(e.LHS); err != nil {
+				return err
+			}
+			return validateDeletePredicate(e.RHS)
+		case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
+		default:
+			return invalidDeletePredicate("invalid tag comparison operator %s", e.Op)
+		}
+
+		ref, ok := e.LHS.(*influxql.VarRef)
+		if !ok {
+			return invalidDeletePredicate("invalid tag comparison %s: left hand side must be a tag key", e)
+		}
+		switch ref.Val {
+		case "time", "_time", "_value":
+			return invalidDeletePredicate("invalid tag comparison %s: %s cannot be used in a delete predicate", e, ref.Val)
+		}
+
+		// Equality compares to a string and regex matching to a regular
+		// expression, so that a string is never taken for a pattern.
+		switch e.Op {
+		case influxql.EQREGEX, influxql.NEQREGEX:
+			if _, ok := e.RHS.(*influxql.RegexLiteral); !ok {
+				return invalidDeletePredicate("invalid tag comparison %s: right hand side must be a regular expression", e)
+			}
+		default:
+			if _, ok := e.RHS.(*influxql.StringLiteral); !ok {
+				return invalidDeletePredicate("invalid tag comparison %s: right hand side must be a string", e)
+			}
+		}
+		return nil
+	default:
+		return invalidDeletePredicate("invalid delete predicate %s", expr)
+	}
+}
+
+func invalidDeletePredicate(format string, args ...interface{}) error {
+	return &platform.Error{
+		Code: platform.EInvalid,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestParseDeletePredicate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		exp  string
+		err  bool
+	}{
+		{name: "empty"},
+		{
+			name: "tag",
+			s:    "host = 'a'",
+			exp:  "host = 'a'",
+		},
+		{
+			name: "measurement and field",
+			s:    "_measurement = 'cpu' AND (_field != 'usage' OR host =~ /^a/)",
+			exp:  tsdb.MeasurementTagKey + " = 'cpu' AND (" + tsdb.FieldKeyTagKey + " != 'usage' OR host =~ /^a/)",
+		},
+		{
+			name: "invalid syntax",
+			s:    "host = ",
+			err:  true,
+		},
+		{
+			name: "invalid operator",
+			s:    "host > 'a'",
+			err:  true,
+		},
+		{
+			name: "time",
+			s:    "time = '2018-01-01T00:00:00Z'",
+			err:  true,
+		},
+		{
+			name: "value",
+			s:    "_value = 'a'",
+			err:  true,
+		},
+		{
+			name: "non-string literal",
+			s:    "host = 1",
+			err:  true,
+		},
+		{
+			name: "string regular expression",
+			s:    "host =~ 'a'",
+			err:  true,
+		},
+		{
+			name: "regular expression equality",
+			s:    "host != /a/",
+			err:  true,
+		},
+		{
+			name: "literal",
+			s:    "true",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseDeletePredicate(tt.s)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("expected error parsing %q", tt.s)
+				} else if platform.ErrorCode(err) != platform.EInvalid {
+					t.Fatalf("got error code %q, expected %q", platform.ErrorCode(err), platform.EInvalid)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.exp == "" {
+				if expr != nil {
+					t.Fatalf("got %s, expected nil", expr)
+				}
+				return
+			}
+			if got := expr.String(); got != tt.exp {
+				t.Fatalf("got %s, expected %s", got, tt.exp)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package storage
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/influxdata/influxql"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/prom"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/tsdb"
@@ -24,8 +24,11 @@ import (
 var timeBytes = []byte("time")
 
 // ErrEngineClosed is returned when a caller attempts to use the engine while
-// it's closed.
-var ErrEngineClosed = errors.New("engine is closed")
+// it's closed. It is unavailable, as the engine may be opened again.
+var ErrEngineClosed = &platform.Error{
+	Code: platform.EUnavailable,
+	Msg:  "engine is closed",
+}
 
 type Engine struct {
 	config   Config
@@ -40,6 +43,7 @@ type Engine struct {
 	engine            *tsm1.Engine
 	wal               *tsm1.WAL
 	retentionEnforcer *retentionEnforcer
+	seriesCounts      seriesCounts
 
 	defaultMetricLabels prometheus.Labels
 
@@ -81,9 +85,14 @@ func WithNodeID(id int) Option {
 // WithRetentionEnforcer initialises a retention enforcer on the engine.
 // WithRetentionEnforcer must be called after other options to ensure that all
 // metrics are labelled correctly.
+//
+// Data for each bucket is partitioned into shard groups whose duration is
+// determined by the bucket's retention period, so that the enforcer can
+// expire data by dropping whole TSM files.
 func WithRetentionEnforcer(finder BucketFinder) Option {
 	return func(e *Engine) {
 		e.retentionEnforcer = newRetentionEnforcer(e, finder)
+		e.engine.WithPartitionFunc(e.retentionEnforcer.shardGroups.partition)
 	}
 }
 
@@ -171,6 +180,9 @@ func (e *Engine) PrometheusCollectors() []prometheus.Collector {
 	metrics = append(metrics, tsi1.PrometheusCollectors()...)
 	metrics = append(metrics, tsm1.PrometheusCollectors()...)
 	metrics = append(metrics, e.retentionEnforcer.PrometheusCollectors()...)
+	if pc, ok := e.engine.CompactionPlan.(prom.PrometheusCollector); ok {
+		metrics = append(metrics, pc.PrometheusCollectors()...)
+	}
 	return metrics
 }
 
@@ -184,6 +196,10 @@ func (e *Engine) Open() error {
 		return nil // Already open
 	}
 
+	if err := e.removeSnapshots(); err != nil {
+		return err
+	}
+
 	if err := e.sfile.Open(); err != nil {
 		return err
 	}
@@ -192,6 +208,13 @@ func (e *Engine) Open() error {
 		return err
 	}
 
+	// Determine bucket shard group durations before any data is written.
+	if e.retentionEnforcer != nil {
+		if err := e.retentionEnforcer.updateShardGroups(); err != nil {
+			e.logger.Error("Unable to determine shard group durations", zap.Error(err))
+		}
+	}
+
 	if err := e.engine.Open(); err != nil {
 		return err
 	}
@@ -302,6 +325,13 @@ func (e *Engine) CreateCursorIterator(ctx context.Context) (tsdb.CursorIterator,
 // WritePoints will however determine if there are any field type conflicts, and
 // return an appropriate error in that case.
 func (e *Engine) WritePoints(points []models.Point) error {
+	return e.writePoints(points, e.engine.WritePoints, nil)
+}
+
+// writePoints validates points and adds their series to the index, and then
+// writes them with write. If created is not nil, it is called with the keys of
+// the series that the points added to the series file.
+func (e *Engine) writePoints(points []models.Point, write func([]models.Point) error, created func([][]byte)) error {
 	collection := tsdb.NewSeriesCollection(points)
 
 	j := 0
@@ -350,8 +380,36 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		return ErrEngineClosed
 	}
 
+	// Drop any new series that would exceed their bucket's series limit.
+	var (
+		limited uint64
+		r       *seriesReservation
+	)
+	if e.config.MaxSeriesPerBucket > 0 {
+		r, limited = e.limitSeriesPerBucket(collection)
+	}
+
+	// Find the series that are not in the series file yet, before they are
+	// added to it.
+	var newKeys [][]byte
+	if created != nil {
+		var buf []byte
+		for iter := collection.Iterator(); iter.Next(); {
+			if e.sfile.SeriesID(iter.Name(), iter.Tags(), buf).IsZero() {
+				newKeys = append(newKeys, iter.Key())
+			}
+		}
+	}
+
 	// Add new series to the index and series file. Check for partial writes.
-	if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+	err := e.index.CreateSeriesListIfNotExists(collection)
+	if r != nil {
+		r.release(e)
+	}
+	if len(newKeys) > 0 {
+		created(newKeys)
+	}
+	if err != nil {
 		// ignore PartialWriteErrors. The collection captures it.
 		// TODO(edd/jeff): should we just remove PartialWriteError from the index then?
 		if _, ok := err.(tsdb.PartialWriteError); !ok {
@@ -359,11 +417,21 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		}
 	}
 
-	// Write the points to the cache and WAL.
-	if err := e.engine.WritePoints(collection.Points); err != nil {
+	// Write the points to the cache and WAL, or the bulk load.
+	if err := write(collection.Points); err != nil {
 		return err
 	}
-	return collection.PartialWriteError()
+
+	err = collection.PartialWriteError()
+	if err != nil && limited > 0 {
+		return &platform.Error{
+			Code: platform.ETooManyRequests,
+			Op:   "storage/WritePoints",
+			Msg:  fmt.Sprintf("max series per bucket exceeded: dropped %d points", collection.Dropped),
+			Err:  err,
+		}
+	}
+	return err
 }
 
 // DeleteBucket deletes an entire bucket from the storage engine.
@@ -373,6 +441,8 @@ func (e *Engine) DeleteBucket(orgID, bucketID platform.ID) error {
 	if e.closing == nil {
 		return ErrEngineClosed
 	}
+	// The series that the deletion drops are no longer counted.
+	defer e.seriesCounts.reset()
 
 	// TODO(edd): we need to clean up how we're encoding the prefix so that we
 	// don't have to remember to get it right everywhere we need to touch TSM data.
@@ -382,6 +452,56 @@ func (e *Engine) DeleteBucket(orgID, bucketID platform.ID) error {
 	return e.engine.DeletePrefix(prefix, math.MinInt64, math.MaxInt64)
 }
 
+// DeleteBucketRange deletes all data in the bucket with timestamps between min
+// and max inclusive, for all series matching pred. If pred is nil, the range
+// is deleted from every series in the bucket.
+func (e *Engine) DeleteBucketRange(orgID, bucketID platform.ID, min, max int64, pred influxql.Expr) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+	// The series that the deletion drops are no longer counted.
+	defer e.seriesCounts.reset()
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	if pred == nil {
+		return e.engine.DeletePrefix(models.EscapeMeasurement(encoded[:]), min, max)
+	}
+
+	req := SeriesCursorRequest{Measurements: tsdb.NewMeasurementSliceIterator([][]byte{encoded[:]})}
+	cur, err := newSeriesCursor(req, e.index, pred)
+	if err != nil {
+		return err
+	}
+	defer cur.Close()
+
+	return e.engine.DeleteSeriesRangeWithPredicate(newSeriesIteratorAdapter(cur), func([]byte, models.Tags) (int64, int64, bool) {
+		return min, max, true
+	})
+}
+
+// DropShardGroups removes the TSM files of all shard groups for the bucket
+// that end before the provided timestamp. It returns the number of files
+// removed and their total size in bytes.
+//
+// Only files containing data exclusively for the bucket are removed; any
+// remaining data must be removed via DeleteSeriesRangeWithPredicate.
+func (e *Engine) DropShardGroups(orgID, bucketID platform.ID, before int64) (int, int64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0, 0, ErrEngineClosed
+	}
+	// The series that the deletion drops are no longer counted.
+	defer e.seriesCounts.reset()
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	prefix := models.EscapeMeasurement(encoded[:])
+
+	return e.engine.DropPrefixFiles(prefix, before)
+}
+
 // DeleteSeriesRangeWithPredicate deletes all series data iterated over if fn returns
 // true for that series.
 func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn func([]byte, models.Tags) (int64, int64, bool)) error {
@@ -390,6 +510,8 @@ func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn func
 	if e.closing == nil {
 		return ErrEngineClosed
 	}
+	// The series that the deletion drops are no longer counted.
+	defer e.seriesCounts.reset()
 	return e.engine.DeleteSeriesRangeWithPredicate(itr, fn)
 }
 
@@ -424,6 +546,26 @@ func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats
 	return e.index.MeasurementCardinalityStats()
 }
 
+// ForEachBucketSeriesCardinality calls fn with the number of series in each
+// bucket stored in the engine.
+func (e *Engine) ForEachBucketSeriesCardinality(fn func(orgID, bucketID platform.ID, n int64)) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return
+	}
+
+	for name, n := range e.index.MeasurementCardinalityStats() {
+		var encoded [16]byte
+		if len(name) != len(encoded) {
+			continue
+		}
+		copy(encoded[:], name)
+		orgID, bucketID := tsdb.DecodeName(encoded)
+		fn(orgID, bucketID, int64(n))
+	}
+}
+
 // MeasurementStats returns the current measurement stats for the engine.
 func (e *Engine) MeasurementStats() (tsm1.MeasurementStats, error) {
 	return e.engine.MeasurementStats()
@@ -2,7 +2,9 @@ package storage_test
 
 import (
 	"io/ioutil"
+	"math"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -181,6 +183,58 @@ func TestEngineClose_RemoveIndex(t *testing.T) {
 	}
 }
 
+func TestEngine_DeleteBucketRange(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	var points []models.Point
+	for _, host := range []string{"A", "B"} {
+		for _, ts := range []int64{1, 2} {
+			points = append(points, models.MustNewPoint(
+				"cpu",
+				models.NewTags(map[string]string{"host": host}),
+				map[string]interface{}{"value": 1.0},
+				time.Unix(ts, 0),
+			))
+		}
+	}
+
+	if err := engine.Write1xPoints(points); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	org, _ := platform.IDFromString("3131313131313131")
+	bucket, _ := platform.IDFromString("3232323232323232")
+
+	pred, err := storage.ParseDeletePredicate("_measurement = 'cpu' AND host = 'A'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting all of the data for a series removes it from the index.
+	if err := engine.DeleteBucketRange(*org, *bucket, math.MinInt64, math.MaxInt64, pred); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	// Deleting some of the data for every series leaves them in the index.
+	if err := engine.DeleteBucketRange(*org, *bucket, 0, time.Unix(1, 0).UnixNano(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+}
+
 func TestEngine_WALDisabled(t *testing.T) {
 	config := storage.NewConfig()
 	config.WAL.Enabled = false
@@ -201,6 +255,133 @@ func TestEngine_WALDisabled(t *testing.T) {
 	}
 }
 
+func TestEngine_MaxSeriesPerBucket(t *testing.T) {
+	config := storage.NewConfig()
+	config.MaxSeriesPerBucket = 2
+
+	engine := NewEngine(config)
+	defer engine.Close()
+	engine.MustOpen()
+
+	point := func(host string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	if err := engine.Write1xPoints([]models.Point{point("a"), point("b")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes to existing series are accepted, while new series are dropped.
+	err := engine.Write1xPoints([]models.Point{point("a"), point("c"), point("d")})
+	if got, exp := platform.ErrorCode(err), platform.ETooManyRequests; got != exp {
+		t.Fatalf("got error code %q, expected %q: %v", got, exp, err)
+	}
+	if pwe, ok := err.(*platform.Error).Err.(tsdb.PartialWriteError); !ok || pwe.Dropped != 2 {
+		t.Fatalf("got %#v, expected partial write dropping 2 series", err.(*platform.Error).Err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %v series, exp %v series in index", got, exp)
+	}
+}
+
+func TestEngine_BulkLoad(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+
+	if _, err := engine.NewBulkLoader(); err != storage.ErrEngineClosed {
+		t.Fatalf("got %v, expected %v", err, storage.ErrEngineClosed)
+	}
+	engine.MustOpen()
+
+	l, err := engine.NewBulkLoader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt := models.MustNewPoint(
+		"cpu",
+		models.NewTags(map[string]string{"host": "server"}),
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)
+	points, err := tsdb.ExplodePoints(platform.ID(1), platform.ID(2), []models.Point{pt})
+	if err != nil {
+		t.Fatal(err)
+	} else if err := l.WritePoints(points); err != nil {
+		t.Fatal(err)
+	}
+
+	// The series is indexed as it is written, but its values are only in a
+	// TSM file once the load is committed.
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %v series, exp %v series in index", got, exp)
+	}
+	pattern := filepath.Join(engine.path, storage.DefaultEngineDirectoryName, "*.tsm")
+	if files, _ := filepath.Glob(pattern); len(files) != 0 {
+		t.Fatalf("got TSM files %v before commit", files)
+	}
+	if err := l.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := filepath.Glob(pattern); len(files) != 1 {
+		t.Fatalf("got TSM files %v after commit, expected 1", files)
+	}
+}
+
+func TestEngine_BulkLoad_Abort(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	point := func(host string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	// A series that exists before the load.
+	existing, err := tsdb.ExplodePoints(platform.ID(1), platform.ID(2), []models.Point{point("a")})
+	if err != nil {
+		t.Fatal(err)
+	} else if err := engine.WritePoints(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := engine.NewBulkLoader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	points, err := tsdb.ExplodePoints(platform.ID(1), platform.ID(2), []models.Point{point("a"), point("b")})
+	if err != nil {
+		t.Fatal(err)
+	} else if err := l.WritePoints(points); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %v series, exp %v series in index", got, exp)
+	}
+
+	// Only the series that the load added is removed.
+	if err := l.Abort(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %v series, exp %v series in index after abort", got, exp)
+	}
+	pattern := filepath.Join(engine.path, storage.DefaultEngineDirectoryName, "*.tsm*")
+	if files, _ := filepath.Glob(pattern); len(files) != 0 {
+		t.Fatalf("got TSM files %v after abort", files)
+	}
+}
+
 type Engine struct {
 	path string
 	*storage.Engine
@@ -18,6 +18,9 @@ type retentionMetrics struct {
 	CheckDuration *prometheus.HistogramVec
 	Unprocessable *prometheus.CounterVec
 	Series        *prometheus.CounterVec
+
+	DroppedFiles   *prometheus.CounterVec
+	ReclaimedBytes *prometheus.CounterVec
 }
 
 func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
@@ -59,6 +62,20 @@ func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
 			Name:      "series_total",
 			Help:      "Number of series that a delete was applied to.",
 		}, names),
+
+		DroppedFiles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retentionSubsystem,
+			Name:      "dropped_files_total",
+			Help:      "Number of TSM files dropped because their shard group expired.",
+		}, names),
+
+		ReclaimedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retentionSubsystem,
+			Name:      "reclaimed_bytes_total",
+			Help:      "Number of bytes reclaimed by dropping expired shard groups.",
+		}, names),
 	}
 }
 
@@ -78,5 +95,7 @@ func (rm *retentionMetrics) PrometheusCollectors() []prometheus.Collector {
 		rm.CheckDuration,
 		rm.Unprocessable,
 		rm.Series,
+		rm.DroppedFiles,
+		rm.ReclaimedBytes,
 	}
 }
@@ -8,3 +8,18 @@ import (
 type PointsWriter interface {
 	WritePoints([]models.Point) error
 }
+
+// BulkPointsWriter describes the ability to load large amounts of points into
+// a storage engine, without writing them to its WAL and cache.
+type BulkPointsWriter interface {
+	NewBulkLoader() (BulkLoader, error)
+}
+
+// BulkLoader writes the points of a single load. None of the points are
+// readable until Commit is called; Abort discards all of them. A BulkLoader
+// is not safe for concurrent use.
+type BulkLoader interface {
+	PointsWriter
+	Commit() error
+	Abort() error
+}
@@ -132,6 +132,14 @@ func (c *floatMultiShardArrayCursor) Next() *cursors.FloatArray {
 	}
 }
 
+// SkipTo discards the points before t if the cursor of the current shard is
+// a cursors.BlockSkipper.
+func (c *floatMultiShardArrayCursor) SkipTo(t int64) {
+	if cur, ok := c.FloatArrayCursor.(cursors.BlockSkipper); ok {
+		cur.SkipTo(t)
+	}
+}
+
 func (c *floatMultiShardArrayCursor) nextArrayCursor() bool {
 	if len(c.itrs) == 0 {
 		return false
@@ -209,6 +217,356 @@ func (c floatArraySumCursor) Next() *cursors.FloatArray {
 	}
 }
 
+type floatArrayMinCursor struct {
+	cursors.FloatArrayCursor
+	res *cursors.FloatArray
+}
+
+func newFloatArrayMinCursor(cur cursors.FloatArrayCursor) *floatArrayMinCursor {
+	return &floatArrayMinCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(1),
+	}
+}
+
+func (c *floatArrayMinCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayMinCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, acc := a.Timestamps[0], a.Values[0]
+	for {
+		for i, v := range a.Values {
+			if v < acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
+		}
+	}
+}
+
+type floatArrayMaxCursor struct {
+	cursors.FloatArrayCursor
+	res *cursors.FloatArray
+}
+
+func newFloatArrayMaxCursor(cur cursors.FloatArrayCursor) *floatArrayMaxCursor {
+	return &floatArrayMaxCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(1),
+	}
+}
+
+func (c *floatArrayMaxCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayMaxCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, acc := a.Timestamps[0], a.Values[0]
+	for {
+		for i, v := range a.Values {
+			if v > acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
+		}
+	}
+}
+
+type floatFloatMeanArrayCursor struct {
+	cursors.FloatArrayCursor
+}
+
+func (c *floatFloatMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatFloatMeanArrayCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var n int64
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		n += int64(len(a.Values))
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewFloatArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = sum / float64(n)
+			return res
+		}
+	}
+}
+
+// floatArrayFirstCursor selects the point with the earliest timestamp. Blocks are
+// ordered by time, so only the first and last point of each block is compared,
+// which allows the cursor to be used for ascending and descending reads.
+type floatArrayFirstCursor struct {
+	cursors.FloatArrayCursor
+	res *cursors.FloatArray
+}
+
+func newFloatArrayFirstCursor(cur cursors.FloatArrayCursor) *floatArrayFirstCursor {
+	return &floatArrayFirstCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(1),
+	}
+}
+
+func (c *floatArrayFirstCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayFirstCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] < ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] < ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// floatArrayLastCursor selects the point with the latest timestamp.
+type floatArrayLastCursor struct {
+	cursors.FloatArrayCursor
+	res *cursors.FloatArray
+}
+
+func newFloatArrayLastCursor(cur cursors.FloatArrayCursor) *floatArrayLastCursor {
+	return &floatArrayLastCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(1),
+	}
+}
+
+func (c *floatArrayLastCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayLastCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] > ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] > ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// floatWindowArrayCursor splits the points of a cursor into windows. Next
+// returns the points of the current window, and nextWindow advances to the
+// next window containing points.
+//
+// If head is set, Next only returns the first array of each window, and
+// nextWindow skips the rest of the window. The blocks of an ascending cursor
+// which only contain the points of the skipped windows are not decoded.
+type floatWindowArrayCursor struct {
+	cursors.FloatArrayCursor
+	every  int64
+	offset int64
+	head   bool
+	asc    bool
+	stop   int64
+	inWin  bool
+	skip   bool
+	buf    *cursors.FloatArray
+	res    *cursors.FloatArray
+}
+
+func newFloatWindowArrayCursor(cur cursors.FloatArrayCursor, every, offset int64, head, asc bool) *floatWindowArrayCursor {
+	return &floatWindowArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		offset:           offset,
+		head:             head,
+		asc:              asc,
+		buf:              &cursors.FloatArray{},
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatWindowArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowArrayCursor) nextWindow() (int64, bool) {
+	if c.skip {
+		c.skip = false
+		c.skipWindow()
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.FloatArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			c.inWin = false
+			return 0, false
+		}
+	}
+
+	start := windowStart(c.buf.Timestamps[0], c.every, c.offset)
+	c.stop = start + c.every
+	c.inWin = true
+	return start, true
+}
+
+func (c *floatWindowArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps, c.res.Values = nil, nil
+	if !c.inWin {
+		return c.res
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.FloatArrayCursor.Next()
+	}
+
+	n := c.windowLen()
+	if n == 0 {
+		c.inWin = false
+		return c.res
+	}
+
+	c.res.Timestamps, c.res.Values = c.buf.Timestamps[:n], c.buf.Values[:n]
+	c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+
+	if c.head {
+		// res may share the array of the cursor, so the rest of the window is
+		// only skipped by nextWindow.
+		c.inWin = false
+		c.skip = true
+	}
+	return c.res
+}
+
+// windowLen returns the number of points at the start of buf which are in
+// the current window.
+func (c *floatWindowArrayCursor) windowLen() int {
+	start := c.stop - c.every
+	n := 0
+	for n < c.buf.Len() && c.buf.Timestamps[n] >= start && c.buf.Timestamps[n] < c.stop {
+		n++
+	}
+	return n
+}
+
+// skipWindow discards the points of the current window which have not been
+// read.
+func (c *floatWindowArrayCursor) skipWindow() {
+	for {
+		n := c.windowLen()
+		c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+		if c.buf.Len() > 0 {
+			return
+		}
+
+		if cur, ok := c.FloatArrayCursor.(cursors.BlockSkipper); ok && c.asc {
+			cur.SkipTo(c.stop)
+		}
+		c.buf = c.FloatArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			return
+		}
+	}
+}
+
+// floatNopCloseArrayCursor prevents the cursor it wraps from being closed.
+type floatNopCloseArrayCursor struct {
+	cursors.FloatArrayCursor
+}
+
+func (c floatNopCloseArrayCursor) Close() {}
+
+// floatWindowAggregateArrayCursor produces a point for each window of an
+// aggregate cursor reading from a windowArrayCursor.
+type floatWindowAggregateArrayCursor struct {
+	cursors.FloatArrayCursor
+	win        windowArrayCursor
+	windowTime bool
+	res        *cursors.FloatArray
+}
+
+func newFloatWindowAggregateArrayCursor(cur cursors.FloatArrayCursor, win windowArrayCursor, windowTime bool) *floatWindowAggregateArrayCursor {
+	return &floatWindowAggregateArrayCursor{
+		FloatArrayCursor: cur,
+		win:              win,
+		windowTime:       windowTime,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatWindowAggregateArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock {
+		start, ok := c.win.nextWindow()
+		if !ok {
+			break
+		}
+
+		a := c.FloatArrayCursor.Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		ts := a.Timestamps[0]
+		if c.windowTime {
+			ts = start
+		}
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+
+	return c.res
+}
+
 type integerFloatCountArrayCursor struct {
 	cursors.FloatArrayCursor
 }
@@ -361,6 +719,14 @@ func (c *integerMultiShardArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// SkipTo discards the points before t if the cursor of the current shard is
+// a cursors.BlockSkipper.
+func (c *integerMultiShardArrayCursor) SkipTo(t int64) {
+	if cur, ok := c.IntegerArrayCursor.(cursors.BlockSkipper); ok {
+		cur.SkipTo(t)
+	}
+}
+
 func (c *integerMultiShardArrayCursor) nextArrayCursor() bool {
 	if len(c.itrs) == 0 {
 		return false
@@ -438,68 +804,418 @@ func (c integerArraySumCursor) Next() *cursors.IntegerArray {
 	}
 }
 
-type integerIntegerCountArrayCursor struct {
+type integerArrayMinCursor struct {
 	cursors.IntegerArrayCursor
+	res *cursors.IntegerArray
 }
 
-func (c *integerIntegerCountArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
+func newIntegerArrayMinCursor(cur cursors.IntegerArrayCursor) *integerArrayMinCursor {
+	return &integerArrayMinCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(1),
+	}
 }
 
-func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
+func (c *integerArrayMinCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayMinCursor) Next() *cursors.IntegerArray {
 	a := c.IntegerArrayCursor.Next()
 	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
+		return a
 	}
 
-	ts := a.Timestamps[0]
-	var acc int64
+	ts, acc := a.Timestamps[0], a.Values[0]
 	for {
-		acc += int64(len(a.Timestamps))
+		for i, v := range a.Values {
+			if v < acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
 		a = c.IntegerArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
-			res := cursors.NewIntegerArrayLen(1)
-			res.Timestamps[0] = ts
-			res.Values[0] = acc
-			return res
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
 		}
 	}
 }
 
-type integerEmptyArrayCursor struct {
-	res cursors.IntegerArray
+type integerArrayMaxCursor struct {
+	cursors.IntegerArrayCursor
+	res *cursors.IntegerArray
 }
 
-var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
-
-func (c *integerEmptyArrayCursor) Err() error                  { return nil }
-func (c *integerEmptyArrayCursor) Close()                      {}
-func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
-func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+func newIntegerArrayMaxCursor(cur cursors.IntegerArrayCursor) *integerArrayMaxCursor {
+	return &integerArrayMaxCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(1),
+	}
+}
 
-// ********************
-// Unsigned Array Cursor
+func (c *integerArrayMaxCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
 
-type unsignedArrayFilterCursor struct {
-	cursors.UnsignedArrayCursor
-	cond expression
-	m    *singleValue
-	res  *cursors.UnsignedArray
-	tmp  *cursors.UnsignedArray
-}
+func (c *integerArrayMaxCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
 
-func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
-	return &unsignedArrayFilterCursor{
-		cond: cond,
-		m:    &singleValue{},
-		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
-		tmp:  &cursors.UnsignedArray{},
+	ts, acc := a.Timestamps[0], a.Values[0]
+	for {
+		for i, v := range a.Values {
+			if v > acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
+		}
 	}
 }
 
-func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
-	c.UnsignedArrayCursor = cur
-	c.tmp.Timestamps, c.tmp.Values = nil, nil
+type floatIntegerMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+}
+
+func (c *floatIntegerMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *floatIntegerMeanArrayCursor) Next() *cursors.FloatArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var n int64
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		n += int64(len(a.Values))
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewFloatArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = sum / float64(n)
+			return res
+		}
+	}
+}
+
+// integerArrayFirstCursor selects the point with the earliest timestamp. Blocks are
+// ordered by time, so only the first and last point of each block is compared,
+// which allows the cursor to be used for ascending and descending reads.
+type integerArrayFirstCursor struct {
+	cursors.IntegerArrayCursor
+	res *cursors.IntegerArray
+}
+
+func newIntegerArrayFirstCursor(cur cursors.IntegerArrayCursor) *integerArrayFirstCursor {
+	return &integerArrayFirstCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(1),
+	}
+}
+
+func (c *integerArrayFirstCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayFirstCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] < ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] < ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// integerArrayLastCursor selects the point with the latest timestamp.
+type integerArrayLastCursor struct {
+	cursors.IntegerArrayCursor
+	res *cursors.IntegerArray
+}
+
+func newIntegerArrayLastCursor(cur cursors.IntegerArrayCursor) *integerArrayLastCursor {
+	return &integerArrayLastCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(1),
+	}
+}
+
+func (c *integerArrayLastCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayLastCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] > ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] > ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// integerWindowArrayCursor splits the points of a cursor into windows. Next
+// returns the points of the current window, and nextWindow advances to the
+// next window containing points.
+//
+// If head is set, Next only returns the first array of each window, and
+// nextWindow skips the rest of the window. The blocks of an ascending cursor
+// which only contain the points of the skipped windows are not decoded.
+type integerWindowArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every  int64
+	offset int64
+	head   bool
+	asc    bool
+	stop   int64
+	inWin  bool
+	skip   bool
+	buf    *cursors.IntegerArray
+	res    *cursors.IntegerArray
+}
+
+func newIntegerWindowArrayCursor(cur cursors.IntegerArrayCursor, every, offset int64, head, asc bool) *integerWindowArrayCursor {
+	return &integerWindowArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		offset:             offset,
+		head:               head,
+		asc:                asc,
+		buf:                &cursors.IntegerArray{},
+		res:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerWindowArrayCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerWindowArrayCursor) nextWindow() (int64, bool) {
+	if c.skip {
+		c.skip = false
+		c.skipWindow()
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.IntegerArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			c.inWin = false
+			return 0, false
+		}
+	}
+
+	start := windowStart(c.buf.Timestamps[0], c.every, c.offset)
+	c.stop = start + c.every
+	c.inWin = true
+	return start, true
+}
+
+func (c *integerWindowArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps, c.res.Values = nil, nil
+	if !c.inWin {
+		return c.res
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.IntegerArrayCursor.Next()
+	}
+
+	n := c.windowLen()
+	if n == 0 {
+		c.inWin = false
+		return c.res
+	}
+
+	c.res.Timestamps, c.res.Values = c.buf.Timestamps[:n], c.buf.Values[:n]
+	c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+
+	if c.head {
+		// res may share the array of the cursor, so the rest of the window is
+		// only skipped by nextWindow.
+		c.inWin = false
+		c.skip = true
+	}
+	return c.res
+}
+
+// windowLen returns the number of points at the start of buf which are in
+// the current window.
+func (c *integerWindowArrayCursor) windowLen() int {
+	start := c.stop - c.every
+	n := 0
+	for n < c.buf.Len() && c.buf.Timestamps[n] >= start && c.buf.Timestamps[n] < c.stop {
+		n++
+	}
+	return n
+}
+
+// skipWindow discards the points of the current window which have not been
+// read.
+func (c *integerWindowArrayCursor) skipWindow() {
+	for {
+		n := c.windowLen()
+		c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+		if c.buf.Len() > 0 {
+			return
+		}
+
+		if cur, ok := c.IntegerArrayCursor.(cursors.BlockSkipper); ok && c.asc {
+			cur.SkipTo(c.stop)
+		}
+		c.buf = c.IntegerArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			return
+		}
+	}
+}
+
+// integerNopCloseArrayCursor prevents the cursor it wraps from being closed.
+type integerNopCloseArrayCursor struct {
+	cursors.IntegerArrayCursor
+}
+
+func (c integerNopCloseArrayCursor) Close() {}
+
+// integerWindowAggregateArrayCursor produces a point for each window of an
+// aggregate cursor reading from a windowArrayCursor.
+type integerWindowAggregateArrayCursor struct {
+	cursors.IntegerArrayCursor
+	win        windowArrayCursor
+	windowTime bool
+	res        *cursors.IntegerArray
+}
+
+func newIntegerWindowAggregateArrayCursor(cur cursors.IntegerArrayCursor, win windowArrayCursor, windowTime bool) *integerWindowAggregateArrayCursor {
+	return &integerWindowAggregateArrayCursor{
+		IntegerArrayCursor: cur,
+		win:                win,
+		windowTime:         windowTime,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowAggregateArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock {
+		start, ok := c.win.nextWindow()
+		if !ok {
+			break
+		}
+
+		a := c.IntegerArrayCursor.Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		ts := a.Timestamps[0]
+		if c.windowTime {
+			ts = start
+		}
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+
+	return c.res
+}
+
+type integerIntegerCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+}
+
+func (c *integerIntegerCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var acc int64
+	for {
+		acc += int64(len(a.Timestamps))
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewIntegerArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = acc
+			return res
+		}
+	}
+}
+
+type integerEmptyArrayCursor struct {
+	res cursors.IntegerArray
+}
+
+var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
+
+func (c *integerEmptyArrayCursor) Err() error                  { return nil }
+func (c *integerEmptyArrayCursor) Close()                      {}
+func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
+func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+
+// ********************
+// Unsigned Array Cursor
+
+type unsignedArrayFilterCursor struct {
+	cursors.UnsignedArrayCursor
+	cond expression
+	m    *singleValue
+	res  *cursors.UnsignedArray
+	tmp  *cursors.UnsignedArray
+}
+
+func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
+	return &unsignedArrayFilterCursor{
+		cond: cond,
+		m:    &singleValue{},
+		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:  &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
+	c.UnsignedArrayCursor = cur
+	c.tmp.Timestamps, c.tmp.Values = nil, nil
 }
 
 func (c *unsignedArrayFilterCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
@@ -590,6 +1306,14 @@ func (c *unsignedMultiShardArrayCursor) Next() *cursors.UnsignedArray {
 	}
 }
 
+// SkipTo discards the points before t if the cursor of the current shard is
+// a cursors.BlockSkipper.
+func (c *unsignedMultiShardArrayCursor) SkipTo(t int64) {
+	if cur, ok := c.UnsignedArrayCursor.(cursors.BlockSkipper); ok {
+		cur.SkipTo(t)
+	}
+}
+
 func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
 	if len(c.itrs) == 0 {
 		return false
@@ -624,47 +1348,397 @@ func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
 		c.UnsignedArrayCursor = UnsignedEmptyArrayCursor
 	}
 
-	return ok
+	return ok
+}
+
+type unsignedArraySumCursor struct {
+	cursors.UnsignedArrayCursor
+	ts  [1]int64
+	vs  [1]uint64
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArraySumCursor(cur cursors.UnsignedArrayCursor) *unsignedArraySumCursor {
+	return &unsignedArraySumCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c unsignedArraySumCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts := a.Timestamps[0]
+	var acc uint64
+
+	for {
+		for _, v := range a.Values {
+			acc += v
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = acc
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type unsignedArrayMinCursor struct {
+	cursors.UnsignedArrayCursor
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayMinCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayMinCursor {
+	return &unsignedArrayMinCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedArrayMinCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayMinCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, acc := a.Timestamps[0], a.Values[0]
+	for {
+		for i, v := range a.Values {
+			if v < acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
+		}
+	}
+}
+
+type unsignedArrayMaxCursor struct {
+	cursors.UnsignedArrayCursor
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayMaxCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayMaxCursor {
+	return &unsignedArrayMaxCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedArrayMaxCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayMaxCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, acc := a.Timestamps[0], a.Values[0]
+	for {
+		for i, v := range a.Values {
+			if v > acc || (v == acc && a.Timestamps[i] < ts) {
+				ts, acc = a.Timestamps[i], v
+			}
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = acc
+			return c.res
+		}
+	}
+}
+
+type floatUnsignedMeanArrayCursor struct {
+	cursors.UnsignedArrayCursor
+}
+
+func (c *floatUnsignedMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *floatUnsignedMeanArrayCursor) Next() *cursors.FloatArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var n int64
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		n += int64(len(a.Values))
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewFloatArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = sum / float64(n)
+			return res
+		}
+	}
+}
+
+// unsignedArrayFirstCursor selects the point with the earliest timestamp. Blocks are
+// ordered by time, so only the first and last point of each block is compared,
+// which allows the cursor to be used for ascending and descending reads.
+type unsignedArrayFirstCursor struct {
+	cursors.UnsignedArrayCursor
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayFirstCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayFirstCursor {
+	return &unsignedArrayFirstCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedArrayFirstCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayFirstCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] < ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] < ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// unsignedArrayLastCursor selects the point with the latest timestamp.
+type unsignedArrayLastCursor struct {
+	cursors.UnsignedArrayCursor
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayLastCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayLastCursor {
+	return &unsignedArrayLastCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedArrayLastCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayLastCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] > ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] > ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// unsignedWindowArrayCursor splits the points of a cursor into windows. Next
+// returns the points of the current window, and nextWindow advances to the
+// next window containing points.
+//
+// If head is set, Next only returns the first array of each window, and
+// nextWindow skips the rest of the window. The blocks of an ascending cursor
+// which only contain the points of the skipped windows are not decoded.
+type unsignedWindowArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every  int64
+	offset int64
+	head   bool
+	asc    bool
+	stop   int64
+	inWin  bool
+	skip   bool
+	buf    *cursors.UnsignedArray
+	res    *cursors.UnsignedArray
+}
+
+func newUnsignedWindowArrayCursor(cur cursors.UnsignedArrayCursor, every, offset int64, head, asc bool) *unsignedWindowArrayCursor {
+	return &unsignedWindowArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		offset:              offset,
+		head:                head,
+		asc:                 asc,
+		buf:                 &cursors.UnsignedArray{},
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedWindowArrayCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedWindowArrayCursor) nextWindow() (int64, bool) {
+	if c.skip {
+		c.skip = false
+		c.skipWindow()
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.UnsignedArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			c.inWin = false
+			return 0, false
+		}
+	}
+
+	start := windowStart(c.buf.Timestamps[0], c.every, c.offset)
+	c.stop = start + c.every
+	c.inWin = true
+	return start, true
+}
+
+func (c *unsignedWindowArrayCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps, c.res.Values = nil, nil
+	if !c.inWin {
+		return c.res
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.UnsignedArrayCursor.Next()
+	}
+
+	n := c.windowLen()
+	if n == 0 {
+		c.inWin = false
+		return c.res
+	}
+
+	c.res.Timestamps, c.res.Values = c.buf.Timestamps[:n], c.buf.Values[:n]
+	c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+
+	if c.head {
+		// res may share the array of the cursor, so the rest of the window is
+		// only skipped by nextWindow.
+		c.inWin = false
+		c.skip = true
+	}
+	return c.res
+}
+
+// windowLen returns the number of points at the start of buf which are in
+// the current window.
+func (c *unsignedWindowArrayCursor) windowLen() int {
+	start := c.stop - c.every
+	n := 0
+	for n < c.buf.Len() && c.buf.Timestamps[n] >= start && c.buf.Timestamps[n] < c.stop {
+		n++
+	}
+	return n
+}
+
+// skipWindow discards the points of the current window which have not been
+// read.
+func (c *unsignedWindowArrayCursor) skipWindow() {
+	for {
+		n := c.windowLen()
+		c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+		if c.buf.Len() > 0 {
+			return
+		}
+
+		if cur, ok := c.UnsignedArrayCursor.(cursors.BlockSkipper); ok && c.asc {
+			cur.SkipTo(c.stop)
+		}
+		c.buf = c.UnsignedArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			return
+		}
+	}
+}
+
+// unsignedNopCloseArrayCursor prevents the cursor it wraps from being closed.
+type unsignedNopCloseArrayCursor struct {
+	cursors.UnsignedArrayCursor
 }
 
-type unsignedArraySumCursor struct {
+func (c unsignedNopCloseArrayCursor) Close() {}
+
+// unsignedWindowAggregateArrayCursor produces a point for each window of an
+// aggregate cursor reading from a windowArrayCursor.
+type unsignedWindowAggregateArrayCursor struct {
 	cursors.UnsignedArrayCursor
-	ts  [1]int64
-	vs  [1]uint64
-	res *cursors.UnsignedArray
+	win        windowArrayCursor
+	windowTime bool
+	res        *cursors.UnsignedArray
 }
 
-func newUnsignedArraySumCursor(cur cursors.UnsignedArrayCursor) *unsignedArraySumCursor {
-	return &unsignedArraySumCursor{
+func newUnsignedWindowAggregateArrayCursor(cur cursors.UnsignedArrayCursor, win windowArrayCursor, windowTime bool) *unsignedWindowAggregateArrayCursor {
+	return &unsignedWindowAggregateArrayCursor{
 		UnsignedArrayCursor: cur,
-		res:                 &cursors.UnsignedArray{},
+		win:                 win,
+		windowTime:          windowTime,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
 	}
 }
 
-func (c unsignedArraySumCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+func (c *unsignedWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
 
-func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
-	a := c.UnsignedArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
+func (c *unsignedWindowAggregateArrayCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
 
-	ts := a.Timestamps[0]
-	var acc uint64
+	for c.res.Len() < MaxPointsPerBlock {
+		start, ok := c.win.nextWindow()
+		if !ok {
+			break
+		}
 
-	for {
-		for _, v := range a.Values {
-			acc += v
+		a := c.UnsignedArrayCursor.Next()
+		if a.Len() == 0 {
+			continue
 		}
-		a = c.UnsignedArrayCursor.Next()
-		if len(a.Timestamps) == 0 {
-			c.ts[0] = ts
-			c.vs[0] = acc
-			c.res.Timestamps = c.ts[:]
-			c.res.Values = c.vs[:]
-			return c.res
+
+		ts := a.Timestamps[0]
+		if c.windowTime {
+			ts = start
 		}
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, a.Values[0])
 	}
+
+	return c.res
 }
 
 type integerUnsignedCountArrayCursor struct {
@@ -819,6 +1893,14 @@ func (c *stringMultiShardArrayCursor) Next() *cursors.StringArray {
 	}
 }
 
+// SkipTo discards the points before t if the cursor of the current shard is
+// a cursors.BlockSkipper.
+func (c *stringMultiShardArrayCursor) SkipTo(t int64) {
+	if cur, ok := c.StringArrayCursor.(cursors.BlockSkipper); ok {
+		cur.SkipTo(t)
+	}
+}
+
 func (c *stringMultiShardArrayCursor) nextArrayCursor() bool {
 	if len(c.itrs) == 0 {
 		return false
@@ -856,6 +1938,252 @@ func (c *stringMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+// stringArrayFirstCursor selects the point with the earliest timestamp. Blocks are
+// ordered by time, so only the first and last point of each block is compared,
+// which allows the cursor to be used for ascending and descending reads.
+type stringArrayFirstCursor struct {
+	cursors.StringArrayCursor
+	res *cursors.StringArray
+}
+
+func newStringArrayFirstCursor(cur cursors.StringArrayCursor) *stringArrayFirstCursor {
+	return &stringArrayFirstCursor{
+		StringArrayCursor: cur,
+		res:               cursors.NewStringArrayLen(1),
+	}
+}
+
+func (c *stringArrayFirstCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringArrayFirstCursor) Next() *cursors.StringArray {
+	a := c.StringArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] < ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] < ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.StringArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// stringArrayLastCursor selects the point with the latest timestamp.
+type stringArrayLastCursor struct {
+	cursors.StringArrayCursor
+	res *cursors.StringArray
+}
+
+func newStringArrayLastCursor(cur cursors.StringArrayCursor) *stringArrayLastCursor {
+	return &stringArrayLastCursor{
+		StringArrayCursor: cur,
+		res:               cursors.NewStringArrayLen(1),
+	}
+}
+
+func (c *stringArrayLastCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringArrayLastCursor) Next() *cursors.StringArray {
+	a := c.StringArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] > ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] > ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.StringArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// stringWindowArrayCursor splits the points of a cursor into windows. Next
+// returns the points of the current window, and nextWindow advances to the
+// next window containing points.
+//
+// If head is set, Next only returns the first array of each window, and
+// nextWindow skips the rest of the window. The blocks of an ascending cursor
+// which only contain the points of the skipped windows are not decoded.
+type stringWindowArrayCursor struct {
+	cursors.StringArrayCursor
+	every  int64
+	offset int64
+	head   bool
+	asc    bool
+	stop   int64
+	inWin  bool
+	skip   bool
+	buf    *cursors.StringArray
+	res    *cursors.StringArray
+}
+
+func newStringWindowArrayCursor(cur cursors.StringArrayCursor, every, offset int64, head, asc bool) *stringWindowArrayCursor {
+	return &stringWindowArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		offset:            offset,
+		head:              head,
+		asc:               asc,
+		buf:               &cursors.StringArray{},
+		res:               &cursors.StringArray{},
+	}
+}
+
+func (c *stringWindowArrayCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringWindowArrayCursor) nextWindow() (int64, bool) {
+	if c.skip {
+		c.skip = false
+		c.skipWindow()
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.StringArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			c.inWin = false
+			return 0, false
+		}
+	}
+
+	start := windowStart(c.buf.Timestamps[0], c.every, c.offset)
+	c.stop = start + c.every
+	c.inWin = true
+	return start, true
+}
+
+func (c *stringWindowArrayCursor) Next() *cursors.StringArray {
+	c.res.Timestamps, c.res.Values = nil, nil
+	if !c.inWin {
+		return c.res
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.StringArrayCursor.Next()
+	}
+
+	n := c.windowLen()
+	if n == 0 {
+		c.inWin = false
+		return c.res
+	}
+
+	c.res.Timestamps, c.res.Values = c.buf.Timestamps[:n], c.buf.Values[:n]
+	c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+
+	if c.head {
+		// res may share the array of the cursor, so the rest of the window is
+		// only skipped by nextWindow.
+		c.inWin = false
+		c.skip = true
+	}
+	return c.res
+}
+
+// windowLen returns the number of points at the start of buf which are in
+// the current window.
+func (c *stringWindowArrayCursor) windowLen() int {
+	start := c.stop - c.every
+	n := 0
+	for n < c.buf.Len() && c.buf.Timestamps[n] >= start && c.buf.Timestamps[n] < c.stop {
+		n++
+	}
+	return n
+}
+
+// skipWindow discards the points of the current window which have not been
+// read.
+func (c *stringWindowArrayCursor) skipWindow() {
+	for {
+		n := c.windowLen()
+		c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+		if c.buf.Len() > 0 {
+			return
+		}
+
+		if cur, ok := c.StringArrayCursor.(cursors.BlockSkipper); ok && c.asc {
+			cur.SkipTo(c.stop)
+		}
+		c.buf = c.StringArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			return
+		}
+	}
+}
+
+// stringNopCloseArrayCursor prevents the cursor it wraps from being closed.
+type stringNopCloseArrayCursor struct {
+	cursors.StringArrayCursor
+}
+
+func (c stringNopCloseArrayCursor) Close() {}
+
+// stringWindowAggregateArrayCursor produces a point for each window of an
+// aggregate cursor reading from a windowArrayCursor.
+type stringWindowAggregateArrayCursor struct {
+	cursors.StringArrayCursor
+	win        windowArrayCursor
+	windowTime bool
+	res        *cursors.StringArray
+}
+
+func newStringWindowAggregateArrayCursor(cur cursors.StringArrayCursor, win windowArrayCursor, windowTime bool) *stringWindowAggregateArrayCursor {
+	return &stringWindowAggregateArrayCursor{
+		StringArrayCursor: cur,
+		win:               win,
+		windowTime:        windowTime,
+		res:               cursors.NewStringArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowAggregateArrayCursor) Next() *cursors.StringArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock {
+		start, ok := c.win.nextWindow()
+		if !ok {
+			break
+		}
+
+		a := c.StringArrayCursor.Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		ts := a.Timestamps[0]
+		if c.windowTime {
+			ts = start
+		}
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+
+	return c.res
+}
+
 type integerStringCountArrayCursor struct {
 	cursors.StringArrayCursor
 }
@@ -1008,6 +2336,14 @@ func (c *booleanMultiShardArrayCursor) Next() *cursors.BooleanArray {
 	}
 }
 
+// SkipTo discards the points before t if the cursor of the current shard is
+// a cursors.BlockSkipper.
+func (c *booleanMultiShardArrayCursor) SkipTo(t int64) {
+	if cur, ok := c.BooleanArrayCursor.(cursors.BlockSkipper); ok {
+		cur.SkipTo(t)
+	}
+}
+
 func (c *booleanMultiShardArrayCursor) nextArrayCursor() bool {
 	if len(c.itrs) == 0 {
 		return false
@@ -1045,6 +2381,252 @@ func (c *booleanMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+// booleanArrayFirstCursor selects the point with the earliest timestamp. Blocks are
+// ordered by time, so only the first and last point of each block is compared,
+// which allows the cursor to be used for ascending and descending reads.
+type booleanArrayFirstCursor struct {
+	cursors.BooleanArrayCursor
+	res *cursors.BooleanArray
+}
+
+func newBooleanArrayFirstCursor(cur cursors.BooleanArrayCursor) *booleanArrayFirstCursor {
+	return &booleanArrayFirstCursor{
+		BooleanArrayCursor: cur,
+		res:                cursors.NewBooleanArrayLen(1),
+	}
+}
+
+func (c *booleanArrayFirstCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+func (c *booleanArrayFirstCursor) Next() *cursors.BooleanArray {
+	a := c.BooleanArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] < ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] < ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.BooleanArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// booleanArrayLastCursor selects the point with the latest timestamp.
+type booleanArrayLastCursor struct {
+	cursors.BooleanArrayCursor
+	res *cursors.BooleanArray
+}
+
+func newBooleanArrayLastCursor(cur cursors.BooleanArrayCursor) *booleanArrayLastCursor {
+	return &booleanArrayLastCursor{
+		BooleanArrayCursor: cur,
+		res:                cursors.NewBooleanArrayLen(1),
+	}
+}
+
+func (c *booleanArrayLastCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+func (c *booleanArrayLastCursor) Next() *cursors.BooleanArray {
+	a := c.BooleanArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, v := a.Timestamps[0], a.Values[0]
+	for {
+		if i := len(a.Timestamps) - 1; a.Timestamps[i] > ts {
+			ts, v = a.Timestamps[i], a.Values[i]
+		}
+		if a.Timestamps[0] > ts {
+			ts, v = a.Timestamps[0], a.Values[0]
+		}
+		a = c.BooleanArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps[0] = ts
+			c.res.Values[0] = v
+			return c.res
+		}
+	}
+}
+
+// booleanWindowArrayCursor splits the points of a cursor into windows. Next
+// returns the points of the current window, and nextWindow advances to the
+// next window containing points.
+//
+// If head is set, Next only returns the first array of each window, and
+// nextWindow skips the rest of the window. The blocks of an ascending cursor
+// which only contain the points of the skipped windows are not decoded.
+type booleanWindowArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every  int64
+	offset int64
+	head   bool
+	asc    bool
+	stop   int64
+	inWin  bool
+	skip   bool
+	buf    *cursors.BooleanArray
+	res    *cursors.BooleanArray
+}
+
+func newBooleanWindowArrayCursor(cur cursors.BooleanArrayCursor, every, offset int64, head, asc bool) *booleanWindowArrayCursor {
+	return &booleanWindowArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		offset:             offset,
+		head:               head,
+		asc:                asc,
+		buf:                &cursors.BooleanArray{},
+		res:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *booleanWindowArrayCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+func (c *booleanWindowArrayCursor) nextWindow() (int64, bool) {
+	if c.skip {
+		c.skip = false
+		c.skipWindow()
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.BooleanArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			c.inWin = false
+			return 0, false
+		}
+	}
+
+	start := windowStart(c.buf.Timestamps[0], c.every, c.offset)
+	c.stop = start + c.every
+	c.inWin = true
+	return start, true
+}
+
+func (c *booleanWindowArrayCursor) Next() *cursors.BooleanArray {
+	c.res.Timestamps, c.res.Values = nil, nil
+	if !c.inWin {
+		return c.res
+	}
+
+	if c.buf.Len() == 0 {
+		c.buf = c.BooleanArrayCursor.Next()
+	}
+
+	n := c.windowLen()
+	if n == 0 {
+		c.inWin = false
+		return c.res
+	}
+
+	c.res.Timestamps, c.res.Values = c.buf.Timestamps[:n], c.buf.Values[:n]
+	c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+
+	if c.head {
+		// res may share the array of the cursor, so the rest of the window is
+		// only skipped by nextWindow.
+		c.inWin = false
+		c.skip = true
+	}
+	return c.res
+}
+
+// windowLen returns the number of points at the start of buf which are in
+// the current window.
+func (c *booleanWindowArrayCursor) windowLen() int {
+	start := c.stop - c.every
+	n := 0
+	for n < c.buf.Len() && c.buf.Timestamps[n] >= start && c.buf.Timestamps[n] < c.stop {
+		n++
+	}
+	return n
+}
+
+// skipWindow discards the points of the current window which have not been
+// read.
+func (c *booleanWindowArrayCursor) skipWindow() {
+	for {
+		n := c.windowLen()
+		c.buf.Timestamps, c.buf.Values = c.buf.Timestamps[n:], c.buf.Values[n:]
+		if c.buf.Len() > 0 {
+			return
+		}
+
+		if cur, ok := c.BooleanArrayCursor.(cursors.BlockSkipper); ok && c.asc {
+			cur.SkipTo(c.stop)
+		}
+		c.buf = c.BooleanArrayCursor.Next()
+		if c.buf.Len() == 0 {
+			return
+		}
+	}
+}
+
+// booleanNopCloseArrayCursor prevents the cursor it wraps from being closed.
+type booleanNopCloseArrayCursor struct {
+	cursors.BooleanArrayCursor
+}
+
+func (c booleanNopCloseArrayCursor) Close() {}
+
+// booleanWindowAggregateArrayCursor produces a point for each window of an
+// aggregate cursor reading from a windowArrayCursor.
+type booleanWindowAggregateArrayCursor struct {
+	cursors.BooleanArrayCursor
+	win        windowArrayCursor
+	windowTime bool
+	res        *cursors.BooleanArray
+}
+
+func newBooleanWindowAggregateArrayCursor(cur cursors.BooleanArrayCursor, win windowArrayCursor, windowTime bool) *booleanWindowAggregateArrayCursor {
+	return &booleanWindowAggregateArrayCursor{
+		BooleanArrayCursor: cur,
+		win:                win,
+		windowTime:         windowTime,
+		res:                cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowAggregateArrayCursor) Next() *cursors.BooleanArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock {
+		start, ok := c.win.nextWindow()
+		if !ok {
+			break
+		}
+
+		a := c.BooleanArrayCursor.Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		ts := a.Timestamps[0]
+		if c.windowTime {
+			ts = start
+		}
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+
+	return c.res
+}
+
 type integerBooleanCountArrayCursor struct {
 	cursors.BooleanArrayCursor
 }
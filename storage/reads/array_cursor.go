@@ -26,12 +26,105 @@ func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, curs
 		return newSumArrayCursor(cursor)
 	case datatypes.AggregateTypeCount:
 		return newCountArrayCursor(cursor)
+	case datatypes.AggregateTypeMin:
+		return newMinArrayCursor(cursor)
+	case datatypes.AggregateTypeMax:
+		return newMaxArrayCursor(cursor)
+	case datatypes.AggregateTypeFirst:
+		return newFirstArrayCursor(cursor)
+	case datatypes.AggregateTypeLast:
+		return newLastArrayCursor(cursor)
+	case datatypes.AggregateTypeMean:
+		return newMeanArrayCursor(cursor)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
 	}
 }
 
+// windowArrayCursor is implemented by the cursors splitting the points of a
+// series into windows.
+type windowArrayCursor interface {
+	// nextWindow advances to the next window containing points and returns
+	// its start time, or false if there are no more points.
+	nextWindow() (int64, bool)
+}
+
+// newWindowArrayCursor returns a cursor splitting the points of cur into
+// windows of duration every. If head is set, only the first array of each
+// window is read, and the rest of the window is skipped. asc is the order of
+// the points of cur.
+func newWindowArrayCursor(cur cursors.Cursor, every, offset int64, head, asc bool) (cursors.Cursor, windowArrayCursor) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		c := newFloatWindowArrayCursor(cur, every, offset, head, asc)
+		return c, c
+	case cursors.IntegerArrayCursor:
+		c := newIntegerWindowArrayCursor(cur, every, offset, head, asc)
+		return c, c
+	case cursors.UnsignedArrayCursor:
+		c := newUnsignedWindowArrayCursor(cur, every, offset, head, asc)
+		return c, c
+	case cursors.StringArrayCursor:
+		c := newStringWindowArrayCursor(cur, every, offset, head, asc)
+		return c, c
+	case cursors.BooleanArrayCursor:
+		c := newBooleanWindowArrayCursor(cur, every, offset, head, asc)
+		return c, c
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+// windowStart returns the start time of the window containing ts.
+func windowStart(ts, every, offset int64) int64 {
+	r := (ts - offset) % every
+	if r < 0 {
+		r += every
+	}
+	return ts - r
+}
+
+// newWindowAggregateArrayCursor returns a cursor producing the aggregate of
+// each window of cursor, whose points are in ascending order if asc is set.
+// The points of aggregates which are not selectors are timestamped with the
+// start time of their window.
+func newWindowAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, cursor cursors.Cursor, asc bool) cursors.Cursor {
+	if cursor == nil {
+		return nil
+	}
+
+	// A selector of the first point of each window in the order of the
+	// cursor only needs the first array of the window, so the rest of the
+	// window is skipped.
+	head := (agg.Type == datatypes.AggregateTypeFirst && asc) ||
+		(agg.Type == datatypes.AggregateTypeLast && !asc)
+
+	cursor, win := newWindowArrayCursor(cursor, window.Every, window.Offset, head, asc)
+
+	var windowTime bool
+	switch agg.Type {
+	case datatypes.AggregateTypeSum, datatypes.AggregateTypeCount, datatypes.AggregateTypeMean:
+		windowTime = true
+	}
+
+	switch cur := newAggregateArrayCursor(ctx, agg, cursor).(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowAggregateArrayCursor(cur, win, windowTime)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowAggregateArrayCursor(cur, win, windowTime)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowAggregateArrayCursor(cur, win, windowTime)
+	case cursors.StringArrayCursor:
+		return newStringWindowAggregateArrayCursor(cur, win, windowTime)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowAggregateArrayCursor(cur, win, windowTime)
+	default:
+		// the aggregate does not support the type of the cursor
+		return nil
+	}
+}
+
 func newSumArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	switch cur := cur.(type) {
 	case cursors.FloatArrayCursor:
@@ -63,6 +156,79 @@ func newCountArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	}
 }
 
+func newMinArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayMinCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayMinCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayMinCursor(cur)
+	default:
+		return nil
+	}
+}
+
+func newMaxArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayMaxCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayMaxCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayMaxCursor(cur)
+	default:
+		return nil
+	}
+}
+
+func newFirstArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayFirstCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayFirstCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayFirstCursor(cur)
+	case cursors.StringArrayCursor:
+		return newStringArrayFirstCursor(cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanArrayFirstCursor(cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newLastArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayLastCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayLastCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayLastCursor(cur)
+	case cursors.StringArrayCursor:
+		return newStringArrayLastCursor(cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanArrayLastCursor(cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newMeanArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return &floatFloatMeanArrayCursor{FloatArrayCursor: cur}
+	case cursors.IntegerArrayCursor:
+		return &floatIntegerMeanArrayCursor{IntegerArrayCursor: cur}
+	case cursors.UnsignedArrayCursor:
+		return &floatUnsignedMeanArrayCursor{UnsignedArrayCursor: cur}
+	default:
+		return nil
+	}
+}
+
 type cursorContext struct {
 	ctx   context.Context
 	req   *cursors.CursorRequest
@@ -161,3 +327,7 @@ func (m *multiShardArrayCursors) createCursor(row SeriesRow) cursors.Cursor {
 func (m *multiShardArrayCursors) newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
 	return newAggregateArrayCursor(ctx, agg, cursor)
 }
+
+func (m *multiShardArrayCursors) newWindowAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, cursor cursors.Cursor) cursors.Cursor {
+	return newWindowAggregateArrayCursor(ctx, agg, window, cursor, m.req.Ascending)
+}
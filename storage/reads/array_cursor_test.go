@@ -0,0 +1,201 @@
+package reads
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// floatBlocksCursor is a FloatArrayCursor returning a block per call to Next.
+type floatBlocksCursor struct {
+	blocks []*cursors.FloatArray
+}
+
+func (c *floatBlocksCursor) Close()                     {}
+func (c *floatBlocksCursor) Err() error                 { return nil }
+func (c *floatBlocksCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *floatBlocksCursor) Next() *cursors.FloatArray {
+	if len(c.blocks) == 0 {
+		return &cursors.FloatArray{}
+	}
+	a := c.blocks[0]
+	c.blocks = c.blocks[1:]
+	return a
+}
+
+func newFloatBlocksCursor(desc bool) *floatBlocksCursor {
+	blocks := []*cursors.FloatArray{
+		{Timestamps: []int64{10, 20, 30}, Values: []float64{4, 1, 8}},
+		{Timestamps: []int64{40, 50}, Values: []float64{8, 3}},
+	}
+	if desc {
+		blocks = []*cursors.FloatArray{
+			{Timestamps: []int64{50, 40}, Values: []float64{3, 8}},
+			{Timestamps: []int64{30, 20, 10}, Values: []float64{8, 1, 4}},
+		}
+	}
+	return &floatBlocksCursor{blocks: blocks}
+}
+
+func TestNewAggregateArrayCursor_Float(t *testing.T) {
+	for _, tt := range []struct {
+		agg  datatypes.Aggregate_AggregateType
+		desc bool
+		exp  *cursors.FloatArray
+	}{
+		{agg: datatypes.AggregateTypeSum, exp: &cursors.FloatArray{Timestamps: []int64{10}, Values: []float64{24}}},
+		{agg: datatypes.AggregateTypeMin, exp: &cursors.FloatArray{Timestamps: []int64{20}, Values: []float64{1}}},
+		{agg: datatypes.AggregateTypeMax, exp: &cursors.FloatArray{Timestamps: []int64{30}, Values: []float64{8}}},
+		{agg: datatypes.AggregateTypeMax, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{30}, Values: []float64{8}}},
+		{agg: datatypes.AggregateTypeFirst, exp: &cursors.FloatArray{Timestamps: []int64{10}, Values: []float64{4}}},
+		{agg: datatypes.AggregateTypeFirst, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{10}, Values: []float64{4}}},
+		{agg: datatypes.AggregateTypeLast, exp: &cursors.FloatArray{Timestamps: []int64{50}, Values: []float64{3}}},
+		{agg: datatypes.AggregateTypeLast, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{50}, Values: []float64{3}}},
+		{agg: datatypes.AggregateTypeMean, exp: &cursors.FloatArray{Timestamps: []int64{10}, Values: []float64{4.8}}},
+	} {
+		name := tt.agg.String()
+		if tt.desc {
+			name += " descending"
+		}
+		t.Run(name, func(t *testing.T) {
+			cur := newAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: tt.agg}, newFloatBlocksCursor(tt.desc))
+
+			got := cur.(cursors.FloatArrayCursor).Next()
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected result: got %v, exp %v", got, tt.exp)
+			}
+			if a := cur.(cursors.FloatArrayCursor).Next(); a.Len() != 0 {
+				t.Fatalf("expected a single point, got %v", a)
+			}
+		})
+	}
+}
+
+func TestNewAggregateArrayCursor_Count(t *testing.T) {
+	cur := newAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: datatypes.AggregateTypeCount}, newFloatBlocksCursor(false))
+
+	exp := &cursors.IntegerArray{Timestamps: []int64{10}, Values: []int64{5}}
+	if got := cur.(cursors.IntegerArrayCursor).Next(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected result: got %v, exp %v", got, exp)
+	}
+}
+
+func TestNewWindowAggregateArrayCursor(t *testing.T) {
+	window := &datatypes.Window{Every: 20}
+	for _, tt := range []struct {
+		agg  datatypes.Aggregate_AggregateType
+		desc bool
+		exp  *cursors.FloatArray
+	}{
+		{agg: datatypes.AggregateTypeSum, exp: &cursors.FloatArray{Timestamps: []int64{0, 20, 40}, Values: []float64{4, 9, 11}}},
+		{agg: datatypes.AggregateTypeSum, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{40, 20, 0}, Values: []float64{11, 9, 4}}},
+		{agg: datatypes.AggregateTypeMax, exp: &cursors.FloatArray{Timestamps: []int64{10, 30, 40}, Values: []float64{4, 8, 8}}},
+		{agg: datatypes.AggregateTypeLast, exp: &cursors.FloatArray{Timestamps: []int64{10, 30, 50}, Values: []float64{4, 8, 3}}},
+		{agg: datatypes.AggregateTypeLast, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{50, 30, 10}, Values: []float64{3, 8, 4}}},
+		{agg: datatypes.AggregateTypeFirst, exp: &cursors.FloatArray{Timestamps: []int64{10, 20, 40}, Values: []float64{4, 1, 8}}},
+		{agg: datatypes.AggregateTypeFirst, desc: true, exp: &cursors.FloatArray{Timestamps: []int64{40, 20, 10}, Values: []float64{8, 1, 4}}},
+		{agg: datatypes.AggregateTypeMean, exp: &cursors.FloatArray{Timestamps: []int64{0, 20, 40}, Values: []float64{4, 4.5, 5.5}}},
+	} {
+		name := tt.agg.String()
+		if tt.desc {
+			name += " descending"
+		}
+		t.Run(name, func(t *testing.T) {
+			cur := newWindowAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: tt.agg}, window, newFloatBlocksCursor(tt.desc), !tt.desc)
+
+			got := cur.(cursors.FloatArrayCursor).Next()
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected result: got %v, exp %v", got, tt.exp)
+			}
+			if a := cur.(cursors.FloatArrayCursor).Next(); a.Len() != 0 {
+				t.Fatalf("expected a point per window, got %v", a)
+			}
+		})
+	}
+}
+
+// floatSkipBlocksCursor is a floatBlocksCursor which skips the blocks whose
+// points are all before the time given to SkipTo.
+type floatSkipBlocksCursor struct {
+	floatBlocksCursor
+	read    int
+	skipped int
+}
+
+func (c *floatSkipBlocksCursor) Next() *cursors.FloatArray {
+	a := c.floatBlocksCursor.Next()
+	if a.Len() > 0 {
+		c.read++
+	}
+	return a
+}
+
+func (c *floatSkipBlocksCursor) SkipTo(t int64) {
+	for len(c.blocks) > 0 && c.blocks[0].MaxTime() < t {
+		c.blocks = c.blocks[1:]
+		c.skipped++
+	}
+}
+
+func TestNewWindowAggregateArrayCursor_SkipBlocks(t *testing.T) {
+	cur := &floatSkipBlocksCursor{floatBlocksCursor: floatBlocksCursor{blocks: []*cursors.FloatArray{
+		{Timestamps: []int64{10, 12}, Values: []float64{1, 2}},
+		{Timestamps: []int64{14, 16}, Values: []float64{3, 4}},
+		{Timestamps: []int64{18, 25}, Values: []float64{5, 6}},
+		{Timestamps: []int64{30, 35}, Values: []float64{7, 8}},
+		{Timestamps: []int64{41}, Values: []float64{9}},
+	}}}
+
+	agg := newWindowAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: datatypes.AggregateTypeFirst}, &datatypes.Window{Every: 20}, cur, true)
+
+	exp := &cursors.FloatArray{Timestamps: []int64{10, 25, 41}, Values: []float64{1, 6, 9}}
+	if got := agg.(cursors.FloatArrayCursor).Next(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected result: got %v, exp %v", got, exp)
+	}
+
+	// The blocks containing only points after the first of their window are
+	// never read.
+	if cur.read != 3 || cur.skipped != 2 {
+		t.Fatalf("unexpected blocks: got %d read and %d skipped, exp 3 and 2", cur.read, cur.skipped)
+	}
+}
+
+func TestWindowStart(t *testing.T) {
+	for _, tt := range []struct {
+		ts, every, offset, exp int64
+	}{
+		{ts: 25, every: 10, exp: 20},
+		{ts: 20, every: 10, exp: 20},
+		{ts: 25, every: 10, offset: 7, exp: 17},
+		{ts: -5, every: 10, exp: -10},
+		{ts: -5, every: 10, offset: 3, exp: -7},
+	} {
+		if got := windowStart(tt.ts, tt.every, tt.offset); got != tt.exp {
+			t.Errorf("windowStart(%d, %d, %d): got %d, exp %d", tt.ts, tt.every, tt.offset, got, tt.exp)
+		}
+	}
+}
+
+func TestDetermineAggregateMethods(t *testing.T) {
+	aggs, err := determineAggregateMethods("min, MAX,mean")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []datatypes.Aggregate_AggregateType
+	for _, agg := range aggs {
+		got = append(got, agg.Type)
+	}
+	exp := []datatypes.Aggregate_AggregateType{datatypes.AggregateTypeMin, datatypes.AggregateTypeMax, datatypes.AggregateTypeMean}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected aggregates: got %v, exp %v", got, exp)
+	}
+
+	if _, err := determineAggregateMethods("min,median"); err == nil {
+		t.Fatal("expected error for unknown aggregate")
+	}
+}
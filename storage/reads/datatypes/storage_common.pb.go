@@ -38,7 +38,8 @@ const (
 	GroupAll ReadRequest_Group = 1
 	// GroupBy returns a group for each unique value of the specified GroupKeys.
 	GroupBy ReadRequest_Group = 2
-	// GroupExcept in not implemented.
+	// GroupExcept groups the data by all tag keys, except those specified
+	// in GroupKeys.
 	GroupExcept ReadRequest_Group = 3
 )
 
@@ -59,7 +60,7 @@ func (x ReadRequest_Group) String() string {
 	return proto.EnumName(ReadRequest_Group_name, int32(x))
 }
 func (ReadRequest_Group) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{0, 0}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{0, 0}
 }
 
 type ReadRequest_HintFlags int32
@@ -89,7 +90,7 @@ func (x ReadRequest_HintFlags) String() string {
 	return proto.EnumName(ReadRequest_HintFlags_name, int32(x))
 }
 func (ReadRequest_HintFlags) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{0, 1}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{0, 1}
 }
 
 type Aggregate_AggregateType int32
@@ -98,24 +99,39 @@ const (
 	AggregateTypeNone  Aggregate_AggregateType = 0
 	AggregateTypeSum   Aggregate_AggregateType = 1
 	AggregateTypeCount Aggregate_AggregateType = 2
+	AggregateTypeMin   Aggregate_AggregateType = 3
+	AggregateTypeMax   Aggregate_AggregateType = 4
+	AggregateTypeFirst Aggregate_AggregateType = 5
+	AggregateTypeLast  Aggregate_AggregateType = 6
+	AggregateTypeMean  Aggregate_AggregateType = 7
 )
 
 var Aggregate_AggregateType_name = map[int32]string{
 	0: "NONE",
 	1: "SUM",
 	2: "COUNT",
+	3: "MIN",
+	4: "MAX",
+	5: "FIRST",
+	6: "LAST",
+	7: "MEAN",
 }
 var Aggregate_AggregateType_value = map[string]int32{
 	"NONE":  0,
 	"SUM":   1,
 	"COUNT": 2,
+	"MIN":   3,
+	"MAX":   4,
+	"FIRST": 5,
+	"LAST":  6,
+	"MEAN":  7,
 }
 
 func (x Aggregate_AggregateType) String() string {
 	return proto.EnumName(Aggregate_AggregateType_name, int32(x))
 }
 func (Aggregate_AggregateType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{1, 0}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{1, 0}
 }
 
 type ReadResponse_FrameType int32
@@ -138,7 +154,7 @@ func (x ReadResponse_FrameType) String() string {
 	return proto.EnumName(ReadResponse_FrameType_name, int32(x))
 }
 func (ReadResponse_FrameType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 0}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 0}
 }
 
 type ReadResponse_DataType int32
@@ -170,7 +186,7 @@ func (x ReadResponse_DataType) String() string {
 	return proto.EnumName(ReadResponse_DataType_name, int32(x))
 }
 func (ReadResponse_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 1}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 1}
 }
 
 // Request message for Storage.Read.
@@ -184,9 +200,13 @@ type ReadRequest struct {
 	GroupKeys []string `protobuf:"bytes,4,rep,name=group_keys,json=groupKeys" json:"group_keys,omitempty"`
 	//
 	Group ReadRequest_Group `protobuf:"varint,11,opt,name=group,proto3,enum=influxdata.platform.storage.ReadRequest_Group" json:"group,omitempty"`
-	// Aggregate specifies an optional aggregate to apply to the data.
-	// TODO(sgc): switch to slice for multiple aggregates in a single request
-	Aggregate *Aggregate `protobuf:"bytes,9,opt,name=aggregate" json:"aggregate,omitempty"`
+	// Aggregate specifies optional aggregates to apply to the data. When more
+	// than one aggregate is specified, each series is returned once per
+	// aggregate, identified by the _aggregate tag.
+	Aggregate []*Aggregate `protobuf:"bytes,9,rep,name=aggregate" json:"aggregate,omitempty"`
+	// Window specifies an optional window for the aggregates. When set, the
+	// aggregates produce a point for each window of each series.
+	Window    *Window    `protobuf:"bytes,14,opt,name=window" json:"window,omitempty"`
 	Predicate *Predicate `protobuf:"bytes,5,opt,name=predicate" json:"predicate,omitempty"`
 	// SeriesLimit determines the maximum number of series to be returned for the request. Specify 0 for no limit.
 	SeriesLimit int64 `protobuf:"varint,6,opt,name=series_limit,json=seriesLimit,proto3" json:"series_limit,omitempty"`
@@ -208,7 +228,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{0}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{0}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -247,7 +267,7 @@ func (m *Aggregate) Reset()         { *m = Aggregate{} }
 func (m *Aggregate) String() string { return proto.CompactTextString(m) }
 func (*Aggregate) ProtoMessage()    {}
 func (*Aggregate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{1}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{1}
 }
 func (m *Aggregate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -287,7 +307,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{2}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{2}
 }
 func (m *Tag) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -327,7 +347,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -374,7 +394,7 @@ func (m *ReadResponse_Frame) Reset()         { *m = ReadResponse_Frame{} }
 func (m *ReadResponse_Frame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_Frame) ProtoMessage()    {}
 func (*ReadResponse_Frame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 0}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 0}
 }
 func (m *ReadResponse_Frame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -677,7 +697,7 @@ func (m *ReadResponse_GroupFrame) Reset()         { *m = ReadResponse_GroupFrame
 func (m *ReadResponse_GroupFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_GroupFrame) ProtoMessage()    {}
 func (*ReadResponse_GroupFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 1}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 1}
 }
 func (m *ReadResponse_GroupFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -717,7 +737,7 @@ func (m *ReadResponse_SeriesFrame) Reset()         { *m = ReadResponse_SeriesFra
 func (m *ReadResponse_SeriesFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_SeriesFrame) ProtoMessage()    {}
 func (*ReadResponse_SeriesFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 2}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 2}
 }
 func (m *ReadResponse_SeriesFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -757,7 +777,7 @@ func (m *ReadResponse_FloatPointsFrame) Reset()         { *m = ReadResponse_Floa
 func (m *ReadResponse_FloatPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_FloatPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_FloatPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 3}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 3}
 }
 func (m *ReadResponse_FloatPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -797,7 +817,7 @@ func (m *ReadResponse_IntegerPointsFrame) Reset()         { *m = ReadResponse_In
 func (m *ReadResponse_IntegerPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_IntegerPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_IntegerPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 4}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 4}
 }
 func (m *ReadResponse_IntegerPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -837,7 +857,7 @@ func (m *ReadResponse_UnsignedPointsFrame) Reset()         { *m = ReadResponse_U
 func (m *ReadResponse_UnsignedPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_UnsignedPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_UnsignedPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 5}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 5}
 }
 func (m *ReadResponse_UnsignedPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -877,7 +897,7 @@ func (m *ReadResponse_BooleanPointsFrame) Reset()         { *m = ReadResponse_Bo
 func (m *ReadResponse_BooleanPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_BooleanPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_BooleanPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 6}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 6}
 }
 func (m *ReadResponse_BooleanPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -917,7 +937,7 @@ func (m *ReadResponse_StringPointsFrame) Reset()         { *m = ReadResponse_Str
 func (m *ReadResponse_StringPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_StringPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_StringPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{3, 7}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{3, 7}
 }
 func (m *ReadResponse_StringPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -956,7 +976,7 @@ func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
 func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*CapabilitiesResponse) ProtoMessage()    {}
 func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{4}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{4}
 }
 func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -994,7 +1014,7 @@ func (m *HintsResponse) Reset()         { *m = HintsResponse{} }
 func (m *HintsResponse) String() string { return proto.CompactTextString(m) }
 func (*HintsResponse) ProtoMessage()    {}
 func (*HintsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{5}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{5}
 }
 func (m *HintsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1037,7 +1057,7 @@ func (m *TimestampRange) Reset()         { *m = TimestampRange{} }
 func (m *TimestampRange) String() string { return proto.CompactTextString(m) }
 func (*TimestampRange) ProtoMessage()    {}
 func (*TimestampRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{6}
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{6}
 }
 func (m *TimestampRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1066,6 +1086,137 @@ func (m *TimestampRange) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TimestampRange proto.InternalMessageInfo
 
+// Window partitions time into consecutive windows of equal duration.
+type Window struct {
+	// Every is the duration of each window in nanoseconds.
+	Every int64 `protobuf:"varint,1,opt,name=every,proto3" json:"every,omitempty"`
+	// Offset shifts the boundaries of the windows, which are aligned to the
+	// Unix epoch, by a number of nanoseconds.
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Window) Reset()         { *m = Window{} }
+func (m *Window) String() string { return proto.CompactTextString(m) }
+func (*Window) ProtoMessage()    {}
+func (*Window) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{7}
+}
+func (m *Window) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Window) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Window.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Window) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Window.Merge(dst, src)
+}
+func (m *Window) XXX_Size() int {
+	return m.Size()
+}
+func (m *Window) XXX_DiscardUnknown() {
+	xxx_messageInfo_Window.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Window proto.InternalMessageInfo
+
+type ExplainRequest struct {
+	ReadRequest          *ReadRequest `protobuf:"bytes,1,opt,name=read_request,json=readRequest" json:"read_request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ExplainRequest) Reset()         { *m = ExplainRequest{} }
+func (m *ExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainRequest) ProtoMessage()    {}
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{8}
+}
+func (m *ExplainRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExplainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExplainRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ExplainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainRequest.Merge(dst, src)
+}
+func (m *ExplainRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExplainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainRequest proto.InternalMessageInfo
+
+type ExplainResponse struct {
+	// Plan describes how the storage engine executes the request.
+	Plan string `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	// SeriesCount is the number of series read.
+	SeriesCount   int64 `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+	ScannedValues int64 `protobuf:"varint,3,opt,name=scanned_values,json=scannedValues,proto3" json:"scanned_values,omitempty"`
+	ScannedBytes  int64 `protobuf:"varint,4,opt,name=scanned_bytes,json=scannedBytes,proto3" json:"scanned_bytes,omitempty"`
+	// ScannedBlocks is the number of TSM blocks read.
+	ScannedBlocks int64 `protobuf:"varint,5,opt,name=scanned_blocks,json=scannedBlocks,proto3" json:"scanned_blocks,omitempty"`
+	// CacheValues is the number of values read from the cache.
+	CacheValues          int64    `protobuf:"varint,6,opt,name=cache_values,json=cacheValues,proto3" json:"cache_values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainResponse) Reset()         { *m = ExplainResponse{} }
+func (m *ExplainResponse) String() string { return proto.CompactTextString(m) }
+func (*ExplainResponse) ProtoMessage()    {}
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_9ead84b3718d40d0, []int{9}
+}
+func (m *ExplainResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExplainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExplainResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ExplainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainResponse.Merge(dst, src)
+}
+func (m *ExplainResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExplainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*ReadRequest)(nil), "influxdata.platform.storage.ReadRequest")
 	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.ReadRequest.TraceEntry")
@@ -1084,6 +1235,9 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.CapabilitiesResponse.CapsEntry")
 	proto.RegisterType((*HintsResponse)(nil), "influxdata.platform.storage.HintsResponse")
 	proto.RegisterType((*TimestampRange)(nil), "influxdata.platform.storage.TimestampRange")
+	proto.RegisterType((*Window)(nil), "influxdata.platform.storage.Window")
+	proto.RegisterType((*ExplainRequest)(nil), "influxdata.platform.storage.ExplainRequest")
+	proto.RegisterType((*ExplainResponse)(nil), "influxdata.platform.storage.ExplainResponse")
 	proto.RegisterEnum("influxdata.platform.storage.ReadRequest_Group", ReadRequest_Group_name, ReadRequest_Group_value)
 	proto.RegisterEnum("influxdata.platform.storage.ReadRequest_HintFlags", ReadRequest_HintFlags_name, ReadRequest_HintFlags_value)
 	proto.RegisterEnum("influxdata.platform.storage.Aggregate_AggregateType", Aggregate_AggregateType_name, Aggregate_AggregateType_value)
@@ -1107,6 +1261,8 @@ type StorageClient interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
 	Hints(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*HintsResponse, error)
+	// Explain describes the costs associated with executing a given Read request
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
 }
 
 type storageClient struct {
@@ -1167,6 +1323,15 @@ func (c *storageClient) Hints(ctx context.Context, in *types.Empty, opts ...grpc
 	return out, nil
 }
 
+func (c *storageClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, "/influxdata.platform.storage.Storage/Explain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Storage service
 
 type StorageServer interface {
@@ -1175,6 +1340,8 @@ type StorageServer interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(context.Context, *types.Empty) (*CapabilitiesResponse, error)
 	Hints(context.Context, *types.Empty) (*HintsResponse, error)
+	// Explain describes the costs associated with executing a given Read request
+	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
 }
 
 func RegisterStorageServer(s *grpc.Server, srv StorageServer) {
@@ -1238,6 +1405,24 @@ func _Storage_Hints_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influxdata.platform.storage.Storage/Explain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Storage_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "influxdata.platform.storage.Storage",
 	HandlerType: (*StorageServer)(nil),
@@ -1250,6 +1435,10 @@ var _Storage_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Hints",
 			Handler:    _Storage_Hints_Handler,
 		},
+		{
+			MethodName: "Explain",
+			Handler:    _Storage_Explain_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1334,15 +1523,17 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintStorageCommon(dAtA, i, uint64(m.PointsLimit))
 	}
-	if m.Aggregate != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Aggregate.Size()))
-		n3, err := m.Aggregate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Aggregate) > 0 {
+		for _, msg := range m.Aggregate {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n3
 	}
 	if len(m.Trace) > 0 {
 		for k, _ := range m.Trace {
@@ -1376,7 +1567,17 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x6a
 		i++
 		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ReadSource.Size()))
-		n4, err := m.ReadSource.MarshalTo(dAtA[i:])
+		n3, err := m.ReadSource.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Window != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Window.Size()))
+		n4, err := m.Window.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
@@ -1949,6 +2150,111 @@ func (m *TimestampRange) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *Window) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Window) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Every != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Every))
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Offset))
+	}
+	return i, nil
+}
+
+func (m *ExplainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExplainRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ReadRequest != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ReadRequest.Size()))
+		n13, err := m.ReadRequest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+
+func (m *ExplainResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExplainResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Plan) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(len(m.Plan)))
+		i += copy(dAtA[i:], m.Plan)
+	}
+	if m.SeriesCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.SeriesCount))
+	}
+	if m.ScannedValues != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ScannedValues))
+	}
+	if m.ScannedBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ScannedBytes))
+	}
+	if m.ScannedBlocks != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ScannedBlocks))
+	}
+	if m.CacheValues != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.CacheValues))
+	}
+	return i, nil
+}
+
 func encodeVarintStorageCommon(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1985,9 +2291,11 @@ func (m *ReadRequest) Size() (n int) {
 	if m.PointsLimit != 0 {
 		n += 1 + sovStorageCommon(uint64(m.PointsLimit))
 	}
-	if m.Aggregate != nil {
-		l = m.Aggregate.Size()
-		n += 1 + l + sovStorageCommon(uint64(l))
+	if len(m.Aggregate) > 0 {
+		for _, e := range m.Aggregate {
+			l = e.Size()
+			n += 1 + l + sovStorageCommon(uint64(l))
+		}
 	}
 	if len(m.Trace) > 0 {
 		for k, v := range m.Trace {
@@ -2007,6 +2315,10 @@ func (m *ReadRequest) Size() (n int) {
 		l = m.ReadSource.Size()
 		n += 1 + l + sovStorageCommon(uint64(l))
 	}
+	if m.Window != nil {
+		l = m.Window.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
 	return n
 }
 
@@ -2253,6 +2565,53 @@ func (m *TimestampRange) Size() (n int) {
 	return n
 }
 
+func (m *Window) Size() (n int) {
+	var l int
+	_ = l
+	if m.Every != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Every))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *ExplainRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ReadRequest != nil {
+		l = m.ReadRequest.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	return n
+}
+
+func (m *ExplainResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Plan)
+	if l > 0 {
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovStorageCommon(uint64(m.SeriesCount))
+	}
+	if m.ScannedValues != 0 {
+		n += 1 + sovStorageCommon(uint64(m.ScannedValues))
+	}
+	if m.ScannedBytes != 0 {
+		n += 1 + sovStorageCommon(uint64(m.ScannedBytes))
+	}
+	if m.ScannedBlocks != 0 {
+		n += 1 + sovStorageCommon(uint64(m.ScannedBlocks))
+	}
+	if m.CacheValues != 0 {
+		n += 1 + sovStorageCommon(uint64(m.CacheValues))
+	}
+	return n
+}
+
 func sovStorageCommon(x uint64) (n int) {
 	for {
 		n++
@@ -2490,10 +2849,8 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Aggregate == nil {
-				m.Aggregate = &Aggregate{}
-			}
-			if err := m.Aggregate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Aggregate = append(m.Aggregate, &Aggregate{})
+			if err := m.Aggregate[len(m.Aggregate)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2677,6 +3034,39 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Window == nil {
+				m.Window = &Window{}
+			}
+			if err := m.Window.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorageCommon(dAtA[iNdEx:])
@@ -4479,6 +4869,351 @@ func (m *TimestampRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Window) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Window: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Window: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Every", wireType)
+			}
+			m.Every = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Every |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExplainRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExplainRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExplainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadRequest == nil {
+				m.ReadRequest = &ReadRequest{}
+			}
+			if err := m.ReadRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExplainResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExplainResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExplainResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Plan", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Plan = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannedValues", wireType)
+			}
+			m.ScannedValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScannedValues |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannedBytes", wireType)
+			}
+			m.ScannedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScannedBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannedBlocks", wireType)
+			}
+			m.ScannedBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScannedBlocks |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheValues", wireType)
+			}
+			m.CacheValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheValues |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipStorageCommon(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4585,106 +5320,121 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("storage_common.proto", fileDescriptor_storage_common_01b6ac29b3fb8162)
-}
-
-var fileDescriptor_storage_common_01b6ac29b3fb8162 = []byte{
-	// 1539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x5f, 0x8f, 0x2a, 0x49,
-	0x15, 0xa7, 0xf9, 0xcf, 0xe1, 0xcf, 0xf4, 0xad, 0xc5, 0x09, 0xdb, 0xd7, 0x85, 0x5e, 0x62, 0x36,
-	0xa8, 0x2b, 0xa3, 0xec, 0x6e, 0xbc, 0xb9, 0xea, 0x03, 0xcc, 0x70, 0x07, 0x9c, 0x19, 0x98, 0x14,
-	0x8c, 0x71, 0x4d, 0x0c, 0xa9, 0x81, 0xa2, 0xb7, 0xb3, 0x4d, 0x77, 0xdb, 0xdd, 0x6c, 0x86, 0xc4,
-	0x77, 0x37, 0x3c, 0x5d, 0x5f, 0x4d, 0x48, 0x4c, 0x7c, 0xf4, 0xdd, 0xcf, 0x70, 0xdf, 0xf4, 0x13,
-	0x10, 0xc5, 0x0f, 0x61, 0xe2, 0x93, 0xa9, 0xaa, 0x6e, 0x68, 0x66, 0xae, 0x13, 0x78, 0xab, 0x3a,
-	0x7f, 0x7e, 0xbf, 0x53, 0xa7, 0xcf, 0x39, 0x55, 0x0d, 0x45, 0xd7, 0xb3, 0x1c, 0xa2, 0xd1, 0xd1,
-	0xd8, 0x9a, 0xcd, 0x2c, 0xb3, 0x6e, 0x3b, 0x96, 0x67, 0xa1, 0x97, 0xba, 0x39, 0x35, 0xe6, 0x0f,
-	0x13, 0xe2, 0x91, 0xba, 0x6d, 0x10, 0x6f, 0x6a, 0x39, 0xb3, 0xba, 0x6f, 0xa9, 0x14, 0x35, 0x4b,
-	0xb3, 0xb8, 0xdd, 0x19, 0x5b, 0x09, 0x17, 0xe5, 0xa5, 0x66, 0x59, 0x9a, 0x41, 0xcf, 0xf8, 0xee,
-	0x7e, 0x3e, 0x3d, 0xa3, 0x33, 0xdb, 0x5b, 0xf8, 0xca, 0x0f, 0x1f, 0x2b, 0x89, 0x19, 0xa8, 0x4e,
-	0x6c, 0x87, 0x4e, 0xf4, 0x31, 0xf1, 0xa8, 0x10, 0x54, 0xff, 0x93, 0x86, 0x2c, 0xa6, 0x64, 0x82,
-	0xe9, 0xef, 0xe6, 0xd4, 0xf5, 0x90, 0x01, 0x27, 0x9e, 0x3e, 0xa3, 0xae, 0x47, 0x66, 0xf6, 0xc8,
-	0x21, 0xa6, 0x46, 0x4b, 0x51, 0x55, 0xaa, 0x65, 0x1b, 0x3f, 0xac, 0x3f, 0x13, 0x65, 0x7d, 0x18,
-	0xf8, 0x60, 0xe6, 0xd2, 0x3a, 0x7d, 0xb7, 0xae, 0x44, 0x36, 0xeb, 0x4a, 0x61, 0x5f, 0x8e, 0x0b,
-	0xde, 0xde, 0x1e, 0x95, 0x01, 0x26, 0xd4, 0x1d, 0x53, 0x73, 0xa2, 0x9b, 0x5a, 0x29, 0xa6, 0x4a,
-	0xb5, 0x34, 0x0e, 0x49, 0xd0, 0xa7, 0x00, 0x9a, 0x63, 0xcd, 0xed, 0xd1, 0xd7, 0x74, 0xe1, 0x96,
-	0xe2, 0x6a, 0xac, 0x96, 0x69, 0xe5, 0x37, 0xeb, 0x4a, 0xe6, 0x92, 0x49, 0xaf, 0xe8, 0xc2, 0xc5,
-	0x19, 0x2d, 0x58, 0xa2, 0x0b, 0xc8, 0x6c, 0x8f, 0x57, 0x4a, 0xf0, 0xa8, 0x3f, 0x79, 0x36, 0xea,
-	0xdb, 0xc0, 0x1a, 0xef, 0x1c, 0x51, 0x03, 0x72, 0x2e, 0x75, 0x74, 0xea, 0x8e, 0x0c, 0x7d, 0xa6,
-	0x7b, 0xa5, 0xa4, 0x2a, 0xd5, 0x62, 0xad, 0x93, 0xcd, 0xba, 0x92, 0x1d, 0x70, 0xf9, 0x35, 0x13,
-	0xe3, 0xac, 0xbb, 0xdb, 0xa0, 0x2f, 0x20, 0xef, 0xfb, 0x58, 0xd3, 0xa9, 0x4b, 0xbd, 0x52, 0x8a,
-	0x3b, 0xc9, 0x9b, 0x75, 0x25, 0x27, 0x9c, 0xfa, 0x5c, 0x8e, 0x7d, 0x68, 0xb1, 0x63, 0x54, 0xb6,
-	0xa5, 0x9b, 0x5e, 0x40, 0x95, 0xde, 0x51, 0xdd, 0x72, 0xb9, 0x4f, 0x65, 0xef, 0x36, 0xec, 0x90,
-	0x44, 0xd3, 0x1c, 0xaa, 0xb1, 0x43, 0x66, 0x0e, 0x38, 0x64, 0x33, 0xb0, 0xc6, 0x3b, 0x47, 0x34,
-	0x84, 0x84, 0xe7, 0x90, 0x31, 0x2d, 0x81, 0x1a, 0xab, 0x65, 0x1b, 0x9f, 0x3d, 0x8b, 0x10, 0xaa,
-	0x8f, 0xfa, 0x90, 0x79, 0xb5, 0x4d, 0xcf, 0x59, 0xb4, 0x32, 0x9b, 0x75, 0x25, 0xc1, 0xf7, 0x58,
-	0x80, 0xa1, 0x0b, 0x48, 0xf0, 0xaf, 0x51, 0xca, 0xaa, 0x52, 0xad, 0xd0, 0xa8, 0x1f, 0x8c, 0xca,
-	0x3f, 0x27, 0x16, 0xce, 0xe8, 0x53, 0x48, 0x7c, 0xc5, 0xce, 0x5b, 0xca, 0xa9, 0x52, 0x2d, 0xd5,
-	0x3a, 0x65, 0x34, 0x1d, 0x26, 0xf8, 0xef, 0xba, 0x92, 0x61, 0x8b, 0x37, 0x06, 0xd1, 0x5c, 0x2c,
-	0x8c, 0x50, 0x1b, 0xb2, 0x0e, 0x25, 0x93, 0x91, 0x6b, 0xcd, 0x9d, 0x31, 0x2d, 0xe5, 0x79, 0x46,
-	0x8a, 0x75, 0xd1, 0x02, 0xf5, 0xa0, 0x05, 0xea, 0x4d, 0x73, 0xd1, 0x2a, 0x6c, 0xd6, 0x15, 0x60,
-	0xb4, 0x03, 0x6e, 0x8b, 0xc1, 0xd9, 0xae, 0x95, 0x57, 0x00, 0xbb, 0xa3, 0x21, 0x19, 0x62, 0x5f,
-	0xd3, 0x45, 0x49, 0x52, 0xa5, 0x5a, 0x06, 0xb3, 0x25, 0x2a, 0x42, 0xe2, 0x1b, 0x62, 0xcc, 0x45,
-	0x37, 0x64, 0xb0, 0xd8, 0xbc, 0x8e, 0xbe, 0x92, 0xaa, 0x7f, 0x90, 0x20, 0xc1, 0xe3, 0x47, 0x1f,
-	0x01, 0x5c, 0xe2, 0xfe, 0xdd, 0xed, 0xa8, 0xd7, 0xef, 0xb5, 0xe5, 0x88, 0x92, 0x5f, 0xae, 0x54,
-	0x51, 0xa9, 0x3d, 0xcb, 0xa4, 0xe8, 0x25, 0x64, 0x84, 0xba, 0x79, 0x7d, 0x2d, 0x4b, 0x4a, 0x6e,
-	0xb9, 0x52, 0xd3, 0x5c, 0xdb, 0x34, 0x0c, 0xf4, 0x21, 0xa4, 0x85, 0xb2, 0xf5, 0xa5, 0x1c, 0x55,
-	0xb2, 0xcb, 0x95, 0x9a, 0xe2, 0xba, 0xd6, 0x02, 0x7d, 0x0c, 0x39, 0xa1, 0x6a, 0xff, 0xfa, 0xbc,
-	0x7d, 0x3b, 0x94, 0x63, 0xca, 0xc9, 0x72, 0xa5, 0x66, 0xb9, 0xba, 0xfd, 0x30, 0xa6, 0xb6, 0xa7,
-	0xc4, 0xbf, 0xfd, 0x4b, 0x39, 0x52, 0xfd, 0xab, 0x04, 0xbb, 0xfc, 0x30, 0xba, 0x4e, 0xb7, 0x37,
-	0x0c, 0x82, 0xe1, 0x74, 0x4c, 0xcb, 0x63, 0xf9, 0x1e, 0x14, 0x7c, 0xe5, 0xe8, 0xb6, 0xdf, 0xed,
-	0x0d, 0x07, 0xb2, 0xa4, 0xc8, 0xcb, 0x95, 0x9a, 0x13, 0x16, 0xa2, 0xfa, 0xc2, 0x56, 0x83, 0x36,
-	0xee, 0xb6, 0x07, 0x72, 0x34, 0x6c, 0x25, 0x2a, 0x1b, 0x9d, 0x41, 0x91, 0x5b, 0x0d, 0xce, 0x3b,
-	0xed, 0x9b, 0x26, 0x3b, 0xdd, 0x68, 0xd8, 0xbd, 0x69, 0xcb, 0x71, 0xe5, 0x3b, 0xcb, 0x95, 0xfa,
-	0x82, 0xd9, 0x0e, 0xc6, 0x5f, 0xd1, 0x19, 0x69, 0x1a, 0x06, 0x9b, 0x07, 0x7e, 0xb4, 0x7f, 0x97,
-	0x20, 0xb3, 0xad, 0x4d, 0xd4, 0x81, 0xb8, 0xb7, 0xb0, 0x29, 0x4f, 0x79, 0xa1, 0xf1, 0xf9, 0x61,
-	0x15, 0xbd, 0x5b, 0x0d, 0x17, 0x36, 0xc5, 0x1c, 0xa1, 0xfa, 0x00, 0xf9, 0x3d, 0x31, 0xaa, 0x40,
-	0xdc, 0xcf, 0x01, 0x8f, 0x67, 0x4f, 0xc9, 0x93, 0xf1, 0x11, 0xc4, 0x06, 0x77, 0x37, 0xb2, 0xa4,
-	0x14, 0x97, 0x2b, 0x55, 0xde, 0xd3, 0x0f, 0xe6, 0x33, 0xf4, 0x31, 0x24, 0xce, 0xfb, 0x77, 0xbd,
-	0xa1, 0x1c, 0x55, 0x4e, 0x97, 0x2b, 0x15, 0xed, 0x19, 0x9c, 0x5b, 0x73, 0x33, 0xc8, 0xff, 0x8f,
-	0x20, 0x36, 0x24, 0x5a, 0xb8, 0x78, 0x72, 0xef, 0x29, 0x9e, 0x9c, 0x5f, 0x3c, 0xd5, 0x3f, 0x16,
-	0x20, 0x27, 0x9a, 0xc0, 0xb5, 0x2d, 0xd3, 0xa5, 0xe8, 0x06, 0x92, 0x53, 0x87, 0xcc, 0xa8, 0x5b,
-	0x92, 0x78, 0x57, 0x9e, 0x1d, 0xd0, 0x3f, 0xc2, 0xb5, 0xfe, 0x86, 0xf9, 0xb5, 0xe2, 0x6c, 0xec,
-	0x62, 0x1f, 0x44, 0xf9, 0x36, 0x09, 0x09, 0x2e, 0x47, 0x7d, 0x48, 0x8a, 0xb9, 0xc3, 0x83, 0xca,
-	0x36, 0xbe, 0x38, 0x1c, 0x58, 0x7c, 0x63, 0x0e, 0xd3, 0x89, 0x60, 0x1f, 0x06, 0xd9, 0x90, 0x9b,
-	0x1a, 0x16, 0xf1, 0x46, 0x62, 0x32, 0xf9, 0x57, 0xc4, 0xeb, 0x23, 0xe2, 0x65, 0xde, 0xa2, 0xca,
-	0x44, 0xe8, 0x7c, 0xe8, 0x85, 0xa4, 0x9d, 0x08, 0xce, 0x4e, 0x77, 0x5b, 0xf4, 0x00, 0x05, 0xdd,
-	0xf4, 0xa8, 0x46, 0x9d, 0x80, 0x33, 0xc6, 0x39, 0x7f, 0x7e, 0x38, 0x67, 0x57, 0xf8, 0x87, 0x59,
-	0x5f, 0x6c, 0xd6, 0x95, 0xfc, 0x9e, 0xbc, 0x13, 0xc1, 0x79, 0x3d, 0x2c, 0x40, 0xbf, 0x87, 0x93,
-	0xb9, 0xe9, 0xea, 0x9a, 0x49, 0x27, 0x01, 0x75, 0x9c, 0x53, 0xff, 0xe2, 0x70, 0xea, 0x3b, 0x1f,
-	0x20, 0xcc, 0x8d, 0xd8, 0xfd, 0xb8, 0xaf, 0xe8, 0x44, 0x70, 0x61, 0xbe, 0x27, 0x61, 0xe7, 0xbe,
-	0xb7, 0x2c, 0x83, 0x12, 0x33, 0x20, 0x4f, 0x1c, 0x7b, 0xee, 0x96, 0xf0, 0x7f, 0x72, 0xee, 0x3d,
-	0x39, 0x3b, 0xf7, 0x7d, 0x58, 0x80, 0x3c, 0xc8, 0xbb, 0x9e, 0xa3, 0x9b, 0x5a, 0x40, 0x9c, 0xe4,
-	0xc4, 0x3f, 0x3b, 0xa2, 0x76, 0xb8, 0x7b, 0x98, 0x57, 0x5c, 0x88, 0x21, 0x71, 0x27, 0x82, 0x73,
-	0x6e, 0x68, 0x8f, 0xae, 0x83, 0x2b, 0x24, 0xc5, 0xd9, 0x3e, 0x3f, 0x9c, 0x8d, 0xcf, 0xc3, 0xa0,
-	0x50, 0x05, 0x48, 0x2b, 0x09, 0x71, 0xe6, 0xa9, 0x3c, 0x00, 0xec, 0xd4, 0xe8, 0x13, 0x48, 0x7b,
-	0x44, 0x13, 0x6f, 0x0a, 0xd6, 0x69, 0xb9, 0x56, 0x76, 0xb3, 0xae, 0xa4, 0x86, 0x44, 0xe3, 0x2f,
-	0x8a, 0x94, 0x27, 0x16, 0xa8, 0x05, 0xc8, 0x26, 0x8e, 0xa7, 0x7b, 0xba, 0x65, 0x32, 0xeb, 0xd1,
-	0x37, 0xc4, 0x60, 0xb5, 0xce, 0x3c, 0x8a, 0x9b, 0x75, 0x45, 0xbe, 0x0d, 0xb4, 0x57, 0x74, 0xf1,
-	0x2b, 0x62, 0xb8, 0x58, 0xb6, 0x1f, 0x49, 0x94, 0x3f, 0x49, 0x90, 0x0d, 0xf5, 0x10, 0x7a, 0x0d,
-	0x71, 0x8f, 0x68, 0x41, 0x87, 0xab, 0xcf, 0x3f, 0xaa, 0x88, 0xe6, 0xb7, 0x34, 0xf7, 0x41, 0x7d,
-	0xc8, 0x30, 0xc3, 0x11, 0x1f, 0x94, 0x51, 0x3e, 0x28, 0x1b, 0x87, 0xe7, 0xe7, 0x82, 0x78, 0x84,
-	0x8f, 0xc9, 0xf4, 0xc4, 0x5f, 0x29, 0xbf, 0x04, 0xf9, 0x71, 0x23, 0xb2, 0x27, 0xd9, 0xf6, 0x91,
-	0x26, 0xc2, 0x94, 0x71, 0x48, 0x82, 0x4e, 0x21, 0xc9, 0xc7, 0x97, 0x48, 0x84, 0x84, 0xfd, 0x9d,
-	0x72, 0x0d, 0xe8, 0x69, 0x83, 0x1d, 0x89, 0x16, 0xdb, 0xa2, 0xdd, 0xc0, 0x07, 0xef, 0xe9, 0x99,
-	0x23, 0xe1, 0xe2, 0xe1, 0xe0, 0x9e, 0x76, 0xc1, 0x91, 0x68, 0xe9, 0x2d, 0xda, 0x15, 0xbc, 0x78,
-	0x52, 0xda, 0x47, 0x82, 0x65, 0x02, 0xb0, 0xea, 0x00, 0x32, 0x1c, 0xc0, 0xbf, 0xaa, 0x92, 0xfe,
-	0x45, 0x1b, 0x51, 0x3e, 0x58, 0xae, 0xd4, 0x93, 0xad, 0xca, 0xbf, 0x6b, 0x2b, 0x90, 0xdc, 0xde,
-	0xd7, 0xfb, 0x06, 0x22, 0x16, 0xff, 0x26, 0xfa, 0x9b, 0x04, 0xe9, 0xe0, 0x7b, 0xa3, 0xef, 0x42,
-	0xe2, 0xcd, 0x75, 0xbf, 0x39, 0x94, 0x23, 0xca, 0x8b, 0xe5, 0x4a, 0xcd, 0x07, 0x0a, 0xfe, 0xe9,
-	0x91, 0x0a, 0xa9, 0x6e, 0x6f, 0xd8, 0xbe, 0x6c, 0xe3, 0x00, 0x32, 0xd0, 0xfb, 0x9f, 0x13, 0x55,
-	0x21, 0x7d, 0xd7, 0x1b, 0x74, 0x2f, 0x7b, 0xed, 0x0b, 0x39, 0x2a, 0xee, 0xc8, 0xc0, 0x24, 0xf8,
-	0x46, 0x0c, 0xa5, 0xd5, 0xef, 0x5f, 0xb7, 0x9b, 0x3d, 0x39, 0xb6, 0x8f, 0xe2, 0xe7, 0x1d, 0x95,
-	0x21, 0x39, 0x18, 0xe2, 0x6e, 0xef, 0x52, 0x8e, 0x2b, 0x68, 0xb9, 0x52, 0x0b, 0x81, 0x81, 0x48,
-	0xa5, 0x1f, 0xf8, 0x9f, 0x25, 0x28, 0x9e, 0x13, 0x9b, 0xdc, 0xeb, 0x86, 0xee, 0xe9, 0xd4, 0xdd,
-	0xde, 0x8d, 0x7d, 0x88, 0x8f, 0x89, 0x1d, 0xf4, 0xcd, 0xf3, 0x43, 0xe8, 0x7d, 0x00, 0x4c, 0xe8,
-	0xf2, 0xc7, 0x1d, 0xe6, 0x40, 0xca, 0x4f, 0x21, 0xb3, 0x15, 0x1d, 0xf5, 0xde, 0x3b, 0x81, 0x3c,
-	0x7f, 0x8d, 0x06, 0xc8, 0xd5, 0x57, 0xf0, 0xe8, 0x37, 0x87, 0x39, 0xbb, 0x1e, 0x71, 0x3c, 0x0e,
-	0x18, 0xc3, 0x62, 0xc3, 0x48, 0xa8, 0x39, 0xe1, 0x80, 0x31, 0xcc, 0x96, 0x8d, 0xb7, 0x51, 0x48,
-	0x0d, 0x44, 0xd0, 0xe8, 0xb7, 0x10, 0x67, 0xed, 0x8a, 0x6a, 0x87, 0x3e, 0x9a, 0x95, 0xef, 0x1f,
-	0xdc, 0xfb, 0x3f, 0x96, 0xd0, 0x97, 0x90, 0x0b, 0xa7, 0x05, 0x9d, 0x3e, 0x79, 0x21, 0xb7, 0xd9,
-	0x1f, 0xa4, 0xf2, 0x93, 0xa3, 0x33, 0x8b, 0xae, 0x40, 0x3c, 0xcf, 0xff, 0x2f, 0xe6, 0x0f, 0x9e,
-	0xc5, 0xdc, 0x4b, 0x66, 0xab, 0xf2, 0xee, 0x5f, 0xe5, 0xc8, 0xbb, 0x4d, 0x59, 0xfa, 0xc7, 0xa6,
-	0x2c, 0xfd, 0x73, 0x53, 0x96, 0xde, 0xfe, 0xbb, 0x1c, 0xf9, 0x0d, 0x9f, 0x7b, 0x6c, 0xec, 0xb9,
-	0xf7, 0x49, 0x0e, 0xfe, 0xd9, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x62, 0x34, 0xad, 0x1c, 0x4b,
-	0x0f, 0x00, 0x00,
+	proto.RegisterFile("storage_common.proto", fileDescriptor_storage_common_9ead84b3718d40d0)
+}
+
+var fileDescriptor_storage_common_9ead84b3718d40d0 = []byte{
+	// 1788 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xcd, 0x6f, 0x23, 0x49,
+	0x15, 0x77, 0xfb, 0x33, 0x7e, 0xfe, 0x48, 0x4f, 0x6d, 0x88, 0xbc, 0x3d, 0x6c, 0xdc, 0xeb, 0x85,
+	0x55, 0x80, 0xc1, 0x81, 0xec, 0x2e, 0x8c, 0x66, 0xe1, 0x60, 0x67, 0x9c, 0xc4, 0x4c, 0x6c, 0x47,
+	0x65, 0x67, 0xd9, 0x45, 0x20, 0xab, 0x62, 0x57, 0x7a, 0x5a, 0xd3, 0xee, 0x6e, 0xba, 0xdb, 0xb3,
+	0xb1, 0xc4, 0x9d, 0x95, 0x4f, 0x70, 0x05, 0x59, 0x42, 0xe2, 0xc8, 0x15, 0xf1, 0x37, 0xcc, 0x91,
+	0xbf, 0xc0, 0x02, 0xf3, 0x27, 0x20, 0x71, 0xe0, 0x84, 0xea, 0xa3, 0xed, 0xf6, 0x24, 0x64, 0xed,
+	0x5b, 0xbd, 0xaf, 0xdf, 0x7b, 0x55, 0xf5, 0x5e, 0xbd, 0xd7, 0x0d, 0x7b, 0x7e, 0xe0, 0x78, 0xc4,
+	0xa0, 0xfd, 0x81, 0x33, 0x1a, 0x39, 0x76, 0xd5, 0xf5, 0x9c, 0xc0, 0x41, 0x8f, 0x4d, 0xfb, 0xc6,
+	0x1a, 0xdf, 0x0e, 0x49, 0x40, 0xaa, 0xae, 0x45, 0x82, 0x1b, 0xc7, 0x1b, 0x55, 0xa5, 0xa6, 0xb6,
+	0x67, 0x38, 0x86, 0xc3, 0xf5, 0x8e, 0xd8, 0x4a, 0x98, 0x68, 0x8f, 0x0d, 0xc7, 0x31, 0x2c, 0x7a,
+	0xc4, 0xa9, 0xeb, 0xf1, 0xcd, 0x11, 0x1d, 0xb9, 0xc1, 0x44, 0x0a, 0xdf, 0x7d, 0x5b, 0x48, 0xec,
+	0x50, 0xb4, 0xeb, 0x7a, 0x74, 0x68, 0x0e, 0x48, 0x40, 0x05, 0xa3, 0xf2, 0xd7, 0x2c, 0xe4, 0x30,
+	0x25, 0x43, 0x4c, 0x7f, 0x3d, 0xa6, 0x7e, 0x80, 0x2c, 0xd8, 0x0d, 0xcc, 0x11, 0xf5, 0x03, 0x32,
+	0x72, 0xfb, 0x1e, 0xb1, 0x0d, 0x5a, 0x8a, 0xeb, 0xca, 0x61, 0xee, 0xf8, 0x7b, 0xd5, 0x07, 0xa2,
+	0xac, 0xf6, 0x42, 0x1b, 0xcc, 0x4c, 0xea, 0xfb, 0x6f, 0xe6, 0xe5, 0xd8, 0x62, 0x5e, 0x2e, 0xae,
+	0xf3, 0x71, 0x31, 0x58, 0xa3, 0xd1, 0x01, 0xc0, 0x90, 0xfa, 0x03, 0x6a, 0x0f, 0x4d, 0xdb, 0x28,
+	0x25, 0x74, 0xe5, 0x70, 0x07, 0x47, 0x38, 0xe8, 0x09, 0x80, 0xe1, 0x39, 0x63, 0xb7, 0xff, 0x8a,
+	0x4e, 0xfc, 0x52, 0x52, 0x4f, 0x1c, 0x66, 0xeb, 0x85, 0xc5, 0xbc, 0x9c, 0x3d, 0x63, 0xdc, 0x17,
+	0x74, 0xe2, 0xe3, 0xac, 0x11, 0x2e, 0xd1, 0x73, 0xc8, 0x2e, 0xb7, 0x57, 0x4a, 0xf1, 0xa8, 0x3f,
+	0x7c, 0x30, 0xea, 0xcb, 0x50, 0x1b, 0xaf, 0x0c, 0xd1, 0x31, 0xe4, 0x7d, 0xea, 0x99, 0xd4, 0xef,
+	0x5b, 0xe6, 0xc8, 0x0c, 0x4a, 0x69, 0x5d, 0x39, 0x4c, 0xd4, 0x77, 0x17, 0xf3, 0x72, 0xae, 0xcb,
+	0xf9, 0x17, 0x8c, 0x8d, 0x73, 0xfe, 0x8a, 0x40, 0x9f, 0x40, 0x41, 0xda, 0x38, 0x37, 0x37, 0x3e,
+	0x0d, 0x4a, 0x19, 0x6e, 0xa4, 0x2e, 0xe6, 0xe5, 0xbc, 0x30, 0xea, 0x70, 0x3e, 0xce, 0xfb, 0x11,
+	0x8a, 0xb9, 0x72, 0x1d, 0xd3, 0x0e, 0x42, 0x57, 0x3b, 0x2b, 0x57, 0x97, 0x9c, 0x2f, 0x5d, 0xb9,
+	0x2b, 0x82, 0x6d, 0x92, 0x18, 0x86, 0x47, 0x0d, 0xb6, 0xc9, 0xac, 0x9e, 0xf8, 0xda, 0x4d, 0xd6,
+	0x42, 0x6d, 0xbc, 0x32, 0x44, 0x3d, 0x48, 0x05, 0x1e, 0x19, 0xd0, 0x12, 0x70, 0x84, 0x8f, 0x1e,
+	0x44, 0x88, 0xe4, 0x47, 0xb5, 0xc7, 0xac, 0x1a, 0x76, 0xe0, 0x4d, 0xea, 0xd9, 0xc5, 0xbc, 0x9c,
+	0xe2, 0x34, 0x16, 0x60, 0xe8, 0x39, 0xa4, 0xf8, 0x6d, 0x94, 0x72, 0xba, 0x72, 0x58, 0x3c, 0xae,
+	0x6e, 0x8c, 0xca, 0xaf, 0x13, 0x0b, 0x63, 0xf4, 0x04, 0x52, 0x2f, 0xd9, 0x7e, 0x4b, 0x79, 0x5d,
+	0x39, 0xcc, 0xd4, 0xf7, 0x99, 0x9b, 0x73, 0xc6, 0xf8, 0xef, 0xbc, 0x9c, 0x65, 0x8b, 0x53, 0x8b,
+	0x18, 0x3e, 0x16, 0x4a, 0xa8, 0x01, 0x39, 0x8f, 0x92, 0x61, 0xdf, 0x77, 0xc6, 0xde, 0x80, 0x96,
+	0x0a, 0xfc, 0xda, 0xf7, 0xaa, 0xa2, 0x04, 0xaa, 0x61, 0x09, 0x54, 0x6b, 0xf6, 0xa4, 0x5e, 0x5c,
+	0xcc, 0xcb, 0xc0, 0xdc, 0x76, 0xb9, 0x2e, 0x06, 0x6f, 0xb9, 0x46, 0x9f, 0x42, 0xfa, 0x4b, 0xd3,
+	0x1e, 0x3a, 0x5f, 0x96, 0x8a, 0x1c, 0xe1, 0x83, 0x07, 0x63, 0xff, 0x39, 0x57, 0xc5, 0xd2, 0x44,
+	0x7b, 0x0a, 0xb0, 0x3a, 0x17, 0xa4, 0x42, 0xe2, 0x15, 0x9d, 0x94, 0x14, 0x5d, 0x39, 0xcc, 0x62,
+	0xb6, 0x44, 0x7b, 0x90, 0x7a, 0x4d, 0xac, 0xb1, 0x28, 0xa5, 0x2c, 0x16, 0xc4, 0xb3, 0xf8, 0x53,
+	0xa5, 0xf2, 0x5b, 0x05, 0x52, 0x7c, 0xf3, 0xe8, 0x3d, 0x80, 0x33, 0xdc, 0xb9, 0xba, 0xec, 0xb7,
+	0x3b, 0xed, 0x86, 0x1a, 0xd3, 0x0a, 0xd3, 0x99, 0x2e, 0xd2, 0xbc, 0xed, 0xd8, 0x14, 0x3d, 0x86,
+	0xac, 0x10, 0xd7, 0x2e, 0x2e, 0x54, 0x45, 0xcb, 0x4f, 0x67, 0xfa, 0x0e, 0x97, 0xd6, 0x2c, 0x0b,
+	0xbd, 0x0b, 0x3b, 0x42, 0x58, 0xff, 0x42, 0x8d, 0x6b, 0xb9, 0xe9, 0x4c, 0xcf, 0x70, 0x59, 0x7d,
+	0x82, 0xde, 0x87, 0xbc, 0x10, 0x35, 0x3e, 0x3f, 0x69, 0x5c, 0xf6, 0xd4, 0x84, 0xb6, 0x3b, 0x9d,
+	0xe9, 0x39, 0x2e, 0x6e, 0xdc, 0x0e, 0xa8, 0x1b, 0x68, 0xc9, 0xaf, 0xfe, 0x7c, 0x10, 0xab, 0xfc,
+	0x45, 0x81, 0xd5, 0xe1, 0x32, 0x77, 0xe7, 0xcd, 0x76, 0x2f, 0x0c, 0x86, 0xbb, 0x63, 0x52, 0x1e,
+	0xcb, 0xb7, 0xa0, 0x28, 0x85, 0xfd, 0xcb, 0x4e, 0xb3, 0xdd, 0xeb, 0xaa, 0x8a, 0xa6, 0x4e, 0x67,
+	0x7a, 0x5e, 0x68, 0x88, 0xd4, 0x8d, 0x6a, 0x75, 0x1b, 0xb8, 0xd9, 0xe8, 0xaa, 0xf1, 0xa8, 0x96,
+	0x28, 0x0b, 0x74, 0x04, 0x7b, 0x5c, 0xab, 0x7b, 0x72, 0xde, 0x68, 0xd5, 0xd8, 0xee, 0xfa, 0xbd,
+	0x66, 0xab, 0xa1, 0x26, 0xb5, 0x6f, 0x4c, 0x67, 0xfa, 0x23, 0xa6, 0xdb, 0x1d, 0xbc, 0xa4, 0x23,
+	0x52, 0xb3, 0x2c, 0xf6, 0x98, 0xc8, 0x68, 0xff, 0x1d, 0x87, 0xec, 0x32, 0xb1, 0xd1, 0x39, 0x24,
+	0x83, 0x89, 0x4b, 0xf9, 0x91, 0x17, 0x8f, 0x3f, 0xde, 0xac, 0x1c, 0x56, 0xab, 0xde, 0xc4, 0xa5,
+	0x98, 0x23, 0x54, 0xfe, 0x18, 0x87, 0xc2, 0x1a, 0x1f, 0x95, 0x21, 0x29, 0x0f, 0x81, 0x07, 0xb4,
+	0x26, 0xe4, 0xa7, 0xf1, 0x1e, 0x24, 0xba, 0x57, 0x2d, 0x55, 0xd1, 0xf6, 0xa6, 0x33, 0x5d, 0x5d,
+	0x93, 0x77, 0xc7, 0x23, 0xf4, 0x3e, 0xa4, 0x4e, 0x3a, 0x57, 0xed, 0x9e, 0x1a, 0xd7, 0xf6, 0xa7,
+	0x33, 0x1d, 0xad, 0x29, 0x9c, 0x38, 0x63, 0x3b, 0x60, 0x08, 0xad, 0x66, 0x5b, 0x4d, 0xdc, 0x83,
+	0xd0, 0x32, 0x6d, 0x2e, 0xae, 0x7d, 0xae, 0x26, 0xef, 0x13, 0x93, 0x5b, 0xe6, 0xe0, 0xb4, 0x89,
+	0xbb, 0x3d, 0x35, 0x75, 0x8f, 0x83, 0x53, 0xd3, 0xf3, 0x03, 0xb6, 0x87, 0x8b, 0x5a, 0xb7, 0xa7,
+	0xa6, 0xef, 0xd9, 0xc3, 0x05, 0x11, 0x0a, 0xad, 0x46, 0xad, 0xad, 0x66, 0xee, 0x51, 0x68, 0x51,
+	0x62, 0xcb, 0x53, 0xff, 0x3e, 0x24, 0x7a, 0xc4, 0x88, 0x26, 0x78, 0xfe, 0x9e, 0x04, 0xcf, 0xcb,
+	0x04, 0xaf, 0xfc, 0xbe, 0x08, 0x79, 0x51, 0xe5, 0xbe, 0xeb, 0xd8, 0x3e, 0x45, 0x2d, 0x48, 0xdf,
+	0x78, 0x64, 0x44, 0xfd, 0x92, 0xc2, 0x9f, 0x9d, 0xa3, 0x0d, 0x1e, 0x08, 0x61, 0x5a, 0x3d, 0x65,
+	0x76, 0xf5, 0x24, 0xeb, 0x2b, 0x58, 0x82, 0x68, 0x5f, 0xa5, 0x21, 0xc5, 0xf9, 0xa8, 0x03, 0x69,
+	0xf1, 0xb0, 0xf2, 0xa0, 0x72, 0xc7, 0x9f, 0x6c, 0x0e, 0x2c, 0xf2, 0x90, 0xc3, 0x9c, 0xc7, 0xb0,
+	0x84, 0x41, 0x2e, 0xe4, 0x6f, 0x2c, 0x87, 0x04, 0x7d, 0xf1, 0xf4, 0xca, 0x1e, 0xf8, 0x6c, 0x8b,
+	0x78, 0x99, 0xb5, 0xa8, 0x04, 0x11, 0x3a, 0x7f, 0xd5, 0x23, 0xdc, 0xf3, 0x18, 0xce, 0xdd, 0xac,
+	0x48, 0x74, 0x0b, 0x45, 0xd3, 0x0e, 0xa8, 0x41, 0xbd, 0xd0, 0x67, 0x82, 0xfb, 0xfc, 0xc9, 0xe6,
+	0x3e, 0x9b, 0xc2, 0x3e, 0xea, 0xf5, 0xd1, 0x62, 0x5e, 0x2e, 0xac, 0xf1, 0xcf, 0x63, 0xb8, 0x60,
+	0x46, 0x19, 0xe8, 0x37, 0xb0, 0x3b, 0xb6, 0x7d, 0xd3, 0xb0, 0xe9, 0x30, 0x74, 0x9d, 0xe4, 0xae,
+	0x7f, 0xba, 0xb9, 0xeb, 0x2b, 0x09, 0x10, 0xf5, 0x8d, 0xd8, 0x00, 0xb0, 0x2e, 0x38, 0x8f, 0xe1,
+	0xe2, 0x78, 0x8d, 0xc3, 0xf6, 0x7d, 0xed, 0x38, 0x16, 0x25, 0x76, 0xe8, 0x3c, 0xb5, 0xed, 0xbe,
+	0xeb, 0xc2, 0xfe, 0xce, 0xbe, 0xd7, 0xf8, 0x6c, 0xdf, 0xd7, 0x51, 0x06, 0x0a, 0xa0, 0xe0, 0x07,
+	0x9e, 0x69, 0x1b, 0xa1, 0xe3, 0x34, 0x77, 0xfc, 0xe9, 0x16, 0xb9, 0xc3, 0xcd, 0xa3, 0x7e, 0x45,
+	0xc7, 0x8f, 0xb0, 0xcf, 0x63, 0x38, 0xef, 0x47, 0x68, 0x74, 0x11, 0xf6, 0xc8, 0x0c, 0xf7, 0xf6,
+	0xf1, 0xe6, 0xde, 0xf8, 0x9b, 0x1d, 0x26, 0xaa, 0x00, 0xa9, 0xa7, 0x21, 0xc9, 0x2c, 0xb5, 0x5b,
+	0x80, 0x95, 0x18, 0x7d, 0x08, 0x3b, 0x01, 0x31, 0xc4, 0xd0, 0xc4, 0x2a, 0x2d, 0x5f, 0xcf, 0x2d,
+	0xe6, 0xe5, 0x4c, 0x8f, 0x18, 0x7c, 0x64, 0xca, 0x04, 0x62, 0x81, 0xea, 0x80, 0x5c, 0xe2, 0x05,
+	0x66, 0x60, 0x3a, 0x36, 0xd3, 0xee, 0xbf, 0x26, 0x16, 0xcb, 0x75, 0x66, 0xb1, 0xb7, 0x98, 0x97,
+	0xd5, 0xcb, 0x50, 0xfa, 0x82, 0x4e, 0x3e, 0x23, 0x96, 0x8f, 0x55, 0xf7, 0x2d, 0x8e, 0xf6, 0x07,
+	0x05, 0x72, 0x91, 0x1a, 0x42, 0xcf, 0x20, 0x19, 0x10, 0x23, 0xac, 0x70, 0xfd, 0xe1, 0xa9, 0x91,
+	0x18, 0xb2, 0xa4, 0xb9, 0x0d, 0xea, 0x40, 0x96, 0x29, 0xf6, 0xf9, 0x63, 0x1e, 0xe7, 0x8f, 0xf9,
+	0xf1, 0xe6, 0xe7, 0xf3, 0x9c, 0x04, 0x84, 0x3f, 0xe5, 0x3b, 0x43, 0xb9, 0xd2, 0x7e, 0x06, 0xea,
+	0xdb, 0x85, 0xc8, 0x66, 0xce, 0xe5, 0x14, 0x2a, 0xc2, 0x54, 0x71, 0x84, 0x83, 0xf6, 0x21, 0xcd,
+	0x9f, 0x2f, 0x71, 0x10, 0x0a, 0x96, 0x94, 0x76, 0x01, 0xe8, 0x6e, 0x81, 0x6d, 0x89, 0x96, 0x58,
+	0xa2, 0xb5, 0xe0, 0x9d, 0x7b, 0x6a, 0x66, 0x4b, 0xb8, 0x64, 0x34, 0xb8, 0xbb, 0x55, 0xb0, 0x25,
+	0xda, 0xce, 0x12, 0xed, 0x05, 0x3c, 0xba, 0x93, 0xda, 0x5b, 0x82, 0x65, 0x43, 0xb0, 0x4a, 0x17,
+	0xb2, 0x1c, 0x40, 0x76, 0xd3, 0xb4, 0x1c, 0x06, 0x62, 0xda, 0x3b, 0xd3, 0x99, 0xbe, 0xbb, 0x14,
+	0xc9, 0x79, 0xa0, 0x0c, 0xe9, 0xe5, 0x4c, 0xb1, 0xae, 0x20, 0x62, 0x91, 0x9d, 0xe8, 0x6f, 0x0a,
+	0xec, 0x84, 0xf7, 0x8d, 0xbe, 0x09, 0xa9, 0xd3, 0x8b, 0x4e, 0xad, 0xa7, 0xc6, 0xb4, 0x47, 0xd3,
+	0x99, 0x5e, 0x08, 0x05, 0xfc, 0xea, 0x91, 0x0e, 0x99, 0x66, 0xbb, 0xd7, 0x38, 0x6b, 0xe0, 0x10,
+	0x32, 0x94, 0xcb, 0xeb, 0x44, 0x15, 0xd8, 0xb9, 0x6a, 0x77, 0x9b, 0x67, 0xed, 0xc6, 0x73, 0x35,
+	0x2e, 0xba, 0x6c, 0xa8, 0x12, 0xde, 0x11, 0x43, 0xa9, 0x77, 0x3a, 0x17, 0xac, 0x49, 0x26, 0xd6,
+	0x51, 0xe4, 0xb9, 0xa3, 0x03, 0x48, 0x77, 0x7b, 0xb8, 0xd9, 0x3e, 0x53, 0x93, 0x1a, 0x9a, 0xce,
+	0xf4, 0x62, 0xa8, 0x20, 0x8e, 0x52, 0x06, 0xfe, 0x27, 0x05, 0xf6, 0x4e, 0x88, 0x4b, 0xae, 0x4d,
+	0xcb, 0x0c, 0x4c, 0xea, 0x2f, 0x7b, 0x63, 0x07, 0x92, 0x03, 0xe2, 0x86, 0x75, 0xf3, 0xf0, 0x23,
+	0x74, 0x1f, 0x00, 0x63, 0xfa, 0x7c, 0x00, 0xc5, 0x1c, 0x48, 0xfb, 0x31, 0x64, 0x97, 0xac, 0xad,
+	0x66, 0xd2, 0x5d, 0x28, 0xf0, 0x71, 0x3b, 0x44, 0xae, 0x3c, 0x85, 0xb7, 0xbe, 0xe3, 0x98, 0xb1,
+	0x1f, 0x10, 0x2f, 0xe0, 0x80, 0x09, 0x2c, 0x08, 0xe6, 0x84, 0xda, 0x43, 0x0e, 0x98, 0xc0, 0x6c,
+	0x59, 0xf9, 0x11, 0xa4, 0xc5, 0xa8, 0xcc, 0x2c, 0xe8, 0x6b, 0xea, 0x4d, 0x42, 0x0b, 0x4e, 0xb0,
+	0x9c, 0x91, 0x1f, 0x4c, 0xc2, 0x48, 0x52, 0x15, 0x1b, 0x8a, 0x8d, 0x5b, 0xd7, 0x22, 0xa6, 0x1d,
+	0x7e, 0x97, 0xfe, 0x12, 0xf2, 0x7c, 0xcc, 0xf7, 0x04, 0x2d, 0xfb, 0xfc, 0xe1, 0xa6, 0x5f, 0x18,
+	0xa2, 0xfd, 0x46, 0x18, 0x38, 0xe7, 0xad, 0x88, 0xca, 0x5c, 0x81, 0xdd, 0xa5, 0x43, 0x79, 0x21,
+	0x08, 0x92, 0xae, 0x45, 0x6c, 0x79, 0x66, 0x7c, 0xcd, 0xa6, 0x69, 0xf9, 0x9d, 0x37, 0x60, 0x93,
+	0x9b, 0x8c, 0x5a, 0x7e, 0x0a, 0x8a, 0x61, 0xee, 0xdb, 0x50, 0xf4, 0x07, 0xc4, 0x66, 0xcd, 0x54,
+	0x96, 0x43, 0x82, 0x2b, 0x15, 0x24, 0xf7, 0x33, 0xce, 0x44, 0x1f, 0x40, 0xc8, 0xe8, 0x5f, 0x4f,
+	0x02, 0x2a, 0x5a, 0x6e, 0x02, 0xe7, 0x25, 0xb3, 0xce, 0x78, 0x51, 0xac, 0x6b, 0xcb, 0x19, 0xbc,
+	0x12, 0xbd, 0x71, 0x85, 0x55, 0xe7, 0x4c, 0x16, 0xd5, 0x80, 0x0c, 0x5e, 0xd2, 0xd0, 0x61, 0x5a,
+	0x44, 0xc5, 0x79, 0xc2, 0xdd, 0xf1, 0x7f, 0xe2, 0x90, 0xe9, 0x8a, 0x63, 0x41, 0xbf, 0x82, 0x24,
+	0x3b, 0x08, 0xb4, 0xf1, 0xe1, 0x69, 0xdf, 0xd9, 0xf8, 0x11, 0xfe, 0x81, 0x82, 0xbe, 0x80, 0x7c,
+	0x34, 0x3f, 0xd1, 0xfe, 0x9d, 0x6f, 0xb1, 0x06, 0xfb, 0x57, 0xa1, 0xfd, 0x70, 0xeb, 0x14, 0x47,
+	0x2f, 0x40, 0x7c, 0x08, 0xfe, 0x5f, 0xcc, 0xef, 0x3e, 0x88, 0xb9, 0x96, 0xd5, 0x68, 0x08, 0x19,
+	0x79, 0xe5, 0xe8, 0xe1, 0x7f, 0x1b, 0xeb, 0x99, 0xa8, 0x3d, 0xd9, 0x4c, 0x59, 0x78, 0xa9, 0x97,
+	0xdf, 0xfc, 0xf3, 0x20, 0xf6, 0x66, 0x71, 0xa0, 0xfc, 0x7d, 0x71, 0xa0, 0xfc, 0x63, 0x71, 0xa0,
+	0xfc, 0xee, 0x5f, 0x07, 0xb1, 0x5f, 0xf0, 0x36, 0xc7, 0xba, 0x9c, 0x7f, 0x9d, 0xe6, 0x5b, 0xf8,
+	0xe8, 0x7f, 0x03, 0x00, 0x77, 0x39, 0xbb, 0x7c, 0x1b, 0x12, 0x00, 0x00,
 }
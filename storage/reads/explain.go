@@ -0,0 +1,191 @@
+package reads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// Explain executes req, discarding the points read, and describes the costs
+// associated with executing it.
+func Explain(ctx context.Context, s Store, req *datatypes.ReadRequest) (*datatypes.ExplainResponse, error) {
+	exp := &datatypes.ExplainResponse{Plan: ExplainPlan(req)}
+
+	if req.Group == datatypes.GroupAll {
+		rs, err := s.Read(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil {
+			return exp, nil
+		}
+
+		rs = newExplainResultSet(rs, exp)
+		for rs.Next() {
+			drainCursor(rs.Cursor())
+		}
+		rs.Close()
+		return exp, rs.Err()
+	}
+
+	rs, err := s.GroupRead(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return exp, nil
+	}
+
+	rs = newExplainGroupResultSet(rs, exp)
+	for gc := rs.Next(); gc != nil; gc = rs.Next() {
+		for gc.Next() {
+			drainCursor(gc.Cursor())
+		}
+		gc.Close()
+	}
+	rs.Close()
+	return exp, rs.Err()
+}
+
+// ExplainPlan describes how the storage engine executes req.
+func ExplainPlan(req *datatypes.ReadRequest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n", req.Group)
+	if len(req.GroupKeys) > 0 {
+		fmt.Fprintf(&b, "  group keys: %s\n", strings.Join(req.GroupKeys, ", "))
+	}
+	fmt.Fprintf(&b, "  range: [%s, %s]\n", formatTimestamp(req.TimestampRange.Start), formatTimestamp(req.TimestampRange.End))
+	if req.Descending {
+		b.WriteString("  order: descending\n")
+	} else {
+		b.WriteString("  order: ascending\n")
+	}
+	fmt.Fprintf(&b, "  predicate: %s\n", PredicateToExprString(req.Predicate))
+	if len(req.Aggregate) > 0 {
+		aggs := make([]string, 0, len(req.Aggregate))
+		for _, agg := range req.Aggregate {
+			aggs = append(aggs, strings.ToLower(agg.Type.String()))
+		}
+		fmt.Fprintf(&b, "  aggregate: %s\n", strings.Join(aggs, ", "))
+	}
+	if req.Window != nil {
+		fmt.Fprintf(&b, "  window: every %s, offset %s\n", time.Duration(req.Window.Every), time.Duration(req.Window.Offset))
+	}
+	if req.SeriesLimit > 0 || req.SeriesOffset > 0 {
+		fmt.Fprintf(&b, "  series limit: %d, offset %d\n", req.SeriesLimit, req.SeriesOffset)
+	}
+	if req.PointsLimit > 0 {
+		fmt.Fprintf(&b, "  points limit: %d\n", req.PointsLimit)
+	}
+	if req.Hints.NoPoints() {
+		b.WriteString("  series keys only\n")
+	}
+	return b.String()
+}
+
+func formatTimestamp(ts int64) string {
+	return time.Unix(0, ts).UTC().Format(time.RFC3339Nano)
+}
+
+// setExplainStats sets the stats of exp to those of the cursors of a read.
+// The stats of the cursors are cumulative for the read.
+func setExplainStats(exp *datatypes.ExplainResponse, stats cursors.CursorStats) {
+	exp.ScannedValues = int64(stats.ScannedValues)
+	exp.ScannedBytes = int64(stats.ScannedBytes)
+	exp.ScannedBlocks = int64(stats.ScannedBlocks)
+	exp.CacheValues = int64(stats.CacheValues)
+}
+
+// explainResultSet counts the series of a ResultSet and records the stats
+// of its cursors when closed.
+type explainResultSet struct {
+	ResultSet
+	exp *datatypes.ExplainResponse
+}
+
+func newExplainResultSet(rs ResultSet, exp *datatypes.ExplainResponse) ResultSet {
+	return &explainResultSet{ResultSet: rs, exp: exp}
+}
+
+func (r *explainResultSet) Next() bool {
+	if !r.ResultSet.Next() {
+		return false
+	}
+	r.exp.SeriesCount++
+	return true
+}
+
+func (r *explainResultSet) Close() {
+	setExplainStats(r.exp, r.ResultSet.Stats())
+	r.ResultSet.Close()
+}
+
+// explainGroupResultSet counts the series of a GroupResultSet and records
+// the stats of its cursors as each group is closed.
+type explainGroupResultSet struct {
+	GroupResultSet
+	exp *datatypes.ExplainResponse
+}
+
+func newExplainGroupResultSet(rs GroupResultSet, exp *datatypes.ExplainResponse) GroupResultSet {
+	return &explainGroupResultSet{GroupResultSet: rs, exp: exp}
+}
+
+func (r *explainGroupResultSet) Next() GroupCursor {
+	gc := r.GroupResultSet.Next()
+	if gc == nil {
+		return nil
+	}
+	return &explainGroupCursor{GroupCursor: gc, exp: r.exp}
+}
+
+type explainGroupCursor struct {
+	GroupCursor
+	exp *datatypes.ExplainResponse
+}
+
+func (c *explainGroupCursor) Next() bool {
+	if !c.GroupCursor.Next() {
+		return false
+	}
+	c.exp.SeriesCount++
+	return true
+}
+
+func (c *explainGroupCursor) Close() {
+	setExplainStats(c.exp, c.GroupCursor.Stats())
+	c.GroupCursor.Close()
+}
+
+// drainCursor reads all points of cur and closes it.
+func drainCursor(cur cursors.Cursor) {
+	if cur == nil {
+		return
+	}
+
+	switch c := cur.(type) {
+	case cursors.IntegerArrayCursor:
+		for c.Next().Len() > 0 {
+		}
+	case cursors.FloatArrayCursor:
+		for c.Next().Len() > 0 {
+		}
+	case cursors.UnsignedArrayCursor:
+		for c.Next().Len() > 0 {
+		}
+	case cursors.BooleanArrayCursor:
+		for c.Next().Len() > 0 {
+		}
+	case cursors.StringArrayCursor:
+		for c.Next().Len() > 0 {
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", c))
+	}
+	cur.Close()
+}
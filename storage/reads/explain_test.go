@@ -0,0 +1,119 @@
+package reads_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+func TestExplainPlan(t *testing.T) {
+	start := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	req := &datatypes.ReadRequest{
+		Group:          datatypes.GroupBy,
+		GroupKeys:      []string{"host", "region"},
+		TimestampRange: datatypes.TimestampRange{Start: start.UnixNano(), End: start.Add(time.Hour).UnixNano()},
+		Aggregate: []*datatypes.Aggregate{
+			{Type: datatypes.AggregateTypeSum},
+			{Type: datatypes.AggregateTypeCount},
+		},
+		Window:      &datatypes.Window{Every: int64(10 * time.Minute)},
+		PointsLimit: 100,
+	}
+
+	exp := `GROUP_BY
+  group keys: host, region
+  range: [2018-10-01T00:00:00Z, 2018-10-01T01:00:00Z]
+  order: ascending
+  predicate: [none]
+  aggregate: sum, count
+  window: every 10m0s, offset 0s
+  points limit: 100
+`
+	if got := reads.ExplainPlan(req); !cmp.Equal(got, exp) {
+		t.Errorf("unexpected value; -got/+exp\n%s", cmp.Diff(strings.Split(got, "\n"), strings.Split(exp, "\n")))
+	}
+}
+
+func TestExplain(t *testing.T) {
+	stats := cursors.CursorStats{ScannedValues: 10, ScannedBytes: 80, ScannedBlocks: 2, CacheValues: 3}
+
+	newCursor := func() (reads.SeriesCursor, error) {
+		rows := newSeriesRows(
+			"cpu,tag0=val00",
+			"cpu,tag0=val01",
+			"cpu,tag0=val02",
+		)
+		// all series of a read share the same cursor iterators
+		itrs := cursors.CursorIterators{&statsCursorIterator{stats: stats}}
+		for i := range rows {
+			rows[i].Query = itrs
+		}
+		return &sliceSeriesCursor{rows: rows}, nil
+	}
+
+	tests := []struct {
+		name  string
+		group datatypes.ReadRequest_Group
+	}{
+		{name: "read", group: datatypes.GroupAll},
+		{name: "group read", group: datatypes.GroupBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hints datatypes.HintFlags
+			hints.SetHintSchemaAllTime()
+			req := &datatypes.ReadRequest{Group: tt.group, GroupKeys: []string{"tag0"}, Hints: hints}
+
+			got, err := reads.Explain(context.Background(), &explainStore{newCursor: newCursor}, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			exp := &datatypes.ExplainResponse{
+				Plan:          reads.ExplainPlan(req),
+				SeriesCount:   3,
+				ScannedValues: 10,
+				ScannedBytes:  80,
+				ScannedBlocks: 2,
+				CacheValues:   3,
+			}
+			if !cmp.Equal(got, exp) {
+				t.Errorf("unexpected value; -got/+exp\n%s", cmp.Diff(got, exp))
+			}
+		})
+	}
+}
+
+type explainStore struct {
+	reads.Store
+	newCursor func() (reads.SeriesCursor, error)
+}
+
+func (s *explainStore) Read(ctx context.Context, req *datatypes.ReadRequest) (reads.ResultSet, error) {
+	cur, err := s.newCursor()
+	if err != nil {
+		return nil, err
+	}
+	return reads.NewResultSet(ctx, req, cur), nil
+}
+
+func (s *explainStore) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (reads.GroupResultSet, error) {
+	return reads.NewGroupResultSet(ctx, req, s.newCursor), nil
+}
+
+type statsCursorIterator struct {
+	stats cursors.CursorStats
+}
+
+func (itr *statsCursorIterator) Next(ctx context.Context, r *cursors.CursorRequest) (cursors.Cursor, error) {
+	return nil, nil
+}
+
+func (itr *statsCursorIterator) Stats() cursors.CursorStats { return itr.stats }
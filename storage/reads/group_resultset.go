@@ -48,7 +48,6 @@ func NewGroupResultSet(ctx context.Context, req *datatypes.ReadRequest, newCurso
 	g := &groupResultSet{
 		ctx:         ctx,
 		req:         req,
-		agg:         req.Aggregate,
 		keys:        make([][]byte, len(req.GroupKeys)),
 		nilSort:     nilSortHi,
 		newCursorFn: newCursorFn,
@@ -58,6 +57,11 @@ func NewGroupResultSet(ctx context.Context, req *datatypes.ReadRequest, newCurso
 		o(g)
 	}
 
+	// Grouped reads support a single aggregate, which is validated by the store.
+	if len(req.Aggregate) > 0 {
+		g.agg = req.Aggregate[0]
+	}
+
 	g.mb = newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit)
 
 	for i, k := range req.GroupKeys {
@@ -71,10 +75,19 @@ func NewGroupResultSet(ctx context.Context, req *datatypes.ReadRequest, newCurso
 		g.rgc = groupByCursor{
 			ctx:  ctx,
 			mb:   g.mb,
-			agg:  req.Aggregate,
+			agg:  g.agg,
 			vals: make([][]byte, len(req.GroupKeys)),
 		}
 
+	case datatypes.GroupExcept:
+		g.sortFn = groupExceptSort
+		g.nextGroupFn = groupExceptNextGroup
+		g.rgc = groupByCursor{
+			ctx: ctx,
+			mb:  g.mb,
+			agg: g.agg,
+		}
+
 	case datatypes.GroupNone:
 		g.sortFn = groupNoneSort
 		g.nextGroupFn = groupNoneNextGroup
@@ -242,6 +255,79 @@ func groupByNextGroup(g *groupResultSet) GroupCursor {
 }
 
 func groupBySort(g *groupResultSet) (int, error) {
+	vals := make([][]byte, len(g.keys))
+	return g.sortRows(func(row *SeriesRow) {
+		l := 0
+		for i, k := range g.keys {
+			vals[i] = row.Tags.Get(k)
+			if len(vals[i]) == 0 {
+				vals[i] = g.nilSort
+			}
+			l += len(vals[i])
+		}
+
+		row.SortKey = make([]byte, 0, l)
+		for _, v := range vals {
+			row.SortKey = append(row.SortKey, v...)
+		}
+	})
+}
+
+func groupExceptNextGroup(g *groupResultSet) GroupCursor {
+	row := g.rows[g.i]
+	g.rgc.vals = g.rgc.vals[:0]
+	for _, t := range row.Tags {
+		if !g.isGroupKey(t.Key) {
+			g.rgc.vals = append(g.rgc.vals, t.Value)
+		}
+	}
+
+	g.km.clear()
+	rowKey := row.SortKey
+	j := g.i
+	for j < len(g.rows) && bytes.Equal(rowKey, g.rows[j].SortKey) {
+		g.km.mergeTagKeys(g.rows[j].Tags)
+		j++
+	}
+
+	g.rgc.reset(g.rows[g.i:j])
+	g.rgc.keys = g.km.get()
+
+	g.i = j
+	if j == len(g.rows) {
+		g.eof = true
+	}
+
+	return &g.rgc
+}
+
+// groupExceptSort sorts the series by the keys and values of all tags except
+// those identified by GroupKeys. Series with a different set of remaining
+// tag keys are in different groups.
+func groupExceptSort(g *groupResultSet) (int, error) {
+	return g.sortRows(func(row *SeriesRow) {
+		l := 0
+		for _, t := range row.Tags {
+			if !g.isGroupKey(t.Key) {
+				l += len(t.Key) + len(t.Value) + 2
+			}
+		}
+
+		row.SortKey = make([]byte, 0, l)
+		for _, t := range row.Tags {
+			if !g.isGroupKey(t.Key) {
+				row.SortKey = append(row.SortKey, t.Key...)
+				row.SortKey = append(row.SortKey, 0)
+				row.SortKey = append(row.SortKey, t.Value...)
+				row.SortKey = append(row.SortKey, 0)
+			}
+		}
+	})
+}
+
+// sortRows reads all series with points, sets the SortKey of each using
+// sortKey and sorts them.
+func (g *groupResultSet) sortRows(sortKey func(row *SeriesRow)) (int, error) {
 	cur, err := g.newCursorFn()
 	if err != nil {
 		return 0, err
@@ -250,7 +336,6 @@ func groupBySort(g *groupResultSet) (int, error) {
 	}
 
 	var rows []*SeriesRow
-	vals := make([][]byte, len(g.keys))
 	tagsBuf := &tagsBuffer{sz: 4096}
 	allTime := g.req.Hints.HintSchemaAllTime()
 
@@ -260,21 +345,7 @@ func groupBySort(g *groupResultSet) (int, error) {
 			nr := *row
 			nr.SeriesTags = tagsBuf.copyTags(nr.SeriesTags)
 			nr.Tags = tagsBuf.copyTags(nr.Tags)
-
-			l := 0
-			for i, k := range g.keys {
-				vals[i] = nr.Tags.Get(k)
-				if len(vals[i]) == 0 {
-					vals[i] = g.nilSort
-				}
-				l += len(vals[i])
-			}
-
-			nr.SortKey = make([]byte, 0, l)
-			for _, v := range vals {
-				nr.SortKey = append(nr.SortKey, v...)
-			}
-
+			sortKey(&nr)
 			rows = append(rows, &nr)
 		}
 		row = cur.Next()
@@ -290,6 +361,16 @@ func groupBySort(g *groupResultSet) (int, error) {
 	return len(rows), nil
 }
 
+// isGroupKey returns true if key is one of the GroupKeys of the request.
+func (g *groupResultSet) isGroupKey(key []byte) bool {
+	for _, k := range g.keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
 type groupNoneCursor struct {
 	ctx  context.Context
 	mb   multiShardCursors
@@ -362,10 +443,11 @@ func (c *groupByCursor) Cursor() cursors.Cursor {
 	return cur
 }
 
+// Stats returns the stats for the underlying cursors. The series of a group
+// share their cursor iterators, so the stats are those of the first series.
 func (c *groupByCursor) Stats() cursors.CursorStats {
-	var stats cursors.CursorStats
-	for _, row := range c.rows {
-		stats.Add(row.Query.Stats())
+	if len(c.rows) == 0 {
+		return cursors.CursorStats{}
 	}
-	return stats
+	return c.rows[0].Query.Stats()
 }
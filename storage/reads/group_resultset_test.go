@@ -168,6 +168,60 @@ group:
   tag key      : _m,tag1,tag2
   partition key: <nil>,val21
     series: _m=mem,tag1=val11,tag2=val21
+`,
+		},
+		{
+			name: "group except tag0 with partial series",
+			cur: &sliceSeriesCursor{
+				rows: newSeriesRows(
+					"aaa,tag0=val00",
+					"aaa,tag0=val01",
+					"cpu,tag0=val00,tag1=val10",
+					"cpu,tag0=val01,tag1=val10",
+					"cpu,tag0=val00,tag1=val11",
+				)},
+			group: datatypes.GroupExcept,
+			keys:  []string{"tag0"},
+			exp: `group:
+  tag key      : _m,tag0
+  partition key: aaa
+    series: _m=aaa,tag0=val00
+    series: _m=aaa,tag0=val01
+group:
+  tag key      : _m,tag0,tag1
+  partition key: cpu,val10
+    series: _m=cpu,tag0=val00,tag1=val10
+    series: _m=cpu,tag0=val01,tag1=val10
+group:
+  tag key      : _m,tag0,tag1
+  partition key: cpu,val11
+    series: _m=cpu,tag0=val00,tag1=val11
+`,
+		},
+		{
+			name: "group except tag1 merges tag keys",
+			cur: &sliceSeriesCursor{
+				rows: newSeriesRows(
+					"cpu,tag0=val00,tag1=val10",
+					"cpu,tag0=val01,tag1=val10",
+					"cpu,tag0=val00",
+					"mem,tag0=val00,tag1=val11",
+				)},
+			group: datatypes.GroupExcept,
+			keys:  []string{"tag1"},
+			exp: `group:
+  tag key      : _m,tag0,tag1
+  partition key: cpu,val00
+    series: _m=cpu,tag0=val00,tag1=val10
+    series: _m=cpu,tag0=val00
+group:
+  tag key      : _m,tag0,tag1
+  partition key: cpu,val01
+    series: _m=cpu,tag0=val01,tag1=val10
+group:
+  tag key      : _m,tag0,tag1
+  partition key: mem,val00
+    series: _m=mem,tag0=val00,tag1=val11
 `,
 		},
 	}
@@ -223,6 +277,21 @@ func TestNewGroupResultSet_GroupBy_NoDataReturnsNil(t *testing.T) {
 	}
 }
 
+func TestNewGroupResultSet_GroupExcept_NoDataReturnsNil(t *testing.T) {
+	newCursor := func() (reads.SeriesCursor, error) {
+		return &sliceSeriesCursor{
+			rows: newSeriesRows(
+				"aaa,tag0=val00",
+				"aaa,tag0=val01",
+			)}, nil
+	}
+
+	rs := reads.NewGroupResultSet(context.Background(), &datatypes.ReadRequest{Group: datatypes.GroupExcept, GroupKeys: []string{"tag0"}}, newCursor)
+	if rs != nil {
+		t.Errorf("expected nil cursor")
+	}
+}
+
 func TestNewGroupResultSet_Sorting(t *testing.T) {
 	tests := []struct {
 		name string
@@ -89,10 +89,32 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		req.Hints.SetNoPoints()
 	}
 
-	if agg, err := determineAggregateMethod(bi.readSpec.AggregateMethod); err != nil {
+	if aggs, err := determineAggregateMethods(bi.readSpec.AggregateMethod); err != nil {
 		return err
-	} else if agg != datatypes.AggregateTypeNone {
-		req.Aggregate = &datatypes.Aggregate{Type: agg}
+	} else {
+		req.Aggregate = aggs
+	}
+
+	if bi.readSpec.WindowEvery > 0 {
+		req.Window = &datatypes.Window{
+			Every:  bi.readSpec.WindowEvery,
+			Offset: bi.readSpec.WindowOffset,
+		}
+	}
+
+	var exp *datatypes.ExplainResponse
+	if c := fstorage.ExplainCollectorFromContext(bi.ctx); c != nil {
+		exp = &datatypes.ExplainResponse{Plan: ExplainPlan(&req)}
+		defer func() {
+			c.Add(fstorage.ReadExplain{
+				Plan:          exp.Plan,
+				SeriesCount:   exp.SeriesCount,
+				ScannedValues: exp.ScannedValues,
+				ScannedBytes:  exp.ScannedBytes,
+				ScannedBlocks: exp.ScannedBlocks,
+				CacheValues:   exp.CacheValues,
+			})
+		}()
 	}
 
 	switch {
@@ -106,6 +128,10 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 			return nil
 		}
 
+		if exp != nil {
+			rs = newExplainGroupResultSet(rs, exp)
+		}
+
 		if req.Hints.NoPoints() {
 			return bi.handleGroupReadNoPoints(f, rs)
 		}
@@ -121,9 +147,16 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 			return nil
 		}
 
+		if exp != nil {
+			rs = newExplainResultSet(rs, exp)
+		}
+
 		if req.Hints.NoPoints() {
 			return bi.handleReadNoPoints(f, rs)
 		}
+		if req.Window != nil {
+			return bi.handleWindowRead(f, rs, req.Window, req.Aggregate)
+		}
 		return bi.handleRead(f, rs)
 	}
 }
@@ -198,6 +231,101 @@ READ:
 	return rs.Err()
 }
 
+// handleWindowRead produces a table for each window of each series.
+func (bi *tableIterator) handleWindowRead(f func(flux.Table) error, rs ResultSet, window *datatypes.Window, aggs []*datatypes.Aggregate) error {
+	// these resources must be closed if not nil on return
+	var (
+		cur   cursors.Cursor
+		table storageTable
+	)
+
+	defer func() {
+		if table != nil {
+			table.Close()
+		}
+		if cur != nil {
+			cur.Close()
+		}
+		rs.Close()
+	}()
+
+READ:
+	for rs.Next() {
+		cur = rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		var win windowArrayCursor
+		cur, win = newWindowArrayCursor(cur, window.Every, window.Offset, false, !bi.readSpec.Descending)
+		hasTime := isSelector(aggregateOfSeries(aggs, rs.Tags()))
+
+		for {
+			start, ok := win.nextWindow()
+			if !ok {
+				break
+			}
+
+			bounds := execute.Bounds{
+				Start: execute.Time(start),
+				Stop:  execute.Time(start + window.Every),
+			}
+			if bounds.Start < bi.bounds.Start {
+				bounds.Start = bi.bounds.Start
+			}
+			if bounds.Stop > bi.bounds.Stop {
+				bounds.Stop = bi.bounds.Stop
+			}
+
+			// the tables share cur, which is closed once all windows are read
+			key := groupKeyForSeries(rs.Tags(), &bi.readSpec, bounds)
+			done := make(chan struct{})
+			switch typedCur := cur.(type) {
+			case cursors.IntegerArrayCursor:
+				cols, defs := determineTableColsForWindow(rs.Tags(), flux.TInt, hasTime)
+				table = newIntegerTable(done, integerNopCloseArrayCursor{typedCur}, bounds, key, cols, rs.Tags(), defs)
+			case cursors.FloatArrayCursor:
+				cols, defs := determineTableColsForWindow(rs.Tags(), flux.TFloat, hasTime)
+				table = newFloatTable(done, floatNopCloseArrayCursor{typedCur}, bounds, key, cols, rs.Tags(), defs)
+			case cursors.UnsignedArrayCursor:
+				cols, defs := determineTableColsForWindow(rs.Tags(), flux.TUInt, hasTime)
+				table = newUnsignedTable(done, unsignedNopCloseArrayCursor{typedCur}, bounds, key, cols, rs.Tags(), defs)
+			case cursors.BooleanArrayCursor:
+				cols, defs := determineTableColsForWindow(rs.Tags(), flux.TBool, hasTime)
+				table = newBooleanTable(done, booleanNopCloseArrayCursor{typedCur}, bounds, key, cols, rs.Tags(), defs)
+			case cursors.StringArrayCursor:
+				cols, defs := determineTableColsForWindow(rs.Tags(), flux.TString, hasTime)
+				table = newStringTable(done, stringNopCloseArrayCursor{typedCur}, bounds, key, cols, rs.Tags(), defs)
+			default:
+				panic(fmt.Sprintf("unreachable: %T", typedCur))
+			}
+
+			if !table.Empty() {
+				if err := f(table); err != nil {
+					table.Close()
+					table = nil
+					return err
+				}
+				select {
+				case <-done:
+				case <-bi.ctx.Done():
+					table.Cancel()
+					break READ
+				}
+			}
+
+			table.Close()
+			bi.stats = bi.stats.Add(table.Statistics())
+			table = nil
+		}
+
+		cur.Close()
+		cur = nil
+	}
+	return rs.Err()
+}
+
 func (bi *tableIterator) handleReadNoPoints(f func(flux.Table) error, rs ResultSet) error {
 	// these resources must be closed if not nil on return
 	var table storageTable
@@ -277,7 +405,7 @@ READ:
 			continue
 		}
 
-		key := groupKeyForGroup(gc.PartitionKeyVals(), &bi.readSpec, bi.bounds)
+		key := groupKeyForGroupCursor(gc, &bi.readSpec, bi.bounds)
 		done := make(chan struct{})
 		switch typedCur := cur.(type) {
 		case cursors.IntegerArrayCursor:
@@ -343,7 +471,7 @@ func (bi *tableIterator) handleGroupReadNoPoints(f func(flux.Table) error, rs Gr
 	gc = rs.Next()
 READ:
 	for gc != nil {
-		key := groupKeyForGroup(gc.PartitionKeyVals(), &bi.readSpec, bi.bounds)
+		key := groupKeyForGroupCursor(gc, &bi.readSpec, bi.bounds)
 		done := make(chan struct{})
 		cols, defs := determineTableColsForGroup(gc.Keys(), flux.TString)
 		table = newGroupTableNoPoints(done, bi.bounds, key, cols, defs)
@@ -370,15 +498,24 @@ READ:
 	return rs.Err()
 }
 
-func determineAggregateMethod(agg string) (datatypes.Aggregate_AggregateType, error) {
-	if agg == "" {
-		return datatypes.AggregateTypeNone, nil
+// determineAggregateMethods parses a comma-separated list of aggregate methods.
+func determineAggregateMethods(aggs string) ([]*datatypes.Aggregate, error) {
+	if aggs == "" {
+		return nil, nil
 	}
 
-	if t, ok := datatypes.Aggregate_AggregateType_value[strings.ToUpper(agg)]; ok {
-		return datatypes.Aggregate_AggregateType(t), nil
+	var res []*datatypes.Aggregate
+	for _, agg := range strings.Split(aggs, ",") {
+		t, ok := datatypes.Aggregate_AggregateType_value[strings.ToUpper(strings.TrimSpace(agg))]
+		if !ok {
+			return nil, fmt.Errorf("unknown aggregate type %q", agg)
+		}
+		if t == int32(datatypes.AggregateTypeNone) {
+			continue
+		}
+		res = append(res, &datatypes.Aggregate{Type: datatypes.Aggregate_AggregateType(t)})
 	}
-	return 0, fmt.Errorf("unknown aggregate type %q", agg)
+	return res, nil
 }
 
 func convertGroupMode(m fstorage.GroupMode) datatypes.ReadRequest_Group {
@@ -433,6 +570,39 @@ func determineTableColsForSeries(tags models.Tags, typ flux.ColType) ([]flux.Col
 	return cols, defs
 }
 
+// determineTableColsForWindow returns the columns of the tables of a windowed
+// aggregate. Like the flux aggregates they replace, the tables of aggregates
+// which are not selectors have no _time column.
+func determineTableColsForWindow(tags models.Tags, typ flux.ColType, hasTime bool) ([]flux.ColMeta, [][]byte) {
+	cols, defs := determineTableColsForSeries(tags, typ)
+	if !hasTime {
+		cols = append(cols[:timeColIdx], cols[timeColIdx+1:]...)
+		defs = append(defs[:timeColIdx], defs[timeColIdx+1:]...)
+	}
+	return cols, defs
+}
+
+// aggregateOfSeries returns the aggregate of a series read with aggs, which
+// is identified by its AggregateTagKey tag if there are several.
+func aggregateOfSeries(aggs []*datatypes.Aggregate, tags models.Tags) datatypes.Aggregate_AggregateType {
+	if len(aggs) == 1 {
+		return aggs[0].Type
+	}
+	name := strings.ToUpper(tags.GetString(AggregateTagKey))
+	return datatypes.Aggregate_AggregateType(datatypes.Aggregate_AggregateType_value[name])
+}
+
+// isSelector reports whether agg selects a point of the series, rather than
+// computing a new value.
+func isSelector(agg datatypes.Aggregate_AggregateType) bool {
+	switch agg {
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return true
+	}
+	return false
+}
+
 func groupKeyForSeries(tags models.Tags, readSpec *fstorage.ReadSpec, bnds execute.Bounds) flux.GroupKey {
 	cols := make([]flux.ColMeta, 2, len(tags))
 	vs := make([]values.Value, 2, len(tags))
@@ -462,8 +632,17 @@ func groupKeyForSeries(tags models.Tags, readSpec *fstorage.ReadSpec, bnds execu
 			}
 		}
 	case fstorage.GroupModeExcept:
-		// group key in GroupKeys order, skipping tags in the GroupKeys slice
-		panic("not implemented")
+		// group key in tag key order, skipping tags in the GroupKeys slice
+		for i := range tags {
+			if isGroupKey(string(tags[i].Key), readSpec.GroupKeys) {
+				continue
+			}
+			cols = append(cols, flux.ColMeta{
+				Label: string(tags[i].Key),
+				Type:  flux.TString,
+			})
+			vs = append(vs, values.NewString(string(tags[i].Value)))
+		}
 	case fstorage.GroupModeDefault, fstorage.GroupModeAll:
 		for i := range tags {
 			cols = append(cols, flux.ColMeta{
@@ -506,6 +685,14 @@ func determineTableColsForGroup(tagKeys [][]byte, typ flux.ColType) ([]flux.ColM
 	return cols, defs
 }
 
+// groupKeyForGroupCursor returns the group key for the partition of gc.
+func groupKeyForGroupCursor(gc GroupCursor, readSpec *fstorage.ReadSpec, bnds execute.Bounds) flux.GroupKey {
+	if readSpec.GroupMode == fstorage.GroupModeExcept {
+		return groupKeyForGroupExcept(gc.Keys(), gc.PartitionKeyVals(), readSpec, bnds)
+	}
+	return groupKeyForGroup(gc.PartitionKeyVals(), readSpec, bnds)
+}
+
 func groupKeyForGroup(kv [][]byte, readSpec *fstorage.ReadSpec, bnds execute.Bounds) flux.GroupKey {
 	cols := make([]flux.ColMeta, 2, len(readSpec.GroupKeys)+2)
 	vs := make([]values.Value, 2, len(readSpec.GroupKeys)+2)
@@ -531,3 +718,43 @@ func groupKeyForGroup(kv [][]byte, readSpec *fstorage.ReadSpec, bnds execute.Bou
 	}
 	return execute.NewGroupKey(cols, vs)
 }
+
+// groupKeyForGroupExcept returns the group key for a partition of the
+// GroupModeExcept strategy. The tag keys of all series of the partition,
+// except GroupKeys, are the partition keys in the order of kv.
+func groupKeyForGroupExcept(tagKeys, kv [][]byte, readSpec *fstorage.ReadSpec, bnds execute.Bounds) flux.GroupKey {
+	cols := make([]flux.ColMeta, 2, len(kv)+2)
+	vs := make([]values.Value, 2, len(kv)+2)
+	cols[0] = flux.ColMeta{
+		Label: execute.DefaultStartColLabel,
+		Type:  flux.TTime,
+	}
+	vs[0] = values.NewTime(bnds.Start)
+	cols[1] = flux.ColMeta{
+		Label: execute.DefaultStopColLabel,
+		Type:  flux.TTime,
+	}
+	vs[1] = values.NewTime(bnds.Stop)
+	i := 0
+	for _, k := range tagKeys {
+		if isGroupKey(string(k), readSpec.GroupKeys) {
+			continue
+		}
+		cols = append(cols, flux.ColMeta{
+			Label: string(k),
+			Type:  flux.TString,
+		})
+		vs = append(vs, values.NewString(string(kv[i])))
+		i++
+	}
+	return execute.NewGroupKey(cols, vs)
+}
+
+func isGroupKey(key string, groupKeys []string) bool {
+	for _, k := range groupKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package reads
 
 import (
 	"context"
+	"strings"
 
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/storage/reads/datatypes"
@@ -11,22 +12,31 @@ import (
 type multiShardCursors interface {
 	createCursor(row SeriesRow) cursors.Cursor
 	newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor
+	newWindowAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, cursor cursors.Cursor) cursors.Cursor
 }
 
+// AggregateTagKey is the key of the tag identifying the aggregate of each
+// series when a read request specifies more than one aggregate.
+const AggregateTagKey = "_aggregate"
+
 type resultSet struct {
-	ctx context.Context
-	agg *datatypes.Aggregate
-	cur SeriesCursor
-	row SeriesRow
-	mb  multiShardCursors
+	ctx    context.Context
+	aggs   []*datatypes.Aggregate
+	agg    int
+	window *datatypes.Window
+	cur    SeriesCursor
+	row    SeriesRow
+	tags   models.Tags
+	mb     multiShardCursors
 }
 
 func NewResultSet(ctx context.Context, req *datatypes.ReadRequest, cur SeriesCursor) ResultSet {
 	return &resultSet{
-		ctx: ctx,
-		agg: req.Aggregate,
-		cur: cur,
-		mb:  newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit),
+		ctx:    ctx,
+		aggs:   req.Aggregate,
+		window: req.Window,
+		cur:    cur,
+		mb:     newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit),
 	}
 }
 
@@ -47,25 +57,49 @@ func (r *resultSet) Next() bool {
 		return false
 	}
 
+	// Each series is visited once per aggregate.
+	if r.tags != nil && r.agg < len(r.aggs)-1 {
+		r.agg++
+		r.setAggregateTag()
+		return true
+	}
+
 	row := r.cur.Next()
 	if row == nil {
 		return false
 	}
 
 	r.row = *row
+	r.agg = 0
+	if len(r.aggs) > 1 {
+		r.tags = append(r.tags[:0], r.row.Tags...)
+		r.setAggregateTag()
+	}
 
 	return true
 }
 
+// setAggregateTag sets the aggregate tag of the current series to the name
+// of the current aggregate.
+func (r *resultSet) setAggregateTag() {
+	r.tags.SetString(AggregateTagKey, strings.ToLower(r.aggs[r.agg].Type.String()))
+}
+
 func (r *resultSet) Cursor() cursors.Cursor {
 	cur := r.mb.createCursor(r.row)
-	if r.agg != nil {
-		cur = r.mb.newAggregateCursor(r.ctx, r.agg, cur)
+	if len(r.aggs) > 0 {
+		if r.window != nil {
+			return r.mb.newWindowAggregateCursor(r.ctx, r.aggs[r.agg], r.window, cur)
+		}
+		cur = r.mb.newAggregateCursor(r.ctx, r.aggs[r.agg], cur)
 	}
 	return cur
 }
 
 func (r *resultSet) Tags() models.Tags {
+	if len(r.aggs) > 1 {
+		return r.tags
+	}
 	return r.row.Tags
 }
 
@@ -61,6 +61,10 @@ type GroupCursor interface {
 	// keys specified in ReadRequest#GroupKeys. The tag values values will
 	// appear in the same order as the GroupKeys.
 	//
+	// When the datatypes.GroupExcept strategy is specified, PartitionKeyVals
+	// returns the values of all tags except those identified by GroupKeys,
+	// in the order of the tag keys.
+	//
 	// When the datatypes.GroupNone strategy is specified, PartitionKeyVals will
 	// be nil.
 	PartitionKeyVals() [][]byte
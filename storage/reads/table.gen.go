@@ -115,8 +115,10 @@ func (t *floatTable) advance() bool {
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -215,8 +217,10 @@ RETRY:
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -353,8 +357,10 @@ func (t *integerTable) advance() bool {
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -453,8 +459,10 @@ RETRY:
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -591,8 +599,10 @@ func (t *unsignedTable) advance() bool {
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -691,8 +701,10 @@ RETRY:
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -829,8 +841,10 @@ func (t *stringTable) advance() bool {
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -929,8 +943,10 @@ RETRY:
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -1067,8 +1083,10 @@ func (t *booleanTable) advance() bool {
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
@@ -1167,8 +1185,10 @@ RETRY:
 	}
 	copy(t.valBuf, a.Values)
 
-	t.colBufs[timeColIdx] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
-	t.colBufs[valueColIdx] = t.toArrowBuffer(t.valBuf)
+	if t.timeCol >= 0 {
+		t.colBufs[t.timeCol] = arrow.NewInt(t.timeBuf, &memory.Allocator{})
+	}
+	t.colBufs[t.valueCol] = t.toArrowBuffer(t.valBuf)
 	t.appendTags()
 	t.appendBounds()
 	return true
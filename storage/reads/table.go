@@ -30,6 +30,11 @@ type table struct {
 	// The current number of records in memory
 	l int
 
+	// the indexes of the _time and _value columns. The tables of windowed
+	// aggregates which are not selectors have no _time column.
+	timeCol  int
+	valueCol int
+
 	colBufs []array.Interface
 	timeBuf []int64
 
@@ -46,13 +51,15 @@ func newTable(
 	defs [][]byte,
 ) table {
 	return table{
-		done:    done,
-		bounds:  bounds,
-		key:     key,
-		tags:    make([][]byte, len(cols)),
-		defs:    defs,
-		colBufs: make([]array.Interface, len(cols)),
-		cols:    cols,
+		done:     done,
+		bounds:   bounds,
+		key:      key,
+		tags:     make([][]byte, len(cols)),
+		defs:     defs,
+		timeCol:  execute.ColIdx(execute.DefaultTimeColLabel, cols),
+		valueCol: execute.ColIdx(execute.DefaultValueColLabel, cols),
+		colBufs:  make([]array.Interface, len(cols)),
+		cols:     cols,
 	}
 }
 
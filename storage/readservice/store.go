@@ -27,6 +27,14 @@ func (s *store) Read(ctx context.Context, req *datatypes.ReadRequest) (reads.Res
 		panic("Read: len(Grouping) > 0")
 	}
 
+	if req.Window != nil {
+		if len(req.Aggregate) == 0 {
+			return nil, errors.New("read: Window requires an Aggregate")
+		} else if req.Window.Every <= 0 {
+			return nil, errors.New("read: Window.Every must be positive")
+		}
+	}
+
 	if req.Hints.NoPoints() {
 		req.PointsLimit = -1
 	}
@@ -69,6 +77,14 @@ func (s *store) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (read
 		return nil, errors.New("groupRead: SeriesLimit and SeriesOffset not supported when Grouping")
 	}
 
+	if len(req.Aggregate) > 1 {
+		return nil, errors.New("groupRead: multiple aggregates not supported when Grouping")
+	}
+
+	if req.Window != nil {
+		return nil, errors.New("groupRead: Window not supported when Grouping")
+	}
+
 	if req.Hints.NoPoints() {
 		req.PointsLimit = -1
 	}
@@ -26,6 +26,7 @@ const (
 type Deleter interface {
 	CreateSeriesCursor(context.Context, SeriesCursorRequest, influxql.Expr) (SeriesCursor, error)
 	DeleteSeriesRangeWithPredicate(tsdb.SeriesIterator, func([]byte, models.Tags) (int64, int64, bool)) error
+	DropShardGroups(orgID, bucketID platform.ID, before int64) (int, int64, error)
 }
 
 // A BucketFinder is responsible for providing access to buckets via a filter.
@@ -46,6 +47,10 @@ type retentionEnforcer struct {
 	// organisations.
 	BucketService BucketFinder
 
+	// shardGroups tracks the shard group duration of each bucket, which
+	// determines how data is partitioned on the engine.
+	shardGroups *shardGroups
+
 	logger *zap.Logger
 
 	metrics *retentionMetrics
@@ -58,6 +63,7 @@ func newRetentionEnforcer(engine Deleter, bucketService BucketFinder) *retention
 	s := &retentionEnforcer{
 		Engine:        engine,
 		BucketService: bucketService,
+		shardGroups:   newShardGroups(),
 		logger:        zap.NewNop(),
 	}
 	s.metrics = newRetentionMetrics(nil)
@@ -78,16 +84,29 @@ func (s *retentionEnforcer) run() {
 	log, logEnd := logger.NewOperation(s.logger, "Data retention check", "data_retention_check")
 	defer logEnd()
 
-	rpByBucketID, err := s.getRetentionPeriodPerBucket()
+	buckets, err := s.getBuckets()
 	if err != nil {
 		log.Error("Unable to determine bucket:RP mapping", zap.Error(err))
 		return
 	}
+	s.shardGroups.update(buckets)
+
+	rpByBucketID := make(map[platform.ID]time.Duration, len(buckets))
+	for _, bucket := range buckets {
+		rpByBucketID[bucket.ID] = bucket.RetentionPeriod
+	}
 
 	now := time.Now().UTC()
 	labels := s.metrics.Labels()
 	labels["status"] = "ok"
 
+	// Drop whole shard groups first, so that as little data as possible is
+	// left to be removed by the more expensive series deletes. The deletes
+	// remove whatever a failed drop left behind, so they run regardless.
+	if err := s.dropShardGroups(buckets, now); err != nil {
+		log.Error("Shard group drop not successful", zap.Error(err))
+		labels["status"] = "error"
+	}
 	if err := s.expireData(rpByBucketID, now); err != nil {
 		log.Error("Deletion not successful", zap.Error(err))
 		labels["status"] = "error"
@@ -96,10 +115,57 @@ func (s *retentionEnforcer) run() {
 	s.metrics.Checks.With(labels).Inc()
 }
 
+// dropShardGroups removes the TSM files of every shard group that falls
+// entirely outside of its bucket's retention period.
+func (s *retentionEnforcer) dropShardGroups(buckets []*platform.Bucket, now time.Time) error {
+	_, logEnd := logger.NewOperation(s.logger, "Shard group drop", "shard_group_drop")
+	defer logEnd()
+
+	var files, size int64
+	defer func() {
+		if s.metrics == nil {
+			return
+		}
+		labels := s.metrics.Labels()
+		labels["status"] = "ok"
+		s.metrics.DroppedFiles.With(labels).Add(float64(files))
+		s.metrics.ReclaimedBytes.With(labels).Add(float64(size))
+	}()
+
+	for _, bucket := range buckets {
+		if bucket.RetentionPeriod == 0 {
+			continue
+		}
+
+		n, sz, err := s.Engine.DropShardGroups(bucket.OrganizationID, bucket.ID, expiredBefore(bucket.RetentionPeriod, now))
+		if err != nil {
+			return err
+		}
+		files += int64(n)
+		size += sz
+	}
+	return nil
+}
+
+// expiredBefore returns the time before which all data in a bucket with the
+// retention period rp has expired.
+//
+// Data is expired a shard group at a time, so that any shard group only
+// partially outside of the retention period is kept in its entirety. This
+// means data may be retained for up to a shard group duration longer than the
+// retention period, but avoids deleting data from shard groups still in use.
+func expiredBefore(rp time.Duration, now time.Time) int64 {
+	return shardGroupStart(now.Add(-rp).UnixNano(), shardGroupDuration(rp))
+}
+
 // expireData runs a delete operation on the storage engine.
 //
 // Any series data that (1) belongs to a bucket in the provided map and
 // (2) falls outside the bucket's indicated retention period will be deleted.
+//
+// expireData removes any data left behind by dropShardGroups, such as data in
+// the cache or in files containing multiple shard groups, and drops series
+// without any remaining data from the index.
 func (s *retentionEnforcer) expireData(rpByBucketID map[platform.ID]time.Duration, now time.Time) error {
 	_, logEnd := logger.NewOperation(s.logger, "Data deletion", "data_deletion")
 	defer logEnd()
@@ -146,8 +212,7 @@ func (s *retentionEnforcer) expireData(rpByBucketID map[platform.ID]time.Duratio
 		}
 
 		atomic.AddUint64(&seriesDeleted, 1)
-		to := now.Add(-retentionPeriod).UnixNano()
-		return math.MinInt64, to, true
+		return math.MinInt64, expiredBefore(retentionPeriod, now) - 1, true
 	}
 
 	defer func() {
@@ -171,20 +236,22 @@ func (s *retentionEnforcer) expireData(rpByBucketID map[platform.ID]time.Duratio
 	return s.Engine.DeleteSeriesRangeWithPredicate(newSeriesIteratorAdapter(cur), fn)
 }
 
-// getRetentionPeriodPerBucket returns a map of (bucket ID -> retention period)
-// for all buckets.
-func (s *retentionEnforcer) getRetentionPeriodPerBucket() (map[platform.ID]time.Duration, error) {
+// getBuckets returns all buckets.
+func (s *retentionEnforcer) getBuckets() ([]*platform.Bucket, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
 	defer cancel()
 	buckets, _, err := s.BucketService.FindBuckets(ctx, platform.BucketFilter{})
+	return buckets, err
+}
+
+// updateShardGroups refreshes the shard group duration of every bucket.
+func (s *retentionEnforcer) updateShardGroups() error {
+	buckets, err := s.getBuckets()
 	if err != nil {
-		return nil, err
-	}
-	rpByBucketID := make(map[platform.ID]time.Duration, len(buckets))
-	for _, bucket := range buckets {
-		rpByBucketID[bucket.ID] = bucket.RetentionPeriod
+		return err
 	}
-	return rpByBucketID, nil
+	s.shardGroups.update(buckets)
+	return nil
 }
 
 // PrometheusCollectors satisfies the prom.PrometheusCollector interface.
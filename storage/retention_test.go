@@ -78,7 +78,10 @@ func TestService_expireData(t *testing.T) {
 				if from != math.MinInt64 {
 					return fmt.Errorf("got from %d, expected %d", from, math.MinInt64)
 				}
-				wantTo := now.Add(-3 * time.Hour).UnixNano()
+				// Shard groups are an hour wide for a 3h retention period,
+				// so data is only deleted up to the start of the shard
+				// group containing now-3h.
+				wantTo := time.Date(2018, 4, 10, 20, 0, 0, 0, time.UTC).UnixNano() - 1
 				if to != wantTo {
 					return fmt.Errorf("got to %d, expected %d", to, wantTo)
 				}
@@ -110,6 +113,43 @@ func TestService_expireData(t *testing.T) {
 	})
 }
 
+func TestService_dropShardGroups(t *testing.T) {
+	engine := NewTestEngine()
+	service := newRetentionEnforcer(engine, NewTestBucketFinder())
+	now := time.Date(2018, 4, 10, 23, 12, 33, 0, time.UTC)
+
+	buckets := []*platform.Bucket{
+		{ID: 1, OrganizationID: 10, RetentionPeriod: 3 * time.Hour},
+		{ID: 2, OrganizationID: 10, RetentionPeriod: 0},
+		{ID: 3, OrganizationID: 20, RetentionPeriod: 30 * 24 * time.Hour},
+	}
+
+	got := map[platform.ID]int64{}
+	engine.DropShardGroupsFn = func(orgID, bucketID platform.ID, before int64) (int, int64, error) {
+		got[bucketID] = before
+		return 1, 100, nil
+	}
+
+	if err := service.dropShardGroups(buckets, now); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[platform.ID]int64{
+		1: time.Date(2018, 4, 10, 20, 0, 0, 0, time.UTC).UnixNano(),
+		3: time.Date(2018, 3, 11, 0, 0, 0, 0, time.UTC).UnixNano(),
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got\n%#v\nexpected\n%#v", got, exp)
+	}
+
+	engine.DropShardGroupsFn = func(platform.ID, platform.ID, int64) (int, int64, error) {
+		return 0, 0, ErrEngineClosed
+	}
+	if err := service.dropShardGroups(buckets, now); err != ErrEngineClosed {
+		t.Fatalf("got error %v, expected %v", err, ErrEngineClosed)
+	}
+}
+
 // genMeasurementName generates a random measurement name or panics.
 func genMeasurementName() []byte {
 	b := make([]byte, 16)
@@ -131,6 +171,7 @@ func (f *TestSeriesCursor) Next() (*SeriesCursorRow, error) { return f.NextFn()
 type TestEngine struct {
 	CreateSeriesCursorFn             func(context.Context, SeriesCursorRequest, influxql.Expr) (SeriesCursor, error)
 	DeleteSeriesRangeWithPredicateFn func(tsdb.SeriesIterator, func([]byte, models.Tags) (int64, int64, bool)) error
+	DropShardGroupsFn                func(platform.ID, platform.ID, int64) (int, int64, error)
 
 	SeriesCursor *TestSeriesCursor
 }
@@ -145,6 +186,7 @@ func NewTestEngine() *TestEngine {
 		SeriesCursor:                     cursor,
 		CreateSeriesCursorFn:             func(context.Context, SeriesCursorRequest, influxql.Expr) (SeriesCursor, error) { return cursor, nil },
 		DeleteSeriesRangeWithPredicateFn: func(tsdb.SeriesIterator, func([]byte, models.Tags) (int64, int64, bool)) error { return nil },
+		DropShardGroupsFn:                func(platform.ID, platform.ID, int64) (int, int64, error) { return 0, 0, nil },
 	}
 }
 
@@ -156,6 +198,10 @@ func (e *TestEngine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn
 	return e.DeleteSeriesRangeWithPredicateFn(itr, fn)
 }
 
+func (e *TestEngine) DropShardGroups(orgID, bucketID platform.ID, before int64) (int, int64, error) {
+	return e.DropShardGroupsFn(orgID, bucketID, before)
+}
+
 type TestBucketFinder struct {
 	FindBucketsFn func(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error)
 }
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsi1"
+)
+
+// seriesCounts tracks the number of series of each bucket, so that
+// MaxSeriesPerBucket is enforced without counting the series of the index on
+// every write. The count of a bucket is taken from the index when it is first
+// needed, and updated as series are created. Deleting series resets the
+// counts, which are then taken again.
+type seriesCounts struct {
+	mu      sync.Mutex
+	buckets map[string]*bucketSeries
+}
+
+// bucketSeries is the series count of a bucket. Its lock is held from checking
+// that new series are within the limit until they are created, so that
+// concurrent writes can't exceed it together.
+type bucketSeries struct {
+	mu      sync.Mutex
+	name    string
+	n       int
+	counted bool
+}
+
+// bucket returns the series count of the bucket, whose measurement is name.
+func (c *seriesCounts) bucket(name []byte) *bucketSeries {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.buckets == nil {
+		c.buckets = make(map[string]*bucketSeries)
+	}
+	b := c.buckets[string(name)]
+	if b == nil {
+		b = &bucketSeries{name: string(name)}
+		c.buckets[string(name)] = b
+	}
+	return b
+}
+
+// reset forgets the series counts, after series may have been dropped.
+func (c *seriesCounts) reset() {
+	c.mu.Lock()
+	c.buckets = nil
+	c.mu.Unlock()
+}
+
+// reservedSeries is a series that a write may create.
+type reservedSeries struct {
+	key       []byte // the key of the point, which partitions the index
+	seriesKey []byte
+}
+
+// seriesReservation holds the locks of the buckets that a write creates
+// series in, until it has created them.
+type seriesReservation struct {
+	buckets []*bucketSeries
+	series  map[*bucketSeries][]reservedSeries
+}
+
+// limitSeriesPerBucket drops the entries of the collection that would create
+// new series in a bucket that already has MaxSeriesPerBucket series. It
+// returns the number of entries dropped, and the reservation of the new series
+// that the caller must release once it has created them.
+//
+// The caller must hold e.mu.
+func (e *Engine) limitSeriesPerBucket(collection *tsdb.SeriesCollection) (*seriesReservation, uint64) {
+	var (
+		buf     []byte
+		created = make(map[string][]int) // the entries of new series per bucket
+	)
+
+	for iter := collection.Iterator(); iter.Next(); {
+		buf = tsdb.AppendSeriesKey(buf[:0], iter.Name(), iter.Tags())
+		if id := e.sfile.SeriesIDTypedBySeriesKey(buf).SeriesID(); !id.IsZero() && e.index.HasSeries(iter.Key(), id) {
+			continue
+		}
+		created[string(iter.Name())] = append(created[string(iter.Name())], iter.Index())
+	}
+
+	r := &seriesReservation{series: make(map[*bucketSeries][]reservedSeries)}
+	if len(created) == 0 {
+		return r, 0
+	}
+
+	// Lock the buckets in order, so that concurrent writes to several
+	// buckets don't deadlock.
+	names := make([]string, 0, len(created))
+	for name := range created {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stats tsi1.MeasurementCardinalityStats
+	for _, name := range names {
+		b := e.seriesCounts.bucket([]byte(name))
+		b.mu.Lock()
+		r.buckets = append(r.buckets, b)
+
+		if !b.counted {
+			if stats == nil {
+				stats = e.index.MeasurementCardinalityStats()
+			}
+			b.n, b.counted = stats[name], true
+		}
+	}
+
+	max := e.config.MaxSeriesPerBucket
+	reason := fmt.Sprintf("max-series-per-bucket limit exceeded: (%d)", max)
+
+	var dropped uint64
+	drop := make(map[int]bool)
+	for i, name := range names {
+		b := r.buckets[i]
+		keys := make(map[string]bool)
+		for _, idx := range created[name] {
+			key := collection.Keys[idx]
+			if keys[string(key)] {
+				continue
+			}
+			if b.n+len(keys) >= max {
+				drop[idx] = true
+				continue
+			}
+			keys[string(key)] = true
+			r.series[b] = append(r.series[b], reservedSeries{
+				key:       key,
+				seriesKey: tsdb.AppendSeriesKey(nil, collection.Names[idx], collection.Tags[idx]),
+			})
+		}
+	}
+
+	if len(drop) == 0 {
+		return r, 0
+	}
+
+	j := 0
+	for iter := collection.Iterator(); iter.Next(); {
+		if drop[iter.Index()] {
+			if collection.Reason == "" {
+				collection.Reason = reason
+			}
+			collection.Dropped++
+			collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+			dropped++
+			continue
+		}
+		collection.Copy(j, iter.Index())
+		j++
+	}
+	collection.Truncate(j)
+
+	return r, dropped
+}
+
+// release adds the reserved series that were created to the counts of their
+// buckets, and unlocks them.
+//
+// The caller must hold e.mu.
+func (r *seriesReservation) release(e *Engine) {
+	for _, b := range r.buckets {
+		for _, s := range r.series[b] {
+			if id := e.sfile.SeriesIDTypedBySeriesKey(s.seriesKey).SeriesID(); !id.IsZero() && e.index.HasSeries(s.key, id) {
+				b.n++
+			}
+		}
+		b.mu.Unlock()
+	}
+}
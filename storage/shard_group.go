@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// DefaultShardGroupDuration is the shard group duration used for buckets with
+// an infinite retention period, and for buckets whose retention period is not
+// yet known to the engine.
+const DefaultShardGroupDuration = 7 * 24 * time.Hour
+
+// shardGroupDuration returns the width of the shard groups for a bucket with
+// the retention period rp. The durations mirror the defaults used for
+// retention policies in InfluxDB 1.x.
+func shardGroupDuration(rp time.Duration) time.Duration {
+	switch {
+	case rp <= 0 || rp >= 180*24*time.Hour:
+		return DefaultShardGroupDuration
+	case rp >= 2*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// shardGroupStart returns the start of the shard group of width d containing
+// the timestamp t. Shard groups are aligned to the Unix epoch.
+func shardGroupStart(t int64, d time.Duration) int64 {
+	r := t % int64(d)
+	if r < 0 {
+		r += int64(d)
+	}
+	return t - r
+}
+
+// shardGroups tracks the shard group duration of every bucket, and partitions
+// TSM data into per-bucket shard groups.
+//
+// Each shard group of a bucket is written to its own TSM files, so data can
+// be expired by dropping files wholesale once the entire shard group falls
+// outside of the bucket's retention period.
+type shardGroups struct {
+	mu        sync.RWMutex
+	durations map[platform.ID]time.Duration
+}
+
+func newShardGroups() *shardGroups {
+	return &shardGroups{durations: make(map[platform.ID]time.Duration)}
+}
+
+// update replaces the shard group durations with those for buckets.
+func (s *shardGroups) update(buckets []*platform.Bucket) {
+	durations := make(map[platform.ID]time.Duration, len(buckets))
+	for _, b := range buckets {
+		durations[b.ID] = shardGroupDuration(b.RetentionPeriod)
+	}
+
+	s.mu.Lock()
+	s.durations = durations
+	s.mu.Unlock()
+}
+
+// duration returns the shard group duration for bucketID.
+func (s *shardGroups) duration(bucketID platform.ID) time.Duration {
+	s.mu.RLock()
+	d, ok := s.durations[bucketID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return DefaultShardGroupDuration
+	}
+	return d
+}
+
+// partition satisfies tsm1.PartitionFunc. TSM keys are partitioned by their
+// encoded org and bucket measurement, into shard groups whose duration is
+// determined by the bucket's retention period.
+func (s *shardGroups) partition(key []byte) ([]byte, int64) {
+	seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+	name := models.ParseName(seriesKey)
+	if len(name) != platform.IDLength {
+		return nil, 0 // Not data belonging to a bucket.
+	}
+
+	var n [16]byte
+	copy(n[:], name)
+	_, bucketID := tsdb.DecodeName(n)
+
+	// The prefix must match the measurement as it appears in the key.
+	return models.EscapeMeasurement(name), int64(s.duration(bucketID))
+}
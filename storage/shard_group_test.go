@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestShardGroupDuration(t *testing.T) {
+	tests := []struct {
+		rp  time.Duration
+		exp time.Duration
+	}{
+		{rp: 0, exp: DefaultShardGroupDuration},
+		{rp: time.Hour, exp: time.Hour},
+		{rp: 47 * time.Hour, exp: time.Hour},
+		{rp: 48 * time.Hour, exp: 24 * time.Hour},
+		{rp: 30 * 24 * time.Hour, exp: 24 * time.Hour},
+		{rp: 180 * 24 * time.Hour, exp: DefaultShardGroupDuration},
+	}
+
+	for _, tt := range tests {
+		if got := shardGroupDuration(tt.rp); got != tt.exp {
+			t.Errorf("shardGroupDuration(%v) = %v, expected %v", tt.rp, got, tt.exp)
+		}
+	}
+}
+
+func TestShardGroupStart(t *testing.T) {
+	tests := []struct {
+		t   int64
+		exp int64
+	}{
+		{t: 0, exp: 0},
+		{t: 15, exp: 10},
+		{t: -1, exp: -10},
+		{t: -10, exp: -10},
+	}
+
+	for _, tt := range tests {
+		if got := shardGroupStart(tt.t, 10); got != tt.exp {
+			t.Errorf("shardGroupStart(%d, 10) = %d, expected %d", tt.t, got, tt.exp)
+		}
+	}
+}
+
+func TestShardGroups_partition(t *testing.T) {
+	sg := newShardGroups()
+	sg.update([]*platform.Bucket{
+		{ID: 1, OrganizationID: 10, RetentionPeriod: time.Hour},
+	})
+
+	// Choose an org ID whose encoded form requires escaping.
+	for _, orgID := range []platform.ID{10, 0x2c20} {
+		encoded := tsdb.EncodeName(orgID, 1)
+		name := models.EscapeMeasurement(encoded[:])
+
+		for _, key := range [][]byte{
+			append(append([]byte{}, name...), []byte(",host=A#!~#value")...),
+			append(append([]byte{}, name...), []byte("#!~#value")...),
+		} {
+			prefix, d := sg.partition(key)
+			if !bytes.Equal(prefix, name) {
+				t.Fatalf("got prefix %q, expected %q", prefix, name)
+			}
+			if d != int64(time.Hour) {
+				t.Fatalf("got duration %d, expected %d", d, int64(time.Hour))
+			}
+		}
+	}
+
+	// Unknown buckets use the default duration.
+	encoded := tsdb.EncodeName(10, 2)
+	if _, d := sg.partition(append(encoded[:], []byte(",host=A#!~#value")...)); d != int64(DefaultShardGroupDuration) {
+		t.Fatalf("got duration %d, expected %d", d, int64(DefaultShardGroupDuration))
+	}
+
+	// Keys that do not belong to a bucket are not partitioned.
+	if prefix, _ := sg.partition([]byte("cpu,host=A#!~#value")); prefix != nil {
+		t.Fatalf("got prefix %q, expected nil", prefix)
+	}
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/influxdata/platform/pkg/file"
+)
+
+// snapshotDirPrefix is the prefix of the temporary directories of snapshots
+// in the directory of the engine.
+const snapshotDirPrefix = "snapshot-"
+
+// CreateSnapshot writes a consistent copy of the series file, index and TSM
+// files of the engine into a new temporary directory, laid out like the
+// directory of the engine with the default directory names. Writes are only
+// blocked while the remainder of the cache is written to a TSM file and the
+// files are hard linked; the files that are appended to are copied once
+// writes resume. It returns the path of the directory, which the caller must
+// remove when done.
+func (e *Engine) CreateSnapshot() (string, error) {
+	// Write most of the cache out before writes are blocked, so that little is
+	// left to write while they are.
+	if err := e.engine.WriteSnapshot(); err != nil {
+		return "", err
+	}
+
+	dir, prefixes, err := e.linkSnapshot()
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range prefixes {
+		if err := p.Copy(); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("error copying snapshot file: %v", err)
+		}
+	}
+	return dir, nil
+}
+
+// linkSnapshot hard links the files of the engine into a new temporary
+// directory while writes are blocked, and returns the links to the files
+// that are appended to, which must be replaced by copies.
+func (e *Engine) linkSnapshot() (string, []*file.LinkedPrefix, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closing == nil {
+		return "", nil, ErrEngineClosed
+	}
+
+	dir, err := ioutil.TempDir(e.path, snapshotDirPrefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	prefixes, err := e.createSnapshot(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	return dir, prefixes, nil
+}
+
+func (e *Engine) createSnapshot(dir string) ([]*file.LinkedPrefix, error) {
+	sfilePrefixes, err := e.sfile.SnapshotTo(filepath.Join(dir, DefaultSeriesFileDirectoryName))
+	if err != nil {
+		return nil, fmt.Errorf("error creating series file snapshot: %v", err)
+	}
+
+	indexPrefixes, err := e.index.SnapshotTo(filepath.Join(dir, DefaultIndexDirectoryName))
+	if err != nil {
+		return nil, fmt.Errorf("error creating index snapshot: %v", err)
+	}
+
+	path, err := e.engine.CreateSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("error creating tsm snapshot: %v", err)
+	}
+	if err := os.Rename(path, filepath.Join(dir, DefaultEngineDirectoryName)); err != nil {
+		os.RemoveAll(path)
+		return nil, err
+	}
+	return append(sfilePrefixes, indexPrefixes...), nil
+}
+
+// removeSnapshots removes the directories of any snapshots that were left
+// behind when the engine was last closed.
+func (e *Engine) removeSnapshots() error {
+	dirs, err := filepath.Glob(filepath.Join(e.path, snapshotDirPrefix+"*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,6 +9,16 @@ const (
 	TaskMaxPageSize     = 500
 )
 
+// ops for tasks.
+const (
+	OpCreateTask = "CreateTask"
+	OpUpdateTask = "UpdateTask"
+	OpDeleteTask = "DeleteTask"
+	OpCancelRun  = "CancelRun"
+	OpRetryRun   = "RetryRun"
+	OpForceRun   = "ForceRun"
+)
+
 // Task is a task. 🎊
 type Task struct {
 	ID              ID     `json:"id,omitempty"`
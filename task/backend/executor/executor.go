@@ -158,7 +158,7 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	}
 
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: it.Err()}, nil)
+	p.finish(newRunResult(it.Err()), nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -287,8 +287,7 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 	case results, ok := <-p.q.Ready():
 		if !ok {
 			// Something went wrong with the flux. Set the error in the run result.
-			rr := &runResult{err: p.q.Err()}
-			p.finish(rr, nil)
+			p.finish(newRunResult(p.q.Err()), nil)
 			return
 		}
 
@@ -336,6 +335,12 @@ type runResult struct {
 
 var _ backend.RunResult = (*runResult)(nil)
 
+// newRunResult returns the result of a run that finished with err,
+// which is only retryable if err is transient.
+func newRunResult(err error) *runResult {
+	return &runResult{err: err, retryable: backend.IsTransient(err)}
+}
+
 func (rr *runResult) Err() error        { return rr.err }
 func (rr *runResult) IsRetryable() bool { return rr.retryable }
 
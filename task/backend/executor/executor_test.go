@@ -20,8 +20,10 @@ import (
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/influxdata/platform/task/backend"
 	"github.com/influxdata/platform/task/backend/executor"
+	"github.com/influxdata/platform/task/mock"
 	platformtesting "github.com/influxdata/platform/testing"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 )
 
 type fakeQueryService struct {
@@ -264,6 +266,7 @@ func TestExecutor(t *testing.T) {
 		testExecutorQueryFailure(t, fn)
 		testExecutorPromiseCancel(t, fn)
 		testExecutorServiceError(t, fn)
+		testExecutorRetryServiceError(t, fn)
 		testExecutorWait(t, fn)
 	}
 }
@@ -358,6 +361,67 @@ func testExecutorQueryFailure(t *testing.T, fn createSysFn) {
 		if got := res.Err(); got != expErr {
 			t.Fatalf("expected error %v; got %v", expErr, got)
 		}
+		if res.IsRetryable() {
+			t.Fatal("expected untyped error not to be retryable")
+		}
+	})
+
+	sys = fn()
+	t.Run(sys.name+"/QueryFailUnavailable", func(t *testing.T) {
+		t.Parallel()
+		script := fmt.Sprintf(fmtTestScript, t.Name())
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expErr := &platform.Error{Err: &platform.Error{Code: platform.EUnavailable, Msg: "forced error"}}
+		sys.svc.WaitForQueryLive(t, script)
+		sys.svc.FailQuery(script, expErr)
+		res, err := rp.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Err(); got != expErr {
+			t.Fatalf("expected error %v; got %v", expErr, got)
+		}
+		if !res.IsRetryable() {
+			t.Fatal("expected unavailable error to be retryable")
+		}
+	})
+
+	sys = fn()
+	t.Run(sys.name+"/QueryFailInvalid", func(t *testing.T) {
+		t.Parallel()
+		script := fmt.Sprintf(fmtTestScript, t.Name())
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expErr := &platform.Error{Code: platform.EInvalid, Msg: "forced error"}
+		sys.svc.WaitForQueryLive(t, script)
+		sys.svc.FailQuery(script, expErr)
+		res, err := rp.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Err(); got != expErr {
+			t.Fatalf("expected error %v; got %v", expErr, got)
+		}
+		if res.IsRetryable() {
+			t.Fatal("expected invalid error not to be retryable")
+		}
 	})
 }
 
@@ -427,6 +491,61 @@ func testExecutorServiceError(t *testing.T, fn createSysFn) {
 	})
 }
 
+func testExecutorRetryServiceError(t *testing.T, fn createSysFn) {
+	var orgID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
+	var userID = platformtesting.MustIDBase16("baaaaaaaaaaaaaab")
+	sys := fn()
+	t.Run(sys.name+"/RetryServiceError", func(t *testing.T) {
+		t.Parallel()
+		script := fmt.Sprintf(fmtTestScript, t.Name())
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d := mock.NewDesiredState()
+		rl := backend.NewInMemRunReaderWriter()
+		s := backend.NewScheduler(d, sys.ex, rl, 122, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 5*time.Millisecond))
+		s.Start(context.Background())
+		defer s.Stop()
+
+		task := &backend.StoreTask{ID: tid, Org: orgID, Script: script}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			MaxRetry:        1,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: 122,
+		}
+		d.SetTaskMeta(tid, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		// The query service fails the first attempt of the run, which is
+		// retried, and runs the second.
+		sys.svc.FailNextQuery(&platform.Error{Code: platform.EUnavailable, Msg: "query queue is full"})
+		s.Tick(123)
+		sys.svc.WaitForQueryLive(t, script)
+		sys.svc.SucceedQuery(script)
+
+		const maxAttempts = 50
+		var runs []*platform.Run
+		for i := 0; i < maxAttempts; i++ {
+			if i != 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+			runs, err = rl.ListRuns(context.Background(), platform.RunFilter{Task: &tid})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(runs) == 1 && runs[0].Status == backend.RunSuccess.String() {
+				return
+			}
+		}
+		t.Fatalf("expected one successful run, got %+v", runs)
+	})
+}
+
 func testExecutorWait(t *testing.T, createSys createSysFn) {
 	// This is a longer delay than I'd prefer,
 	// but it needs to be large-ish for slow machines running with the race detector.
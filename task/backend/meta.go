@@ -20,6 +20,7 @@ func NewStoreTaskMeta(req CreateTaskRequest, o options.Options) StoreTaskMeta {
 		LatestCompleted: req.ScheduleAfter,
 		EffectiveCron:   o.EffectiveCronString(),
 		Offset:          int32(o.Offset / time.Second),
+		MaxRetry:        int32(o.Retry),
 	}
 
 	if stm.Status == "" {
@@ -265,6 +266,7 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		stm.Status != other.Status ||
 		stm.EffectiveCron != other.EffectiveCron ||
 		stm.Offset != other.Offset ||
+		stm.MaxRetry != other.MaxRetry ||
 		len(stm.CurrentlyRunning) != len(other.CurrentlyRunning) ||
 		len(stm.ManualRuns) != len(other.ManualRuns) {
 		return false
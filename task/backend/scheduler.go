@@ -95,6 +95,44 @@ type RunResult interface {
 	// TODO(mr): add more detail here like number of points written, execution time, etc.
 }
 
+// temporary is implemented by errors that mark themselves as transient, such as net.Error.
+type temporary interface {
+	Temporary() bool
+}
+
+// causer is implemented by errors that wrap their cause, such as those of github.com/pkg/errors.
+type causer interface {
+	Cause() error
+}
+
+// IsTransient reports whether err is explicitly a temporary failure: either a *platform.Error
+// whose code is EUnavailable or ETooManyRequests, or an error marked temporary.
+// Any other error, including an untyped one, is permanent.
+// A run that failed with a transient error, or whose execution did, is eligible for retry.
+func IsTransient(err error) bool {
+	for err != nil {
+		if t, ok := err.(temporary); ok {
+			return t.Temporary()
+		}
+
+		switch e := err.(type) {
+		case *platform.Error:
+			if e == nil {
+				return false
+			}
+			if e.Code != "" {
+				return e.Code == platform.EUnavailable || e.Code == platform.ETooManyRequests
+			}
+			err = e.Err
+		case causer:
+			err = e.Cause()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
 // Scheduler accepts tasks and handles their scheduling.
 //
 // TODO(mr): right now the methods on Scheduler are synchronous.
@@ -122,6 +160,11 @@ type Scheduler interface {
 	CancelRun(ctx context.Context, taskID, runID platform.ID) error
 }
 
+const (
+	defaultRetryBackoff    = time.Second
+	defaultMaxRetryBackoff = time.Minute
+)
+
 // TickSchedulerOption is a option you can use to modify the schedulers behavior.
 type TickSchedulerOption func(*TickScheduler)
 
@@ -160,6 +203,16 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// WithRetryBackoff sets the delay before retrying a failed run.
+// The delay doubles with each failed attempt of the run, up to max.
+// If not set, the scheduler waits one second before the first retry of a run, and at most one minute.
+func WithRetryBackoff(initial, max time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.retryBackoff = initial
+		s.maxRetryBackoff = max
+	}
+}
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -171,6 +224,9 @@ func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, no
 		logger:         zap.NewNop(),
 		wg:             &sync.WaitGroup{},
 		metrics:        newSchedulerMetrics(),
+
+		retryBackoff:    defaultRetryBackoff,
+		maxRetryBackoff: defaultMaxRetryBackoff,
 	}
 
 	for _, opt := range opts {
@@ -190,6 +246,9 @@ type TickScheduler struct {
 
 	metrics *schedulerMetrics
 
+	retryBackoff    time.Duration // Delay before the first retry of a failed run.
+	maxRetryBackoff time.Duration // Maximum delay between retries of a failed run.
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -382,6 +441,11 @@ type taskScheduler struct {
 	// Task we are scheduling for.
 	task *StoreTask
 
+	// Maximum number of times a failed run is retried.
+	maxRetry int
+
+	retryBackoff, maxRetryBackoff time.Duration
+
 	// CancelFunc for context passed to runners, to enable Cancel method.
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -427,6 +491,10 @@ func newTaskScheduler(
 		nextDue:       firstDue,
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(meta.ManualRuns) > 0,
+
+		maxRetry:        int(meta.MaxRetry),
+		retryBackoff:    s.retryBackoff,
+		maxRetryBackoff: s.maxRetryBackoff,
 	}
 
 	for i := range ts.runners {
@@ -480,6 +548,18 @@ func (ts *taskScheduler) NextDue() (int64, bool) {
 	return ts.nextDue, ts.hasQueue
 }
 
+// RetryBackoff returns the delay before retrying a run whose attempt-th attempt failed.
+func (ts *taskScheduler) RetryBackoff(attempt int) time.Duration {
+	d := ts.retryBackoff
+	for i := 1; i < attempt && d < ts.maxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > ts.maxRetryBackoff {
+		d = ts.maxRetryBackoff
+	}
+	return d
+}
+
 // SetNextDue sets the next due timestamp and whether the task has a queue,
 // and records the source (the now value of the run who reported nextDue).
 func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64) {
@@ -633,35 +713,40 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 	sp, spCtx := opentracing.StartSpanFromContext(ctx, "task.run.execution")
 	defer sp.Finish()
 
-	rp, err := r.executor.Execute(spCtx, qr)
+	var (
+		res RunResult
+		err error
+	)
+	for attempt := 1; ; attempt++ {
+		var rp RunPromise
+		rp, err = r.executor.Execute(spCtx, qr)
+		if err == nil {
+			res, err = r.wait(ctx, rp)
+		}
 
-	if err != nil {
-		// TODO(mr): retry? and log error.
-		atomic.StoreUint32(r.state, runnerIdle)
-		r.updateRunState(qr, RunFail, runLogger)
-		return
-	}
+		// Failing to execute the run, such as when the query queue is full,
+		// is retried like the run failing.
+		runErr := err
+		if err != nil {
+			if !IsTransient(err) {
+				break
+			}
+		} else if res.Err() == nil || !res.IsRetryable() {
+			break
+		} else {
+			runErr = res.Err()
+		}
+		if attempt > r.ts.maxRetry {
+			break
+		}
 
-	ready := make(chan struct{})
-	go func() {
-		// If the runner's context is canceled, cancel the RunPromise.
-		select {
-		case <-ctx.Done():
-			r.clearRunning(qr.RunID)
-			rp.Cancel()
-		// Canceled context.
-		case <-r.ctx.Done():
-			r.clearRunning(qr.RunID)
-			rp.Cancel()
-		// Wait finished.
-		case <-ready:
-			r.clearRunning(qr.RunID)
+		// The run failed, but it may succeed if executed again.
+		if err = r.waitToRetry(ctx, qr, attempt, runErr, runLogger); err != nil {
+			break
 		}
-	}()
+	}
+	r.clearRunning(qr.RunID)
 
-	// TODO(mr): handle res.IsRetryable().
-	_, err = rp.Wait()
-	close(ready)
 	if err != nil {
 		if err == ErrRunCanceled {
 			_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
@@ -672,8 +757,7 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 			return
 		}
 
-		runLogger.Info("Failed to wait for execution result", zap.Error(err))
-		// TODO(mr): retry?
+		runLogger.Info("Failed to execute run", zap.Error(err))
 		r.updateRunState(qr, RunFail, runLogger)
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
@@ -687,39 +771,100 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 		r.updateRunState(qr, RunFail, runLogger)
 		return
 	}
-	r.updateRunState(qr, RunSuccess, runLogger)
-	runLogger.Info("Execution succeeded")
+
+	if err := res.Err(); err != nil {
+		runLogger.Info("Execution failed", zap.Error(err))
+		r.logRunState(qr, RunFail, fmt.Sprintf("Failed: %v", err), runLogger)
+		r.ts.metrics.FinishRun(r.task.ID.String(), false)
+	} else {
+		r.updateRunState(qr, RunSuccess, runLogger)
+		runLogger.Info("Execution succeeded")
+	}
 
 	// Check again if there is a new run available, without returning to idle state.
 	r.startFromWorking(atomic.LoadInt64(r.ts.now))
 }
 
-func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
-	rlb := RunLogBase{
-		Task:            r.task,
-		RunID:           qr.RunID,
-		RunScheduledFor: qr.Now,
-		RequestedAt:     qr.RequestedAt,
+// wait blocks until rp completes, canceling rp if ctx or the runner's context is canceled first.
+func (r *runner) wait(ctx context.Context, rp RunPromise) (RunResult, error) {
+	ready := make(chan struct{})
+	go func() {
+		// If the runner's context is canceled, cancel the RunPromise.
+		select {
+		case <-ctx.Done():
+			rp.Cancel()
+		// Canceled context.
+		case <-r.ctx.Done():
+			rp.Cancel()
+		// Wait finished.
+		case <-ready:
+		}
+	}()
+
+	res, err := rp.Wait()
+	close(ready)
+	return res, err
+}
+
+// waitToRetry records that the given attempt of qr failed with runErr,
+// and blocks until qr is due to be executed again.
+// If the run is canceled in the meantime, waitToRetry returns ErrRunCanceled.
+func (r *runner) waitToRetry(ctx context.Context, qr QueuedRun, attempt int, runErr error, runLogger *zap.Logger) error {
+	delay := r.ts.RetryBackoff(attempt)
+	runLogger.Info("Execution failed; retrying", zap.Error(runErr), zap.Int("attempt", attempt), zap.Duration("backoff", delay))
+	r.ts.metrics.RetryRun(r.task.ID.String())
+	r.logRunState(qr, RunScheduled, fmt.Sprintf("Attempt %d failed: %v; retrying in %s", attempt, runErr, delay), runLogger)
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+		return ErrRunCanceled
+	case <-r.ctx.Done():
+		return ErrRunCanceled
 	}
 
+	r.logRunState(qr, RunStarted, fmt.Sprintf("Retrying, attempt %d of %d", attempt+1, r.ts.maxRetry+1), runLogger)
+	return nil
+}
+
+func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
+	var log string
 	switch s {
 	case RunStarted:
 		r.ts.metrics.StartRun(r.task.ID.String())
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), fmt.Sprintf("Started task from script: %q", r.task.Script))
+		log = fmt.Sprintf("Started task from script: %q", r.task.Script)
 	case RunSuccess:
 		r.ts.metrics.FinishRun(r.task.ID.String(), true)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Completed successfully")
+		log = "Completed successfully"
 	case RunFail:
 		r.ts.metrics.FinishRun(r.task.ID.String(), false)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Failed")
+		log = "Failed"
 	case RunCanceled:
 		r.ts.metrics.FinishRun(r.task.ID.String(), false)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Canceled")
+		log = "Canceled"
 	default: // We are deliberately not handling RunQueued yet.
 		// There is not really a notion of being queued in this runner architecture.
 		runLogger.Warn("Unhandled run state", zap.Stringer("state", s))
 	}
 
+	r.logRunState(qr, s, log, runLogger)
+}
+
+// logRunState adds log to the log of the run, if log is not empty, and sets the state of the run to s.
+func (r *runner) logRunState(qr QueuedRun, s RunStatus, log string, runLogger *zap.Logger) {
+	rlb := RunLogBase{
+		Task:            r.task,
+		RunID:           qr.RunID,
+		RunScheduledFor: qr.Now,
+		RequestedAt:     qr.RequestedAt,
+	}
+
+	if log != "" {
+		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), log)
+	}
+
 	// Arbitrarily chosen short time limit for how fast the log write must complete.
 	// If we start seeing errors from this, we know the time limit is too short or the system is overloaded.
 	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Millisecond)
@@ -11,6 +11,7 @@ type schedulerMetrics struct {
 
 	runsComplete *prometheus.CounterVec
 	runsActive   *prometheus.GaugeVec
+	runsRetried  *prometheus.CounterVec
 
 	claimsComplete *prometheus.CounterVec
 	claimsActive   prometheus.Gauge
@@ -46,6 +47,12 @@ func newSchedulerMetrics() *schedulerMetrics {
 			Name:      "runs_active",
 			Help:      "Total number of runs that have started but not yet completed, split out by task ID.",
 		}, []string{"task_id"}),
+		runsRetried: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_retried",
+			Help:      "Number of failed run attempts that were retried, split out by task ID.",
+		}, []string{"task_id"}),
 
 		claimsComplete: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -69,6 +76,7 @@ func (sm *schedulerMetrics) PrometheusCollectors() []prometheus.Collector {
 		sm.totalRunsActive,
 		sm.runsComplete,
 		sm.runsActive,
+		sm.runsRetried,
 		sm.claimsComplete,
 		sm.claimsActive,
 	}
@@ -91,6 +99,12 @@ func (sm *schedulerMetrics) FinishRun(tid string, succeeded bool) {
 	sm.runsComplete.WithLabelValues(tid, status).Inc()
 }
 
+// RetryRun adjusts the metrics to indicate a failed run attempt is retried for the given task ID.
+// The run remains in progress.
+func (sm *schedulerMetrics) RetryRun(tid string) {
+	sm.runsRetried.WithLabelValues(tid).Inc()
+}
+
 // ClaimTask adjusts the metrics to indicate the result of an attempted claim.
 func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 	status := statusString(succeeded)
@@ -106,6 +120,7 @@ func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 func (sm *schedulerMetrics) ReleaseTask(tid string) {
 	sm.claimsActive.Dec()
 	sm.runsActive.DeleteLabelValues(tid)
+	sm.runsRetried.DeleteLabelValues(tid)
 	sm.runsComplete.DeleteLabelValues(tid, statusString(true))
 	sm.runsComplete.DeleteLabelValues(tid, statusString(false))
 }
@@ -509,6 +509,113 @@ func TestScheduler_RunLog(t *testing.T) {
 	pollForRunStatus(t, rl, task.ID, 3, 2, backend.RunCanceled.String())
 }
 
+func TestScheduler_Retry(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 5*time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		MaxRetry:        2,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fail every attempt of the first run with a retryable error.
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			promises = pollForRetriedRun(t, e, task.ID, promises[0])
+		}
+		promises[0].Finish(mock.NewRunResult(errors.New("transient failure"), true), nil)
+	}
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+
+	runs, err := rl.ListRuns(context.Background(), platform.RunFilter{Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(runs[0].Log)
+	for _, exp := range []string{
+		"Attempt 1 failed: transient failure",
+		"Retrying, attempt 2 of 3",
+		"Attempt 2 failed: transient failure",
+		"Retrying, attempt 3 of 3",
+		"Failed: transient failure",
+	} {
+		if !strings.Contains(log, exp) {
+			t.Fatalf("expected run log to contain %q, got:\n%s", exp, log)
+		}
+	}
+	if strings.Contains(log, "Attempt 3 failed") {
+		t.Fatalf("expected run not to be retried more than twice, got:\n%s", log)
+	}
+
+	// A run that fails with a non-retryable error is not retried.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(errors.New("permanent failure"), false), nil)
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunFail.String())
+
+	// A run that succeeds when retried is successful.
+	s.Tick(8)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(errors.New("transient failure"), true), nil)
+	promises = pollForRetriedRun(t, e, task.ID, promises[0])
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	pollForRunStatus(t, rl, task.ID, 3, 2, backend.RunSuccess.String())
+}
+
+// pollForRetriedRun waits for the run of prev to be executed again, and returns the running promises for the task.
+func pollForRetriedRun(t *testing.T, e *mock.Executor, taskID platform.ID, prev *mock.RunPromise) []*mock.RunPromise {
+	t.Helper()
+
+	const maxAttempts = 50
+	for i := 0; i < maxAttempts; i++ {
+		if i != 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		promises := e.RunningFor(taskID)
+		if len(promises) == 1 && promises[0] != prev && promises[0].Run() == prev.Run() {
+			return promises
+		}
+	}
+
+	t.Fatalf("run %s was not retried", prev.Run().RunID)
+	return nil
+}
+
 func TestScheduler_Metrics(t *testing.T) {
 	d := mock.NewDesiredState()
 	e := mock.NewExecutor()
@@ -502,6 +502,7 @@ func testStoreFindMeta(t *testing.T, create CreateStoreFunc, destroy DestroyStor
 		cron: "* * * * *",
 		concurrency: 3,
 		offset: 5s,
+		retry: 2,
 	}
 
 from(bucket:"test") |> range(start:-1h)`
@@ -539,6 +540,10 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("unexpected delay stored in meta: %v", meta.Offset)
 		}
 
+		if meta.MaxRetry != 2 {
+			t.Fatalf("unexpected max retry stored in meta: %v", meta.MaxRetry)
+		}
+
 		if meta.Status != string(backend.DefaultTaskStatus) {
 			t.Fatalf("unexpected status: got %v, exp %v", meta.Status, backend.DefaultTaskStatus)
 		}
@@ -310,7 +310,10 @@ func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.
 		defer e.wg.Done()
 		res, _ := rp.Wait()
 		e.mu.Lock()
-		delete(e.running, id)
+		// A retried run is executed again with the same ID.
+		if e.running[id] == rp {
+			delete(e.running, id)
+		}
 		e.finished[id] = res
 		e.mu.Unlock()
 	}()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
@@ -18,6 +19,12 @@ const (
 	authThreeID = "020f755c3c082002"
 )
 
+var (
+	authCreatedAt = time.Date(2009, time.November, 10, 24, 0, 0, 0, time.UTC)
+	authExpiresAt = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+	authLaterAt   = authExpiresAt.Add(time.Hour)
+)
+
 var authorizationCmpOptions = cmp.Options{
 	cmp.Comparer(func(x, y []byte) bool {
 		return bytes.Equal(x, y)
@@ -35,6 +42,7 @@ var authorizationCmpOptions = cmp.Options{
 type AuthorizationFields struct {
 	IDGenerator    platform.IDGenerator
 	TokenGenerator platform.TokenGenerator
+	NowFn          func() time.Time
 	Authorizations []*platform.Authorization
 	Users          []*platform.User
 	Orgs           []*platform.Organization
@@ -91,6 +99,7 @@ func CreateAuthorization(
 	}
 	type wants struct {
 		err            error
+		token          string
 		authorizations []*platform.Authorization
 	}
 
@@ -109,6 +118,7 @@ func CreateAuthorization(
 						return "rand", nil
 					},
 				},
+				NowFn: func() time.Time { return authCreatedAt },
 				Users: []*platform.User{
 					{
 						Name: "cooluser",
@@ -138,16 +148,18 @@ func CreateAuthorization(
 					UserID:      MustIDBase16(userOneID),
 					Permissions: createUsersPermission(),
 					Description: "new auth",
+					CreatedBy:   MustIDBase16(userOneID),
+					ExpiresAt:   &authExpiresAt,
 				},
 			},
 			wants: wants{
+				token: "rand",
 				authorizations: []*platform.Authorization{
 					{
 						ID:          MustIDBase16(authOneID),
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "supersecret",
 						Permissions: allUsersPermission(),
 						Description: "already existing auth",
 					},
@@ -155,10 +167,12 @@ func CreateAuthorization(
 						ID:          MustIDBase16(authTwoID),
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
-						Token:       "rand",
 						Status:      platform.Active,
 						Permissions: createUsersPermission(),
 						Description: "new auth",
+						CreatedBy:   MustIDBase16(userOneID),
+						CreatedAt:   authCreatedAt,
+						ExpiresAt:   &authExpiresAt,
 					},
 				},
 			},
@@ -172,6 +186,7 @@ func CreateAuthorization(
 						return "rand", nil
 					},
 				},
+				NowFn: func() time.Time { return authCreatedAt },
 				Users: []*platform.User{
 					{
 						Name: "cooluser",
@@ -200,25 +215,27 @@ func CreateAuthorization(
 					UserID:      MustIDBase16(userOneID),
 					OrgID:       MustIDBase16(orgOneID),
 					Permissions: createUsersPermission(),
+					CreatedBy:   MustIDBase16(userOneID),
 				},
 			},
 			wants: wants{
+				token: "rand",
 				authorizations: []*platform.Authorization{
 					{
 						ID:          MustIDBase16(authOneID),
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "supersecret",
 						Permissions: allUsersPermission(),
 					},
 					{
 						ID:          MustIDBase16(authTwoID),
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
-						Token:       "rand",
 						Status:      platform.Active,
 						Permissions: createUsersPermission(),
+						CreatedBy:   MustIDBase16(userOneID),
+						CreatedAt:   authCreatedAt,
 					},
 				},
 			},
@@ -270,7 +287,6 @@ func CreateAuthorization(
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "supersecret",
 						Permissions: allUsersPermission(),
 						Description: "already existing auth",
 					},
@@ -325,7 +341,6 @@ func CreateAuthorization(
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "supersecret",
 						Permissions: allUsersPermission(),
 						Description: "already existing auth",
 					},
@@ -347,6 +362,11 @@ func CreateAuthorization(
 
 			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
 
+			// The token is only returned on creation, it is not stored.
+			if tt.args.authorization.Token != tt.wants.token {
+				t.Errorf("got token %q, want %q", tt.args.authorization.Token, tt.wants.token)
+			}
+
 			defer s.DeleteAuthorization(ctx, tt.args.authorization.ID)
 
 			authorizations, _, err := s.FindAuthorizations(ctx, platform.AuthorizationFilter{})
@@ -424,7 +444,6 @@ func FindAuthorizationByID(
 					UserID:      MustIDBase16(userTwoID),
 					OrgID:       MustIDBase16(orgOneID),
 					Status:      platform.Active,
-					Token:       "rand2",
 					Permissions: createUsersPermission(),
 				},
 			},
@@ -530,7 +549,6 @@ func UpdateAuthorizationStatus(
 					ID:          MustIDBase16(authTwoID),
 					UserID:      MustIDBase16(userTwoID),
 					OrgID:       MustIDBase16(orgOneID),
-					Token:       "rand2",
 					Permissions: createUsersPermission(),
 					Status:      platform.Inactive,
 				},
@@ -769,11 +787,141 @@ func FindAuthorizationByToken(
 					UserID:      MustIDBase16(userOneID),
 					OrgID:       MustIDBase16(orgTwoID),
 					Status:      platform.Inactive,
-					Token:       "rand1",
 					Permissions: allUsersPermission(),
 				},
 			},
 		},
+		{
+			name: "find authorization by a token sharing its prefix with another",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret1",
+						Permissions: allUsersPermission(),
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret2",
+						Permissions: createUsersPermission(),
+					},
+				},
+			},
+			args: args{
+				token: "supersecret2",
+			},
+			wants: wants{
+				authorization: &platform.Authorization{
+					ID:          MustIDBase16(authTwoID),
+					UserID:      MustIDBase16(userOneID),
+					OrgID:       MustIDBase16(orgOneID),
+					Status:      platform.Active,
+					Permissions: createUsersPermission(),
+				},
+			},
+		},
+		{
+			name: "a prefix of a token does not find the authorization",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret1",
+						Permissions: allUsersPermission(),
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret2",
+						Permissions: createUsersPermission(),
+					},
+				},
+			},
+			args: args{
+				token: "supersecret",
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Op:   platform.OpFindAuthorizationByToken,
+					Msg:  "authorization not found",
+				},
+			},
+		},
+		{
+			name: "unknown token",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret1",
+						Permissions: allUsersPermission(),
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "supersecret2",
+						Permissions: createUsersPermission(),
+					},
+				},
+			},
+			args: args{
+				token: "supersecret3",
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Op:   platform.OpFindAuthorizationByToken,
+					Msg:  "authorization not found",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -798,9 +946,12 @@ func FindAuthorizations(
 	t *testing.T,
 ) {
 	type args struct {
-		ID     platform.ID
-		UserID platform.ID
-		token  string
+		ID            platform.ID
+		UserID        platform.ID
+		token         string
+		createdBy     platform.ID
+		unusedSince   *time.Time
+		expiresBefore *time.Time
 	}
 
 	type wants struct {
@@ -856,7 +1007,6 @@ func FindAuthorizations(
 						ID:          MustIDBase16(authOneID),
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
-						Token:       "rand1",
 						Status:      platform.Active,
 						Permissions: allUsersPermission(),
 					},
@@ -864,7 +1014,6 @@ func FindAuthorizations(
 						ID:          MustIDBase16(authTwoID),
 						UserID:      MustIDBase16(userTwoID),
 						OrgID:       MustIDBase16(orgOneID),
-						Token:       "rand2",
 						Status:      platform.Active,
 						Permissions: createUsersPermission(),
 					},
@@ -925,7 +1074,6 @@ func FindAuthorizations(
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "rand1",
 						Permissions: allUsersPermission(),
 					},
 					{
@@ -933,7 +1081,6 @@ func FindAuthorizations(
 						UserID:      MustIDBase16(userOneID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "rand3",
 						Permissions: deleteUsersPermission(),
 					},
 				},
@@ -994,13 +1141,143 @@ func FindAuthorizations(
 			},
 			wants: wants{
 				authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userTwoID),
+						OrgID:       MustIDBase16(orgOneID),
+						Status:      platform.Active,
+						Permissions: createUsersPermission(),
+					},
+				},
+			},
+		},
+		{
+			name: "find authorizations created by a user",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+					{
+						Name: "regularuser",
+						ID:   MustIDBase16(userTwoID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand1",
+						Permissions: allUsersPermission(),
+						CreatedBy:   MustIDBase16(userOneID),
+					},
 					{
 						ID:          MustIDBase16(authTwoID),
 						UserID:      MustIDBase16(userTwoID),
 						OrgID:       MustIDBase16(orgOneID),
 						Token:       "rand2",
+						Permissions: createUsersPermission(),
+						CreatedBy:   MustIDBase16(userTwoID),
+					},
+				},
+			},
+			args: args{
+				createdBy: MustIDBase16(userTwoID),
+			},
+			wants: wants{
+				authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userTwoID),
+						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
 						Permissions: createUsersPermission(),
+						CreatedBy:   MustIDBase16(userTwoID),
+					},
+				},
+			},
+		},
+		{
+			name: "find authorizations unused since and expiring before a time",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authZeroID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand0",
+						Permissions: allUsersPermission(),
+						ExpiresAt:   &authExpiresAt,
+					},
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand1",
+						Permissions: allUsersPermission(),
+						ExpiresAt:   &authExpiresAt,
+						LastUsedAt:  &authCreatedAt,
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand2",
+						Permissions: createUsersPermission(),
+						ExpiresAt:   &authExpiresAt,
+						LastUsedAt:  &authExpiresAt,
+					},
+					{
+						ID:          MustIDBase16(authThreeID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand3",
+						Permissions: deleteUsersPermission(),
+					},
+				},
+			},
+			args: args{
+				unusedSince:   &authExpiresAt,
+				expiresBefore: &authLaterAt,
+			},
+			wants: wants{
+				authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authZeroID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Status:      platform.Active,
+						Permissions: allUsersPermission(),
+						ExpiresAt:   &authExpiresAt,
+					},
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Status:      platform.Active,
+						Permissions: allUsersPermission(),
+						ExpiresAt:   &authExpiresAt,
+						LastUsedAt:  &authCreatedAt,
 					},
 				},
 			},
@@ -1023,6 +1300,11 @@ func FindAuthorizations(
 			if tt.args.token != "" {
 				filter.Token = &tt.args.token
 			}
+			if tt.args.createdBy.Valid() {
+				filter.CreatedBy = &tt.args.createdBy
+			}
+			filter.UnusedSince = tt.args.unusedSince
+			filter.ExpiresBefore = tt.args.expiresBefore
 
 			authorizations, _, err := s.FindAuthorizations(ctx, filter)
 			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
@@ -1098,7 +1380,6 @@ func DeleteAuthorization(
 						UserID:      MustIDBase16(userTwoID),
 						OrgID:       MustIDBase16(orgOneID),
 						Status:      platform.Active,
-						Token:       "rand2",
 						Permissions: createUsersPermission(),
 					},
 				},
@@ -1153,7 +1434,6 @@ func DeleteAuthorization(
 					{
 						ID:          MustIDBase16(authOneID),
 						UserID:      MustIDBase16(userOneID),
-						Token:       "rand1",
 						Status:      platform.Active,
 						OrgID:       MustIDBase16(orgOneID),
 						Permissions: allUsersPermission(),
@@ -1162,7 +1442,6 @@ func DeleteAuthorization(
 						ID:          MustIDBase16(authTwoID),
 						UserID:      MustIDBase16(userTwoID),
 						OrgID:       MustIDBase16(orgOneID),
-						Token:       "rand2",
 						Status:      platform.Active,
 						Permissions: createUsersPermission(),
 					},
@@ -29,6 +29,9 @@ var dbrpMappingCmpOptions = cmp.Options{
 		out := make([]*platform.DBRPMapping, len(in))
 		copy(out, in) // Copy input slice to avoid mutating it
 		sort.Slice(out, func(i, j int) bool {
+			if out[i].OrganizationID != out[j].OrganizationID {
+				return out[i].OrganizationID < out[j].OrganizationID
+			}
 			if out[i].Cluster != out[j].Cluster {
 				return out[i].Cluster < out[j].Cluster
 			}
@@ -64,7 +67,7 @@ func CleanupDBRPMappings(ctx context.Context, s platform.DBRPMappingService) err
 	}
 
 	for _, m := range mappings {
-		if err := s.Delete(ctx, m.Cluster, m.Database, m.RetentionPolicy); err != nil {
+		if err := s.Delete(ctx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy); err != nil {
 			return errors.Wrapf(err, "failed to remove dbrp mapping %s/%s/%s", m.Cluster, m.Database, m.RetentionPolicy)
 		}
 	}
@@ -217,7 +220,7 @@ func CreateDBRPMapping(
 				},
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping already exists"),
+				err: platform.ErrDBRPMappingExists,
 				dbrpMappings: []*platform.DBRPMapping{
 					{
 						Cluster:         "cluster1",
@@ -230,6 +233,49 @@ func CreateDBRPMapping(
 				},
 			},
 		},
+		{
+			name: "create same dbrpMapping in another organization",
+			fields: DBRPMappingFields{
+				DBRPMappings: []*platform.DBRPMapping{{
+					Cluster:         "cluster1",
+					Database:        "database1",
+					RetentionPolicy: "retention_policy1",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg1ID),
+					BucketID:        MustIDBase16(dbrpBucket1ID),
+				}},
+			},
+			args: args{
+				dbrpMapping: &platform.DBRPMapping{
+					Cluster:         "cluster1",
+					Database:        "database1",
+					RetentionPolicy: "retention_policy1",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg2ID),
+					BucketID:        MustIDBase16(dbrpBucket2ID),
+				},
+			},
+			wants: wants{
+				dbrpMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg1ID),
+						BucketID:        MustIDBase16(dbrpBucket1ID),
+					},
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg2ID),
+						BucketID:        MustIDBase16(dbrpBucket2ID),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -406,6 +452,49 @@ func FindDBRPMappings(
 				},
 			},
 		},
+		{
+			name: "find dbrpMappings by organization",
+			fields: DBRPMappingFields{
+				DBRPMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg1ID),
+						BucketID:        MustIDBase16(dbrpBucket1ID),
+					},
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg2ID),
+						BucketID:        MustIDBase16(dbrpBucket2ID),
+					},
+				},
+			},
+			args: args{
+				filter: platform.DBRPMappingFilter{
+					OrganizationID: idPtr(MustIDBase16(dbrpOrg2ID)),
+					Cluster:        strPtr("cluster1"),
+					Database:       strPtr("database1"),
+					Default:        boolPtr(true),
+				},
+			},
+			wants: wants{
+				dbrpMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg2ID),
+						BucketID:        MustIDBase16(dbrpBucket2ID),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -438,6 +527,7 @@ func FindDBRPMappingByKey(
 	t *testing.T,
 ) {
 	type args struct {
+		OrganizationID platform.ID
 		Cluster,
 		Database,
 		RetentionPolicy string
@@ -477,6 +567,7 @@ func FindDBRPMappingByKey(
 				},
 			},
 			args: args{
+				OrganizationID:  MustIDBase16(dbrpOrg3ID),
 				Cluster:         "cluster",
 				Database:        "database",
 				RetentionPolicy: "retention_policyB",
@@ -507,12 +598,37 @@ func FindDBRPMappingByKey(
 				},
 			},
 			args: args{
+				OrganizationID:  MustIDBase16(dbrpOrg3ID),
 				Cluster:         "clusterX",
 				Database:        "database",
 				RetentionPolicy: "retention_policyA",
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping not found"),
+				err: platform.ErrDBRPMappingNotFound,
+			},
+		},
+		{
+			name: "find dbrpMapping of another organization",
+			fields: DBRPMappingFields{
+				DBRPMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster",
+						Database:        "database",
+						RetentionPolicy: "retention_policyA",
+						Default:         false,
+						OrganizationID:  MustIDBase16(dbrpOrg3ID),
+						BucketID:        MustIDBase16(dbrpBucketAID),
+					},
+				},
+			},
+			args: args{
+				OrganizationID:  MustIDBase16(dbrpOrg1ID),
+				Cluster:         "cluster",
+				Database:        "database",
+				RetentionPolicy: "retention_policyA",
+			},
+			wants: wants{
+				err: platform.ErrDBRPMappingNotFound,
 			},
 		},
 	}
@@ -523,7 +639,7 @@ func FindDBRPMappingByKey(
 			defer done()
 			ctx := context.Background()
 
-			dbrpMapping, err := s.FindBy(ctx, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
+			dbrpMapping, err := s.FindBy(ctx, tt.args.OrganizationID, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
 			if (err != nil) != (tt.wants.err != nil) {
 				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
 			}
@@ -673,6 +789,7 @@ func DeleteDBRPMapping(
 	t *testing.T,
 ) {
 	type args struct {
+		OrganizationID                     platform.ID
 		Cluster, Database, RetentionPolicy string
 	}
 	type wants struct {
@@ -709,6 +826,7 @@ func DeleteDBRPMapping(
 				},
 			},
 			args: args{
+				OrganizationID:  MustIDBase16(dbrpOrg1ID),
 				Cluster:         "cluster1",
 				Database:        "database1",
 				RetentionPolicy: "retention_policy1",
@@ -747,6 +865,7 @@ func DeleteDBRPMapping(
 				},
 			},
 			args: args{
+				OrganizationID:  MustIDBase16(dbrpOrg1ID),
 				Cluster:         "cluster3",
 				Database:        "db",
 				RetentionPolicy: "rp",
@@ -779,7 +898,7 @@ func DeleteDBRPMapping(
 			s, done := init(tt.fields, t)
 			defer done()
 			ctx := context.Background()
-			err := s.Delete(ctx, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
+			err := s.Delete(ctx, tt.args.OrganizationID, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
 			if (err != nil) != (tt.wants.err != nil) {
 				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
 			}
@@ -15,6 +15,7 @@ import (
 type OnboardingFields struct {
 	IDGenerator    platform.IDGenerator
 	TokenGenerator platform.TokenGenerator
+	NowFn          func() time.Time
 	IsOnboarding   bool
 }
 
@@ -134,7 +135,10 @@ func Generate(
 					s: []string{oneID, twoID, threeID, fourID},
 				},
 				TokenGenerator: mock.NewTokenGenerator(oneToken, nil),
-				IsOnboarding:   true,
+				NowFn: func() time.Time {
+					return time.Date(2009, time.November, 10, 24, 0, 0, 0, time.UTC)
+				},
+				IsOnboarding: true,
 			},
 			args: args{
 				request: &platform.OnboardingRequest{
@@ -171,6 +175,8 @@ func Generate(
 						Description: "admin's Token",
 						OrgID:       MustIDBase16(twoID),
 						Permissions: mustGeneratePermissions(MustIDBase16(twoID), MustIDBase16(threeID)),
+						CreatedBy:   MustIDBase16(oneID),
+						CreatedAt:   time.Date(2009, time.November, 10, 24, 0, 0, 0, time.UTC),
 					},
 				},
 			},
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
@@ -165,6 +166,122 @@ func AddTarget(
 				},
 			},
 		},
+		{
+			name: "create target with schedule, auth and relabel rules",
+			fields: TargetFields{
+				IDGenerator: mock.NewIDGenerator(targetOneID, t),
+				Targets:     []*platform.ScraperTarget{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:       "name1",
+					Type:       platform.PrometheusScraperType,
+					OrgName:    "org1",
+					BucketName: "bucket1",
+					URL:        "url1",
+					Interval:   30 * time.Second,
+					Timeout:    5 * time.Second,
+					Auth: &platform.ScraperAuth{
+						Type:      platform.BasicScraperAuth,
+						Username:  "user1",
+						SecretKey: "password1",
+					},
+					TLS: &platform.ScraperTLSConfig{
+						InsecureSkipVerify: true,
+					},
+					RelabelRules: []platform.RelabelRule{
+						{
+							SourceLabels: []string{platform.MetricNameLabel},
+							Regex:        "go_.*",
+							Action:       platform.RelabelDrop,
+						},
+					},
+				},
+			},
+			wants: wants{
+				targets: []platform.ScraperTarget{
+					{
+						Name:       "name1",
+						Type:       platform.PrometheusScraperType,
+						OrgName:    "org1",
+						BucketName: "bucket1",
+						URL:        "url1",
+						ID:         MustIDBase16(targetOneID),
+						Interval:   30 * time.Second,
+						Timeout:    5 * time.Second,
+						Auth: &platform.ScraperAuth{
+							Type:      platform.BasicScraperAuth,
+							Username:  "user1",
+							SecretKey: "password1",
+						},
+						TLS: &platform.ScraperTLSConfig{
+							InsecureSkipVerify: true,
+						},
+						RelabelRules: []platform.RelabelRule{
+							{
+								SourceLabels: []string{platform.MetricNameLabel},
+								Regex:        "go_.*",
+								Action:       platform.RelabelDrop,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create target with invalid relabel rule",
+			fields: TargetFields{
+				IDGenerator: mock.NewIDGenerator(targetOneID, t),
+				Targets:     []*platform.ScraperTarget{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:       "name1",
+					Type:       platform.PrometheusScraperType,
+					OrgName:    "org1",
+					BucketName: "bucket1",
+					URL:        "url1",
+					RelabelRules: []platform.RelabelRule{
+						{
+							Regex:  "go_.*",
+							Action: "rename",
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Op:   platform.OpAddTarget,
+					Msg:  `invalid relabel rule 0: unsupported relabel action: "rename"`,
+				},
+				targets: []platform.ScraperTarget{},
+			},
+		},
+		{
+			name: "create json target without mapping",
+			fields: TargetFields{
+				IDGenerator: mock.NewIDGenerator(targetOneID, t),
+				Targets:     []*platform.ScraperTarget{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:       "name1",
+					Type:       platform.JSONScraperType,
+					OrgName:    "org1",
+					BucketName: "bucket1",
+					URL:        "url1",
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Op:   platform.OpAddTarget,
+					Msg:  "json scraper target requires a json mapping",
+				},
+				targets: []platform.ScraperTarget{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -556,3 +673,118 @@ func UpdateTarget(
 		})
 	}
 }
+
+// TargetHealth testing.
+func TargetHealth(
+	init func(TargetFields, *testing.T) (platform.ScraperTargetHealthService, string, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id     platform.ID
+		health *platform.ScraperTargetHealth
+	}
+	type wants struct {
+		err    error
+		health *platform.ScraperTargetHealth
+	}
+	tests := []struct {
+		name   string
+		fields TargetFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "put and get health",
+			fields: TargetFields{
+				Targets: []*platform.ScraperTarget{
+					{
+						ID:  MustIDBase16(targetOneID),
+						URL: "url1",
+					},
+				},
+			},
+			args: args{
+				id: MustIDBase16(targetOneID),
+				health: &platform.ScraperTargetHealth{
+					Up:                 false,
+					LastScrape:         time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+					LastScrapeDuration: 50 * time.Millisecond,
+					LastError:          "connection refused",
+				},
+			},
+			wants: wants{
+				health: &platform.ScraperTargetHealth{
+					Up:                 false,
+					LastScrape:         time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+					LastScrapeDuration: 50 * time.Millisecond,
+					LastError:          "connection refused",
+				},
+			},
+		},
+		{
+			name: "get health of target never scraped",
+			fields: TargetFields{
+				Targets: []*platform.ScraperTarget{
+					{
+						ID:  MustIDBase16(targetOneID),
+						URL: "url1",
+					},
+				},
+			},
+			args: args{
+				id: MustIDBase16(targetOneID),
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Op:   platform.OpGetTargetHealth,
+					Msg:  "scraper target health is not found",
+				},
+			},
+		},
+		{
+			name: "put health of non existent target",
+			fields: TargetFields{
+				Targets: []*platform.ScraperTarget{},
+			},
+			args: args{
+				id: MustIDBase16(targetOneID),
+				health: &platform.ScraperTargetHealth{
+					Up:          true,
+					SampleCount: 10,
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Op:   platform.OpPutTargetHealth,
+					Msg:  "scraper target is not found",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, opPrefix, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			if tt.args.health != nil {
+				err := s.PutTargetHealth(ctx, tt.args.id, tt.args.health)
+				if tt.wants.health == nil {
+					diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
+					return
+				}
+				if err != nil {
+					t.Fatalf("failed to put scraper target health: %v", err)
+				}
+			}
+
+			health, err := s.GetTargetHealth(ctx, tt.args.id)
+			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
+			if diff := cmp.Diff(health, tt.wants.health); diff != "" {
+				t.Errorf("scraper target health is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
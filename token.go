@@ -1,7 +1,67 @@
 package platform
 
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+)
+
 // TokenGenerator represents a generator for API tokens.
 type TokenGenerator interface {
 	// Token generates a new API token.
 	Token() (string, error)
 }
+
+// TokenPrefixLength is the number of leading characters of a token that are
+// stored in plain text to look up the hash of the token.
+const TokenPrefixLength = 8
+
+// tokenSaltLength is the number of random bytes each token is salted with.
+const tokenSaltLength = 16
+
+// TokenHash is the salted and peppered hash of an API token. It is stored in
+// place of the token, so that a copy of the store does not leak the tokens.
+type TokenHash struct {
+	// Prefix is the beginning of the token, used to look up its hash.
+	Prefix string `json:"prefix"`
+	Salt   []byte `json:"salt"`
+	Hash   []byte `json:"hash"`
+}
+
+// TokenPrefix returns the prefix of the token used to look up its hash.
+func TokenPrefix(token string) string {
+	if len(token) > TokenPrefixLength {
+		return token[:TokenPrefixLength]
+	}
+	return token
+}
+
+// HashToken hashes the token with a random salt and the pepper, a secret that
+// is kept apart from the hashes.
+func HashToken(token string, pepper []byte) (*TokenHash, error) {
+	salt := make([]byte, tokenSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &TokenHash{
+		Prefix: TokenPrefix(token),
+		Salt:   salt,
+		Hash:   hashToken(token, salt, pepper),
+	}, nil
+}
+
+// Matches returns true if the token hashes to h with the pepper.
+func (h *TokenHash) Matches(token string, pepper []byte) bool {
+	if h.Prefix != TokenPrefix(token) {
+		return false
+	}
+	return hmac.Equal(h.Hash, hashToken(token, h.Salt, pepper))
+}
+
+func hashToken(token string, salt, pepper []byte) []byte {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write(salt)
+	mac.Write([]byte(token))
+	return mac.Sum(nil)
+}
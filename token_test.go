@@ -0,0 +1,51 @@
+package platform_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestHashToken(t *testing.T) {
+	pepper := []byte("pepper")
+	token := "ZNe2NLfRrMw6JGa6HUx8fkrWnQnMwgkHd2gWIdUhCdMNg5dVXpVlx7BbO5shO5G4"
+
+	h, err := platform.HashToken(token, pepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Prefix != "ZNe2NLfR" {
+		t.Errorf("got prefix %q, want %q", h.Prefix, "ZNe2NLfR")
+	}
+	if bytes.Contains(h.Hash, []byte(token)) {
+		t.Error("hash contains the token")
+	}
+	if !h.Matches(token, pepper) {
+		t.Error("expected the token to match its hash")
+	}
+	if h.Matches(token+"x", pepper) {
+		t.Error("expected another token not to match the hash")
+	}
+	if h.Matches(token, []byte("salt")) {
+		t.Error("expected the token not to match the hash with another pepper")
+	}
+
+	other, err := platform.HashToken(token, pepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(h.Hash, other.Hash) {
+		t.Error("expected hashes of the same token to be salted differently")
+	}
+}
+
+func TestTokenPrefix(t *testing.T) {
+	if got := platform.TokenPrefix("abc"); got != "abc" {
+		t.Errorf("got prefix %q of a short token, want %q", got, "abc")
+	}
+	if got := platform.TokenPrefix("abcdefghijk"); got != "abcdefgh" {
+		t.Errorf("got prefix %q, want %q", got, "abcdefgh")
+	}
+}
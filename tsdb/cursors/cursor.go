@@ -39,6 +39,14 @@ type BooleanArrayCursor interface {
 	Next() *BooleanArray
 }
 
+// BlockSkipper is implemented by ascending cursors that can skip ahead
+// using the min and max times of the TSM blocks they read.
+type BlockSkipper interface {
+	// SkipTo discards the points before t, without decoding the blocks
+	// whose points are all before t.
+	SkipTo(t int64)
+}
+
 type CursorRequest struct {
 	Name      []byte
 	Tags      models.Tags
@@ -68,10 +76,14 @@ func (a CursorIterators) Stats() CursorStats {
 type CursorStats struct {
 	ScannedValues int // number of values scanned
 	ScannedBytes  int // number of uncompressed bytes scanned
+	ScannedBlocks int // number of TSM blocks read
+	CacheValues   int // number of values read from the cache
 }
 
 // Add adds other to s and updates s.
 func (s *CursorStats) Add(other CursorStats) {
 	s.ScannedValues += other.ScannedValues
 	s.ScannedBytes += other.ScannedBytes
+	s.ScannedBlocks += other.ScannedBlocks
+	s.CacheValues += other.CacheValues
 }
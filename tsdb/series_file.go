@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/influxdata/platform/logger"
+	"github.com/influxdata/platform/pkg/file"
 	"github.com/influxdata/platform/pkg/rhh"
 
 	"github.com/cespare/xxhash"
@@ -201,6 +202,29 @@ func (f *SeriesFile) Wait() {
 	defer f.refs.Unlock()
 }
 
+// SnapshotTo hard links the files of the series file, as of the time of the
+// call, into the directory at path. Series may be created while it is taken,
+// but the snapshot of each partition is consistent. The links to the segments
+// must be replaced by copies by calling Copy on each returned prefix.
+func (f *SeriesFile) SnapshotTo(path string) ([]*file.LinkedPrefix, error) {
+	release := f.Retain()
+	defer release()
+
+	if err := os.MkdirAll(path, 0777); err != nil {
+		return nil, err
+	}
+
+	var prefixes []*file.LinkedPrefix
+	for _, p := range f.partitions {
+		ps, err := p.SnapshotTo(filepath.Join(path, filepath.Base(p.Path())))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, ps...)
+	}
+	return prefixes, nil
+}
+
 // CreateSeriesListIfNotExists creates a list of series in bulk if they don't exist. It overwrites
 // the collection's Keys and SeriesIDs fields. The collection's SeriesIDs slice will have IDs for
 // every name+tags, creating new series IDs as needed. If any SeriesID is zero, then a type
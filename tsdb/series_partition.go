@@ -12,6 +12,7 @@ import (
 
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/file"
 	"github.com/influxdata/platform/pkg/rhh"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -173,6 +174,42 @@ func (p *SeriesPartition) Close() (err error) {
 // ID returns the partition id.
 func (p *SeriesPartition) ID() int { return p.id }
 
+// SnapshotTo hard links the segments and index of the partition into a new
+// directory at path. Segments are appended to, so the links to them must be
+// replaced by copies of their current contents once the partition may change
+// again, by calling Copy on each returned prefix.
+func (p *SeriesPartition) SnapshotTo(path string) ([]*file.LinkedPrefix, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrSeriesPartitionClosed
+	}
+
+	if err := os.Mkdir(path, 0777); err != nil {
+		return nil, err
+	}
+
+	var prefixes []*file.LinkedPrefix
+	for _, s := range p.segments {
+		if err := s.Flush(); err != nil {
+			return nil, err
+		}
+		prefix, err := file.LinkPrefix(s.path, filepath.Join(path, filepath.Base(s.path)), s.Size(), int64(SeriesSegmentSize(s.id)))
+		if err != nil {
+			return nil, fmt.Errorf("error creating series segment hard link: %q", err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	// The index is only ever replaced by compactions, never modified. It may
+	// not exist yet, in which case it is rebuilt from the segments on open.
+	if err := os.Link(p.IndexPath(), filepath.Join(path, filepath.Base(p.IndexPath()))); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error creating series index hard link: %q", err)
+	}
+	return prefixes, nil
+}
+
 // Path returns the path to the partition.
 func (p *SeriesPartition) Path() string { return p.path }
 
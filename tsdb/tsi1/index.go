@@ -18,6 +18,7 @@ import (
 	"github.com/cespare/xxhash"
 	"github.com/influxdata/influxql"
 	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/file"
 	"github.com/influxdata/platform/pkg/slices"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/tsdb"
@@ -336,6 +337,28 @@ func (i *Index) Close() error {
 // Path returns the path the index was opened with.
 func (i *Index) Path() string { return i.path }
 
+// SnapshotTo hard links the files of every partition of the index into path.
+// The links to log files must be replaced by copies by calling Copy on each
+// returned prefix.
+func (i *Index) SnapshotTo(path string) ([]*file.LinkedPrefix, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if err := os.MkdirAll(path, 0777); err != nil {
+		return nil, err
+	}
+
+	var prefixes []*file.LinkedPrefix
+	for _, p := range i.partitions {
+		ps, err := p.SnapshotTo(filepath.Join(path, p.id))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, ps...)
+	}
+	return prefixes, nil
+}
+
 // PartitionAt returns the partition by index.
 func (i *Index) PartitionAt(index int) *Partition {
 	return i.partitions[index]
@@ -448,6 +471,12 @@ func (i *Index) MeasurementHasSeries(name []byte) (bool, error) {
 	return false, nil
 }
 
+// HasSeries returns true if the series of id, whose key is key, is in the
+// index. Unlike SeriesIDSet, it only looks at the partition of the series.
+func (i *Index) HasSeries(key []byte, id tsdb.SeriesID) bool {
+	return i.partition(key).HasSeries(id)
+}
+
 // fetchByteValues is a helper for gathering values from each partition in the index,
 // based on some criteria.
 //
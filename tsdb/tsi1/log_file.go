@@ -16,6 +16,7 @@ import (
 
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/pkg/bloom"
+	"github.com/influxdata/platform/pkg/file"
 	"github.com/influxdata/platform/pkg/mmap"
 	"github.com/influxdata/platform/tsdb"
 )
@@ -215,6 +216,21 @@ func (f *LogFile) FlushAndSync() error {
 	return f.file.Sync()
 }
 
+// LinkTo hard links the log file, including any buffered entries, to a new
+// file at path. It returns the link to the entries written so far, which must
+// be replaced by a copy by calling Copy, as the log file is appended to.
+func (f *LogFile) LinkTo(path string) (*file.LinkedPrefix, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.w != nil {
+		if err := f.w.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	return file.LinkPrefix(f.path, path, f.size, f.size)
+}
+
 // ID returns the file sequence identifier.
 func (f *LogFile) ID() int { return f.id }
 
@@ -18,6 +18,7 @@ import (
 	"github.com/influxdata/influxql"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/pkg/bytesutil"
+	"github.com/influxdata/platform/pkg/file"
 	"github.com/influxdata/platform/tsdb"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -423,6 +424,48 @@ func (p *Partition) Manifest() *Manifest {
 	return m
 }
 
+// SnapshotTo writes a copy of the manifest of the partition into a new
+// directory at path, and hard links its files. Log files are appended to, so
+// the links to them must be replaced by copies once the partition may change
+// again, by calling Copy on each returned prefix.
+func (p *Partition) SnapshotTo(path string) ([]*file.LinkedPrefix, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fs := p.retainFileSet()
+	defer fs.Release()
+
+	if err := os.Mkdir(path, 0777); err != nil {
+		return nil, err
+	}
+
+	m := p.Manifest()
+	m.path = filepath.Join(path, ManifestFileName)
+	if _, err := m.Write(); err != nil {
+		return nil, err
+	}
+
+	var prefixes []*file.LinkedPrefix
+	for _, f := range fs.files {
+		newpath := filepath.Join(path, filepath.Base(f.Path()))
+		if lf, ok := f.(*LogFile); ok {
+			prefix, err := lf.LinkTo(newpath)
+			if err != nil {
+				return nil, fmt.Errorf("error creating tsi log file hard link: %q", err)
+			}
+			prefixes = append(prefixes, prefix)
+		} else if err := os.Link(f.Path(), newpath); err != nil {
+			return nil, fmt.Errorf("error creating tsi hard link: %q", err)
+		}
+	}
+
+	// The stats file is only ever replaced, never modified, and may not exist.
+	if err := os.Link(p.StatsPath(), filepath.Join(path, StatsFileName)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error creating tsi stats hard link: %q", err)
+	}
+	return prefixes, nil
+}
+
 // StatsPath returns the path to the partition's stats file.
 func (p *Partition) StatsPath() string {
 	return filepath.Join(p.path, StatsFileName)
@@ -711,6 +754,11 @@ func (p *Partition) createSeriesListIfNotExists(collection *tsdb.SeriesCollectio
 	return ids, nil
 }
 
+// HasSeries returns true if the series id is in the partition.
+func (p *Partition) HasSeries(seriesID tsdb.SeriesID) bool {
+	return p.seriesIDSet.Contains(seriesID)
+}
+
 // DropSeries removes the provided series id from the index.
 //
 // TODO(edd): We should support a bulk drop here.
@@ -44,6 +44,7 @@ func newFloatArrayAscendingCursor() *floatArrayAscendingCursor {
 func (c *floatArrayAscendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 		return c.cache.values[i].UnixNano() >= seek
 	})
@@ -160,9 +161,38 @@ func (c *floatArrayAscendingCursor) nextTSM() *tsdb.FloatArray {
 
 func (c *floatArrayAscendingCursor) readArrayBlock() *tsdb.FloatArray {
 	values, _ := c.tsm.keyCursor.ReadFloatArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 	return values
 }
 
+// SkipTo discards the points before t. The TSM blocks whose points are all
+// before t are skipped without being decoded.
+func (c *floatArrayAscendingCursor) SkipTo(t int64) {
+	cvals := c.cache.values[c.cache.pos:]
+	c.cache.pos += sort.Search(len(cvals), func(i int) bool {
+		return cvals[i].UnixNano() >= t
+	})
+
+	tvals := c.tsm.values
+	if tvals.Len() > 0 && tvals.MaxTime() < t {
+		c.tsm.keyCursor.skipTo(t)
+		tvals = c.nextTSM()
+	}
+
+	for tvals.Len() > 0 {
+		ts := tvals.Timestamps[c.tsm.pos:]
+		c.tsm.pos += sort.Search(len(ts), func(i int) bool {
+			return ts[i] >= t
+		})
+		if c.tsm.pos < tvals.Len() {
+			return
+		}
+		tvals = c.nextTSM()
+	}
+}
+
 type floatArrayDescendingCursor struct {
 	cache struct {
 		values Values
@@ -192,6 +222,7 @@ func newFloatArrayDescendingCursor() *floatArrayDescendingCursor {
 func (c *floatArrayDescendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	if len(c.cache.values) > 0 {
 		c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 			return c.cache.values[i].UnixNano() >= seek
@@ -315,6 +346,9 @@ func (c *floatArrayDescendingCursor) nextTSM() *tsdb.FloatArray {
 
 func (c *floatArrayDescendingCursor) readArrayBlock() *tsdb.FloatArray {
 	values, _ := c.tsm.keyCursor.ReadFloatArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 
 	c.stats.ScannedValues += len(values.Values)
 
@@ -352,6 +386,7 @@ func newIntegerArrayAscendingCursor() *integerArrayAscendingCursor {
 func (c *integerArrayAscendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 		return c.cache.values[i].UnixNano() >= seek
 	})
@@ -468,9 +503,38 @@ func (c *integerArrayAscendingCursor) nextTSM() *tsdb.IntegerArray {
 
 func (c *integerArrayAscendingCursor) readArrayBlock() *tsdb.IntegerArray {
 	values, _ := c.tsm.keyCursor.ReadIntegerArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 	return values
 }
 
+// SkipTo discards the points before t. The TSM blocks whose points are all
+// before t are skipped without being decoded.
+func (c *integerArrayAscendingCursor) SkipTo(t int64) {
+	cvals := c.cache.values[c.cache.pos:]
+	c.cache.pos += sort.Search(len(cvals), func(i int) bool {
+		return cvals[i].UnixNano() >= t
+	})
+
+	tvals := c.tsm.values
+	if tvals.Len() > 0 && tvals.MaxTime() < t {
+		c.tsm.keyCursor.skipTo(t)
+		tvals = c.nextTSM()
+	}
+
+	for tvals.Len() > 0 {
+		ts := tvals.Timestamps[c.tsm.pos:]
+		c.tsm.pos += sort.Search(len(ts), func(i int) bool {
+			return ts[i] >= t
+		})
+		if c.tsm.pos < tvals.Len() {
+			return
+		}
+		tvals = c.nextTSM()
+	}
+}
+
 type integerArrayDescendingCursor struct {
 	cache struct {
 		values Values
@@ -500,6 +564,7 @@ func newIntegerArrayDescendingCursor() *integerArrayDescendingCursor {
 func (c *integerArrayDescendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	if len(c.cache.values) > 0 {
 		c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 			return c.cache.values[i].UnixNano() >= seek
@@ -623,6 +688,9 @@ func (c *integerArrayDescendingCursor) nextTSM() *tsdb.IntegerArray {
 
 func (c *integerArrayDescendingCursor) readArrayBlock() *tsdb.IntegerArray {
 	values, _ := c.tsm.keyCursor.ReadIntegerArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 
 	c.stats.ScannedValues += len(values.Values)
 
@@ -660,6 +728,7 @@ func newUnsignedArrayAscendingCursor() *unsignedArrayAscendingCursor {
 func (c *unsignedArrayAscendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 		return c.cache.values[i].UnixNano() >= seek
 	})
@@ -776,9 +845,38 @@ func (c *unsignedArrayAscendingCursor) nextTSM() *tsdb.UnsignedArray {
 
 func (c *unsignedArrayAscendingCursor) readArrayBlock() *tsdb.UnsignedArray {
 	values, _ := c.tsm.keyCursor.ReadUnsignedArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 	return values
 }
 
+// SkipTo discards the points before t. The TSM blocks whose points are all
+// before t are skipped without being decoded.
+func (c *unsignedArrayAscendingCursor) SkipTo(t int64) {
+	cvals := c.cache.values[c.cache.pos:]
+	c.cache.pos += sort.Search(len(cvals), func(i int) bool {
+		return cvals[i].UnixNano() >= t
+	})
+
+	tvals := c.tsm.values
+	if tvals.Len() > 0 && tvals.MaxTime() < t {
+		c.tsm.keyCursor.skipTo(t)
+		tvals = c.nextTSM()
+	}
+
+	for tvals.Len() > 0 {
+		ts := tvals.Timestamps[c.tsm.pos:]
+		c.tsm.pos += sort.Search(len(ts), func(i int) bool {
+			return ts[i] >= t
+		})
+		if c.tsm.pos < tvals.Len() {
+			return
+		}
+		tvals = c.nextTSM()
+	}
+}
+
 type unsignedArrayDescendingCursor struct {
 	cache struct {
 		values Values
@@ -808,6 +906,7 @@ func newUnsignedArrayDescendingCursor() *unsignedArrayDescendingCursor {
 func (c *unsignedArrayDescendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	if len(c.cache.values) > 0 {
 		c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 			return c.cache.values[i].UnixNano() >= seek
@@ -931,6 +1030,9 @@ func (c *unsignedArrayDescendingCursor) nextTSM() *tsdb.UnsignedArray {
 
 func (c *unsignedArrayDescendingCursor) readArrayBlock() *tsdb.UnsignedArray {
 	values, _ := c.tsm.keyCursor.ReadUnsignedArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 
 	c.stats.ScannedValues += len(values.Values)
 
@@ -968,6 +1070,7 @@ func newStringArrayAscendingCursor() *stringArrayAscendingCursor {
 func (c *stringArrayAscendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 		return c.cache.values[i].UnixNano() >= seek
 	})
@@ -1086,9 +1189,38 @@ func (c *stringArrayAscendingCursor) nextTSM() *tsdb.StringArray {
 
 func (c *stringArrayAscendingCursor) readArrayBlock() *tsdb.StringArray {
 	values, _ := c.tsm.keyCursor.ReadStringArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 	return values
 }
 
+// SkipTo discards the points before t. The TSM blocks whose points are all
+// before t are skipped without being decoded.
+func (c *stringArrayAscendingCursor) SkipTo(t int64) {
+	cvals := c.cache.values[c.cache.pos:]
+	c.cache.pos += sort.Search(len(cvals), func(i int) bool {
+		return cvals[i].UnixNano() >= t
+	})
+
+	tvals := c.tsm.values
+	if tvals.Len() > 0 && tvals.MaxTime() < t {
+		c.tsm.keyCursor.skipTo(t)
+		tvals = c.nextTSM()
+	}
+
+	for tvals.Len() > 0 {
+		ts := tvals.Timestamps[c.tsm.pos:]
+		c.tsm.pos += sort.Search(len(ts), func(i int) bool {
+			return ts[i] >= t
+		})
+		if c.tsm.pos < tvals.Len() {
+			return
+		}
+		tvals = c.nextTSM()
+	}
+}
+
 type stringArrayDescendingCursor struct {
 	cache struct {
 		values Values
@@ -1118,6 +1250,7 @@ func newStringArrayDescendingCursor() *stringArrayDescendingCursor {
 func (c *stringArrayDescendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	if len(c.cache.values) > 0 {
 		c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 			return c.cache.values[i].UnixNano() >= seek
@@ -1241,6 +1374,9 @@ func (c *stringArrayDescendingCursor) nextTSM() *tsdb.StringArray {
 
 func (c *stringArrayDescendingCursor) readArrayBlock() *tsdb.StringArray {
 	values, _ := c.tsm.keyCursor.ReadStringArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 
 	c.stats.ScannedValues += len(values.Values)
 
@@ -1280,6 +1416,7 @@ func newBooleanArrayAscendingCursor() *booleanArrayAscendingCursor {
 func (c *booleanArrayAscendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 		return c.cache.values[i].UnixNano() >= seek
 	})
@@ -1396,9 +1533,38 @@ func (c *booleanArrayAscendingCursor) nextTSM() *tsdb.BooleanArray {
 
 func (c *booleanArrayAscendingCursor) readArrayBlock() *tsdb.BooleanArray {
 	values, _ := c.tsm.keyCursor.ReadBooleanArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 	return values
 }
 
+// SkipTo discards the points before t. The TSM blocks whose points are all
+// before t are skipped without being decoded.
+func (c *booleanArrayAscendingCursor) SkipTo(t int64) {
+	cvals := c.cache.values[c.cache.pos:]
+	c.cache.pos += sort.Search(len(cvals), func(i int) bool {
+		return cvals[i].UnixNano() >= t
+	})
+
+	tvals := c.tsm.values
+	if tvals.Len() > 0 && tvals.MaxTime() < t {
+		c.tsm.keyCursor.skipTo(t)
+		tvals = c.nextTSM()
+	}
+
+	for tvals.Len() > 0 {
+		ts := tvals.Timestamps[c.tsm.pos:]
+		c.tsm.pos += sort.Search(len(ts), func(i int) bool {
+			return ts[i] >= t
+		})
+		if c.tsm.pos < tvals.Len() {
+			return
+		}
+		tvals = c.nextTSM()
+	}
+}
+
 type booleanArrayDescendingCursor struct {
 	cache struct {
 		values Values
@@ -1428,6 +1594,7 @@ func newBooleanArrayDescendingCursor() *booleanArrayDescendingCursor {
 func (c *booleanArrayDescendingCursor) reset(seek, end int64, cacheValues Values, tsmKeyCursor *KeyCursor) {
 	c.end = end
 	c.cache.values = cacheValues
+	c.stats.CacheValues += len(cacheValues)
 	if len(c.cache.values) > 0 {
 		c.cache.pos = sort.Search(len(c.cache.values), func(i int) bool {
 			return c.cache.values[i].UnixNano() >= seek
@@ -1551,6 +1718,9 @@ func (c *booleanArrayDescendingCursor) nextTSM() *tsdb.BooleanArray {
 
 func (c *booleanArrayDescendingCursor) readArrayBlock() *tsdb.BooleanArray {
 	values, _ := c.tsm.keyCursor.ReadBooleanArrayBlock(c.tsm.buf)
+	if values.Len() > 0 {
+		c.stats.ScannedBlocks++
+	}
 
 	c.stats.ScannedValues += len(values.Values)
 
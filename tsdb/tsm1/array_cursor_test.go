@@ -0,0 +1,54 @@
+package tsm1
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestFloatArrayAscendingCursor_SkipTo(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	// Each file holds a single block.
+	blocks := [][]Value{
+		{NewValue(0, 0.0), NewValue(10, 1.0)},
+		{NewValue(20, 2.0), NewValue(30, 3.0)},
+		{NewValue(40, 4.0), NewValue(50, 5.0)},
+	}
+	for i, values := range blocks {
+		f := mustTempFile(dir)
+		w, err := NewTSMWriter(f)
+		fatalIfErr(t, "creating writer", err)
+		fatalIfErr(t, "writing", w.Write([]byte("cpu"), values))
+		fatalIfErr(t, "writing index", w.WriteIndex())
+		fatalIfErr(t, "closing", w.Close())
+		fatalIfErr(t, "renaming", os.Rename(f.Name(), filepath.Join(dir, DefaultFormatFileName(i+1, 1)+".tsm")))
+	}
+
+	fs := NewFileStore(dir)
+	fatalIfErr(t, "opening file store", fs.Open())
+	defer fs.Close()
+
+	cur := newFloatArrayAscendingCursor()
+	cache := Values{NewValue(25, 2.5), NewValue(35, 3.5)}
+	cur.reset(0, math.MaxInt64, cache, fs.KeyCursor(context.Background(), []byte("cpu"), 0, true))
+	defer cur.Close()
+
+	cur.SkipTo(31)
+
+	exp := &tsdb.FloatArray{Timestamps: []int64{35, 40, 50}, Values: []float64{3.5, 4.0, 5.0}}
+	if got := cur.Next(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points: got %v, exp %v", got, exp)
+	}
+
+	// The second block only contains points before 31, so it is not decoded.
+	if got, exp := cur.Stats().ScannedBlocks, 2; got != exp {
+		t.Fatalf("unexpected scanned blocks: got %d, exp %d", got, exp)
+	}
+}
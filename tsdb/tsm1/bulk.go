@@ -0,0 +1,207 @@
+package tsm1
+
+import (
+	"os"
+	"strings"
+
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/bytesutil"
+)
+
+// A BulkLoader writes points directly to new TSM files of an Engine, bypassing
+// its WAL and cache. The values are buffered in a cache of the loader's own
+// until it reaches the BulkLoadMemorySize of the engine, and are then written
+// to TSM files that are not yet part of the FileStore. Commit adds all of the
+// files to the FileStore at once, so the points of a load are either all
+// readable or none of them are.
+//
+// Points of a bulk load overwrite the values of the same series and time that
+// are in the TSM files of the engine, but not those still in its cache.
+//
+// Abort removes the files of a load, and the series that were added to the
+// index for it, so a failed load leaves nothing behind.
+//
+// A BulkLoader is not safe for concurrent use.
+type BulkLoader struct {
+	e       *Engine
+	maxSize uint64
+	cache   *Cache
+	files   []string
+	series  map[string]struct{}
+}
+
+// NewBulkLoader returns a new BulkLoader that writes to the TSM files of e.
+// The series of the points must be added to the index before they are
+// written to the loader.
+func (e *Engine) NewBulkLoader() *BulkLoader {
+	return &BulkLoader{
+		e:       e,
+		maxSize: e.BulkLoadMemorySize,
+		cache:   NewCache(0),
+		series:  make(map[string]struct{}),
+	}
+}
+
+// AddNewSeries records the keys of series that were added to the index for
+// the load, so that Abort can remove them again.
+func (l *BulkLoader) AddNewSeries(seriesKeys [][]byte) {
+	for _, key := range seriesKeys {
+		l.series[string(key)] = struct{}{}
+	}
+}
+
+// WritePoints buffers the values of points, and writes the buffered values to
+// new TSM files once they reach the size limit of the loader.
+func (l *BulkLoader) WritePoints(points []models.Point) error {
+	values, err := valuesFromPoints(points)
+	if err != nil {
+		return err
+	}
+
+	if err := l.cache.WriteMulti(values); err != nil {
+		return err
+	}
+
+	if l.maxSize > 0 && l.cache.Size() >= l.maxSize {
+		return l.flush()
+	}
+	return nil
+}
+
+// flush writes the buffered values to new TSM files, and resets the buffer.
+func (l *BulkLoader) flush() error {
+	if l.cache.Size() == 0 {
+		return nil
+	}
+
+	l.cache.Deduplicate()
+	files, err := l.e.Compactor.WriteBulk(l.cache)
+	l.files = append(l.files, files...)
+	if err != nil {
+		return err
+	}
+
+	l.cache = NewCache(0)
+	return nil
+}
+
+// Commit writes the values that are still buffered, and adds all of the TSM
+// files of the load to the FileStore. If it fails, the load must be aborted,
+// and otherwise the loader must not be used afterwards.
+func (l *BulkLoader) Commit() error {
+	if err := l.flush(); err != nil {
+		return err
+	}
+
+	l.e.mu.RLock()
+	defer l.e.mu.RUnlock()
+
+	if err := l.e.FileStore.Replace(nil, l.files); err != nil {
+		return err
+	}
+	l.files, l.series = nil, nil
+	return nil
+}
+
+// Abort removes the TSM files that the loader has written, and the new series
+// of the load that have no values in the engine. The loader must not be used
+// afterwards.
+func (l *BulkLoader) Abort() error {
+	l.cache = NewCache(0)
+
+	err := l.removeFiles()
+	if e := l.dropSeries(); e != nil && err == nil {
+		err = e
+	}
+	l.files, l.series = nil, nil
+	return err
+}
+
+// removeFiles removes the TSM files of the load that are not in the
+// FileStore. A failed Commit may have renamed some of the files to their
+// final names, or may even have added them to the FileStore, in which case
+// they are kept.
+func (l *BulkLoader) removeFiles() error {
+	var err error
+	for _, file := range l.files {
+		path := strings.TrimSuffix(file, "."+TmpTSMFileExtension)
+		if r := l.e.FileStore.TSMReader(path); r != nil {
+			r.Unref()
+			continue
+		}
+
+		for _, p := range []string{file, path, StatsFilename(path)} {
+			if e := os.Remove(p); e != nil && !os.IsNotExist(e) && err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// dropSeries removes the series that were added to the index for the load
+// from the index and series file, unless they have values in the engine, such
+// as from files of the load that were committed or from writes since.
+func (l *BulkLoader) dropSeries() error {
+	if len(l.series) == 0 {
+		return nil
+	}
+
+	keys := make([][]byte, 0, len(l.series))
+	for key := range l.series {
+		keys = append(keys, []byte(key))
+	}
+	bytesutil.Sort(keys)
+
+	// Ensure that the index and series file do not compact away the series
+	// while they are dropped.
+	l.e.index.DisableCompactions()
+	defer l.e.index.EnableCompactions()
+	l.e.index.Wait()
+
+	fs, err := l.e.index.RetainFileSet()
+	if err != nil {
+		return err
+	}
+	defer fs.Release()
+
+	l.e.sfile.DisableCompactions()
+	defer l.e.sfile.EnableCompactions()
+	l.e.sfile.Wait()
+
+	return l.e.dropSeriesWithoutValues(keys, l.e.cacheKeys(keys))
+}
+
+// WriteBulk writes the deduplicated values of cache to new TSM files, that are
+// partitioned like snapshots. The files are not added to the FileStore, and
+// are not throttled, as they do not compete with the snapshots of the engine
+// for memory.
+func (c *Compactor) WriteBulk(cache *Cache) ([]string, error) {
+	c.mu.RLock()
+	enabled := c.snapshotsEnabled
+	intC := c.snapshotsInterrupt
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, errSnapshotsDisabled
+	}
+
+	splits := []*Cache{cache}
+	if c.Partitioner != nil {
+		var err error
+		if splits, err = cache.Partition(c.Partitioner); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, split := range splits {
+		iter := NewCacheKeyIterator(split, MaxPointsPerBlock, intC)
+		newFiles, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, false)
+		files = append(files, newFiles...)
+		if err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
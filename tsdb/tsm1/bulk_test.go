@@ -0,0 +1,178 @@
+package tsm1_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+func TestBulkLoader_Commit(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	// A value in the TSM files that the load overwrites.
+	if err := e.writePoints(MustParsePointString("cpu,host=A value=1.0 1000000000")); err != nil {
+		t.Fatal(err)
+	}
+	e.MustWriteSnapshot()
+
+	// Flush after every write, so the load spans several files.
+	e.BulkLoadMemorySize = 1
+	l := e.NewBulkLoader()
+	for _, pts := range []string{
+		"cpu,host=A value=1.1 1000000000",
+		"cpu,host=B value=1.2 2000000000",
+		"cpu,host=A value=1.3 3000000000",
+	} {
+		points := MustParsePointsString(pts)
+		if err := e.CreateSeriesListIfNotExists(tsdb.NewSeriesCollection(points)); err != nil {
+			t.Fatal(err)
+		} else if err := l.WritePoints(points); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The points are not readable until the load is committed.
+	if got := e.FileStore.Count(); got != 1 {
+		t.Fatalf("got %d files before commit, exp 1", got)
+	}
+	if err := l.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.FileStore.Count(); got != 4 {
+		t.Fatalf("got %d files after commit, exp 4", got)
+	}
+	if e.Cache.Size() != 0 {
+		t.Fatalf("bulk load wrote to the cache")
+	}
+
+	values := mustReadAll(t, e, "cpu,host=A#!~#value")
+	if len(values) != 2 || values[0].Value() != 1.1 || values[1].Value() != 1.3 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if values := mustReadAll(t, e, "cpu,host=B#!~#value"); len(values) != 1 || values[0].Value() != 1.2 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// The files of the load are kept when the engine is reopened.
+	if err := e.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got := e.FileStore.Count(); got != 4 {
+		t.Fatalf("got %d files after reopen, exp 4", got)
+	}
+}
+
+func TestBulkLoader_Abort(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	// A series that existed before the load, and that the load also adds to.
+	if err := e.writePoints(MustParsePointString("cpu,host=A value=1.0 1000000000")); err != nil {
+		t.Fatal(err)
+	}
+
+	e.BulkLoadMemorySize = 1
+	l := e.NewBulkLoader()
+	points := MustParsePointsString("cpu,host=A value=1.1 1000000000\ncpu,host=B value=1.2 2000000000")
+	if err := e.CreateSeriesListIfNotExists(tsdb.NewSeriesCollection(points)); err != nil {
+		t.Fatal(err)
+	}
+	l.AddNewSeries([][]byte{[]byte("cpu,host=B")})
+	if err := l.WritePoints(points); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Abort(); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.FileStore.Count(); got != 0 {
+		t.Fatalf("got %d files after abort, exp 0", got)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(e.Path(), "*."+tsm1.TSMFileExtension+"*"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 0 {
+		t.Fatalf("files of the aborted load were not removed: %v", paths)
+	}
+
+	// Only the series that the load added is removed.
+	if id := e.sfile.SeriesID([]byte("cpu"), models.NewTags(map[string]string{"host": "B"}), nil); !id.IsZero() {
+		t.Fatalf("series of the aborted load was not removed from the series file")
+	}
+	if id := e.sfile.SeriesID([]byte("cpu"), models.NewTags(map[string]string{"host": "A"}), nil); id.IsZero() {
+		t.Fatalf("series written before the load was removed from the series file")
+	}
+}
+
+func TestBulkLoader_Abort_FailedCommit(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	// Flush after every write, so the load spans several files.
+	e.BulkLoadMemorySize = 1
+	l := e.NewBulkLoader()
+	for _, pts := range []string{
+		"cpu,host=A value=1.1 1000000000",
+		"cpu,host=B value=1.2 2000000000",
+	} {
+		points := MustParsePointsString(pts)
+		if err := e.CreateSeriesListIfNotExists(tsdb.NewSeriesCollection(points)); err != nil {
+			t.Fatal(err)
+		} else if err := l.WritePoints(points); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Remove the last file, so that the commit fails after the first file
+	// was renamed to its final name.
+	paths, err := filepath.Glob(filepath.Join(e.Path(), "*."+tsm1.TSMFileExtension+"."+tsm1.TmpTSMFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 2 {
+		t.Fatalf("got files %v, exp 2", paths)
+	}
+	sort.Strings(paths)
+	if err := os.Remove(paths[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Commit(); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := l.Abort(); err != nil {
+		t.Fatal(err)
+	}
+	if paths, err := filepath.Glob(filepath.Join(e.Path(), "*."+tsm1.TSMFileExtension+"*")); err != nil {
+		t.Fatal(err)
+	} else if len(paths) != 0 {
+		t.Fatalf("files of the aborted load were not removed: %v", paths)
+	}
+
+	// The renamed file is not loaded when the engine is reopened.
+	if err := e.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got := e.FileStore.Count(); got != 0 {
+		t.Fatalf("got %d files after reopen, exp 0", got)
+	}
+}
+
+// mustReadAll returns the values of key in the TSM files of e.
+func mustReadAll(t *testing.T, e *Engine, key string) []tsm1.Value {
+	t.Helper()
+
+	var values []tsm1.Value
+	for _, f := range e.FileStore.Files() {
+		vs, err := f.(*tsm1.TSMReader).ReadAll([]byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = tsm1.Values(values).Merge(vs)
+	}
+	return values
+}
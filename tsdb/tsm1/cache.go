@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -456,6 +457,61 @@ func (c *Cache) Split(n int) []*Cache {
 	return caches
 }
 
+// Partition splits the cache into one cache per partition determined by fn.
+// Values for keys that are not partitioned are placed in a single cache of
+// their own. The values in the cache must already be deduplicated, and the
+// returned caches share them with c.
+func (c *Cache) Partition(fn PartitionFunc) ([]*Cache, error) {
+	c.mu.RLock()
+	store := c.store
+	c.mu.RUnlock()
+
+	caches := make(map[timePartition]*Cache)
+	get := func(p timePartition) (*Cache, error) {
+		if pc := caches[p]; pc != nil {
+			return pc, nil
+		}
+		store, err := newring(1)
+		if err != nil {
+			return nil, err
+		}
+		pc := &Cache{store: store}
+		caches[p] = pc
+		return pc, nil
+	}
+
+	if err := store.applySerial(func(key []byte, e *entry) error {
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		for len(values) > 0 {
+			p, ok := fn.partition(key, values[0].UnixNano())
+			n := len(values)
+			if ok {
+				// Values are sorted, so find the first one past the window.
+				n = sort.Search(len(values), func(i int) bool { return values[i].UnixNano() > p.max })
+			}
+
+			pc, err := get(p)
+			if err != nil {
+				return err
+			}
+			pc.store.add(key, &entry{values: values[:n], vtype: e.vtype})
+			values = values[n:]
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	partitioned := make([]*Cache, 0, len(caches))
+	for _, pc := range caches {
+		partitioned = append(partitioned, pc)
+	}
+	return partitioned, nil
+}
+
 // Type returns the series type for a key.
 func (c *Cache) Type(key []byte) (models.FieldType, error) {
 	c.mu.RLock()
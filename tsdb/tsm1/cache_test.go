@@ -841,6 +841,51 @@ func TestCache_Split(t *testing.T) {
 	}
 }
 
+func TestCache_Partition(t *testing.T) {
+	c := NewCache(0)
+
+	writes := map[string]Values{
+		"cpu,host=A#!~#value": {NewValue(1, 1.0), NewValue(12, 2.0), NewValue(15, 3.0)},
+		"mem,host=A#!~#value": {NewValue(-1, 1.0), NewValue(3, 2.0)},
+		"cpu#!~#value":        {NewValue(1, 1.0), NewValue(100, 2.0)},
+	}
+	for k, v := range writes {
+		if err := c.Write([]byte(k), v); err != nil {
+			t.Fatalf("failed to write key %s to cache: %s", k, err.Error())
+		}
+	}
+	c.Deduplicate()
+
+	caches, err := c.Partition(testPartitionFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := len(caches), 5; got != exp {
+		t.Fatalf("got %d partitions, exp %d", got, exp)
+	}
+
+	// Record the number of values for each key, by the time of the first value.
+	got := make(map[string]int)
+	for _, pc := range caches {
+		for _, k := range pc.Keys() {
+			values := pc.Values(k)
+			got[fmt.Sprintf("%s@%d", k, values[0].UnixNano())] = len(values)
+		}
+	}
+
+	exp := map[string]int{
+		"cpu,host=A#!~#value@1":  1,
+		"cpu,host=A#!~#value@12": 2,
+		"mem,host=A#!~#value@-1": 1,
+		"mem,host=A#!~#value@3":  1,
+		"cpu#!~#value@1":         2,
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected partitions:\ngot %v\nexp %v", got, exp)
+	}
+}
+
 func mustTempDir() string {
 	dir, err := ioutil.TempDir("", "tsm1-test")
 	if err != nil {
@@ -121,6 +121,15 @@ type DefaultPlanner struct {
 	// filesInUse is the set of files that have been returned as part of a plan and might
 	// be being compacted.  Two plans should not return the same file at any given time.
 	filesInUse map[string]struct{}
+
+	// partitioner, if set, is used to plan the compactions of each partition's
+	// files independently.
+	partitioner PartitionFunc
+
+	// filterRuns, if set, selects the runs of generations that are planned.
+	// It allows a planner embedding DefaultPlanner, such as TimePlanner, to
+	// plan some of the runs itself.
+	filterRuns func(runs []tsmGenerations) []tsmGenerations
 }
 
 type fileStore interface {
@@ -192,18 +201,44 @@ func (t *tsmGeneration) hasTombstones() bool {
 	return false
 }
 
+// partition returns the partition that all the files in the generation belong
+// to. ok is false if the files span more than one partition.
+func (t *tsmGeneration) partition(fn PartitionFunc) (p timePartition, ok bool) {
+	for i, f := range t.files {
+		fp, ok := fn.fileStatPartition(f)
+		if !ok || (i > 0 && fp != p) {
+			return timePartition{}, false
+		}
+		p = fp
+	}
+	return p, len(t.files) > 0
+}
+
 func (c *DefaultPlanner) SetFileStore(fs *FileStore) {
 	c.FileStore = fs
 }
 
+// SetPartitionFunc sets the function used to determine the partition of each
+// TSM file. Files belonging to different partitions are never planned into
+// the same compaction group.
+func (c *DefaultPlanner) SetPartitionFunc(fn PartitionFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.partitioner = fn
+}
+
 func (c *DefaultPlanner) ParseFileName(path string) (int, int, error) {
 	return c.FileStore.ParseFileName(path)
 }
 
 // FullyCompacted returns true if the shard is fully compacted.
 func (c *DefaultPlanner) FullyCompacted() bool {
-	gens := c.findGenerations(false)
-	return len(gens) <= 1 && !gens.hasTombstones()
+	for _, gens := range c.findGenerationRuns(false) {
+		if len(gens) > 1 || gens.hasTombstones() {
+			return false
+		}
+	}
+	return true
 }
 
 // ForceFull causes the planner to return a full compaction plan the next time
@@ -229,8 +264,21 @@ func (c *DefaultPlanner) PlanLevel(level int) []CompactionGroup {
 	// Determine the generations from all files on disk.  We need to treat
 	// a generation conceptually as a single file even though it may be
 	// split across several files in sequence.
-	generations := c.findGenerations(true)
+	var cGroups []CompactionGroup
+	for _, generations := range c.findGenerationRuns(true) {
+		cGroups = append(cGroups, c.planLevel(generations, level)...)
+	}
+
+	if !c.acquire(cGroups) {
+		return nil
+	}
+
+	return cGroups
+}
 
+// planLevel returns the compaction groups for a specific level within a single
+// run of generations.
+func (c *DefaultPlanner) planLevel(generations tsmGenerations, level int) []CompactionGroup {
 	// If there is only one generation and no tombstones, then there's nothing to
 	// do.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -302,10 +350,6 @@ func (c *DefaultPlanner) PlanLevel(level int) []CompactionGroup {
 		}
 	}
 
-	if !c.acquire(cGroups) {
-		return nil
-	}
-
 	return cGroups
 }
 
@@ -325,8 +369,21 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 	// Determine the generations from all files on disk.  We need to treat
 	// a generation conceptually as a single file even though it may be
 	// split across several files in sequence.
-	generations := c.findGenerations(true)
+	var cGroups []CompactionGroup
+	for _, generations := range c.findGenerationRuns(true) {
+		cGroups = append(cGroups, c.planOptimize(generations)...)
+	}
 
+	if !c.acquire(cGroups) {
+		return nil
+	}
+
+	return cGroups
+}
+
+// planOptimize returns the optimize compaction groups within a single run of
+// generations.
+func (c *DefaultPlanner) planOptimize(generations tsmGenerations) []CompactionGroup {
 	// If there is only one generation and no tombstones, then there's nothing to
 	// do.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -394,24 +451,26 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 		cGroups = append(cGroups, cGroup)
 	}
 
-	if !c.acquire(cGroups) {
-		return nil
-	}
-
 	return cGroups
 }
 
 // Plan returns a set of TSM files to rewrite for level 4 or higher.  The planning returns
 // multiple groups if possible to allow compactions to run concurrently.
 func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
-	generations := c.findGenerations(true)
+	runs := c.findGenerationRuns(true)
 
 	c.mu.RLock()
 	forceFull := c.forceFull
 	c.mu.RUnlock()
 
+	var multipleGenerations, hasTombstones bool
+	for _, generations := range runs {
+		multipleGenerations = multipleGenerations || len(generations) > 1
+		hasTombstones = hasTombstones || generations.hasTombstones()
+	}
+
 	// first check if we should be doing a full compaction because nothing has been written in a long time
-	if forceFull || c.compactFullWriteColdDuration > 0 && time.Since(lastWrite) > c.compactFullWriteColdDuration && len(generations) > 1 {
+	if forceFull || c.compactFullWriteColdDuration > 0 && time.Since(lastWrite) > c.compactFullWriteColdDuration && multipleGenerations {
 
 		// Reset the full schedule if we planned because of it.
 		if forceFull {
@@ -420,56 +479,81 @@ func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 			c.mu.Unlock()
 		}
 
-		var tsmFiles []string
-		var genCount int
-		for i, group := range generations {
-			var skip bool
-
-			// Skip the file if it's over the max size and contains a full block and it does not have any tombstones
-			if len(generations) > 2 && group.size() > uint64(maxTSMFileSize) && c.FileStore.BlockCount(group.files[0].Path, 1) == MaxPointsPerBlock && !group.hasTombstones() {
-				skip = true
+		var groups []CompactionGroup
+		for _, generations := range runs {
+			if group := c.planFull(generations); group != nil {
+				groups = append(groups, group)
 			}
+		}
 
-			// We need to look at the level of the next file because it may need to be combined with this generation
-			// but won't get picked up on it's own if this generation is skipped.  This allows the most recently
-			// created files to get picked up by the full compaction planner and avoids having a few less optimally
-			// compressed files.
-			if i < len(generations)-1 {
-				if generations[i+1].level() <= 3 {
-					skip = false
-				}
-			}
+		if len(groups) == 0 || !c.acquire(groups) {
+			return nil
+		}
+		return groups
+	}
 
-			if skip {
-				continue
-			}
+	// don't plan if nothing has changed in the filestore
+	if c.lastPlanCheck.After(c.FileStore.LastModified()) && !hasTombstones {
+		return nil
+	}
 
-			for _, f := range group.files {
-				tsmFiles = append(tsmFiles, f.Path)
+	c.lastPlanCheck = time.Now()
+
+	var tsmFiles []CompactionGroup
+	for _, generations := range runs {
+		tsmFiles = append(tsmFiles, c.plan(generations)...)
+	}
+
+	if len(tsmFiles) == 0 || !c.acquire(tsmFiles) {
+		return nil
+	}
+	return tsmFiles
+}
+
+// planFull returns a single compaction group containing the files of every
+// generation within a run that should be included in a full compaction.
+func (c *DefaultPlanner) planFull(generations tsmGenerations) CompactionGroup {
+	var tsmFiles []string
+	var genCount int
+	for i, group := range generations {
+		var skip bool
+
+		// Skip the file if it's over the max size and contains a full block and it does not have any tombstones
+		if len(generations) > 2 && group.size() > uint64(maxTSMFileSize) && c.FileStore.BlockCount(group.files[0].Path, 1) == MaxPointsPerBlock && !group.hasTombstones() {
+			skip = true
+		}
+
+		// We need to look at the level of the next file because it may need to be combined with this generation
+		// but won't get picked up on it's own if this generation is skipped.  This allows the most recently
+		// created files to get picked up by the full compaction planner and avoids having a few less optimally
+		// compressed files.
+		if i < len(generations)-1 {
+			if generations[i+1].level() <= 3 {
+				skip = false
 			}
-			genCount += 1
 		}
-		sort.Strings(tsmFiles)
 
-		// Make sure we have more than 1 file and more than 1 generation
-		if len(tsmFiles) <= 1 || genCount <= 1 {
-			return nil
+		if skip {
+			continue
 		}
 
-		group := []CompactionGroup{tsmFiles}
-		if !c.acquire(group) {
-			return nil
+		for _, f := range group.files {
+			tsmFiles = append(tsmFiles, f.Path)
 		}
-		return group
+		genCount += 1
 	}
+	sort.Strings(tsmFiles)
 
-	// don't plan if nothing has changed in the filestore
-	if c.lastPlanCheck.After(c.FileStore.LastModified()) && !generations.hasTombstones() {
+	// Make sure we have more than 1 file and more than 1 generation
+	if len(tsmFiles) <= 1 || genCount <= 1 {
 		return nil
 	}
+	return tsmFiles
+}
 
-	c.lastPlanCheck = time.Now()
-
+// plan returns the level 4 compaction groups within a single run of
+// generations.
+func (c *DefaultPlanner) plan(generations tsmGenerations) []CompactionGroup {
 	// If there is only one generation, return early to avoid re-compacting the same file
 	// over and over again.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -592,10 +676,6 @@ func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 		sort.Strings(cGroup)
 		tsmFiles = append(tsmFiles, cGroup)
 	}
-
-	if !c.acquire(tsmFiles) {
-		return nil
-	}
 	return tsmFiles
 }
 
@@ -647,6 +727,75 @@ func (c *DefaultPlanner) findGenerations(skipInUse bool) tsmGenerations {
 	return orderedGenerations
 }
 
+// findGenerationRuns returns the runs of generations that the planner plans,
+// which are all of them unless filterRuns is set.
+func (c *DefaultPlanner) findGenerationRuns(skipInUse bool) []tsmGenerations {
+	runs := c.findAllGenerationRuns(skipInUse)
+	if c.filterRuns != nil {
+		runs = c.filterRuns(runs)
+	}
+	return runs
+}
+
+// findAllGenerationRuns splits the generations returned by findGenerations
+// into runs that can be planned independently of each other. Without a
+// partitioner every generation belongs to a single run.
+//
+// With a partitioner, the generations of each partition form their own runs.
+// A run is ended by any later generation that could hold data for the same
+// keys and time range, such as one spanning several partitions, so that
+// compacting a run never changes which file takes precedence for a point.
+func (c *DefaultPlanner) findAllGenerationRuns(skipInUse bool) []tsmGenerations {
+	generations := c.findGenerations(skipInUse)
+
+	c.mu.RLock()
+	fn := c.partitioner
+	c.mu.RUnlock()
+
+	if fn == nil {
+		return []tsmGenerations{generations}
+	}
+
+	// A lane is the run currently being built for a partition. Generations
+	// that do not fit within a single partition share the mixed lane.
+	type lane struct {
+		partition timePartition
+		mixed     bool
+		run       tsmGenerations
+	}
+
+	var lanes []*lane
+	var runs []tsmGenerations
+	for _, g := range generations {
+		p, ok := g.partition(fn)
+
+		var cur *lane
+		open := lanes[:0]
+		for _, l := range lanes {
+			switch {
+			case l.mixed == !ok && (!ok || l.partition == p):
+				cur = l
+			case !ok || l.mixed || l.partition.overlaps(p):
+				runs = append(runs, l.run)
+				continue
+			}
+			open = append(open, l)
+		}
+		lanes = open
+
+		if cur == nil {
+			cur = &lane{partition: p, mixed: !ok}
+			lanes = append(lanes, cur)
+		}
+		cur.run = append(cur.run, g)
+	}
+
+	for _, l := range lanes {
+		runs = append(runs, l.run)
+	}
+	return runs
+}
+
 func (c *DefaultPlanner) acquire(groups []CompactionGroup) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -695,6 +844,10 @@ type Compactor struct {
 	// RateLimit is the limit for disk writes for all concurrent compactions.
 	RateLimit limiter.Rate
 
+	// Partitioner, if set, causes snapshots to be written as a separate
+	// generation for each partition of the cache.
+	Partitioner PartitionFunc
+
 	formatFileName FormatFileNameFunc
 	parseFileName  ParseFileNameFunc
 
@@ -836,26 +989,39 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 		throttle = false
 	}
 
-	splits := cache.Split(concurrency)
+	var splits []*Cache
+	if c.Partitioner != nil {
+		var err error
+		if splits, err = cache.Partition(c.Partitioner); err != nil {
+			return nil, err
+		}
+	} else {
+		splits = cache.Split(concurrency)
+	}
 
 	type res struct {
 		files []string
 		err   error
 	}
 
-	resC := make(chan res, concurrency)
-	for i := 0; i < concurrency; i++ {
+	// Each split is written to its own generation, with at most concurrency
+	// splits being written at any one time.
+	limit := limiter.NewFixed(concurrency)
+	resC := make(chan res, len(splits))
+	for _, split := range splits {
 		go func(sp *Cache) {
+			limit.Take()
+			defer limit.Release()
+
 			iter := NewCacheKeyIterator(sp, MaxPointsPerBlock, intC)
 			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
 			resC <- res{files: files, err: err}
-
-		}(splits[i])
+		}(split)
 	}
 
 	var err error
-	files := make([]string, 0, concurrency)
-	for i := 0; i < concurrency; i++ {
+	files := make([]string, 0, len(splits))
+	for range splits {
 		result := <-resC
 		if result.err != nil {
 			err = result.err
@@ -2,10 +2,12 @@ package tsm1_test
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 	"time"
@@ -2162,6 +2164,62 @@ func TestDefaultPlanner_PlanLevel_InUse(t *testing.T) {
 	}
 }
 
+func TestDefaultPlanner_PlanLevel_Partitioned(t *testing.T) {
+	// Interleave level 1 generations for two partitions, followed by a
+	// generation containing data for both.
+	var data []tsm1.FileStat
+	for i := 1; i <= 16; i++ {
+		key := "cpu,host=A#!~#value"
+		if i%2 == 0 {
+			key = "mem,host=A#!~#value"
+		}
+		data = append(data, tsm1.FileStat{
+			Path:    fmt.Sprintf("%02d-01.tsm1", i),
+			Size:    1 * 1024 * 1024,
+			MinKey:  []byte(key),
+			MaxKey:  []byte(key),
+			MinTime: 1,
+			MaxTime: 9,
+		})
+	}
+	data = append(data, tsm1.FileStat{
+		Path:    "17-01.tsm1",
+		Size:    1 * 1024 * 1024,
+		MinKey:  []byte("cpu,host=A#!~#value"),
+		MaxKey:  []byte("mem,host=A#!~#value"),
+		MinTime: 1,
+		MaxTime: 9,
+	})
+
+	cp := tsm1.NewDefaultPlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration,
+	)
+	cp.SetPartitionFunc(func(key []byte) ([]byte, int64) {
+		return key[:bytes.IndexByte(key, ',')], 10
+	})
+
+	var cpuFiles, memFiles []string
+	for i := 0; i < 16; i += 2 {
+		cpuFiles = append(cpuFiles, data[i].Path)
+		memFiles = append(memFiles, data[i+1].Path)
+	}
+
+	tsm := cp.PlanLevel(1)
+	if exp, got := 2, len(tsm); got != exp {
+		t.Fatalf("compaction group length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for i, exp := range [][]string{cpuFiles, memFiles} {
+		if got := []string(tsm[i]); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("group %d mismatch: got %v, exp %v", i, got, exp)
+		}
+	}
+}
+
 func TestDefaultPlanner_PlanOptimize_NoLevel4(t *testing.T) {
 	data := []tsm1.FileStat{
 		{
@@ -0,0 +1,220 @@
+package tsm1
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultTimePlannerWindow is the width of the time windows of a
+	// TimePlanner when the engine does not partition its TSM files.
+	DefaultTimePlannerWindow = 7 * 24 * time.Hour
+
+	// DefaultTimePlannerColdAge is how long after a time window ends that a
+	// TimePlanner treats it as cold.
+	DefaultTimePlannerColdAge = 24 * time.Hour
+)
+
+// The compaction states of the time windows of a TimePlanner.
+const (
+	windowHot    = "hot"    // younger than the cold age, or files spanning several windows
+	windowCold   = "cold"   // older than the cold age, but not yet fully compacted
+	windowSealed = "sealed" // fully compacted since it turned cold
+)
+
+// TimePlanner is a CompactionPlanner for data that mostly arrives in time
+// order. It groups the TSM files into time windows, which are the partitions
+// of the engine's PartitionFunc, such as shard groups, or else windows of a
+// fixed width.
+//
+// The files of a window are planned as by DefaultPlanner until the window
+// has ended for longer than the cold age. They are then fully compacted into a
+// single generation, which seals the window. A window is sealed when its first
+// generation is fully compacted, at level 4, or when it has a single
+// generation. The sealed generation is only rewritten to remove the data of
+// its tombstones; points that arrive late for the window are compacted with
+// each other, but never with the sealed generation. A window whose sealed
+// generation is below level 4, as it held little data, is sealed again with
+// its late points.
+type TimePlanner struct {
+	*DefaultPlanner
+
+	window  time.Duration
+	coldAge time.Duration
+
+	now     func() time.Time
+	metrics *timePlannerMetrics
+}
+
+// NewTimePlanner returns a new TimePlanner for the files of fs. Without a
+// PartitionFunc, its windows are window wide. Windows turn cold once they have
+// ended for coldAge.
+func NewTimePlanner(fs fileStore, writeColdDuration, window, coldAge time.Duration) *TimePlanner {
+	t := &TimePlanner{
+		DefaultPlanner: NewDefaultPlanner(fs, writeColdDuration),
+		window:         window,
+		coldAge:        coldAge,
+		now:            time.Now,
+		metrics:        newTimePlannerMetrics(nil),
+	}
+	t.partitioner = t.windowPartition
+	t.filterRuns = t.hotRuns
+	return t
+}
+
+// windowPartition puts every key in the same group of partitions, split into
+// windows of the planner's width. It is replaced by SetPartitionFunc.
+func (t *TimePlanner) windowPartition(key []byte) ([]byte, int64) {
+	return []byte{}, int64(t.window)
+}
+
+// SetDefaultMetricLabels sets the default labels of the planner's metrics. It
+// must be called before the metrics are registered.
+func (t *TimePlanner) SetDefaultMetricLabels(labels prometheus.Labels) {
+	t.metrics = newTimePlannerMetrics(labels)
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (t *TimePlanner) PrometheusCollectors() []prometheus.Collector {
+	return t.metrics.PrometheusCollectors()
+}
+
+// FullyCompacted returns true if the hot windows are fully compacted, and no
+// cold window needs to be sealed or have its tombstones removed.
+func (t *TimePlanner) FullyCompacted() bool {
+	if !t.DefaultPlanner.FullyCompacted() {
+		return false
+	}
+	return len(t.planCold(t.findAllGenerationRuns(false), t.now().UnixNano())) == 0
+}
+
+// Plan returns the groups of DefaultPlanner for the hot windows, followed by
+// the groups that seal cold windows or remove the tombstones of sealed ones.
+func (t *TimePlanner) Plan(lastWrite time.Time) []CompactionGroup {
+	groups := t.DefaultPlanner.Plan(lastWrite)
+
+	now := t.now().UnixNano()
+	t.updateMetrics(t.findAllGenerationRuns(false), now)
+
+	// Cold groups are acquired one at a time, so that a window being sealed
+	// does not hold up the others.
+	for _, group := range t.planCold(t.findAllGenerationRuns(true), now) {
+		if t.acquire([]CompactionGroup{group}) {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// runWindow returns the time window of run, and the time at which it turns
+// cold. ok is false if the files of run span several windows, which never
+// turn cold.
+func (t *TimePlanner) runWindow(run tsmGenerations) (p timePartition, coldAt int64, ok bool) {
+	t.mu.RLock()
+	fn := t.partitioner
+	t.mu.RUnlock()
+
+	if len(run) > 0 {
+		p, ok = run[0].partition(fn)
+	}
+	if !ok || p.max > math.MaxInt64-int64(t.coldAge) {
+		return p, math.MaxInt64, ok
+	}
+	return p, p.max + int64(t.coldAge), true
+}
+
+// hotRuns returns the runs of generations that the embedded DefaultPlanner
+// plans: those of hot windows, and those written to sealed windows after
+// their sealed generation.
+func (t *TimePlanner) hotRuns(runs []tsmGenerations) []tsmGenerations {
+	now := t.now().UnixNano()
+
+	var hot []tsmGenerations
+	for _, run := range runs {
+		_, coldAt, _ := t.runWindow(run)
+		if now <= coldAt {
+			hot = append(hot, run)
+		} else if len(run) > 1 && sealed(run) {
+			hot = append(hot, run[1:])
+		}
+	}
+	return hot
+}
+
+// planCold returns a group for each run of a cold window that must be sealed,
+// or whose sealed generation has tombstones.
+func (t *TimePlanner) planCold(runs []tsmGenerations, now int64) []CompactionGroup {
+	var groups []CompactionGroup
+	for _, run := range runs {
+		_, coldAt, _ := t.runWindow(run)
+		if now <= coldAt {
+			continue
+		}
+
+		// A sealed window only has its sealed generation rewritten, while
+		// every generation of the others is compacted to seal them.
+		seal := run
+		if sealed(run) {
+			if !run[0].hasTombstones() {
+				continue
+			}
+			seal = run[:1]
+		}
+
+		var group CompactionGroup
+		for _, g := range seal {
+			for _, f := range g.files {
+				group = append(group, f.Path)
+			}
+		}
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// sealed reports whether the cold window of run is sealed: its first
+// generation is fully compacted, or it is its only generation.
+func sealed(run tsmGenerations) bool {
+	return len(run) == 1 || run[0].level() == 4
+}
+
+// updateMetrics sets the number of windows, and of their files, in each state.
+func (t *TimePlanner) updateMetrics(runs []tsmGenerations, now int64) {
+	windows := make(map[timePartition]string)
+	files := make(map[string]int)
+	for _, run := range runs {
+		p, coldAt, ok := t.runWindow(run)
+
+		state := windowHot
+		if now > coldAt {
+			state = windowSealed
+			if !sealed(run) {
+				state = windowCold
+			}
+		}
+
+		for _, g := range run {
+			files[state] += g.count()
+		}
+
+		// A window split into several runs is cold until all of them are
+		// sealed.
+		if ok && windows[p] != windowCold {
+			windows[p] = state
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, state := range windows {
+		counts[state]++
+	}
+	for _, state := range []string{windowHot, windowCold, windowSealed} {
+		labels := t.metrics.Labels(state)
+		t.metrics.Windows.With(labels).Set(float64(counts[state]))
+		t.metrics.WindowFiles.With(labels).Set(float64(files[state]))
+	}
+}
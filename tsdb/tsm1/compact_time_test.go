@@ -0,0 +1,170 @@
+package tsm1_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+func TestTimePlanner_Plan_Seal(t *testing.T) {
+	// Level 1 generations of a window that ended long ago, all written before
+	// it turned cold, followed by a generation of a hot window.
+	var data []tsm1.FileStat
+	var coldFiles []string
+	for i := 1; i <= 8; i++ {
+		path := fmt.Sprintf("%02d-01.tsm1", i)
+		data = append(data, tsm1.FileStat{
+			Path:    path,
+			Size:    1 * 1024 * 1024,
+			MinKey:  []byte("cpu,host=A#!~#value"),
+			MaxKey:  []byte("cpu,host=A#!~#value"),
+			MinTime: 1,
+			MaxTime: 9,
+		})
+		coldFiles = append(coldFiles, path)
+	}
+	now := time.Now().UnixNano()
+	data = append(data, tsm1.FileStat{
+		Path:         "09-01.tsm1",
+		Size:         1 * 1024 * 1024,
+		MinKey:       []byte("cpu,host=A#!~#value"),
+		MaxKey:       []byte("cpu,host=A#!~#value"),
+		MinTime:      now,
+		MaxTime:      now,
+		LastModified: now,
+	})
+
+	cp := tsm1.NewTimePlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration, 10, time.Hour,
+	)
+
+	// The cold window is not planned by level.
+	if tsm := cp.PlanLevel(1); len(tsm) != 0 {
+		t.Fatalf("unexpected level 1 groups: %v", tsm)
+	}
+
+	tsm := cp.Plan(time.Now())
+	if exp := []tsm1.CompactionGroup{coldFiles}; !reflect.DeepEqual(tsm, exp) {
+		t.Fatalf("compaction groups mismatch: got %v, exp %v", tsm, exp)
+	}
+	if cp.FullyCompacted() {
+		t.Fatal("expected the cold window to not be fully compacted")
+	}
+}
+
+func TestTimePlanner_Plan_Backfilled(t *testing.T) {
+	// Level 1 generations of a window that ended long ago, all written after
+	// it turned cold, such as by a bulk load.
+	now := time.Now().UnixNano()
+	var data []tsm1.FileStat
+	var files []string
+	for i := 1; i <= 4; i++ {
+		path := fmt.Sprintf("%02d-01.tsm1", i)
+		data = append(data, tsm1.FileStat{
+			Path:         path,
+			Size:         1 * 1024 * 1024,
+			MinKey:       []byte("cpu,host=A#!~#value"),
+			MaxKey:       []byte("cpu,host=A#!~#value"),
+			MinTime:      1,
+			MaxTime:      9,
+			LastModified: now,
+		})
+		files = append(files, path)
+	}
+
+	cp := tsm1.NewTimePlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration, 10, time.Hour,
+	)
+
+	// None of the generations seals the window, so all of them are compacted
+	// to seal it.
+	if tsm := cp.PlanLevel(1); len(tsm) != 0 {
+		t.Fatalf("unexpected level 1 groups: %v", tsm)
+	}
+	tsm := cp.Plan(time.Now())
+	if exp := []tsm1.CompactionGroup{files}; !reflect.DeepEqual(tsm, exp) {
+		t.Fatalf("compaction groups mismatch: got %v, exp %v", tsm, exp)
+	}
+	cp.Release(tsm)
+
+	// Once they are, the window is sealed.
+	data = []tsm1.FileStat{data[3]}
+	data[0].Path = "04-02.tsm1"
+	if tsm := cp.Plan(time.Now()); len(tsm) != 0 {
+		t.Fatalf("unexpected groups: %v", tsm)
+	} else if !cp.FullyCompacted() {
+		t.Fatal("expected the sealed window to be fully compacted")
+	}
+}
+
+func TestTimePlanner_Plan_Sealed(t *testing.T) {
+	// A cold window that was sealed, followed by generations of late points.
+	now := time.Now().UnixNano()
+	data := []tsm1.FileStat{
+		{
+			Path:         "01-04.tsm1",
+			Size:         251 * 1024 * 1024,
+			MinKey:       []byte("cpu,host=A#!~#value"),
+			MaxKey:       []byte("cpu,host=A#!~#value"),
+			MinTime:      1,
+			MaxTime:      9,
+			LastModified: now,
+		},
+	}
+	var lateFiles []string
+	for i := 2; i <= 9; i++ {
+		path := fmt.Sprintf("%02d-01.tsm1", i)
+		data = append(data, tsm1.FileStat{
+			Path:         path,
+			Size:         1 * 1024 * 1024,
+			MinKey:       []byte("cpu,host=A#!~#value"),
+			MaxKey:       []byte("cpu,host=A#!~#value"),
+			MinTime:      5,
+			MaxTime:      5,
+			LastModified: now,
+		})
+		lateFiles = append(lateFiles, path)
+	}
+
+	cp := tsm1.NewTimePlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration, 10, time.Hour,
+	)
+
+	// The late points are compacted with each other, but not with the sealed
+	// generation.
+	tsm := cp.PlanLevel(1)
+	if exp := []tsm1.CompactionGroup{lateFiles}; !reflect.DeepEqual(tsm, exp) {
+		t.Fatalf("compaction groups mismatch: got %v, exp %v", tsm, exp)
+	}
+	cp.Release(tsm)
+
+	// Once they are, the window is fully compacted.
+	data = data[:2]
+	if tsm := cp.Plan(time.Now()); len(tsm) != 0 {
+		t.Fatalf("unexpected groups: %v", tsm)
+	} else if !cp.FullyCompacted() {
+		t.Fatal("expected the sealed window to be fully compacted")
+	}
+
+	// The sealed generation is rewritten on its own to remove its tombstones.
+	data[0].HasTombstone = true
+	tsm = cp.Plan(time.Now())
+	if exp := []tsm1.CompactionGroup{{"01-04.tsm1"}}; !reflect.DeepEqual(tsm, exp) {
+		t.Fatalf("compaction groups mismatch: got %v, exp %v", tsm, exp)
+	}
+}
@@ -39,6 +39,7 @@ func NewConfig() Config {
 			MaxMemorySize:             toml.Size(DefaultCacheMaxMemorySize),
 			SnapshotMemorySize:        toml.Size(DefaultCacheSnapshotMemorySize),
 			SnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
+			BulkLoadMemorySize:        toml.Size(DefaultCacheBulkLoadMemorySize),
 		},
 		Compaction: CompactionConfig{
 			FullWriteColdDuration: toml.Duration(DefaultCompactFullWriteColdDuration),
@@ -83,6 +84,7 @@ const (
 	DefaultCacheMaxMemorySize             = 1024 * 1024 * 1024 // 1GB
 	DefaultCacheSnapshotMemorySize        = 25 * 1024 * 1024   // 25MB
 	DefaultCacheSnapshotWriteColdDuration = time.Duration(10 * time.Minute)
+	DefaultCacheBulkLoadMemorySize        = 256 * 1024 * 1024 // 256MB
 )
 
 // CacheConfig holds all of the configuration for the in memory cache of values that
@@ -100,6 +102,10 @@ type CacheConfig struct {
 	// the cache and write it to a new TSM file if the shard hasn't received writes or
 	// deletes
 	SnapshotWriteColdDuration toml.Duration `toml:"snapshot-write-cold-duration"`
+
+	// BulkLoadMemorySize is the size at which a bulk load writes the values it has
+	// buffered to new TSM files. Bulk loads do not use the cache of the shard.
+	BulkLoadMemorySize toml.Size `toml:"bulk-load-memory-size"`
 }
 
 const (
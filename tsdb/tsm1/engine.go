@@ -24,6 +24,7 @@ import (
 	"github.com/influxdata/platform/pkg/bytesutil"
 	"github.com/influxdata/platform/pkg/limiter"
 	"github.com/influxdata/platform/pkg/metrics"
+	"github.com/influxdata/platform/pkg/tar"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/tsdb"
 	"github.com/influxdata/platform/tsdb/tsi1"
@@ -100,8 +101,7 @@ var WithTraceLogging = func(logging bool) EngineOption {
 // WithCompactionPlanner sets the compaction planner for the engine.
 var WithCompactionPlanner = func(planner CompactionPlanner) EngineOption {
 	return func(e *Engine) {
-		planner.SetFileStore(e.FileStore)
-		e.CompactionPlan = planner
+		e.WithCompactionPlanner(planner)
 	}
 }
 
@@ -149,9 +149,16 @@ type Engine struct {
 	// a snapshot of the cache to a TSM file
 	CacheFlushWriteColdDuration time.Duration
 
+	// BulkLoadMemorySize specifies the size at which a BulkLoader writes the
+	// values it has buffered to TSM files.
+	BulkLoadMemorySize uint64
+
 	// Invoked when creating a backup file "as new".
 	formatFileName FormatFileNameFunc
 
+	// Determines how data is partitioned across TSM files, if at all.
+	partitionFunc PartitionFunc
+
 	// Controls whether to enabled compactions when the engine is open
 	enableCompactionsOnOpen bool
 
@@ -217,6 +224,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 
 		CacheFlushMemorySizeThreshold: uint64(config.Cache.SnapshotMemorySize),
 		CacheFlushWriteColdDuration:   time.Duration(config.Cache.SnapshotWriteColdDuration),
+		BulkLoadMemorySize:            uint64(config.Cache.BulkLoadMemorySize),
 		enableCompactionsOnOpen:       true,
 		formatFileName:                DefaultFormatFileName,
 		compactionLimiter:             limiter.NewFixed(maxCompactions),
@@ -246,13 +254,29 @@ func (e *Engine) WithFileStoreObserver(obs FileStoreObserver) {
 
 func (e *Engine) WithCompactionPlanner(planner CompactionPlanner) {
 	planner.SetFileStore(e.FileStore)
+	if p, ok := planner.(PartitionedPlanner); ok && e.partitionFunc != nil {
+		p.SetPartitionFunc(e.partitionFunc)
+	}
 	e.CompactionPlan = planner
 }
 
+// WithPartitionFunc sets the function used to partition data across TSM
+// files. It must be called before the Engine is opened.
+func (e *Engine) WithPartitionFunc(fn PartitionFunc) {
+	e.partitionFunc = fn
+	e.Compactor.Partitioner = fn
+	if p, ok := e.CompactionPlan.(PartitionedPlanner); ok {
+		p.SetPartitionFunc(fn)
+	}
+}
+
 // SetDefaultMetricLabels sets the default labels for metrics on the engine.
 // It must be called before the Engine is opened.
 func (e *Engine) SetDefaultMetricLabels(labels prometheus.Labels) {
 	e.defaultMetricLabels = labels
+	if p, ok := e.CompactionPlan.(*TimePlanner); ok {
+		p.SetDefaultMetricLabels(labels)
+	}
 }
 
 // SetEnabled sets whether the engine is enabled.
@@ -598,6 +622,29 @@ func (e *Engine) Free() error {
 // WritePoints writes metadata and point data into the engine.
 // It returns an error if new points are added to an existing key.
 func (e *Engine) WritePoints(points []models.Point) error {
+	values, err := valuesFromPoints(points)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	// first try to write to the cache
+	if err := e.Cache.WriteMulti(values); err != nil {
+		return err
+	}
+
+	// Then make the write durable in the cache.
+	if _, err := e.WAL.WriteMulti(values); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// valuesFromPoints returns the values of the fields of points by TSM key.
+func valuesFromPoints(points []models.Point) (map[string][]Value, error) {
 	values := make(map[string][]Value, len(points))
 	var (
 		keyBuf  []byte
@@ -618,19 +665,19 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			case models.Float:
 				fv, err := iter.FloatValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewFloatValue(t, fv)
 			case models.Integer:
 				iv, err := iter.IntegerValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewIntegerValue(t, iv)
 			case models.Unsigned:
 				iv, err := iter.UnsignedValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewUnsignedValue(t, iv)
 			case models.String:
@@ -638,30 +685,16 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			case models.Boolean:
 				bv, err := iter.BooleanValue()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				v = NewBooleanValue(t, bv)
 			default:
-				return fmt.Errorf("unknown field type for %s: %s", string(iter.FieldKey()), p.String())
+				return nil, fmt.Errorf("unknown field type for %s: %s", string(iter.FieldKey()), p.String())
 			}
 			values[string(keyBuf)] = append(values[string(keyBuf)], v)
 		}
 	}
-
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-
-	// first try to write to the cache
-	if err := e.Cache.WriteMulti(values); err != nil {
-		return err
-	}
-
-	// Then make the write durable in the cache.
-	if _, err := e.WAL.WriteMulti(values); err != nil {
-		return err
-	}
-
-	return nil
+	return values, nil
 }
 
 // DeleteSeriesRange removes the values between min and max (inclusive) from all series
@@ -849,7 +882,24 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 	}
 
 	// find the keys in the cache and remove them
-	deleteKeys := make([][]byte, 0, len(seriesKeys))
+	deleteKeys := e.cacheKeys(seriesKeys)
+	e.Cache.DeleteRange(deleteKeys, min, max)
+
+	// delete from the WAL
+	if _, err := e.WAL.DeleteRange(deleteKeys, min, max); err != nil {
+		return err
+	}
+
+	// The series are deleted on disk, but the index may still say they exist.
+	// Depending on the the min,max time passed in, the series may or not actually
+	// exists now.
+	return e.dropSeriesWithoutValues(seriesKeys, deleteKeys)
+}
+
+// cacheKeys returns the sorted keys in the cache of the fields of the sorted
+// seriesKeys.
+func (e *Engine) cacheKeys(seriesKeys [][]byte) [][]byte {
+	keys := make([][]byte, 0, len(seriesKeys))
 
 	// ApplySerialEntryFn cannot return an error in this invocation.
 	_ = e.Cache.ApplyEntryFn(func(k []byte, _ *entry) error {
@@ -860,24 +910,25 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 		i := bytesutil.SearchBytes(seriesKeys, seriesKey)
 		if i < len(seriesKeys) && bytes.Equal(seriesKey, seriesKeys[i]) {
 			// k is the measurement + tags + sep + field
-			deleteKeys = append(deleteKeys, k)
+			keys = append(keys, k)
 		}
 		return nil
 	})
 
 	// Sort the series keys because ApplyEntryFn iterates over the keys randomly.
-	bytesutil.Sort(deleteKeys)
-
-	e.Cache.DeleteRange(deleteKeys, min, max)
+	bytesutil.Sort(keys)
+	return keys
+}
 
-	// delete from the WAL
-	if _, err := e.WAL.DeleteRange(deleteKeys, min, max); err != nil {
-		return err
+// dropSeriesWithoutValues removes the series of the sorted seriesKeys that
+// have no values in the TSM files, or in the cache under the sorted cacheKeys,
+// from the index and series file. seriesKeys is modified.
+func (e *Engine) dropSeriesWithoutValues(seriesKeys, cacheKeys [][]byte) error {
+	if len(seriesKeys) == 0 {
+		return nil
 	}
 
-	// The series are deleted on disk, but the index may still say they exist.
-	// Depending on the the min,max time passed in, the series may or not actually
-	// exists now.  To reconcile the index, we walk the series keys that still exists
+	// To reconcile the index, we walk the series keys that still exists
 	// on disk and cross out any keys that match the passed in series.  Any series
 	// left in the slice at the end do not exist and can be deleted from the index.
 	// Note: this is inherently racy if writes are occurring to the same measurement/series are
@@ -941,13 +992,13 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 			}
 
 			// See if this series was found in the cache earlier
-			i := bytesutil.SearchBytes(deleteKeys, k)
+			i := bytesutil.SearchBytes(cacheKeys, k)
 
 			var hasCacheValues bool
 			// If there are multiple fields, they will have the same prefix.  If any field
 			// has values, then we can't delete it from the index.
-			for i < len(deleteKeys) && bytes.HasPrefix(deleteKeys[i], k) {
-				if e.Cache.Values(deleteKeys[i]).Len() > 0 {
+			for i < len(cacheKeys) && bytes.HasPrefix(cacheKeys[i], k) {
+				if e.Cache.Values(cacheKeys[i]).Len() > 0 {
 					hasCacheValues = true
 					break
 				}
@@ -1021,8 +1072,44 @@ func (e *Engine) CreateSeriesListIfNotExists(collection *tsdb.SeriesCollection)
 	return e.index.CreateSeriesListIfNotExists(collection)
 }
 
-// WriteTo is not implemented.
-func (e *Engine) WriteTo(w io.Writer) (n int64, err error) { panic("not implemented") }
+// CreateSnapshot writes the cache to a TSM file, and then hard links every TSM
+// and tombstone file of the engine into a new temporary directory. It returns
+// the path of the directory, which the caller must remove when done.
+func (e *Engine) CreateSnapshot() (string, error) {
+	if err := e.WriteSnapshot(); err != nil {
+		return "", err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.FileStore.CreateSnapshot()
+}
+
+// WriteTo writes a tar archive of a snapshot of the TSM and tombstone files of
+// the engine to w.
+func (e *Engine) WriteTo(w io.Writer) (n int64, err error) {
+	path, err := e.CreateSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(path)
+
+	cw := &countingWriter{w: w}
+	err = tar.Stream(cw, path, "", nil)
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
 
 // compactionLevel describes a snapshot or levelled compaction.
 type compactionLevel int
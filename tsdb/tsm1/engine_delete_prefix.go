@@ -165,3 +165,58 @@ func (e *Engine) DeletePrefix(prefix []byte, min, max int64) error {
 
 	return nil
 }
+
+// DropPrefixFiles removes every TSM file containing only keys that begin with
+// prefix and values with timestamps before max. Files are removed whole and
+// atomically, without writing any tombstones, which makes it a cheap way to
+// expire data that has been partitioned by time.
+//
+// Data in the cache and in files that do not qualify is left untouched, as is
+// the index. Callers are expected to follow up with a regular delete of the
+// same range to remove anything left behind, which will also drop series that
+// no longer have any data from the index.
+//
+// DropPrefixFiles returns the number of files removed and their size in bytes.
+func (e *Engine) DropPrefixFiles(prefix []byte, max int64) (int, int64, error) {
+	// Most calls have nothing to drop, so look for candidates before touching
+	// the compactions.
+	if paths, _ := e.prefixFiles(prefix, max); len(paths) == 0 {
+		return 0, 0, nil
+	}
+
+	// Disable and abort running compactions so that none of the files being
+	// removed are rewritten into new ones, which would resurrect their data.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	// Collect the files again, as compactions may have replaced them since.
+	paths, size := e.prefixFiles(prefix, max)
+	if len(paths) == 0 {
+		return 0, 0, nil
+	}
+
+	if err := e.FileStore.Replace(paths, nil); err != nil {
+		return 0, 0, err
+	}
+	return len(paths), size, nil
+}
+
+// prefixFiles returns the paths of the TSM files containing only keys that
+// begin with prefix and values with timestamps before max, and their total
+// size in bytes.
+func (e *Engine) prefixFiles(prefix []byte, max int64) ([]string, int64) {
+	var (
+		paths []string
+		size  int64
+	)
+	for _, stat := range e.FileStore.Stats() {
+		// Keys are sorted, so if the min and max key have the prefix, all of
+		// the keys in between do as well.
+		if stat.MaxTime >= max || !bytes.HasPrefix(stat.MinKey, prefix) || !bytes.HasPrefix(stat.MaxKey, prefix) {
+			continue
+		}
+		paths = append(paths, stat.Path)
+		size += int64(stat.Size)
+	}
+	return paths, size
+}
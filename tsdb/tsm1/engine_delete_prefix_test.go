@@ -122,3 +122,66 @@ func TestEngine_DeletePrefix(t *testing.T) {
 		t.Fatalf("got an undeleted series id, but series should be dropped from index")
 	}
 }
+
+func TestEngine_DropPrefixFiles(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 1")
+	p2 := MustParsePointString("cpu,host=A value=1.2 2")
+	p3 := MustParsePointString("cpu,host=A value=1.3 12")
+	p4 := MustParsePointString("mem,host=A value=1.4 1")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	// Partition data by measurement into windows of 10ns.
+	e.WithPartitionFunc(func(key []byte) ([]byte, int64) {
+		return key[:bytes.IndexByte(key, ',')], 10
+	})
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3, p4); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	if exp, got := 3, len(e.FileStore.Stats()); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+
+	n, size, err := e.DropPrefixFiles([]byte("cpu"), 10)
+	if err != nil {
+		t.Fatalf("failed to drop files: %v", err)
+	}
+	if exp, got := 1, n; exp != got {
+		t.Fatalf("dropped file count mismatch: exp %v, got %v", exp, got)
+	} else if size <= 0 {
+		t.Fatalf("expected dropped files to have a size, got %d", size)
+	}
+
+	stats := e.FileStore.Stats()
+	if exp, got := 2, len(stats); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+	for _, stat := range stats {
+		if bytes.HasPrefix(stat.MinKey, []byte("cpu")) && stat.MinTime < 10 {
+			t.Fatalf("unexpected file remaining: %s", stat.Path)
+		}
+	}
+
+	// Dropping again should be a no-op.
+	if n, _, err := e.DropPrefixFiles([]byte("cpu"), 10); err != nil {
+		t.Fatalf("failed to drop files: %v", err)
+	} else if n != 0 {
+		t.Fatalf("dropped file count mismatch: exp 0, got %v", n)
+	}
+}
@@ -1361,6 +1361,25 @@ func (c *KeyCursor) seekDescending(t int64) {
 	}
 }
 
+// skipTo moves an ascending cursor to the blocks containing points at or
+// after t. Everything before t is marked as read, as when seeking, so the
+// blocks whose points are all before t are never decoded.
+func (c *KeyCursor) skipTo(t int64) {
+	c.current = c.current[:0]
+	for i, e := range c.seeks {
+		e.markRead(math.MinInt64, t-1)
+		if e.read() {
+			continue
+		}
+
+		// Record the position of the first block matching our seek time
+		if len(c.current) == 0 {
+			c.pos = i
+		}
+		c.current = append(c.current, e)
+	}
+}
+
 // Next moves the cursor to the next position.
 // Data should be read by the ReadBlock functions.
 func (c *KeyCursor) Next() {
@@ -306,3 +306,56 @@ func (m *walMetrics) PrometheusCollectors() []prometheus.Collector {
 		m.Writes,
 	}
 }
+
+// timePlannerMetrics are a set of metrics concerned with tracking the time
+// windows of a TimePlanner.
+type timePlannerMetrics struct {
+	labels prometheus.Labels
+
+	// The following metrics include a `"state" = {hot, cold, sealed}` label
+	Windows     *prometheus.GaugeVec
+	WindowFiles *prometheus.GaugeVec
+}
+
+// newTimePlannerMetrics initialises the prometheus metrics for a TimePlanner.
+func newTimePlannerMetrics(labels prometheus.Labels) *timePlannerMetrics {
+	names := []string{"state"} // All time planner metrics have a `state` label.
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return &timePlannerMetrics{
+		labels: labels,
+		Windows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: compactionSubsystem,
+			Name:      "time_windows",
+			Help:      "Number of time windows of TSM files in each compaction state.",
+		}, names),
+		WindowFiles: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: compactionSubsystem,
+			Name:      "time_window_files",
+			Help:      "Number of TSM files in time windows of each compaction state.",
+		}, names),
+	}
+}
+
+// Labels returns a copy of the default labels with the state label set.
+func (m *timePlannerMetrics) Labels(state string) prometheus.Labels {
+	labels := make(prometheus.Labels, len(m.labels)+1)
+	for k, v := range m.labels {
+		labels[k] = v
+	}
+	labels["state"] = state
+	return labels
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *timePlannerMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.Windows,
+		m.WindowFiles,
+	}
+}
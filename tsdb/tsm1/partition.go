@@ -0,0 +1,100 @@
+package tsm1
+
+import "math"
+
+// A PartitionFunc determines how the data for a TSM key is partitioned across
+// TSM files.
+//
+// Keys sharing the same prefix belong to the same group of partitions, and
+// their data is further split into non-overlapping time windows, each duration
+// nanoseconds wide and aligned to the Unix epoch. A duration of zero means the
+// data for the key is partitioned by prefix only. A nil prefix means the key
+// does not belong to any partition.
+//
+// When an Engine has a PartitionFunc, cache snapshots are written as one
+// TSM generation per partition, and compactions are planned such that files
+// from different partitions are never merged. Expiring all of the data in a
+// partition then becomes a matter of removing its files.
+type PartitionFunc func(key []byte) (prefix []byte, duration int64)
+
+// timePartition identifies a single partition: a key prefix and an inclusive
+// window of time.
+type timePartition struct {
+	prefix   string
+	min, max int64
+}
+
+// overlaps returns true if p and o could contain data for the same key and
+// timestamp.
+func (p timePartition) overlaps(o timePartition) bool {
+	return p.prefix == o.prefix && p.min <= o.max && p.max >= o.min
+}
+
+// partitionWindow returns the inclusive bounds of the window of width d that
+// contains t. If d is not positive, the window is unbounded.
+func partitionWindow(t, d int64) (min, max int64) {
+	if d <= 0 {
+		return math.MinInt64, math.MaxInt64
+	}
+
+	r := t % d
+	if r < 0 {
+		r += d
+	}
+
+	// Windows at either end of the time range are clamped rather than allowed
+	// to overflow.
+	if t < math.MinInt64+r {
+		min = math.MinInt64
+	} else {
+		min = t - r
+	}
+	if t > math.MaxInt64-(d-1-r) {
+		max = math.MaxInt64
+	} else {
+		max = t + (d - 1 - r)
+	}
+	return min, max
+}
+
+// partition returns the partition that the value at time t for key belongs
+// to. ok is false if the key is not partitioned.
+func (fn PartitionFunc) partition(key []byte, t int64) (p timePartition, ok bool) {
+	prefix, d := fn(key)
+	if prefix == nil {
+		return timePartition{}, false
+	}
+
+	min, max := partitionWindow(t, d)
+	return timePartition{prefix: string(prefix), min: min, max: max}, true
+}
+
+// fileStatPartition returns the partition that all of the data described by
+// stat belongs to. ok is false if the file contains data for more than one
+// partition, or for keys that are not partitioned.
+func (fn PartitionFunc) fileStatPartition(stat FileStat) (p timePartition, ok bool) {
+	if fn == nil {
+		return timePartition{}, false
+	}
+
+	p, ok = fn.partition(stat.MinKey, stat.MinTime)
+	if !ok {
+		return timePartition{}, false
+	}
+
+	// The key range is contiguous, so if the min and max keys share a prefix
+	// every key in the file does. Both ends must also fall in the same window,
+	// whose width may have changed since the file was written.
+	if maxP, ok := fn.partition(stat.MaxKey, stat.MaxTime); !ok || maxP != p {
+		return timePartition{}, false
+	}
+	return p, true
+}
+
+// A PartitionedPlanner is a CompactionPlanner that is aware of how TSM files
+// are partitioned, and never plans files from different partitions into the
+// same compaction group.
+type PartitionedPlanner interface {
+	CompactionPlanner
+	SetPartitionFunc(fn PartitionFunc)
+}
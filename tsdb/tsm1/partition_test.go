@@ -0,0 +1,80 @@
+package tsm1
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPartitionWindow(t *testing.T) {
+	tests := []struct {
+		t, d     int64
+		min, max int64
+	}{
+		{t: 0, d: 10, min: 0, max: 9},
+		{t: 15, d: 10, min: 10, max: 19},
+		{t: 19, d: 10, min: 10, max: 19},
+		{t: -1, d: 10, min: -10, max: -1},
+		{t: -10, d: 10, min: -10, max: -1},
+		{t: -11, d: 10, min: -20, max: -11},
+		{t: 42, d: 0, min: math.MinInt64, max: math.MaxInt64},
+		{t: math.MaxInt64, d: 10, min: math.MaxInt64 - 7, max: math.MaxInt64},
+		{t: math.MinInt64, d: 10, min: math.MinInt64, max: math.MinInt64 + 7},
+	}
+
+	for _, tt := range tests {
+		min, max := partitionWindow(tt.t, tt.d)
+		if min != tt.min || max != tt.max {
+			t.Errorf("partitionWindow(%d, %d) = [%d, %d], exp [%d, %d]", tt.t, tt.d, min, max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestPartitionFunc_fileStatPartition(t *testing.T) {
+	fn := PartitionFunc(testPartitionFunc)
+
+	tests := []struct {
+		name string
+		stat FileStat
+		exp  timePartition
+		ok   bool
+	}{
+		{
+			name: "single partition",
+			stat: FileStat{MinKey: []byte("cpu,host=A#!~#value"), MaxKey: []byte("cpu,host=B#!~#value"), MinTime: 11, MaxTime: 19},
+			exp:  timePartition{prefix: "cpu", min: 10, max: 19},
+			ok:   true,
+		},
+		{
+			name: "multiple windows",
+			stat: FileStat{MinKey: []byte("cpu,host=A#!~#value"), MaxKey: []byte("cpu,host=B#!~#value"), MinTime: 9, MaxTime: 10},
+		},
+		{
+			name: "multiple prefixes",
+			stat: FileStat{MinKey: []byte("cpu,host=A#!~#value"), MaxKey: []byte("mem,host=A#!~#value"), MinTime: 1, MaxTime: 2},
+		},
+		{
+			name: "unpartitioned keys",
+			stat: FileStat{MinKey: []byte("cpu#!~#value"), MaxKey: []byte("cpu#!~#value"), MinTime: 1, MaxTime: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := fn.fileStatPartition(tt.stat)
+			if ok != tt.ok || p != tt.exp {
+				t.Fatalf("got %v (%v), exp %v (%v)", p, ok, tt.exp, tt.ok)
+			}
+		})
+	}
+}
+
+// testPartitionFunc partitions keys by measurement into windows of 10ns.
+// Keys without tags are not partitioned.
+func testPartitionFunc(key []byte) ([]byte, int64) {
+	for i, b := range key {
+		if b == ',' {
+			return key[:i], 10
+		}
+	}
+	return nil, 0
+}
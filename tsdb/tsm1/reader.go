@@ -3,6 +3,7 @@ package tsm1
 import (
 	"bufio"
 	"fmt"
+	"hash/crc32"
 	"os"
 	"sync"
 	"sync/atomic"
@@ -456,6 +457,46 @@ func (t *TSMReader) BlockIterator() *BlockIterator {
 	}
 }
 
+// tsmHeaderSize is the size of the magic number and version that start a TSM
+// file, before its first block.
+const tsmHeaderSize = 5
+
+// VerifyBlock returns an error if the index entry e does not point within the
+// blocks of the file, or if its block does not match its checksum or can not
+// be decoded.
+func (t *TSMReader) VerifyBlock(e *IndexEntry) error {
+	// Blocks are between the header and the index, which is followed by its
+	// 8 byte offset.
+	end := int64(t.Size()) - int64(t.IndexSize()) - 8
+	if e.Offset < tsmHeaderSize || e.Size <= crc32.Size || e.Offset+int64(e.Size) > end {
+		return fmt.Errorf("index entry points past the blocks, at bytes %d-%d of %d", e.Offset, e.Offset+int64(e.Size), end)
+	}
+
+	checksum, buf, err := t.ReadBytes(e, nil)
+	if err != nil {
+		return err
+	} else if got := crc32.ChecksumIEEE(buf); got != checksum {
+		return fmt.Errorf("checksum is %08x, expected %08x", got, checksum)
+	} else if _, err := DecodeBlock(buf, nil); err != nil {
+		return fmt.Errorf("can not be decoded: %v", err)
+	}
+	return nil
+}
+
+// Verify checks every block of the file with VerifyBlock. It returns the error
+// of the first bad block.
+func (t *TSMReader) Verify() error {
+	itr := t.Iterator(nil)
+	for itr.Next() {
+		for _, e := range itr.Entries() {
+			if err := t.VerifyBlock(&e); err != nil {
+				return fmt.Errorf("block of key %q at offset %d: %v", itr.Key(), e.Offset, err)
+			}
+		}
+	}
+	return itr.Err()
+}
+
 type BatchDeleter interface {
 	DeleteRange(keys [][]byte, min, max int64) error
 	Commit() error
@@ -33,6 +33,12 @@ type Usage struct {
 	Value          float64     `json:"value"`
 }
 
+// ops for usage errors.
+const (
+	OpGetUsage    = "GetUsage"
+	OpDeleteUsage = "DeleteUsage"
+)
+
 // UsageService is a service for accessing usage statistics.
 type UsageService interface {
 	GetUsage(ctx context.Context, filter UsageFilter) (map[UsageMetric]*Usage, error)
@@ -50,3 +56,11 @@ type Timespan struct {
 	Start time.Time `json:"start"`
 	Stop  time.Time `json:"stop"`
 }
+
+// UsageRecorder records the utilization of resources.
+type UsageRecorder interface {
+	// RecordUsage adds value to the usage metric m of the organization and
+	// bucket. If bucketID is not valid, the usage is attributed to the
+	// organization as a whole.
+	RecordUsage(ctx context.Context, orgID, bucketID ID, m UsageMetric, value float64)
+}
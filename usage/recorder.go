@@ -0,0 +1,212 @@
+// Package usage provides accounting of the resources used by organizations
+// and buckets.
+package usage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultFlushInterval is how often a Recorder persists usage by default.
+	DefaultFlushInterval = time.Minute
+
+	// DefaultRetention is how long a Recorder keeps usage by default.
+	DefaultRetention = 90 * 24 * time.Hour
+)
+
+// Store persists usage recorded at a point in time.
+type Store interface {
+	AddUsage(ctx context.Context, usage []*platform.Usage, t time.Time) error
+	DeleteUsageBefore(ctx context.Context, t time.Time) error
+}
+
+// SeriesCardinalitySource provides the number of series stored for each
+// bucket.
+type SeriesCardinalitySource interface {
+	ForEachBucketSeriesCardinality(fn func(orgID, bucketID platform.ID, n int64))
+}
+
+var _ platform.UsageRecorder = (*Recorder)(nil)
+
+// Recorder aggregates usage in memory and periodically flushes it to a Store,
+// so that recording usage does not add any I/O to the requests being
+// accounted for.
+//
+// Each flush stores the usage recorded since the previous flush, so the flush
+// interval determines the resolution at which usage can be queried.
+type Recorder struct {
+	Store Store
+
+	// Series, if set, is sampled on every flush to record the series
+	// cardinality of every bucket as UsageSeries.
+	Series SeriesCardinalitySource
+
+	FlushInterval time.Duration
+
+	// Retention is how long usage is kept in the Store. Older usage is
+	// deleted after every periodic flush. Zero keeps usage forever.
+	Retention time.Duration
+
+	Logger *zap.Logger
+	Now    func() time.Time
+
+	mu      sync.Mutex
+	pending map[usageKey]float64
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+type usageKey struct {
+	orgID    platform.ID
+	bucketID platform.ID
+	metric   platform.UsageMetric
+}
+
+// NewRecorder returns a new Recorder that flushes usage to s.
+func NewRecorder(s Store) *Recorder {
+	return &Recorder{
+		Store:         s,
+		FlushInterval: DefaultFlushInterval,
+		Retention:     DefaultRetention,
+		Logger:        zap.NewNop(),
+		Now:           time.Now,
+		pending:       make(map[usageKey]float64),
+	}
+}
+
+// RecordUsage adds value to the usage metric m of the organization and bucket.
+func (r *Recorder) RecordUsage(ctx context.Context, orgID, bucketID platform.ID, m platform.UsageMetric, value float64) {
+	if !orgID.Valid() {
+		return
+	}
+
+	r.mu.Lock()
+	r.pending[usageKey{orgID: orgID, bucketID: bucketID, metric: m}] += value
+	r.mu.Unlock()
+}
+
+// Open starts flushing usage, and deleting usage older than the retention,
+// every FlushInterval.
+func (r *Recorder) Open() error {
+	if r.closing != nil {
+		return nil // Already open
+	}
+	r.closing = make(chan struct{})
+
+	ticker := time.NewTicker(r.FlushInterval)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.closing:
+				return
+			case <-ticker.C:
+				if err := r.Flush(context.Background()); err != nil {
+					r.Logger.Error("Failed to flush usage", zap.Error(err))
+				}
+				if err := r.DeleteExpired(context.Background()); err != nil {
+					r.Logger.Error("Failed to delete expired usage", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the periodic flushes, and flushes any outstanding usage.
+func (r *Recorder) Close() error {
+	if r.closing == nil {
+		return nil // Already closed
+	}
+	close(r.closing)
+	r.wg.Wait()
+	r.closing = nil
+
+	return r.Flush(context.Background())
+}
+
+// Flush stores all usage recorded since the previous flush, along with the
+// current series cardinality of every bucket. If the usage cannot be stored,
+// it is retained and retried on the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[usageKey]float64)
+	r.mu.Unlock()
+
+	usage := make([]*platform.Usage, 0, len(pending))
+	for k, v := range pending {
+		usage = append(usage, newUsage(k.orgID, k.bucketID, k.metric, v))
+	}
+
+	if r.Series != nil {
+		r.Series.ForEachBucketSeriesCardinality(func(orgID, bucketID platform.ID, n int64) {
+			usage = append(usage, newUsage(orgID, bucketID, platform.UsageSeries, float64(n)))
+		})
+	}
+
+	if len(usage) == 0 {
+		return nil
+	}
+	sortUsage(usage)
+
+	if err := r.Store.AddUsage(ctx, usage, r.Now()); err != nil {
+		r.mu.Lock()
+		for k, v := range pending {
+			r.pending[k] += v
+		}
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// DeleteExpired deletes the usage stored longer ago than the retention.
+func (r *Recorder) DeleteExpired(ctx context.Context) error {
+	if r.Retention <= 0 {
+		return nil
+	}
+	return r.Store.DeleteUsageBefore(ctx, r.Now().Add(-r.Retention))
+}
+
+func newUsage(orgID, bucketID platform.ID, m platform.UsageMetric, value float64) *platform.Usage {
+	u := &platform.Usage{
+		OrganizationID: &orgID,
+		Type:           m,
+		Value:          value,
+	}
+	if bucketID.Valid() {
+		u.BucketID = &bucketID
+	}
+	return u
+}
+
+// sortUsage sorts usage by organization, bucket and metric.
+func sortUsage(usage []*platform.Usage) {
+	id := func(id *platform.ID) platform.ID {
+		if id == nil {
+			return 0
+		}
+		return *id
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if oa, ob := id(a.OrganizationID), id(b.OrganizationID); oa != ob {
+			return oa < ob
+		}
+		if ba, bb := id(a.BucketID), id(b.BucketID); ba != bb {
+			return ba < bb
+		}
+		return a.Type < b.Type
+	})
+}
@@ -0,0 +1,115 @@
+package usage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/usage"
+)
+
+type fakeStore struct {
+	usage  []*platform.Usage
+	t      time.Time
+	before time.Time
+	err    error
+}
+
+func (s *fakeStore) AddUsage(ctx context.Context, u []*platform.Usage, t time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.usage, s.t = append(s.usage, u...), t
+	return nil
+}
+
+func (s *fakeStore) DeleteUsageBefore(ctx context.Context, t time.Time) error {
+	s.before = t
+	return s.err
+}
+
+type fakeSeries map[[2]platform.ID]int64
+
+func (s fakeSeries) ForEachBucketSeriesCardinality(fn func(orgID, bucketID platform.ID, n int64)) {
+	for k, n := range s {
+		fn(k[0], k[1], n)
+	}
+}
+
+func idPtr(id platform.ID) *platform.ID { return &id }
+
+func TestRecorder_Flush(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &fakeStore{err: errors.New("unavailable")}
+
+	r := usage.NewRecorder(store)
+	r.Now = func() time.Time { return now }
+	r.Series = fakeSeries{{1, 2}: 5}
+
+	ctx := context.Background()
+	r.RecordUsage(ctx, 1, 2, platform.UsageWriteRequestCount, 1)
+	r.RecordUsage(ctx, 1, 2, platform.UsageWriteRequestCount, 1)
+	r.RecordUsage(ctx, 1, platform.InvalidID(), platform.UsageQueryRequestBytes, 100)
+	r.RecordUsage(ctx, platform.InvalidID(), 2, platform.UsageValues, 10) // ignored
+
+	// Usage is retained when it cannot be stored.
+	if err := r.Flush(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+	store.err = nil
+	r.RecordUsage(ctx, 1, 2, platform.UsageWriteRequestCount, 1)
+
+	if err := r.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*platform.Usage{
+		{OrganizationID: idPtr(1), Type: platform.UsageQueryRequestBytes, Value: 100},
+		{OrganizationID: idPtr(1), BucketID: idPtr(2), Type: platform.UsageSeries, Value: 5},
+		{OrganizationID: idPtr(1), BucketID: idPtr(2), Type: platform.UsageWriteRequestCount, Value: 3},
+	}
+	if diff := cmp.Diff(store.usage, want); diff != "" {
+		t.Fatalf("unexpected usage -got/+want\n%s", diff)
+	}
+	if !store.t.Equal(now) {
+		t.Fatalf("got time %v, want %v", store.t, now)
+	}
+
+	// Only the series gauge is stored once the pending usage is flushed.
+	store.usage = nil
+	if err := r.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(store.usage, want[1:2]); diff != "" {
+		t.Fatalf("unexpected usage -got/+want\n%s", diff)
+	}
+}
+
+func TestRecorder_DeleteExpired(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &fakeStore{}
+
+	r := usage.NewRecorder(store)
+	r.Now = func() time.Time { return now }
+	r.Retention = time.Hour
+
+	if err := r.DeleteExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(-time.Hour); !store.before.Equal(want) {
+		t.Fatalf("got time %v, want %v", store.before, want)
+	}
+
+	// Usage is kept forever without a retention.
+	store.before = time.Time{}
+	r.Retention = 0
+	if err := r.DeleteExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !store.before.IsZero() {
+		t.Fatalf("unexpected delete before %v", store.before)
+	}
+}
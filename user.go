@@ -44,6 +44,12 @@ type UserService interface {
 	DeleteUser(ctx context.Context, id ID) error
 }
 
+// Ops for basic auth errors and audit log.
+const (
+	OpSetPassword           = "SetPassword"
+	OpCompareAndSetPassword = "CompareAndSetPassword"
+)
+
 // BasicAuthService is the service for managing basic auth.
 type BasicAuthService interface {
 	SetPassword(ctx context.Context, name string, password string) error
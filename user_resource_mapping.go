@@ -14,11 +14,17 @@ var (
 	ErrResourceIDRequired = errors.New("resource id is required")
 )
 
+// ops for user resource mappings.
+const (
+	OpCreateUserResourceMapping = "CreateUserResourceMapping"
+	OpDeleteUserResourceMapping = "DeleteUserResourceMapping"
+)
+
 // UserType can either be owner or member.
 type UserType string
 
 const (
-	// Owner can read and write to a resource
+	// Owner can read, write, delete and administer a resource
 	Owner UserType = "owner" // 1
 	// Member can read from a resource.
 	Member UserType = "member" // 2
@@ -85,11 +91,11 @@ type UserResourceMappingFilter struct {
 	UserType   UserType
 }
 
-var ownerActions = []Action{WriteAction, ReadAction}
+var ownerActions = []Action{WriteAction, ReadAction, DeleteAction, AdminAction}
 var memberActions = []Action{ReadAction}
 
 func (m *UserResourceMapping) ownerPerms() ([]Permission, error) {
-	ps := make([]Permission, 0, len(ownerActions))
+	ps := make([]Permission, 0, len(ownerActions)+1)
 	for _, a := range ownerActions {
 		p, err := NewPermissionAtID(m.ResourceID, a, m.Resource)
 		if err != nil {
@@ -97,11 +103,13 @@ func (m *UserResourceMapping) ownerPerms() ([]Permission, error) {
 		}
 
 		ps = append(ps, *p)
+	}
 
-		if m.Resource == OrgsResource {
-			ps = append(ps, OrgAdminPermissions(m.ResourceID)...)
-		}
-
+	if m.Resource == OrgsResource {
+		ps = append(ps, OrgAdminPermissions(m.ResourceID)...)
+	} else {
+		// Owners may label the resources they own.
+		ps = append(ps, Permission{ID: &m.ResourceID, Action: AdminAction, Resource: LabelsResource})
 	}
 
 	return ps, nil
@@ -116,10 +124,10 @@ func (m *UserResourceMapping) memberPerms() ([]Permission, error) {
 		}
 
 		ps = append(ps, *p)
+	}
 
-		if m.Resource == OrgsResource {
-			ps = append(ps, OrgMemberPermissions(m.ResourceID)...)
-		}
+	if m.Resource == OrgsResource {
+		ps = append(ps, OrgMemberPermissions(m.ResourceID)...)
 	}
 
 	return ps, nil